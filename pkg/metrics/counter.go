@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"errors"
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
 	"strings"
@@ -74,6 +75,18 @@ func GetOrRegisterCounterVec(opts CounterOpts, labels []string) *CounterVec {
 		labels,
 	)
 
+	// метрика отдаётся и через стандартный prometheus-обработчик
+	if err := prometheus.Register(promConstructor); err != nil {
+		var already prometheus.AlreadyRegisteredError
+		if errors.As(err, &already) {
+			if existing, ok := already.ExistingCollector.(*prometheus.CounterVec); ok {
+				promConstructor = existing
+			}
+		} else {
+			globalRegistry.logf("can't register counter %s in prometheus: %v", opts.Name, err)
+		}
+	}
+
 	counterVec := &CounterVec{
 		opts:        opts,
 		labels:      labels,
@@ -0,0 +1,109 @@
+package msbus
+
+import (
+	"context"
+	"github.com/juju/zaputil/zapctx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"time"
+)
+
+const tracerName = "music-snap/pkg/msbus"
+
+// Заголовки сообщений шины
+const (
+	HeaderID            = "id"
+	HeaderType          = "type"
+	HeaderSchemaVersion = "schema-version"
+)
+
+// Message: Сообщение шины, trace context передаётся в заголовках
+type Message struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+	Time    time.Time
+}
+
+// Publisher: Публикация сообщений в топик
+type Publisher interface {
+	Publish(ctx context.Context, messages ...Message) error
+	Close() error
+}
+
+// Handler processes message, message is committed only after handler succeeds
+type Handler func(ctx context.Context, message Message) error
+
+// Consumer: Обработка сообщений топика группой потребителей
+type Consumer interface {
+	// Consume blocks until ctx is done or consumer is closed
+	Consume(ctx context.Context, handler Handler) error
+	Close() error
+}
+
+// Inject writes trace context of ctx into headers
+func Inject(ctx context.Context, headers map[string]string) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(headers))
+}
+
+// Extract returns ctx with trace context from headers
+func Extract(ctx context.Context, headers map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(headers))
+}
+
+// startPublish starts producer span and returns copies of messages with its trace context in headers
+func startPublish(ctx context.Context, topic string, messages []Message) (context.Context, trace.Span, []Message) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "msbus.Publish "+topic,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attribute.String("messaging.destination", topic),
+			attribute.Int("messaging.batch.message_count", len(messages))))
+
+	traced := make([]Message, len(messages))
+	for i, m := range messages {
+		headers := make(map[string]string, len(m.Headers)+2)
+		for k, v := range m.Headers {
+			headers[k] = v
+		}
+		Inject(ctx, headers)
+		m.Topic = topic
+		m.Headers = headers
+		traced[i] = m
+	}
+	return ctx, span, traced
+}
+
+// handle runs handler in span continuing trace of message, failed message is retried
+// after backoff until MaxRetries. Error is returned only when ctx is done
+func handle(ctx context.Context, cfg ReaderConfig, handler Handler, message Message) error {
+	ctx, span := otel.Tracer(tracerName).Start(Extract(ctx, message.Headers), "msbus.Consume "+message.Topic,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attribute.String("messaging.destination", message.Topic),
+			attribute.String("messaging.message.type", message.Headers[HeaderType])))
+	defer span.End()
+
+	for attempt := 1; ; attempt++ {
+		err := handler(ctx, message)
+		if err == nil {
+			return nil
+		}
+		zapctx.Logger(ctx).Error("can't handle bus message", zap.String("topic", message.Topic),
+			zap.String("type", message.Headers[HeaderType]), zap.Int("attempt", attempt), zap.Error(err))
+		if cfg.MaxRetries > 0 && attempt > cfg.MaxRetries {
+			span.SetStatus(codes.Error, err.Error())
+			zapctx.Logger(ctx).Warn("bus message skipped after retries", zap.String("topic", message.Topic),
+				zap.String("id", message.Headers[HeaderID]))
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(cfg.retryBackoff()):
+		}
+	}
+}
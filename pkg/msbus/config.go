@@ -0,0 +1,44 @@
+package msbus
+
+import "time"
+
+const (
+	defaultRetryBackoff = time.Second
+	defaultWriteTimeout = 10 * time.Second
+)
+
+// ReaderConfig: Настройки группы потребителей топика
+type ReaderConfig struct {
+	Enable   bool     `mapstructure:"enable"`
+	Brokers  []string `mapstructure:"brokers"`
+	Topic    string   `mapstructure:"topic"`
+	IDGroup  string   `mapstructure:"id_group"`
+	MinBytes int      `mapstructure:"min_bytes"`
+	MaxBytes int      `mapstructure:"max_bytes"`
+	// RetryBackoff is the pause before failed message is handled again
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+	// MaxRetries of failed message before it is skipped, zero retries it until success
+	MaxRetries int `mapstructure:"max_retries"`
+}
+
+func (cfg ReaderConfig) retryBackoff() time.Duration {
+	if cfg.RetryBackoff <= 0 {
+		return defaultRetryBackoff
+	}
+	return cfg.RetryBackoff
+}
+
+// WriterConfig: Настройки публикации в топик
+type WriterConfig struct {
+	Enable       bool          `mapstructure:"enable"`
+	Broker       string        `mapstructure:"broker"`
+	Topic        string        `mapstructure:"topic"`
+	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+}
+
+func (cfg WriterConfig) writeTimeout() time.Duration {
+	if cfg.WriteTimeout <= 0 {
+		return defaultWriteTimeout
+	}
+	return cfg.WriteTimeout
+}
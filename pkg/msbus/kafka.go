@@ -0,0 +1,95 @@
+package msbus
+
+import (
+	"context"
+	"errors"
+	"github.com/segmentio/kafka-go"
+)
+
+var _ Publisher = &KafkaPublisher{}
+
+// KafkaPublisher writes messages with the same key to the same partition, so they keep order
+type KafkaPublisher struct {
+	w *kafka.Writer
+}
+
+func NewKafkaPublisher(cfg WriterConfig) *KafkaPublisher {
+	return &KafkaPublisher{w: &kafka.Writer{
+		Addr:                   kafka.TCP(cfg.Broker),
+		Topic:                  cfg.Topic,
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		WriteTimeout:           cfg.writeTimeout(),
+		AllowAutoTopicCreation: true,
+	}}
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, messages ...Message) error {
+	ctx, span, traced := startPublish(ctx, p.w.Topic, messages)
+	defer span.End()
+
+	kafkaMessages := make([]kafka.Message, len(traced))
+	for i, m := range traced {
+		headers := make([]kafka.Header, 0, len(m.Headers))
+		for k, v := range m.Headers {
+			headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+		}
+		kafkaMessages[i] = kafka.Message{Key: m.Key, Value: m.Value, Headers: headers, Time: m.Time}
+	}
+	return p.w.WriteMessages(ctx, kafkaMessages...)
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.w.Close()
+}
+
+var _ Consumer = &KafkaConsumer{}
+
+// KafkaConsumer reads topic in consumer group and commits offset of message after it is handled
+type KafkaConsumer struct {
+	cfg ReaderConfig
+	r   *kafka.Reader
+}
+
+func NewKafkaConsumer(cfg ReaderConfig) *KafkaConsumer {
+	return &KafkaConsumer{cfg: cfg, r: kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  cfg.Brokers,
+		GroupID:  cfg.IDGroup,
+		Topic:    cfg.Topic,
+		MinBytes: cfg.MinBytes,
+		MaxBytes: cfg.MaxBytes,
+	})}
+}
+
+func (k *KafkaConsumer) Consume(ctx context.Context, handler Handler) error {
+	for {
+		m, err := k.r.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		}
+
+		headers := make(map[string]string, len(m.Headers))
+		for _, h := range m.Headers {
+			headers[h.Key] = string(h.Value)
+		}
+		message := Message{Topic: m.Topic, Key: m.Key, Value: m.Value, Headers: headers, Time: m.Time}
+		if err = handle(ctx, k.cfg, handler, message); err != nil {
+			// сообщение не подтверждено и будет прочитано снова
+			return nil
+		}
+
+		if err = k.r.CommitMessages(ctx, m); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (k *KafkaConsumer) Close() error {
+	return k.r.Close()
+}
@@ -0,0 +1,118 @@
+package msbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned by publisher of closed memory bus
+var ErrClosed = errors.New("bus is closed")
+
+// MemoryBus: Шина в памяти процесса для тестов, потребители топика образуют одну группу
+type MemoryBus struct {
+	mu     sync.RWMutex
+	buffer int
+	closed bool
+	topics map[string]chan Message
+}
+
+func NewMemoryBus(buffer int) *MemoryBus {
+	return &MemoryBus{buffer: buffer, topics: make(map[string]chan Message)}
+}
+
+func (b *MemoryBus) topic(name string) chan Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ch, ok := b.topics[name]
+	if !ok {
+		ch = make(chan Message, b.buffer)
+		b.topics[name] = ch
+	}
+	return ch
+}
+
+func (b *MemoryBus) Publisher(cfg WriterConfig) Publisher {
+	return memoryPublisher{bus: b, topic: cfg.Topic}
+}
+
+func (b *MemoryBus) Consumer(cfg ReaderConfig) Consumer {
+	return memoryConsumer{bus: b, cfg: cfg}
+}
+
+// Close stops consumers after they handle published messages
+func (b *MemoryBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	for _, ch := range b.topics {
+		close(ch)
+	}
+	return nil
+}
+
+type memoryPublisher struct {
+	bus   *MemoryBus
+	topic string
+}
+
+func (p memoryPublisher) Publish(ctx context.Context, messages ...Message) error {
+	ctx, span, traced := startPublish(ctx, p.topic, messages)
+	defer span.End()
+
+	ch := p.bus.topic(p.topic)
+	for _, m := range traced {
+		if err := p.send(ctx, ch, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// send holds read lock, so Close does not close channel under it
+func (p memoryPublisher) send(ctx context.Context, ch chan Message, m Message) error {
+	p.bus.mu.RLock()
+	defer p.bus.mu.RUnlock()
+	if p.bus.closed {
+		return ErrClosed
+	}
+	select {
+	case ch <- m:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p memoryPublisher) Close() error {
+	return nil
+}
+
+type memoryConsumer struct {
+	bus *MemoryBus
+	cfg ReaderConfig
+}
+
+func (k memoryConsumer) Consume(ctx context.Context, handler Handler) error {
+	ch := k.bus.topic(k.cfg.Topic)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case m, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if err := handle(ctx, k.cfg, handler, m); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+func (k memoryConsumer) Close() error {
+	return nil
+}
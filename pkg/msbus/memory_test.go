@@ -0,0 +1,67 @@
+package msbus
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+	"time"
+)
+
+func TestMemoryBus(t *testing.T) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	bus := NewMemoryBus(10)
+	publisher := bus.Publisher(WriterConfig{Topic: "inbound"})
+	consumer := bus.Consumer(ReaderConfig{Topic: "inbound", RetryBackoff: time.Millisecond, MaxRetries: 2})
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "publish")
+	err := publisher.Publish(ctx,
+		Message{Key: []byte("1"), Value: []byte(`{"n":1}`), Headers: map[string]string{HeaderType: "ok", HeaderSchemaVersion: "1"}},
+		Message{Key: []byte("2"), Value: []byte(`{"n":2}`), Headers: map[string]string{HeaderType: "flaky"}},
+		Message{Key: []byte("3"), Value: []byte(`{"n":3}`), Headers: map[string]string{HeaderType: "broken"}},
+	)
+	span.End()
+	require.NoError(t, err)
+	require.NoError(t, bus.Close())
+
+	var handled []Message
+	calls := map[string]int{}
+	err = consumer.Consume(context.Background(), func(ctx context.Context, m Message) error {
+		typ := m.Headers[HeaderType]
+		calls[typ]++
+		assert.Equal(t, span.SpanContext().TraceID(), trace.SpanContextFromContext(ctx).TraceID(),
+			"handler continues trace of publisher")
+		switch {
+		case typ == "flaky" && calls[typ] == 1, typ == "broken":
+			return errors.New("failed")
+		}
+		handled = append(handled, m)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, handled, 2)
+	assert.Equal(t, "inbound", handled[0].Topic)
+	assert.Equal(t, `{"n":1}`, string(handled[0].Value))
+	assert.Equal(t, "1", handled[0].Headers[HeaderSchemaVersion])
+	assert.Equal(t, 2, calls["flaky"], "failed message is retried")
+	assert.Equal(t, 3, calls["broken"], "message is skipped after max retries")
+
+	assert.ErrorIs(t, publisher.Publish(context.Background(), Message{}), ErrClosed)
+}
+
+func TestMemoryConsumerStopsOnContext(t *testing.T) {
+	bus := NewMemoryBus(1)
+	consumer := bus.Consumer(ReaderConfig{Topic: "outbound"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, consumer.Consume(ctx, func(context.Context, Message) error { return nil }))
+}
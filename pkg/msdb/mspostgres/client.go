@@ -16,8 +16,9 @@ type Config struct {
 	SSL      string `mapstructure:"ssl"`
 }
 
-func NewDB(cfg *Config) (*sqlx.DB, func() error, error) {
-	dbParams := fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=%s password=%s",
+// DataSourceName returns connection string of lib/pq driver
+func (cfg *Config) DataSourceName() string {
+	return fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=%s password=%s",
 		cfg.Host,
 		cfg.Port,
 		cfg.User,
@@ -25,6 +26,10 @@ func NewDB(cfg *Config) (*sqlx.DB, func() error, error) {
 		cfg.SSL,
 		cfg.Password,
 	)
+}
+
+func NewDB(cfg *Config) (*sqlx.DB, func() error, error) {
+	dbParams := cfg.DataSourceName()
 	log.Println(dbParams)
 	db, err := sqlx.Open("postgres", dbParams)
 	if err != nil {
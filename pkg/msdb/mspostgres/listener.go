@@ -0,0 +1,18 @@
+package mspostgres
+
+import (
+	"github.com/lib/pq"
+	"time"
+)
+
+// NewListener opens dedicated connection listening to channel, lost connection is restored
+// with interval growing from minReconnect to maxReconnect
+func NewListener(cfg *Config, channel string, minReconnect, maxReconnect time.Duration,
+	eventCallback pq.EventCallbackType) (*pq.Listener, error) {
+	listener := pq.NewListener(cfg.DataSourceName(), minReconnect, maxReconnect, eventCallback)
+	if err := listener.Listen(channel); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
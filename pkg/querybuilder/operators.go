@@ -28,6 +28,9 @@ func LET() Operator {
 func EQ() Operator {
 	return "="
 }
+func NEQ() Operator {
+	return "<>"
+}
 func IS() Operator {
 	return "IS"
 }
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+	"log"
+	"music-snap/pkg/msdb/mspostgres"
+	"music-snap/pkg/mslogger"
+	"music-snap/services/musicsnap/internal/catalogimport"
+	"music-snap/services/musicsnap/internal/config"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+)
+
+const MainEnvName = ".env"
+const AppCapsName = "MUSICSNAP"
+
+func init() {
+	if err := godotenv.Load(MainEnvName); err != nil {
+		log.Print(fmt.Sprintf("No '%s' file found", MainEnvName))
+	}
+}
+
+func main() {
+	dumpPath := flag.String("file", "", "path to MusicBrainz JSON lines dump or CSV file")
+	format := flag.String("format", "", "dump format: json or csv, guessed by file extension if empty")
+	name := flag.String("name", "", "checkpoint name, base name of the file if empty")
+	batchSize := flag.Int("batch", 1000, "dump records per transaction")
+	restart := flag.Bool("restart", false, "ignore saved checkpoint and import from the beginning")
+	flag.Parse()
+
+	if *dumpPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	configPath := os.Getenv("CONFIG_" + AppCapsName)
+	log.Println("Catalog import config path: ", configPath)
+
+	// Собираем конфиг приложения
+	cfg, err := config.NewConfig(configPath, AppCapsName)
+	if err != nil {
+		log.Fatal("Fail to parse musicsnap config: ", err)
+	}
+
+	logger, err := mslogger.InitLogger(cfg.Logger, "catalog-import")
+	if err != nil {
+		log.Fatal("Fail to init logger: ", err)
+	}
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	db, closeDB, err := mspostgres.NewDB(cfg.Postgres)
+	if err != nil {
+		logger.Fatal("Error init Postgres DB:", zap.Error(err))
+	}
+	defer func() {
+		_ = closeDB()
+	}()
+
+	dump, err := os.Open(*dumpPath)
+	if err != nil {
+		logger.Fatal("Error open dump:", zap.Error(err))
+	}
+	defer func() {
+		_ = dump.Close()
+	}()
+	info, err := dump.Stat()
+	if err != nil {
+		logger.Fatal("Error stat dump:", zap.Error(err))
+	}
+
+	dumpFormat := catalogimport.Format(*format)
+	if dumpFormat == "" {
+		dumpFormat = catalogimport.FormatFromPath(*dumpPath)
+	}
+	if *name == "" {
+		*name = filepath.Base(*dumpPath)
+	}
+
+	// Прерывание сохраняет уже записанные пачки, следующий запуск продолжит с контрольной точки
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	importer := catalogimport.NewImporter(catalogimport.NewPostgresStore(db), logger, catalogimport.Config{
+		Name:      *name,
+		BatchSize: *batchSize,
+		Restart:   *restart,
+	})
+
+	stats, err := importer.Run(ctx, dump, info.Size(), dumpFormat)
+	if err != nil {
+		logger.Error("catalog import stopped, rerun to resume from checkpoint",
+			zap.Error(err), zap.Int64("records", stats.Records), zap.Int64("offset", stats.Offset))
+		_ = logger.Sync()
+		_ = closeDB()
+		os.Exit(1)
+	}
+
+	logger.Info("catalog import finished",
+		zap.String("name", *name),
+		zap.Int64("records", stats.Records),
+		zap.Int64("artists", stats.Artists),
+		zap.Int64("albums", stats.Albums),
+		zap.Int64("tracks", stats.Tracks),
+	)
+}
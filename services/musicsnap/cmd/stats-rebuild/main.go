@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/joho/godotenv"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+	"log"
+	"music-snap/pkg/msdb/mspostgres"
+	"music-snap/pkg/mslogger"
+	"music-snap/services/musicsnap/internal/config"
+	"music-snap/services/musicsnap/internal/repository/postgre"
+	"music-snap/services/musicsnap/internal/service"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const MainEnvName = ".env"
+const AppCapsName = "MUSICSNAP"
+
+func init() {
+	if err := godotenv.Load(MainEnvName); err != nil {
+		log.Print(fmt.Sprintf("No '%s' file found", MainEnvName))
+	}
+}
+
+// Пересчитывает агрегаты оценок и реакций по произведениям с нуля
+func main() {
+	configPath := os.Getenv("CONFIG_" + AppCapsName)
+	log.Println("Stats rebuild config path: ", configPath)
+
+	cfg, err := config.NewConfig(configPath, AppCapsName)
+	if err != nil {
+		log.Fatal("Fail to parse musicsnap config: ", err)
+	}
+
+	logger, err := mslogger.InitLogger(cfg.Logger, "stats-rebuild")
+	if err != nil {
+		log.Fatal("Fail to init logger: ", err)
+	}
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	db, closeDB, err := mspostgres.NewDB(cfg.Postgres)
+	if err != nil {
+		logger.Fatal("Error init Postgres DB:", zap.Error(err))
+	}
+	defer func() {
+		_ = closeDB()
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx = zapctx.WithLogger(ctx, logger)
+
+	stats := service.NewStatsSvc(postgre.NewStatsRepository(db), postgre.NewCatalogRepository(db))
+
+	started := time.Now()
+	if err = stats.UpdateDWH(ctx); err != nil {
+		logger.Error("stats rebuild failed", zap.Error(err))
+		_ = logger.Sync()
+		_ = closeDB()
+		os.Exit(1)
+	}
+	logger.Info("stats rebuild finished", zap.Duration("elapsed", time.Since(started).Round(time.Millisecond)))
+}
@@ -9,17 +9,32 @@ import (
 	"go.uber.org/zap"
 	"music-snap/pkg/app"
 	"music-snap/pkg/metrics"
+	"music-snap/pkg/msbus"
 	"music-snap/pkg/msdb/mspostgres"
 	"music-snap/pkg/mslogger"
 	"music-snap/pkg/msshutdown"
 	"music-snap/pkg/mstracer"
 	"music-snap/services/musicsnap/internal/config"
 	"music-snap/services/musicsnap/internal/daemons/cacherefresher"
+	"music-snap/services/musicsnap/internal/daemons/consumer"
+	"music-snap/services/musicsnap/internal/daemons/deleter"
+	"music-snap/services/musicsnap/internal/daemons/digester"
+	"music-snap/services/musicsnap/internal/daemons/publisher"
+	"music-snap/services/musicsnap/internal/daemons/relay"
+	"music-snap/services/musicsnap/internal/daemons/reminder"
+	"music-snap/services/musicsnap/internal/daemons/streamer"
+	"music-snap/services/musicsnap/internal/daemons/webhooker"
 	"music-snap/services/musicsnap/internal/repository/cache"
 	"music-snap/services/musicsnap/internal/repository/postgre"
 	"music-snap/services/musicsnap/internal/service"
+	"music-snap/services/musicsnap/internal/service/contentfilter"
+	"music-snap/services/musicsnap/internal/service/eventbus"
+	"music-snap/services/musicsnap/internal/service/icalendar"
 	"music-snap/services/musicsnap/internal/service/jwtservice"
-	//"music-snap/services/musicsnap/internal/service/ports"
+	"music-snap/services/musicsnap/internal/service/mailsender"
+	"music-snap/services/musicsnap/internal/service/notifytext"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"music-snap/services/musicsnap/internal/service/webhooksender"
 )
 
 type App struct {
@@ -29,6 +44,14 @@ type App struct {
 	tracerProvider *trace.TracerProvider
 	service        service.MusicSnapService
 	daemon         *cacherefresher.CacheRefresher
+	publisher      *publisher.Publisher
+	digester       *digester.Digester
+	cleaner        *deleter.DBCleaner
+	streamer       *streamer.Streamer
+	webhooker      *webhooker.Webhooker
+	relay          *relay.Relay
+	reminder       *reminder.Reminder
+	consumer       *consumer.Consumer
 }
 
 func NewApp(cfg *config.Config) (*App, error) {
@@ -142,8 +165,49 @@ func NewApp(cfg *config.Config) (*App, error) {
 		return nil, errors.Wrap(err, "Init JWTService")
 	}
 
+	contentFilter, err := contentfilter.New(cfg.ContentFilter)
+	if err != nil {
+		logger.Fatal("Error init content filter:", zap.Error(err))
+		return nil, errors.Wrap(err, "Init content filter")
+	}
+
+	mailSender := mailsender.New(cfg.MailSender)
+
+	notificationRenderer, err := notifytext.New()
+	if err != nil {
+		logger.Fatal("Error init notification templates:", zap.Error(err))
+		return nil, errors.Wrap(err, "Init notification templates")
+	}
+
+	webhookSender := webhooksender.New(cfg.WebhookSender)
+	calendarEncoder := icalendar.New(cfg.ICalendar)
+
+	// Kafka writer of domain events, it is closed after outbox relay stops
+	var eventBus ports.DomainEventHandler
+	if cfg.KafkaWriter.Enable {
+		kafkaWriter := msbus.NewKafkaPublisher(*cfg.KafkaWriter)
+		msshutdown.AddCallback(
+			&msshutdown.Callback{
+				Name: "kafka writer close",
+				FnCtx: func(ctx context.Context) error {
+					return kafkaWriter.Close()
+				},
+			})
+		eventBus = eventbus.NewPublisher(kafkaWriter)
+		logger.Info("Init Kafka writer – success")
+	}
+
 	repos := postgre.NewRepository(PostgreSQL)
 
+	// Шина real-time потока между репликами через LISTEN/NOTIFY.
+	// Колбэки выполняются в обратном порядке, поэтому потоки завершаются до отключения от PostgreSQL
+	streamBus := streamer.New(logger, *cfg.Streamer, cfg.Postgres)
+	msshutdown.AddCallback(
+		&msshutdown.Callback{
+			Name:  "stream drain",
+			FnCtx: streamBus.StopFunc(),
+		})
+
 	// User
 	//userRepository := postgre.NewUserRepository(PostgreSQL)
 
@@ -152,7 +216,9 @@ func NewApp(cfg *config.Config) (*App, error) {
 	// Service layer
 
 	//bannerService := service.NewBannerService(bannerRepository, profileCache)
-	musicSnapService := service.New(repos, jwtService, profileCache)
+	musicSnapService := service.New(repos, jwtService, profileCache, contentFilter, *cfg.Moderation, *cfg.Publications, *cfg.Comments, *cfg.Tags, *cfg.Reactions,
+		streamBus, *cfg.Stream, mailSender, notificationRenderer, *cfg.Notifications, webhookSender, *cfg.Webhooks, *cfg.Outbox, eventBus,
+		calendarEncoder, *cfg.Calendar, *cfg.EventReminders)
 
 	//userSvc := service.NewUserSvc(userRepository, jwtService, profileCache)
 	//authSvc := service.NewAuthSvc(jwtService, userRepository)
@@ -191,12 +257,86 @@ func NewApp(cfg *config.Config) (*App, error) {
 
 	logger.Info("Init CacheRefresher – success")
 
+	// Publisher for scheduled reviews and publication side effects
+	reviewPublisher := publisher.New(logger, musicSnapService.Review)
+	msshutdown.AddCallback(
+		&msshutdown.Callback{
+			Name:  "review publisher daemon stop",
+			FnCtx: reviewPublisher.StopFunc(),
+		})
+
+	logger.Info("Init ReviewPublisher – success")
+
+	// Digester for daily and weekly notification digests
+	notificationDigester := digester.New(logger, musicSnapService.Notification)
+	msshutdown.AddCallback(
+		&msshutdown.Callback{
+			Name:  "notification digester daemon stop",
+			FnCtx: notificationDigester.StopFunc(),
+		})
+
+	logger.Info("Init Digester – success")
+
+	// DBCleaner for retention of notifications
+	cleaner := deleter.New(logger, repos.Notification, repos.Stream, *cfg.Retention)
+	msshutdown.AddCallback(
+		&msshutdown.Callback{
+			Name:  "notification retention daemon stop",
+			FnCtx: cleaner.StopFunc(),
+		})
+
+	logger.Info("Init DBCleaner – success")
+
+	// Webhooker for delivery of outgoing webhooks
+	webhookDeliverer := webhooker.New(logger, musicSnapService.Webhook)
+	msshutdown.AddCallback(
+		&msshutdown.Callback{
+			Name:  "webhook delivery daemon stop",
+			FnCtx: webhookDeliverer.StopFunc(),
+		})
+
+	logger.Info("Init Webhooker – success")
+
+	// Relay of domain events from transactional outbox
+	outboxRelay := relay.New(logger, musicSnapService.Outbox)
+	msshutdown.AddCallback(
+		&msshutdown.Callback{
+			Name:  "outbox relay daemon stop",
+			FnCtx: outboxRelay.StopFunc(),
+		})
+
+	logger.Info("Init Relay – success")
+
+	// Scheduler of reminders to event participants
+	eventReminder := reminder.New(logger, musicSnapService.Event)
+	msshutdown.AddCallback(
+		&msshutdown.Callback{
+			Name:  "event reminder daemon stop",
+			FnCtx: eventReminder.StopFunc(),
+		})
+
+	logger.Info("Init Reminder – success")
+
+	// Consumer group of Kafka topic with events of other services
+	// события шины передаются тем же обработчикам, что и события outbox
+	var busConsumer *consumer.Consumer
+	if cfg.KafkaReader.Enable {
+		busConsumer = consumer.New(logger, msbus.NewKafkaConsumer(*cfg.KafkaReader),
+			eventbus.NewDispatcher(musicSnapService.EventHandlers))
+		msshutdown.AddCallback(
+			&msshutdown.Callback{
+				Name:  "kafka consumer stop",
+				FnCtx: busConsumer.StopFunc(),
+			})
+		logger.Info("Init Kafka consumer – success")
+	}
+
 	//service.NewMusicSnapService()
 
 	// TRANSPORT LAYER ----------------------------------------------------------------------
 
 	// инициализируем адрес сервера
-	address := fmt.Sprintf(":%d", cfg.Http.Port)
+	address := fmt.Sprintf(":%s", cfg.Http.Port)
 
 	return &App{
 		cfg:            cfg,
@@ -205,5 +345,13 @@ func NewApp(cfg *config.Config) (*App, error) {
 		address:        address,
 		tracerProvider: tp,
 		daemon:         daemon,
+		publisher:      reviewPublisher,
+		digester:       notificationDigester,
+		cleaner:        cleaner,
+		streamer:       streamBus,
+		webhooker:      webhookDeliverer,
+		relay:          outboxRelay,
+		reminder:       eventReminder,
+		consumer:       busConsumer,
 	}, nil
 }
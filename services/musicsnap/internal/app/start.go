@@ -25,6 +25,50 @@ func (a *App) Start(ctx context.Context) {
 
 	//a.daemon.Start(daemonInterval)
 
+	publisherInterval, err := a.cfg.ReviewPublisher.GetIterationInterval()
+	if err != nil {
+		a.logger.Fatal("can't parse time from review publisher config string:", zap.Error(err))
+	}
+	a.publisher.Start(publisherInterval)
+
+	digesterInterval, err := a.cfg.Digester.GetIterationInterval()
+	if err != nil {
+		a.logger.Fatal("can't parse time from digester config string:", zap.Error(err))
+	}
+	a.digester.Start(digesterInterval)
+
+	retentionInterval, err := a.cfg.Retention.GetIterationInterval()
+	if err != nil {
+		a.logger.Fatal("can't parse time from notification retention config string:", zap.Error(err))
+	}
+	a.cleaner.Start(retentionInterval)
+
+	webhookerInterval, err := a.cfg.Webhooker.GetIterationInterval()
+	if err != nil {
+		a.logger.Fatal("can't parse time from webhooker config string:", zap.Error(err))
+	}
+	a.webhooker.Start(webhookerInterval)
+
+	relayInterval, err := a.cfg.Relay.GetIterationInterval()
+	if err != nil {
+		a.logger.Fatal("can't parse time from outbox relay config string:", zap.Error(err))
+	}
+	a.relay.Start(relayInterval)
+
+	reminderInterval, err := a.cfg.Reminders.GetIterationInterval()
+	if err != nil {
+		a.logger.Fatal("can't parse time from reminder scheduler config string:", zap.Error(err))
+	}
+	a.reminder.Start(reminderInterval)
+
+	if a.consumer != nil {
+		a.consumer.Start()
+	}
+
+	if err := a.streamer.Start(); err != nil {
+		a.logger.Fatal("can't start stream listener:", zap.Error(err))
+	}
+
 	go a.startHTTPServer(ctx)
 
 	if err := msshutdown.Wait(a.cfg.GracefulShutdown); err != nil {
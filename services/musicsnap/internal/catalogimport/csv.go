@@ -0,0 +1,137 @@
+package catalogimport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSV dump columns, header row is required and column order is free.
+// kind is one of artist, album, track; name is artist name, album or track title
+const (
+	kindColumn        = "kind"
+	mbidColumn        = "mbid"
+	nameColumn        = "name"
+	artistMBIDColumn  = "artist_mbid"
+	artistNameColumn  = "artist_name"
+	albumMBIDColumn   = "album_mbid"
+	durationColumn    = "duration_ms"
+	releaseDateColumn = "release_date"
+	explicitColumn    = "explicit"
+	coverURLColumn    = "cover_url"
+)
+
+var requiredCSVColumns = []string{kindColumn, mbidColumn, nameColumn}
+
+type csvDecoder struct {
+	r       *csv.Reader
+	base    int64
+	columns map[string]int
+	row     []string
+}
+
+func newCSVDecoder(r io.ReadSeeker, offset int64) (*csvDecoder, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("seek csv dump to header: %w", err)
+	}
+	headerReader := csv.NewReader(r)
+	header, err := headerReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, col := range header {
+		columns[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+	for _, col := range requiredCSVColumns {
+		if _, ok := columns[col]; !ok {
+			return nil, fmt.Errorf("csv header has no %q column", col)
+		}
+	}
+
+	if headerEnd := headerReader.InputOffset(); offset < headerEnd {
+		offset = headerEnd
+	}
+	if _, err = r.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("seek csv dump to %d: %w", offset, err)
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(header)
+	reader.ReuseRecord = true
+
+	return &csvDecoder{r: reader, base: offset, columns: columns}, nil
+}
+
+func (d *csvDecoder) Offset() int64 {
+	return d.base + d.r.InputOffset()
+}
+
+func (d *csvDecoder) get(col string) string {
+	i, ok := d.columns[col]
+	if !ok || i >= len(d.row) {
+		return ""
+	}
+	return strings.TrimSpace(d.row[i])
+}
+
+func (d *csvDecoder) Decode(b *Batch) error {
+	rowStart := d.Offset()
+	row, err := d.r.Read()
+	if err != nil {
+		return err
+	}
+	d.row = row
+
+	mbid := d.get(mbidColumn)
+	name := d.get(nameColumn)
+	if mbid == "" || name == "" {
+		return fmt.Errorf("csv row at byte %d: mbid and name are required", rowStart)
+	}
+
+	artistMBID := d.get(artistMBIDColumn)
+	if artistName := d.get(artistNameColumn); artistMBID != "" && artistName != "" {
+		b.Artists = append(b.Artists, Artist{MBID: artistMBID, Name: artistName})
+	}
+
+	switch kind := d.get(kindColumn); kind {
+	case "artist":
+		b.Artists = append(b.Artists, Artist{MBID: mbid, Name: name})
+	case "album":
+		b.Albums = append(b.Albums, Album{
+			MBID:        mbid,
+			Title:       name,
+			ArtistMBID:  artistMBID,
+			CoverURL:    d.get(coverURLColumn),
+			ReleaseDate: parseReleaseDate(d.get(releaseDateColumn)),
+		})
+	case "track":
+		track := Track{
+			MBID:       mbid,
+			Title:      name,
+			ArtistMBID: artistMBID,
+			AlbumMBID:  d.get(albumMBIDColumn),
+		}
+		if v := d.get(durationColumn); v != "" {
+			track.DurationMs, err = strconv.Atoi(v)
+			if err != nil || track.DurationMs < 0 {
+				return fmt.Errorf("csv row at byte %d: invalid duration_ms %q", rowStart, v)
+			}
+		}
+		if v := d.get(explicitColumn); v != "" {
+			track.Explicit, err = strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("csv row at byte %d: invalid explicit %q", rowStart, v)
+			}
+		}
+		b.Tracks = append(b.Tracks, track)
+	default:
+		return fmt.Errorf("csv row at byte %d: unknown kind %q", rowStart, kind)
+	}
+
+	b.Records++
+	return nil
+}
@@ -0,0 +1,43 @@
+package catalogimport
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+type Format string
+
+const (
+	JSONFormat Format = "json"
+	CSVFormat  Format = "csv"
+)
+
+// FormatFromPath guesses dump format by file extension, MusicBrainz dumps without extension are JSON lines
+func FormatFromPath(path string) Format {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return CSVFormat
+	}
+	return JSONFormat
+}
+
+// Decoder reads catalog entities from a dump one record at a time
+type Decoder interface {
+	// Decode appends entities of the next record to the batch, returns io.EOF at the end of dump
+	Decode(b *Batch) error
+	// Offset returns byte offset right after the last decoded record
+	Offset() int64
+}
+
+// NewDecoder creates decoder of the format that starts reading from offset
+func NewDecoder(format Format, r io.ReadSeeker, offset int64) (Decoder, error) {
+	switch format {
+	case JSONFormat:
+		return newJSONDecoder(r, offset)
+	case CSVFormat:
+		return newCSVDecoder(r, offset)
+	default:
+		return nil, fmt.Errorf("unknown dump format %q", format)
+	}
+}
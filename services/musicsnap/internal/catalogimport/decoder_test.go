@@ -0,0 +1,155 @@
+package catalogimport
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+const releaseLine = `{"id":"r1","title":"Группа крови","date":"1988-01-05",` +
+	`"artist-credit":[{"artist":{"id":"a1","name":"Кино"}}],` +
+	`"release-group":{"id":"rg1","title":"Группа крови","first-release-date":"1988"},` +
+	`"cover-art-archive":{"front":true},` +
+	`"media":[{"tracks":[` +
+	`{"title":"Группа крови","length":286000,"recording":{"id":"rec1","title":"Группа крови","length":285000}},` +
+	`{"title":"Закрой за мной дверь","recording":{"id":"rec2","title":"Закрой за мной дверь","length":255000}}` +
+	`]}]}`
+
+const artistLine = `{"id":"a2","name":"Аквариум","sort-name":"Aquarium"}`
+
+const recordingLine = `{"id":"rec3","title":"Город золотой","length":190000,"artist-credit":[{"artist":{"id":"a2","name":"Аквариум"}}]}`
+
+func decodeAll(t *testing.T, dec Decoder) Batch {
+	t.Helper()
+	var b Batch
+	for {
+		err := dec.Decode(&b)
+		if errors.Is(err, io.EOF) {
+			return b
+		}
+		require.NoError(t, err)
+	}
+}
+
+func TestJSONDecoder(t *testing.T) {
+	t.Parallel()
+
+	dump := releaseLine + "\n\n" + artistLine + "\n" + recordingLine
+
+	t.Run("Release", func(t *testing.T) {
+		t.Parallel()
+
+		dec, err := NewDecoder(JSONFormat, strings.NewReader(dump), 0)
+		require.NoError(t, err)
+
+		b := decodeAll(t, dec)
+		assert.Equal(t, 3, b.Records)
+		assert.Equal(t, int64(len(dump)), dec.Offset())
+
+		require.Len(t, b.Albums, 1)
+		album := b.Albums[0]
+		assert.Equal(t, "rg1", album.MBID)
+		assert.Equal(t, "a1", album.ArtistMBID)
+		assert.Equal(t, time.Date(1988, 1, 1, 0, 0, 0, 0, time.UTC), album.ReleaseDate)
+		assert.Equal(t, "https://coverartarchive.org/release-group/rg1/front", album.CoverURL)
+
+		require.Len(t, b.Tracks, 3)
+		assert.Equal(t, Track{MBID: "rec1", Title: "Группа крови", ArtistMBID: "a1", AlbumMBID: "rg1", DurationMs: 285000}, b.Tracks[0])
+		assert.Equal(t, Track{MBID: "rec3", Title: "Город золотой", ArtistMBID: "a2", DurationMs: 190000}, b.Tracks[2])
+
+		assert.Contains(t, b.Artists, Artist{MBID: "a1", Name: "Кино"})
+		assert.Contains(t, b.Artists, Artist{MBID: "a2", Name: "Аквариум"})
+	})
+
+	t.Run("ResumeFromOffset", func(t *testing.T) {
+		t.Parallel()
+
+		offset := int64(len(releaseLine) + 2)
+		dec, err := NewDecoder(JSONFormat, strings.NewReader(dump), offset)
+		require.NoError(t, err)
+
+		b := decodeAll(t, dec)
+		assert.Equal(t, 2, b.Records)
+		assert.Empty(t, b.Albums)
+		require.Len(t, b.Tracks, 1)
+		assert.Equal(t, "rec3", b.Tracks[0].MBID)
+	})
+
+	t.Run("BrokenLine", func(t *testing.T) {
+		t.Parallel()
+
+		dec, err := NewDecoder(JSONFormat, strings.NewReader(artistLine+"\n{broken\n"), 0)
+		require.NoError(t, err)
+
+		var b Batch
+		require.NoError(t, dec.Decode(&b))
+		assert.Error(t, dec.Decode(&b))
+	})
+}
+
+func TestCSVDecoder(t *testing.T) {
+	t.Parallel()
+
+	const header = "kind,mbid,name,artist_mbid,artist_name,album_mbid,duration_ms,release_date,explicit\n"
+	const rows = "album,rg1,Группа крови,a1,Кино,,,1988,\n" +
+		"track,rec1,Кукушка,a1,Кино,rg1,396000,,false\n" +
+		"artist,a2,Аквариум,,,,,,\n"
+
+	t.Run("Decode", func(t *testing.T) {
+		t.Parallel()
+
+		dec, err := NewDecoder(CSVFormat, strings.NewReader(header+rows), 0)
+		require.NoError(t, err)
+
+		b := decodeAll(t, dec)
+		assert.Equal(t, 3, b.Records)
+		assert.Equal(t, int64(len(header+rows)), dec.Offset())
+
+		require.Len(t, b.Albums, 1)
+		assert.Equal(t, "a1", b.Albums[0].ArtistMBID)
+		require.Len(t, b.Tracks, 1)
+		assert.Equal(t, Track{MBID: "rec1", Title: "Кукушка", ArtistMBID: "a1", AlbumMBID: "rg1", DurationMs: 396000}, b.Tracks[0])
+		assert.Len(t, b.Artists, 3)
+	})
+
+	t.Run("ResumeFromOffset", func(t *testing.T) {
+		t.Parallel()
+
+		dec, err := NewDecoder(CSVFormat, strings.NewReader(header+rows), 0)
+		require.NoError(t, err)
+		var b Batch
+		require.NoError(t, dec.Decode(&b))
+
+		resumed, err := NewDecoder(CSVFormat, strings.NewReader(header+rows), dec.Offset())
+		require.NoError(t, err)
+		b = decodeAll(t, resumed)
+		assert.Equal(t, 2, b.Records)
+		assert.Empty(t, b.Albums)
+	})
+
+	t.Run("InvalidRows", func(t *testing.T) {
+		t.Parallel()
+
+		for _, row := range []string{
+			"playlist,p1,Mix,,,,,,\n",
+			"track,rec1,Song,,,,-5,,\n",
+			"track,,Song,,,,,,\n",
+		} {
+			dec, err := NewDecoder(CSVFormat, strings.NewReader(header+row), 0)
+			require.NoError(t, err)
+			var b Batch
+			assert.Error(t, dec.Decode(&b), row)
+		}
+	})
+
+	t.Run("MissingColumns", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewDecoder(CSVFormat, strings.NewReader("mbid,name\n"), 0)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,131 @@
+package catalogimport
+
+import (
+	c "context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"time"
+)
+
+const defaultBatchSize = 1000
+
+type Config struct {
+	// Name identifies import checkpoint, the same dump should be imported with the same name
+	Name string
+	// BatchSize is the amount of dump records written in one transaction
+	BatchSize int
+	// Restart ignores saved checkpoint and imports dump from the beginning
+	Restart bool
+}
+
+// Importer streams dump into catalog by batches and saves checkpoint after each batch
+type Importer struct {
+	store  Store
+	logger *zap.Logger
+	cfg    Config
+}
+
+func NewImporter(store Store, logger *zap.Logger, cfg Config) Importer {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	return Importer{store: store, logger: logger, cfg: cfg}
+}
+
+// Stats: Итог импорта
+type Stats struct {
+	Records int64
+	Artists int64
+	Albums  int64
+	Tracks  int64
+	// Unattributed is the amount of albums and tracks without artist, they are imported with unknown artist
+	Unattributed int64
+	Offset       int64
+}
+
+// Run imports dump of the format, dumpSize is used only for progress reporting
+func (im Importer) Run(ctx c.Context, dump io.ReadSeeker, dumpSize int64, format Format) (Stats, error) {
+	cp := Checkpoint{Name: im.cfg.Name}
+	if !im.cfg.Restart {
+		var err error
+		cp, err = im.store.LoadCheckpoint(ctx, im.cfg.Name)
+		if err != nil {
+			return Stats{}, err
+		}
+	}
+	if cp.Offset > 0 {
+		im.logger.Info("resuming import from checkpoint",
+			zap.String("name", cp.Name), zap.Int64("offset", cp.Offset), zap.Int64("records", cp.Records))
+	}
+
+	dec, err := NewDecoder(format, dump, cp.Offset)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	stats := Stats{Records: cp.Records, Offset: cp.Offset}
+	started := time.Now()
+	startOffset := cp.Offset
+
+	var b Batch
+	for {
+		if err = ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		decodeErr := dec.Decode(&b)
+		if decodeErr != nil && !errors.Is(decodeErr, io.EOF) {
+			return stats, fmt.Errorf("record %d: %w", stats.Records+int64(b.Records)+1, decodeErr)
+		}
+
+		done := errors.Is(decodeErr, io.EOF)
+		if b.Records >= im.cfg.BatchSize || (done && b.Records > 0) {
+			cp.Offset = dec.Offset()
+			cp.Records = stats.Records + int64(b.Records)
+			if err = im.store.Flush(ctx, b, cp); err != nil {
+				return stats, err
+			}
+
+			stats.Records = cp.Records
+			stats.Offset = cp.Offset
+			stats.Artists += int64(len(b.Artists))
+			stats.Albums += int64(len(b.Albums))
+			stats.Tracks += int64(len(b.Tracks))
+			stats.Unattributed += b.unattributed()
+			im.reportProgress(stats, dumpSize, startOffset, started)
+			b.Reset()
+		}
+
+		if done {
+			return stats, nil
+		}
+	}
+}
+
+func (im Importer) reportProgress(stats Stats, dumpSize, startOffset int64, started time.Time) {
+	elapsed := time.Since(started)
+
+	var percent float64
+	if dumpSize > 0 {
+		percent = float64(stats.Offset) * 100 / float64(dumpSize)
+	}
+
+	var eta time.Duration
+	if read := stats.Offset - startOffset; read > 0 && dumpSize > stats.Offset {
+		eta = time.Duration(float64(elapsed) * float64(dumpSize-stats.Offset) / float64(read)).Round(time.Second)
+	}
+
+	im.logger.Info("catalog import progress",
+		zap.String("name", im.cfg.Name),
+		zap.Int64("records", stats.Records),
+		zap.Int64("artists", stats.Artists),
+		zap.Int64("albums", stats.Albums),
+		zap.Int64("tracks", stats.Tracks),
+		zap.Int64("unattributed", stats.Unattributed),
+		zap.String("progress", fmt.Sprintf("%.2f%%", percent)),
+		zap.Duration("elapsed", elapsed.Round(time.Second)),
+		zap.Duration("eta", eta),
+	)
+}
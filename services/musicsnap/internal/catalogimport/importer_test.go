@@ -0,0 +1,100 @@
+package catalogimport
+
+import (
+	c "context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"music-snap/services/musicsnap/internal/domain"
+	"strings"
+	"testing"
+)
+
+type memoryStore struct {
+	checkpoint Checkpoint
+	tracks     []Track
+	failAfter  int
+}
+
+func (s *memoryStore) LoadCheckpoint(_ c.Context, name string) (Checkpoint, error) {
+	if s.checkpoint.Name != name {
+		return Checkpoint{Name: name}, nil
+	}
+	return s.checkpoint, nil
+}
+
+func (s *memoryStore) Flush(_ c.Context, b Batch, cp Checkpoint) error {
+	if s.failAfter == 0 {
+		return errors.New("connection lost")
+	}
+	s.failAfter--
+	s.tracks = append(s.tracks, b.Tracks...)
+	s.checkpoint = cp
+	return nil
+}
+
+func TestImporterResume(t *testing.T) {
+	t.Parallel()
+
+	const header = "kind,mbid,name\n"
+	dump := header +
+		"track,t1,One\n" +
+		"track,t2,Two\n" +
+		"track,t3,Three\n" +
+		"track,t4,Four\n" +
+		"track,t5,Five\n"
+
+	store := &memoryStore{failAfter: 1}
+	im := NewImporter(store, zap.NewNop(), Config{Name: "dump.csv", BatchSize: 2})
+
+	stats, err := im.Run(c.Background(), strings.NewReader(dump), int64(len(dump)), CSVFormat)
+	require.Error(t, err)
+	assert.Equal(t, int64(2), stats.Records)
+	assert.Equal(t, int64(2), store.checkpoint.Records)
+
+	store.failAfter = -1
+	stats, err = im.Run(c.Background(), strings.NewReader(dump), int64(len(dump)), CSVFormat)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), stats.Records)
+	assert.Equal(t, int64(len(dump)), store.checkpoint.Offset)
+
+	titles := make([]string, len(store.tracks))
+	for i, tr := range store.tracks {
+		titles[i] = tr.Title
+	}
+	assert.Equal(t, []string{"One", "Two", "Three", "Four", "Five"}, titles)
+}
+
+func TestImporterWithoutArtist(t *testing.T) {
+	t.Parallel()
+
+	const header = "kind,mbid,name,artist_mbid,artist_name\n"
+	dump := header +
+		"album,rg1,Single,,\n" +
+		"track,t1,One,a1,Кино\n" +
+		"track,t2,Two,,\n" +
+		"track,t3,Three,a2,\n"
+
+	store := &memoryStore{failAfter: -1}
+	im := NewImporter(store, zap.NewNop(), Config{Name: "dump.csv", BatchSize: 10})
+
+	stats, err := im.Run(c.Background(), strings.NewReader(dump), int64(len(dump)), CSVFormat)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), stats.Records)
+	assert.Equal(t, int64(2), stats.Unattributed)
+
+	require.Len(t, store.tracks, 3)
+	assert.Equal(t, ArtistID("a1"), artistID(store.tracks[0].ArtistMBID))
+	assert.Equal(t, domain.UnknownArtistID, artistID(store.tracks[1].ArtistMBID))
+	// artist without name is not imported, the store falls back to unknown artist if it is missing in catalog
+	assert.Equal(t, ArtistID("a2"), artistID(store.tracks[2].ArtistMBID))
+}
+
+func TestDeterministicIDs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ArtistID("a1"), ArtistID("a1"))
+	assert.NotEqual(t, ArtistID("a1"), AlbumID("a1"))
+	assert.NotEqual(t, AlbumID("a1"), TrackID("a1"))
+}
@@ -0,0 +1,198 @@
+package catalogimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const coverArtURLPrefix = "https://coverartarchive.org/release-group/"
+
+type mbArtistCredit struct {
+	Artist struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"artist"`
+}
+
+type mbTrack struct {
+	Title     string    `json:"title"`
+	Length    *int      `json:"length"`
+	Recording *mbEntity `json:"recording"`
+}
+
+type mbMedium struct {
+	Tracks []mbTrack `json:"tracks"`
+}
+
+// mbEntity covers artist, release, release-group and recording documents of MusicBrainz JSON dumps
+type mbEntity struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Title string `json:"title"`
+
+	Length           *int   `json:"length"`
+	Date             string `json:"date"`
+	FirstReleaseDate string `json:"first-release-date"`
+	PrimaryType      string `json:"primary-type"`
+
+	ArtistCredit []mbArtistCredit `json:"artist-credit"`
+	ReleaseGroup *mbEntity        `json:"release-group"`
+	Media        []mbMedium       `json:"media"`
+	CoverArt     *struct {
+		Front bool `json:"front"`
+	} `json:"cover-art-archive"`
+}
+
+// jsonDecoder reads MusicBrainz JSON dump, one document per line
+type jsonDecoder struct {
+	r      *bufio.Reader
+	offset int64
+}
+
+func newJSONDecoder(r io.ReadSeeker, offset int64) (*jsonDecoder, error) {
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("seek json dump to %d: %w", offset, err)
+	}
+	return &jsonDecoder{r: bufio.NewReaderSize(r, 1<<20), offset: offset}, nil
+}
+
+func (d *jsonDecoder) Offset() int64 {
+	return d.offset
+}
+
+func (d *jsonDecoder) Decode(b *Batch) error {
+	for {
+		line, err := d.r.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if len(line) == 0 && errors.Is(err, io.EOF) {
+			return io.EOF
+		}
+		lineStart := d.offset
+		d.offset += int64(len(line))
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		var e mbEntity
+		if jsonErr := json.Unmarshal(line, &e); jsonErr != nil {
+			return fmt.Errorf("decode json record at byte %d: %w", lineStart, jsonErr)
+		}
+		addMBEntity(b, e)
+		b.Records++
+		return nil
+	}
+}
+
+func firstCredit(credits []mbArtistCredit) (Artist, bool) {
+	if len(credits) == 0 || credits[0].Artist.ID == "" {
+		return Artist{}, false
+	}
+	return Artist{MBID: credits[0].Artist.ID, Name: credits[0].Artist.Name}, true
+}
+
+// addMBEntity converts MusicBrainz document to catalog entities.
+// Only the first artist credit is used, because catalog keeps one artist per piece
+func addMBEntity(b *Batch, e mbEntity) {
+	switch {
+	case e.Media != nil || e.ReleaseGroup != nil:
+		addMBRelease(b, e)
+	case e.Title == "" && e.Name != "":
+		b.Artists = append(b.Artists, Artist{MBID: e.ID, Name: e.Name})
+	case e.PrimaryType != "" || e.FirstReleaseDate != "":
+		artist, ok := firstCredit(e.ArtistCredit)
+		if ok {
+			b.Artists = append(b.Artists, artist)
+		}
+		b.Albums = append(b.Albums, Album{
+			MBID:        e.ID,
+			Title:       e.Title,
+			ArtistMBID:  artist.MBID,
+			ReleaseDate: parseReleaseDate(e.FirstReleaseDate),
+		})
+	case e.Title != "":
+		artist, ok := firstCredit(e.ArtistCredit)
+		if ok {
+			b.Artists = append(b.Artists, artist)
+		}
+		b.Tracks = append(b.Tracks, Track{
+			MBID:       e.ID,
+			Title:      e.Title,
+			ArtistMBID: artist.MBID,
+			DurationMs: lengthOrZero(e.Length),
+		})
+	}
+}
+
+// addMBRelease adds release group as album and release tracks as album tracks
+func addMBRelease(b *Batch, e mbEntity) {
+	artist, ok := firstCredit(e.ArtistCredit)
+	if ok {
+		b.Artists = append(b.Artists, artist)
+	}
+
+	album := Album{
+		MBID:        e.ID,
+		Title:       e.Title,
+		ArtistMBID:  artist.MBID,
+		ReleaseDate: parseReleaseDate(e.Date),
+	}
+	if rg := e.ReleaseGroup; rg != nil && rg.ID != "" {
+		album.MBID = rg.ID
+		if rg.Title != "" {
+			album.Title = rg.Title
+		}
+		if date := parseReleaseDate(rg.FirstReleaseDate); !date.IsZero() {
+			album.ReleaseDate = date
+		}
+	}
+	if e.CoverArt != nil && e.CoverArt.Front {
+		album.CoverURL = coverArtURLPrefix + album.MBID + "/front"
+	}
+	b.Albums = append(b.Albums, album)
+
+	for _, m := range e.Media {
+		for _, t := range m.Tracks {
+			if t.Recording == nil || t.Recording.ID == "" {
+				continue
+			}
+			trackArtist, ok := firstCredit(t.Recording.ArtistCredit)
+			if ok {
+				b.Artists = append(b.Artists, trackArtist)
+			} else {
+				trackArtist = artist
+			}
+
+			title := t.Recording.Title
+			if title == "" {
+				title = t.Title
+			}
+			length := t.Recording.Length
+			if length == nil {
+				length = t.Length
+			}
+
+			b.Tracks = append(b.Tracks, Track{
+				MBID:       t.Recording.ID,
+				Title:      title,
+				ArtistMBID: trackArtist.MBID,
+				AlbumMBID:  album.MBID,
+				DurationMs: lengthOrZero(length),
+			})
+		}
+	}
+}
+
+func lengthOrZero(l *int) int {
+	if l == nil || *l < 0 {
+		return 0
+	}
+	return *l
+}
@@ -0,0 +1,222 @@
+package catalogimport
+
+import (
+	c "context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"music-snap/services/musicsnap/internal/domain"
+)
+
+// Store persists batches together with checkpoint of the import
+type Store interface {
+	LoadCheckpoint(ctx c.Context, name string) (Checkpoint, error)
+	// Flush writes batch and checkpoint in one transaction, so checkpoint never gets ahead of data
+	Flush(ctx c.Context, b Batch, cp Checkpoint) error
+}
+
+func NewPostgresStore(db *sqlx.DB) Store {
+	return postgresStore{db: db}
+}
+
+type postgresStore struct {
+	db *sqlx.DB
+}
+
+func (s postgresStore) LoadCheckpoint(ctx c.Context, name string) (Checkpoint, error) {
+	q := `
+	SELECT name, byte_offset, records FROM catalog_import_checkpoints
+	WHERE name = $1;
+	`
+	cp := Checkpoint{Name: name}
+	err := s.db.QueryRowContext(ctx, q, name).Scan(&cp.Name, &cp.Offset, &cp.Records)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Checkpoint{Name: name}, nil
+		}
+		return Checkpoint{}, fmt.Errorf("load checkpoint %q: %w", name, err)
+	}
+	return cp, nil
+}
+
+// Staging tables are dropped on commit, COPY goes into them and upserts read from them
+var stagingTables = []string{
+	`CREATE TEMP TABLE import_artists (id UUID, mbid TEXT, name TEXT) ON COMMIT DROP;`,
+	`CREATE TEMP TABLE import_albums (id UUID, mbid TEXT, artist_id UUID, title TEXT, cover_url TEXT, release_date TIMESTAMP) ON COMMIT DROP;`,
+	`CREATE TEMP TABLE import_tracks (id UUID, mbid TEXT, artist_id UUID, album_mbid TEXT, title TEXT, duration_ms INTEGER, explicit BOOLEAN) ON COMMIT DROP;`,
+}
+
+// unknownArtist is set for albums and tracks without artist credit or with artist missing in the dump,
+// it never replaces artist already known for the piece
+var unknownArtist = fmt.Sprintf("'%s'::UUID", domain.UnknownArtistID)
+
+// Upserts are idempotent: artists have deterministic ids, pieces are matched by musicbrainz alias,
+// so pieces created by hand with the same alias are updated instead of duplicated
+var upserts = []string{
+	`
+	INSERT INTO artists (id, name)
+	SELECT DISTINCT ON (id) id, name FROM import_artists
+	ORDER BY id
+	ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name
+	WHERE artists.name IS DISTINCT FROM EXCLUDED.name;
+	`,
+
+	`
+	INSERT INTO pieces (id, type)
+	SELECT DISTINCT s.id, 'album' FROM import_albums s
+	WHERE NOT EXISTS (SELECT 1 FROM piece_aliases a WHERE a.source = 'musicbrainz' AND a.external_id = s.mbid)
+	ON CONFLICT (id) DO NOTHING;
+	`,
+	`
+	INSERT INTO piece_aliases (source, external_id, piece_id)
+	SELECT DISTINCT ON (s.mbid) 'musicbrainz', s.mbid, s.id FROM import_albums s
+	ORDER BY s.mbid
+	ON CONFLICT (source, external_id) DO NOTHING;
+	`,
+	fmt.Sprintf(`
+	INSERT INTO albums (id, artist_id, title, cover_url, release_date)
+	SELECT DISTINCT ON (a.piece_id) a.piece_id, COALESCE(ar.id, %[1]s), s.title, NULLIF(s.cover_url, ''), s.release_date
+	FROM import_albums s
+	JOIN piece_aliases a ON a.source = 'musicbrainz' AND a.external_id = s.mbid
+	JOIN pieces p ON p.id = a.piece_id AND p.type = 'album'
+	LEFT JOIN artists ar ON ar.id = s.artist_id
+	ORDER BY a.piece_id
+	ON CONFLICT (id) DO UPDATE SET artist_id    = CASE WHEN EXCLUDED.artist_id = %[1]s THEN albums.artist_id
+	                                                   ELSE EXCLUDED.artist_id END,
+	                               title        = EXCLUDED.title,
+	                               cover_url    = COALESCE(EXCLUDED.cover_url, albums.cover_url),
+	                               release_date = COALESCE(EXCLUDED.release_date, albums.release_date);
+	`, unknownArtist),
+
+	`
+	INSERT INTO pieces (id, type)
+	SELECT DISTINCT s.id, 'track' FROM import_tracks s
+	WHERE NOT EXISTS (SELECT 1 FROM piece_aliases a WHERE a.source = 'musicbrainz' AND a.external_id = s.mbid)
+	ON CONFLICT (id) DO NOTHING;
+	`,
+	`
+	INSERT INTO piece_aliases (source, external_id, piece_id)
+	SELECT DISTINCT ON (s.mbid) 'musicbrainz', s.mbid, s.id FROM import_tracks s
+	ORDER BY s.mbid
+	ON CONFLICT (source, external_id) DO NOTHING;
+	`,
+	fmt.Sprintf(`
+	INSERT INTO tracks (id, artist_id, album_id, title, duration_ms, explicit)
+	SELECT DISTINCT ON (a.piece_id) a.piece_id, COALESCE(ar.id, %[1]s), al.id, s.title, s.duration_ms, s.explicit
+	FROM import_tracks s
+	JOIN piece_aliases a ON a.source = 'musicbrainz' AND a.external_id = s.mbid
+	JOIN pieces p ON p.id = a.piece_id AND p.type = 'track'
+	LEFT JOIN artists ar ON ar.id = s.artist_id
+	LEFT JOIN piece_aliases aa ON aa.source = 'musicbrainz' AND aa.external_id = NULLIF(s.album_mbid, '')
+	LEFT JOIN albums al ON al.id = aa.piece_id
+	ORDER BY a.piece_id, al.id NULLS LAST
+	ON CONFLICT (id) DO UPDATE SET artist_id   = CASE WHEN EXCLUDED.artist_id = %[1]s THEN tracks.artist_id
+	                                                  ELSE EXCLUDED.artist_id END,
+	                               album_id    = COALESCE(EXCLUDED.album_id, tracks.album_id),
+	                               title       = EXCLUDED.title,
+	                               duration_ms = GREATEST(EXCLUDED.duration_ms, tracks.duration_ms),
+	                               explicit    = EXCLUDED.explicit OR tracks.explicit;
+	`, unknownArtist),
+}
+
+func (s postgresStore) Flush(ctx c.Context, b Batch, cp Checkpoint) error {
+	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	for _, q := range stagingTables {
+		if _, err = tx.ExecContext(ctx, q); err != nil {
+			return fmt.Errorf("create staging table: %w", err)
+		}
+	}
+
+	err = copyRows(ctx, tx, "import_artists", []string{"id", "mbid", "name"}, len(b.Artists), func(i int) []interface{} {
+		a := b.Artists[i]
+		return []interface{}{ArtistID(a.MBID), a.MBID, a.Name}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = copyRows(ctx, tx, "import_albums", []string{"id", "mbid", "artist_id", "title", "cover_url", "release_date"}, len(b.Albums), func(i int) []interface{} {
+		a := b.Albums[i]
+		return []interface{}{AlbumID(a.MBID), a.MBID, artistID(a.ArtistMBID), a.Title, a.CoverURL, nullableTime(a)}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = copyRows(ctx, tx, "import_tracks", []string{"id", "mbid", "artist_id", "album_mbid", "title", "duration_ms", "explicit"}, len(b.Tracks), func(i int) []interface{} {
+		t := b.Tracks[i]
+		return []interface{}{TrackID(t.MBID), t.MBID, artistID(t.ArtistMBID), t.AlbumMBID, t.Title, t.DurationMs, t.Explicit}
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, q := range upserts {
+		if _, err = tx.ExecContext(ctx, q); err != nil {
+			return fmt.Errorf("upsert catalog: %w", err)
+		}
+	}
+
+	qCheckpoint := `
+	INSERT INTO catalog_import_checkpoints (name, byte_offset, records)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (name) DO UPDATE SET byte_offset = EXCLUDED.byte_offset, records = EXCLUDED.records;
+	`
+	if _, err = tx.ExecContext(ctx, qCheckpoint, cp.Name, cp.Offset, cp.Records); err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit batch: %w", err)
+	}
+	return nil
+}
+
+// copyRows streams rows into table with COPY FROM STDIN
+func copyRows(ctx c.Context, tx *sqlx.Tx, table string, columns []string, n int, row func(i int) []interface{}) error {
+	if n == 0 {
+		return nil
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn(table, columns...))
+	if err != nil {
+		return fmt.Errorf("prepare copy into %s: %w", table, err)
+	}
+
+	for i := 0; i < n; i++ {
+		if _, err = stmt.ExecContext(ctx, row(i)...); err != nil {
+			_ = stmt.Close()
+			return fmt.Errorf("copy into %s: %w", table, err)
+		}
+	}
+	if _, err = stmt.ExecContext(ctx); err != nil {
+		_ = stmt.Close()
+		return fmt.Errorf("flush copy into %s: %w", table, err)
+	}
+	return stmt.Close()
+}
+
+// artistID returns unknown artist for records without artist credit
+func artistID(mbid string) uuid.UUID {
+	if mbid == "" {
+		return domain.UnknownArtistID
+	}
+	return ArtistID(mbid)
+}
+
+func nullableTime(a Album) interface{} {
+	if a.ReleaseDate.IsZero() {
+		return nil
+	}
+	return a.ReleaseDate
+}
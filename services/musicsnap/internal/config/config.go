@@ -6,14 +6,27 @@ import (
 	"music-snap/pkg/app"
 	msconfig "music-snap/pkg/config"
 	"music-snap/pkg/metrics"
+	"music-snap/pkg/msbus"
 	"music-snap/pkg/msdb/mspostgres"
 	"music-snap/pkg/mshttp"
 	"music-snap/pkg/mslogger"
 	"music-snap/pkg/msshutdown"
 	"music-snap/pkg/mstracer"
 	"music-snap/services/musicsnap/internal/daemons/cacherefresher"
+	"music-snap/services/musicsnap/internal/daemons/deleter"
+	"music-snap/services/musicsnap/internal/daemons/digester"
+	"music-snap/services/musicsnap/internal/daemons/publisher"
+	"music-snap/services/musicsnap/internal/daemons/relay"
+	"music-snap/services/musicsnap/internal/daemons/reminder"
+	"music-snap/services/musicsnap/internal/daemons/streamer"
+	"music-snap/services/musicsnap/internal/daemons/webhooker"
 	"music-snap/services/musicsnap/internal/repository/cache"
+	"music-snap/services/musicsnap/internal/service/contentfilter"
+	"music-snap/services/musicsnap/internal/service/icalendar"
 	"music-snap/services/musicsnap/internal/service/jwtservice"
+	"music-snap/services/musicsnap/internal/service/mailsender"
+	"music-snap/services/musicsnap/internal/service/webhooksender"
+	"time"
 	//"music-snap/services/musicsnap/internal/repository/postgre"
 )
 
@@ -25,9 +38,33 @@ type Config struct {
 	GracefulShutdown *msshutdown.Config     `mapstructure:"graceful_shutdown"`
 	Tracer           *mstracer.Config       `mapstructure:"tracer"`
 	CacheRefresher   *cacherefresher.Config `mapstructure:"cache_refresher"`
+	ReviewPublisher  *publisher.Config      `mapstructure:"review_publisher"`
+	Publications     *PublicationsConfig    `mapstructure:"review_publications"`
+	Retention        *deleter.Config        `mapstructure:"notification_retention"`
 	Cache            *cache.Config          `mapstructure:"cache"`
 	Postgres         *mspostgres.Config     `mapstructure:"postgres"`
 	JWTService       *jwtservice.Config     `mapstructure:"jwtservice"`
+	Moderation       *ModerationConfig      `mapstructure:"moderation"`
+	ContentFilter    *contentfilter.Config  `mapstructure:"content_filter"`
+	Comments         *CommentsConfig        `mapstructure:"comments"`
+	Tags             *TagsConfig            `mapstructure:"tags"`
+	Reactions        *ReactionsConfig       `mapstructure:"reactions"`
+	Streamer         *streamer.Config       `mapstructure:"streamer"`
+	Stream           *StreamConfig          `mapstructure:"stream"`
+	Notifications    *NotificationsConfig   `mapstructure:"notifications"`
+	MailSender       *mailsender.Config     `mapstructure:"mail_sender"`
+	Digester         *digester.Config       `mapstructure:"digester"`
+	Webhooks         *WebhooksConfig        `mapstructure:"webhooks"`
+	WebhookSender    *webhooksender.Config  `mapstructure:"webhook_sender"`
+	Webhooker        *webhooker.Config      `mapstructure:"webhooker"`
+	Outbox           *OutboxConfig          `mapstructure:"outbox"`
+	Relay            *relay.Config          `mapstructure:"outbox_relay"`
+	KafkaReader      *msbus.ReaderConfig    `mapstructure:"kafka_reader"`
+	KafkaWriter      *msbus.WriterConfig    `mapstructure:"kafka_writer"`
+	Calendar         *CalendarConfig        `mapstructure:"calendar"`
+	ICalendar        *icalendar.Config      `mapstructure:"icalendar"`
+	EventReminders   *EventRemindersConfig  `mapstructure:"event_reminders"`
+	Reminders        *reminder.Config       `mapstructure:"reminder_scheduler"`
 }
 
 func NewConfig(filePath string, appName string) (*Config, error) {
@@ -46,3 +83,108 @@ func NewConfig(filePath string, appName string) (*Config, error) {
 	msconfig.ReplaceWithEnv(&config, appName)
 	return &config, nil
 }
+
+// ModerationConfig: Настройки модерации рецензий
+type ModerationConfig struct {
+	// PreModeration hides new reviews from public until moderator approves them
+	PreModeration bool `mapstructure:"pre_moderation"`
+	// ClaimTTL is the time after which claimed review can be taken by other moderator
+	ClaimTTL time.Duration `mapstructure:"claim_ttl"`
+	// ReportHideThreshold is the amount of open reports after which content is hidden until moderator decision
+	ReportHideThreshold int `mapstructure:"report_hide_threshold"`
+}
+
+// PublicationsConfig: Настройки обработки первых публикаций рецензий
+type PublicationsConfig struct {
+	// Lease is the time claimed publication is hidden from other servers while its handlers run
+	Lease time.Duration `mapstructure:"lease"`
+	// BaseBackoff is the delay after the first failed processing, it doubles with every next one up to MaxBackoff
+	BaseBackoff time.Duration `mapstructure:"base_backoff"`
+	MaxBackoff  time.Duration `mapstructure:"max_backoff"`
+	// BatchSize limits reviews published and publications processed in one iteration of publisher
+	BatchSize int `mapstructure:"batch_size"`
+}
+
+// CommentsConfig: Настройки комментариев к рецензиям
+type CommentsConfig struct {
+	// MaxDepth is the deepest allowed reply, top-level comments have depth 0
+	MaxDepth int `mapstructure:"max_depth"`
+}
+
+// TagsConfig: Настройки хэштегов
+type TagsConfig struct {
+	// TrendingWindow is the sliding window of trending tags
+	TrendingWindow time.Duration `mapstructure:"trending_window"`
+}
+
+// ReactionsConfig: Настройки реакций на рецензии
+type ReactionsConfig struct {
+	// Types is the set of allowed reactions, like and dislike are also counted in piece stats
+	Types []string `mapstructure:"types"`
+}
+
+// StreamConfig: Настройки real-time потока уведомлений и ленты
+type StreamConfig struct {
+	// HeartbeatInterval is the period of keep-alive messages of idle stream
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`
+	// BatchSize limits events read from database at once, also on resume
+	BatchSize int `mapstructure:"batch_size"`
+}
+
+// NotificationsConfig: Настройки уведомлений
+type NotificationsConfig struct {
+	// AggregationWindow is the time after the last event in which similar notifications are collapsed into one
+	AggregationWindow time.Duration `mapstructure:"aggregation_window"`
+	// DigestLease is the time claimed digest is hidden from other replicas while it is being sent
+	DigestLease time.Duration `mapstructure:"digest_lease"`
+	// DigestBaseBackoff is the delay after the first failed digest, it doubles with every next one up to DigestMaxBackoff
+	DigestBaseBackoff time.Duration `mapstructure:"digest_base_backoff"`
+	DigestMaxBackoff  time.Duration `mapstructure:"digest_max_backoff"`
+	// DigestBatchSize limits digests sent in one iteration of digest daemon
+	DigestBatchSize int `mapstructure:"digest_batch_size"`
+}
+
+// WebhooksConfig: Настройки доставки исходящих вебхуков
+type WebhooksConfig struct {
+	// MaxAttempts is the number of attempts after which delivery goes to the dead-letter list
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// BaseBackoff is the delay after the first failed attempt, it doubles with every next one up to MaxBackoff
+	BaseBackoff time.Duration `mapstructure:"base_backoff"`
+	MaxBackoff  time.Duration `mapstructure:"max_backoff"`
+	// Lease is the time claimed delivery is hidden from other servers while it is being sent
+	Lease time.Duration `mapstructure:"lease"`
+	// BatchSize limits deliveries sent in one iteration of webhook daemon
+	BatchSize int `mapstructure:"batch_size"`
+}
+
+// OutboxConfig: Настройки публикации доменных событий
+type OutboxConfig struct {
+	// Lease is the time claimed event is hidden from other relays while its handlers run
+	Lease time.Duration `mapstructure:"lease"`
+	// BaseBackoff is the delay after the first failed publication, it doubles with every next one up to MaxBackoff
+	BaseBackoff time.Duration `mapstructure:"base_backoff"`
+	MaxBackoff  time.Duration `mapstructure:"max_backoff"`
+	// BatchSize limits events published in one iteration of relay
+	BatchSize int `mapstructure:"batch_size"`
+}
+
+// CalendarConfig: Настройки личных iCal-лент
+type CalendarConfig struct {
+	// FeedURL is the public address of feeds, token of feed is appended to it
+	FeedURL string `mapstructure:"feed_url"`
+	// FeedPast is the time past events stay in feeds
+	FeedPast time.Duration `mapstructure:"feed_past"`
+	// FeedLimit limits events of one feed
+	FeedLimit int `mapstructure:"feed_limit"`
+}
+
+// EventRemindersConfig: Настройки напоминаний участникам событий
+type EventRemindersConfig struct {
+	// Offsets are times before start of event at which participants are reminded,
+	// reminders are scheduled when user joins event and when event is moved
+	Offsets []time.Duration `mapstructure:"offsets"`
+	// MaxDelay is the time after which unsent reminder is skipped, e.g. for late participants
+	MaxDelay time.Duration `mapstructure:"max_delay"`
+	// BatchSize limits reminders sent in one iteration of reminder daemon
+	BatchSize int `mapstructure:"batch_size"`
+}
@@ -0,0 +1,70 @@
+package consumer
+
+import (
+	"context"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+	"music-snap/pkg/msbus"
+	"time"
+)
+
+// restartDelay is the pause before consuming again after error of the bus
+const restartDelay = 5 * time.Second
+
+// Consumer processes messages of the bus topic until it is stopped
+type Consumer struct {
+	started  bool
+	cancel   context.CancelFunc
+	done     chan struct{}
+	consumer msbus.Consumer
+	handler  msbus.Handler
+	logger   *zap.Logger
+}
+
+func New(logger *zap.Logger, consumer msbus.Consumer, handler msbus.Handler) *Consumer {
+	return &Consumer{
+		logger:   logger,
+		consumer: consumer,
+		handler:  handler,
+		done:     make(chan struct{}),
+		started:  false}
+}
+
+// stopCallback waits for message in progress, it is not committed if ctx is done first
+func (s *Consumer) stopCallback(ctx context.Context) error {
+	if s.started != true {
+		return nil
+	}
+	s.started = false
+	s.cancel()
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		s.logger.Warn("bus consumer is not stopped in time")
+	}
+	return s.consumer.Close()
+}
+
+func (s *Consumer) StopFunc() func(context.Context) error {
+	return s.stopCallback
+}
+
+func (s *Consumer) Start() {
+	ctx, cancel := context.WithCancel(zapctx.WithLogger(context.Background(), s.logger))
+	s.cancel = cancel
+	s.started = true
+	go func() {
+		defer close(s.done)
+		for ctx.Err() == nil {
+			err := s.consumer.Consume(ctx, s.handler)
+			if err == nil {
+				continue
+			}
+			s.logger.Error("bus consumer failed", zap.Error(err))
+			select {
+			case <-ctx.Done():
+			case <-time.After(restartDelay):
+			}
+		}
+	}()
+}
@@ -0,0 +1,52 @@
+package consumer
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"music-snap/pkg/msbus"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/eventbus"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"testing"
+	"time"
+)
+
+type handlerFunc func(ctx context.Context, event domain.DomainEvent) error
+
+func (f handlerFunc) HandleEvent(ctx context.Context, event domain.DomainEvent) error {
+	return f(ctx, event)
+}
+
+func TestConsumerDispatchesPublishedEvent(t *testing.T) {
+	bus := msbus.NewMemoryBus(10)
+	received := make(chan domain.DomainEvent, 1)
+	handlers := map[string]ports.DomainEventHandler{
+		domain.EventReviewCreated: handlerFunc(func(_ context.Context, e domain.DomainEvent) error {
+			received <- e
+			return nil
+		}),
+	}
+	c := New(zap.NewNop(), bus.Consumer(msbus.ReaderConfig{Topic: "outbound"}), eventbus.NewDispatcher(handlers))
+	c.Start()
+
+	event, err := domain.NewDomainEvent(domain.EventReviewCreated, domain.ReviewCreatedEvent{ReviewID: 7})
+	require.NoError(t, err)
+	publisher := eventbus.NewPublisher(bus.Publisher(msbus.WriterConfig{Topic: "outbound"}))
+	require.NoError(t, publisher.HandleEvent(context.Background(), event))
+
+	select {
+	case got := <-received:
+		assert.Equal(t, event.ID, got.ID)
+		var payload domain.ReviewCreatedEvent
+		require.NoError(t, got.Decode(&payload))
+		assert.Equal(t, 7, payload.ReviewID)
+	case <-time.After(time.Second):
+		t.Fatal("event is not dispatched to handler")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, c.StopFunc()(ctx))
+}
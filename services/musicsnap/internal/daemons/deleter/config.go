@@ -4,6 +4,14 @@ import "time"
 
 type Config struct {
 	IterationInterval string `mapstructure:"iteration_interval"`
+	// ReadTTL is the age after which read notifications are deleted
+	ReadTTL time.Duration `mapstructure:"read_ttl"`
+	// UnreadTTL is the age after which notifications are deleted even if they were not read
+	UnreadTTL time.Duration `mapstructure:"unread_ttl"`
+	// StreamTTL is the age after which events of real-time stream are deleted, clients resume from the oldest kept one
+	StreamTTL time.Duration `mapstructure:"stream_ttl"`
+	// BatchSize limits rows deleted by one statement, so deletes do not hold locks for long
+	BatchSize int `mapstructure:"batch_size"`
 }
 
 func (c Config) GetIterationInterval() (time.Duration, error) {
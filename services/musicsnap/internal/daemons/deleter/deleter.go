@@ -7,45 +7,104 @@ import (
 	global "go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"music-snap/pkg/metrics"
 	"music-snap/services/musicsnap/internal/domain"
 	"music-snap/services/musicsnap/internal/domain/keys"
 	"music-snap/services/musicsnap/internal/service/ports"
-	"runtime"
 	"time"
 )
 
+const (
+	defaultReadTTL   = 30 * 24 * time.Hour
+	defaultUnreadTTL = 365 * 24 * time.Hour
+	defaultStreamTTL = 7 * 24 * time.Hour
+	defaultBatchSize = 1000
+)
+
+// DBCleaner deletes outdated notifications and events of real-time stream by small batches
 type DBCleaner struct {
-	stop                   chan bool
-	notificationRepository *ports.NotificationRepository
+	started bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	cfg                    Config
+	notificationRepository ports.NotificationRepository
+	streamRepository       ports.StreamRepository
+	purged                 *metrics.CounterVec
+	streamPurged           *metrics.CounterVec
 	logger                 *zap.Logger
 }
 
-func New(logger *zap.Logger, repository *ports.NotificationRepository) *DBCleaner {
+func New(logger *zap.Logger, repository ports.NotificationRepository, streams ports.StreamRepository, cfg Config) *DBCleaner {
+	if cfg.ReadTTL <= 0 {
+		cfg.ReadTTL = defaultReadTTL
+	}
+	if cfg.UnreadTTL <= 0 {
+		cfg.UnreadTTL = defaultUnreadTTL
+	}
+	if cfg.StreamTTL <= 0 {
+		cfg.StreamTTL = defaultStreamTTL
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
 	return &DBCleaner{
 		logger:                 logger,
+		cfg:                    cfg,
 		notificationRepository: repository,
-		stop:                   make(chan bool)}
+		streamRepository:       streams,
+		purged: metrics.GetOrRegisterCounterVec(metrics.CounterOpts{
+			Namespace:   "musicsnap",
+			Name:        "notifications_purged_total",
+			Description: "Outdated notifications deleted by retention daemon",
+		}, []string{"state"}),
+		streamPurged: metrics.GetOrRegisterCounterVec(metrics.CounterOpts{
+			Namespace:   "musicsnap",
+			Name:        "stream_events_purged_total",
+			Description: "Outdated events of real-time stream deleted by retention daemon",
+		}, []string{}),
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		started: false}
 }
 
+// stopCallback interrupts purge between batches and waits for it
 func (s *DBCleaner) stopCallback(ctx context.Context) error {
-	s.stop <- true
-	return nil
+	if s.started != true {
+		return nil
+	}
+	s.started = false
+	s.cancel()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
-func (s DBCleaner) StopFunc() func(context.Context) error {
+func (s *DBCleaner) StopFunc() func(context.Context) error {
 	return s.stopCallback
 }
 
 func (s *DBCleaner) Start(scrapeInterval time.Duration) {
+	s.started = true
 	go func() {
-		stop := s.stop
-		go func() {
-			for {
-				s.refresh(scrapeInterval)
-				runtime.Gosched()
+		defer close(s.done)
+		for {
+			s.purge()
+
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(scrapeInterval):
 			}
-		}()
-		<-stop
+		}
 	}()
 }
 
@@ -59,21 +118,65 @@ func WithRequestID(ctx context.Context) context.Context {
 	return context.WithValue(ctx, keys.KeyRequestID, requestID)
 }
 
-func (s *DBCleaner) refresh(scrapeInterval time.Duration) {
-	initialCtx := context.Background()
-
-	requestIdCtx := WithRequestID(initialCtx)
+// purge deletes batches until there are no outdated notifications left
+func (s *DBCleaner) purge() {
+	requestIdCtx := WithRequestID(s.ctx)
 	ctxLogger := zapctx.WithLogger(requestIdCtx, s.logger)
 
 	tr := global.Tracer(domain.ServiceName)
-	ctx, span := tr.Start(ctxLogger, "musicsnap/daemon/refresher.refresh", trace.WithNewRoot())
+	ctx, span := tr.Start(ctxLogger, "musicsnap/daemon/deleter.purge", trace.WithNewRoot())
 	defer span.End()
 
-	err := s.notificationRepository.DeleteOutdated(ctx, time.Hour*24*365)
-	if err != nil {
-		s.logger.Error("failed to refresh cache", zap.Error(err))
-		return
+	totalRead, totalUnread := 0, 0
+	for ctx.Err() == nil {
+		now := time.Now().UTC()
+		read, unread, err := s.notificationRepository.DeleteOutdated(ctx,
+			now.Add(-s.cfg.ReadTTL), now.Add(-s.cfg.UnreadTTL), s.cfg.BatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.logger.Error("failed to delete outdated notifications", zap.Error(err))
+			}
+			break
+		}
+
+		s.purged.WithLabelValues("read").Add(float64(read))
+		s.purged.WithLabelValues("unread").Add(float64(unread))
+		totalRead += read
+		totalUnread += unread
+
+		if read+unread < s.cfg.BatchSize {
+			break
+		}
+	}
+
+	if totalRead+totalUnread > 0 {
+		s.logger.Info("outdated notifications deleted",
+			zap.Int("read", totalRead), zap.Int("unread", totalUnread))
+	}
+	s.purgeStream(ctx)
+}
+
+// purgeStream deletes batches of outdated stream events
+func (s *DBCleaner) purgeStream(ctx context.Context) {
+	total := 0
+	for ctx.Err() == nil {
+		deleted, err := s.streamRepository.DeleteOutdated(ctx, time.Now().UTC().Add(-s.cfg.StreamTTL), s.cfg.BatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.logger.Error("failed to delete outdated stream events", zap.Error(err))
+			}
+			break
+		}
+
+		s.streamPurged.WithLabelValues().Add(float64(deleted))
+		total += deleted
+
+		if deleted < s.cfg.BatchSize {
+			break
+		}
 	}
 
-	time.Sleep(scrapeInterval)
+	if total > 0 {
+		s.logger.Info("outdated stream events deleted", zap.Int("count", total))
+	}
 }
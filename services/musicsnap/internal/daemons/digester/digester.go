@@ -0,0 +1,90 @@
+package digester
+
+import (
+	"context"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/domain/keys"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"sync/atomic"
+	"time"
+)
+
+// Digester mails daily and weekly notification digests
+type Digester struct {
+	started atomic.Bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	notifications ports.NotificationSvc
+	logger        *zap.Logger
+}
+
+func New(logger *zap.Logger, notifications ports.NotificationSvc) *Digester {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Digester{
+		logger:        logger,
+		notifications: notifications,
+		ctx:           ctx,
+		cancel:        cancel,
+		done:          make(chan struct{})}
+}
+
+// stopCallback interrupts mailing of digests and waits for the current iteration
+func (s *Digester) stopCallback(ctx context.Context) error {
+	if !s.started.CompareAndSwap(true, false) {
+		return nil
+	}
+	s.cancel()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Digester) StopFunc() func(context.Context) error {
+	return s.stopCallback
+}
+
+func (s *Digester) Start(scrapeInterval time.Duration) {
+	s.started.Store(true)
+	go func() {
+		defer close(s.done)
+		for {
+			s.send()
+
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(scrapeInterval):
+			}
+		}
+	}()
+}
+
+func (s *Digester) send() {
+	requestIdCtx := keys.WithRequestID(s.ctx)
+	ctxLogger := zapctx.WithLogger(requestIdCtx, s.logger)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctxLogger, "musicsnap/daemon/digester.send", trace.WithNewRoot())
+	defer span.End()
+
+	sent, err := s.notifications.SendDigests(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("failed to send notification digests", zap.Error(err))
+		}
+		return
+	}
+	if sent > 0 {
+		s.logger.Info("notification digests sent", zap.Int("count", sent))
+	}
+}
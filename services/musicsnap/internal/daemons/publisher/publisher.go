@@ -0,0 +1,90 @@
+package publisher
+
+import (
+	"context"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/domain/keys"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"sync/atomic"
+	"time"
+)
+
+// Publisher publishes scheduled reviews and runs side effects of publications
+type Publisher struct {
+	started atomic.Bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	reviews ports.ReviewService
+	logger  *zap.Logger
+}
+
+func New(logger *zap.Logger, reviews ports.ReviewService) *Publisher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Publisher{
+		logger:  logger,
+		reviews: reviews,
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{})}
+}
+
+// stopCallback interrupts publication of scheduled reviews and waits for the current iteration
+func (s *Publisher) stopCallback(ctx context.Context) error {
+	if !s.started.CompareAndSwap(true, false) {
+		return nil
+	}
+	s.cancel()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Publisher) StopFunc() func(context.Context) error {
+	return s.stopCallback
+}
+
+func (s *Publisher) Start(scrapeInterval time.Duration) {
+	s.started.Store(true)
+	go func() {
+		defer close(s.done)
+		for {
+			s.publish()
+
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(scrapeInterval):
+			}
+		}
+	}()
+}
+
+func (s *Publisher) publish() {
+	requestIdCtx := keys.WithRequestID(s.ctx)
+	ctxLogger := zapctx.WithLogger(requestIdCtx, s.logger)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctxLogger, "musicsnap/daemon/publisher.publish", trace.WithNewRoot())
+	defer span.End()
+
+	published, err := s.reviews.PublishScheduled(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("failed to publish scheduled reviews", zap.Error(err))
+		}
+		return
+	}
+	if published > 0 {
+		s.logger.Info("scheduled reviews published", zap.Int("count", published))
+	}
+}
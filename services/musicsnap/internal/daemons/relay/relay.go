@@ -0,0 +1,90 @@
+package relay
+
+import (
+	"context"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/domain/keys"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"sync/atomic"
+	"time"
+)
+
+// Relay publishes domain events of outbox to their handlers
+type Relay struct {
+	started atomic.Bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	outbox ports.OutboxService
+	logger *zap.Logger
+}
+
+func New(logger *zap.Logger, outbox ports.OutboxService) *Relay {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Relay{
+		logger: logger,
+		outbox: outbox,
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{})}
+}
+
+// stopCallback interrupts relay of outbox events and waits for the current iteration
+func (s *Relay) stopCallback(ctx context.Context) error {
+	if !s.started.CompareAndSwap(true, false) {
+		return nil
+	}
+	s.cancel()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Relay) StopFunc() func(context.Context) error {
+	return s.stopCallback
+}
+
+func (s *Relay) Start(scrapeInterval time.Duration) {
+	s.started.Store(true)
+	go func() {
+		defer close(s.done)
+		for {
+			s.relay()
+
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(scrapeInterval):
+			}
+		}
+	}()
+}
+
+func (s *Relay) relay() {
+	requestIdCtx := keys.WithRequestID(s.ctx)
+	ctxLogger := zapctx.WithLogger(requestIdCtx, s.logger)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctxLogger, "musicsnap/daemon/relay.relay", trace.WithNewRoot())
+	defer span.End()
+
+	published, err := s.outbox.Relay(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("failed to relay outbox events", zap.Error(err))
+		}
+		return
+	}
+	if published > 0 {
+		s.logger.Info("outbox events published", zap.Int("count", published))
+	}
+}
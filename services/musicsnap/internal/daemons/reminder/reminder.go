@@ -0,0 +1,90 @@
+package reminder
+
+import (
+	"context"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/domain/keys"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"sync/atomic"
+	"time"
+)
+
+// Reminder schedules reminders of event participants and sends due ones
+type Reminder struct {
+	started atomic.Bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	events ports.EventService
+	logger *zap.Logger
+}
+
+func New(logger *zap.Logger, events ports.EventService) *Reminder {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Reminder{
+		logger: logger,
+		events: events,
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{})}
+}
+
+// stopCallback interrupts sending of event reminders and waits for the current iteration
+func (s *Reminder) stopCallback(ctx context.Context) error {
+	if !s.started.CompareAndSwap(true, false) {
+		return nil
+	}
+	s.cancel()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Reminder) StopFunc() func(context.Context) error {
+	return s.stopCallback
+}
+
+func (s *Reminder) Start(scrapeInterval time.Duration) {
+	s.started.Store(true)
+	go func() {
+		defer close(s.done)
+		for {
+			s.remind()
+
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(scrapeInterval):
+			}
+		}
+	}()
+}
+
+func (s *Reminder) remind() {
+	requestIdCtx := keys.WithRequestID(s.ctx)
+	ctxLogger := zapctx.WithLogger(requestIdCtx, s.logger)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctxLogger, "musicsnap/daemon/reminder.remind", trace.WithNewRoot())
+	defer span.End()
+
+	sent, err := s.events.SendReminders(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("failed to send event reminders", zap.Error(err))
+		}
+		return
+	}
+	if sent > 0 {
+		s.logger.Info("event reminders sent", zap.Int("count", sent))
+	}
+}
@@ -0,0 +1,21 @@
+package streamer
+
+import "time"
+
+type Config struct {
+	// MinReconnectInterval and MaxReconnectInterval bound pauses between reconnects of LISTEN connection
+	MinReconnectInterval string `mapstructure:"min_reconnect_interval"`
+	MaxReconnectInterval string `mapstructure:"max_reconnect_interval"`
+}
+
+func (c Config) GetReconnectIntervals() (time.Duration, time.Duration, error) {
+	minInterval, err := time.ParseDuration(c.MinReconnectInterval)
+	if err != nil {
+		return 0, 0, err
+	}
+	maxInterval, err := time.ParseDuration(c.MaxReconnectInterval)
+	if err != nil {
+		return 0, 0, err
+	}
+	return minInterval, maxInterval, nil
+}
@@ -0,0 +1,198 @@
+package streamer
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+	"music-snap/pkg/msdb/mspostgres"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"sync"
+	"time"
+)
+
+const (
+	// channel is notified by trigger of stream_events table
+	channel = "musicsnap_stream"
+	// pingInterval checks LISTEN connection which can die silently
+	pingInterval = time.Minute
+	// drainCheckInterval is the period of checking that all streams are finished on stop
+	drainCheckInterval = 50 * time.Millisecond
+)
+
+var _ ports.StreamBus = &Streamer{}
+
+// Streamer wakes streams of connected users on new events from any instance of service
+type Streamer struct {
+	started  bool
+	stop     chan bool
+	draining chan struct{}
+	logger   *zap.Logger
+	cfg      Config
+	postgres *mspostgres.Config
+	listener *pq.Listener
+
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan struct{}]bool
+	count       int
+}
+
+func New(logger *zap.Logger, cfg Config, postgres *mspostgres.Config) *Streamer {
+	return &Streamer{
+		logger:      logger,
+		cfg:         cfg,
+		postgres:    postgres,
+		stop:        make(chan bool),
+		draining:    make(chan struct{}),
+		subscribers: make(map[uuid.UUID]map[chan struct{}]bool),
+		started:     false}
+}
+
+// stopCallback finishes open streams and waits for them until ctx is done
+func (s *Streamer) stopCallback(ctx context.Context) error {
+	if s.started != true {
+		return nil
+	}
+	s.started = false
+	close(s.draining)
+
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+	for s.active() > 0 {
+		select {
+		case <-ctx.Done():
+			s.logger.Warn("streams are not drained", zap.Int("active", s.active()))
+			s.stop <- true
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	s.stop <- true
+	return nil
+}
+
+func (s *Streamer) StopFunc() func(context.Context) error {
+	return s.stopCallback
+}
+
+func (s *Streamer) Start() error {
+	minReconnect, maxReconnect, err := s.cfg.GetReconnectIntervals()
+	if err != nil {
+		return err
+	}
+	s.listener, err = mspostgres.NewListener(s.postgres, channel, minReconnect, maxReconnect, s.listenerEvent)
+	if err != nil {
+		return err
+	}
+
+	s.started = true
+	go s.listen()
+	return nil
+}
+
+func (s *Streamer) listen() {
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+	defer func() {
+		_ = s.listener.Close()
+	}()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case n := <-s.listener.Notify:
+			// nil приходит после переподключения, события за время разрыва могли потеряться
+			if n == nil {
+				s.wakeAll()
+				continue
+			}
+			var event struct {
+				UserID uuid.UUID `json:"user_id"`
+			}
+			err := json.Unmarshal([]byte(n.Extra), &event)
+			if err != nil {
+				s.logger.Error("invalid stream notification payload", zap.String("payload", n.Extra), zap.Error(err))
+				continue
+			}
+			s.wake(event.UserID)
+		case <-ping.C:
+			go func() {
+				_ = s.listener.Ping()
+			}()
+		}
+	}
+}
+
+func (s *Streamer) listenerEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventDisconnected:
+		s.logger.Error("stream listener disconnected", zap.Error(err))
+	case pq.ListenerEventReconnected:
+		s.logger.Info("stream listener reconnected")
+	case pq.ListenerEventConnectionAttemptFailed:
+		s.logger.Error("stream listener connection attempt failed", zap.Error(err))
+	}
+}
+
+// Subscribe returns channel with buffer of one wake, wakes during processing of previous one are merged
+func (s *Streamer) Subscribe(userID uuid.UUID) (<-chan struct{}, func()) {
+	wake := make(chan struct{}, 1)
+
+	s.mu.Lock()
+	if s.subscribers[userID] == nil {
+		s.subscribers[userID] = make(map[chan struct{}]bool)
+	}
+	s.subscribers[userID][wake] = true
+	s.count++
+	s.mu.Unlock()
+
+	var once sync.Once
+	return wake, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			delete(s.subscribers[userID], wake)
+			if len(s.subscribers[userID]) == 0 {
+				delete(s.subscribers, userID)
+			}
+			s.count--
+		})
+	}
+}
+
+func (s *Streamer) Draining() <-chan struct{} {
+	return s.draining
+}
+
+func (s *Streamer) active() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.count
+}
+
+func (s *Streamer) wake(userID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for wake := range s.subscribers[userID] {
+		notify(wake)
+	}
+}
+
+func (s *Streamer) wakeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, user := range s.subscribers {
+		for wake := range user {
+			notify(wake)
+		}
+	}
+}
+
+func notify(wake chan struct{}) {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,90 @@
+package webhooker
+
+import (
+	"context"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/domain/keys"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"sync/atomic"
+	"time"
+)
+
+// Webhooker sends due webhook deliveries and retries failed ones
+type Webhooker struct {
+	started atomic.Bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	webhooks ports.WebhookService
+	logger   *zap.Logger
+}
+
+func New(logger *zap.Logger, webhooks ports.WebhookService) *Webhooker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Webhooker{
+		logger:   logger,
+		webhooks: webhooks,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{})}
+}
+
+// stopCallback interrupts delivery of webhooks and waits for the current iteration
+func (s *Webhooker) stopCallback(ctx context.Context) error {
+	if !s.started.CompareAndSwap(true, false) {
+		return nil
+	}
+	s.cancel()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Webhooker) StopFunc() func(context.Context) error {
+	return s.stopCallback
+}
+
+func (s *Webhooker) Start(scrapeInterval time.Duration) {
+	s.started.Store(true)
+	go func() {
+		defer close(s.done)
+		for {
+			s.deliver()
+
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(scrapeInterval):
+			}
+		}
+	}()
+}
+
+func (s *Webhooker) deliver() {
+	requestIdCtx := keys.WithRequestID(s.ctx)
+	ctxLogger := zapctx.WithLogger(requestIdCtx, s.logger)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctxLogger, "musicsnap/daemon/webhooker.deliver", trace.WithNewRoot())
+	defer span.End()
+
+	delivered, err := s.webhooks.DeliverDue(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("failed to deliver webhooks", zap.Error(err))
+		}
+		return
+	}
+	if delivered > 0 {
+		s.logger.Info("webhooks delivered", zap.Int("count", delivered))
+	}
+}
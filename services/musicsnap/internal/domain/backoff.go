@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// Backoff is delay before the next attempt after given number of failed ones:
+// base doubles with every attempt and is capped by max
+func Backoff(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
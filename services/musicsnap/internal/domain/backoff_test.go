@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	base, max := 30*time.Second, 10*time.Minute
+	assert.Equal(t, 30*time.Second, Backoff(1, base, max))
+	assert.Equal(t, time.Minute, Backoff(2, base, max))
+	assert.Equal(t, 4*time.Minute, Backoff(4, base, max))
+	assert.Equal(t, max, Backoff(6, base, max))
+	assert.Equal(t, max, Backoff(100, base, max))
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CalendarFeed: Личная iCal-лента пользователя, доступная по секретному токену
+type CalendarFeed struct {
+	UserID uuid.UUID
+	Token  string
+	// URL is the address of feed for calendar apps, it contains the token
+	URL       string
+	CreatedAt time.Time
+}
+
+// Calendar: Набор событий для экспорта в iCalendar
+type Calendar struct {
+	Name   string
+	Events []Event
+	// GeneratedAt is written as DTSTAMP of every event
+	GeneratedAt time.Time
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CommentMaxLength limits comment text in characters
+const CommentMaxLength = 2000
+
+const (
+	// NotificationCommentReply is sent to the author of comment when someone replies to it
+	NotificationCommentReply = "comment_reply"
+	// NotificationReviewComment is sent to the author of review on new top-level comment
+	NotificationReviewComment = "review_comment"
+)
+
+// Comment: Комментарий к рецензии, ответы образуют дерево
+type Comment struct {
+	ID       uuid.UUID
+	ReviewID int
+	ThreadID uuid.UUID
+	UserID   uuid.UUID
+
+	// ParentID is nil for top-level comment
+	ParentID *uuid.UUID
+	// Depth is 0 for top-level comment
+	Depth int
+	Text  string
+
+	EditedAt *time.Time
+	// DeletedAt is set for tombstone: comment without text kept in the tree for its replies
+	DeletedAt *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (c Comment) Deleted() bool {
+	return c.DeletedAt != nil
+}
+
+func (c Comment) Edited() bool {
+	return c.EditedAt != nil
+}
+
+func (c Comment) Validate() error {
+	if strings.TrimSpace(c.Text) == "" {
+		return fmt.Errorf("comment text is empty")
+	}
+	if utf8.RuneCountInString(c.Text) > CommentMaxLength {
+		return fmt.Errorf("comment text is longer than %d characters", CommentMaxLength)
+	}
+	return nil
+}
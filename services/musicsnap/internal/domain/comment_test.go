@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCommentValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{name: "Text", text: "Agree, the second side is better"},
+		{name: "MaxLength", text: strings.Repeat("я", CommentMaxLength)},
+		{name: "Empty", text: "", wantErr: true},
+		{name: "Spaces", text: " \n\t", wantErr: true},
+		{name: "TooLong", text: strings.Repeat("a", CommentMaxLength+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Comment{Text: tt.text}.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package domain
+
+import "strings"
+
+// FilterVerdict: Решение фильтра контента, более строгое решение больше
+type FilterVerdict int
+
+const (
+	FilterAllow FilterVerdict = iota
+	// FilterFlag saves content but hides it until moderator decision
+	FilterFlag
+	FilterReject
+)
+
+func (v FilterVerdict) String() string {
+	switch v {
+	case FilterFlag:
+		return "flag"
+	case FilterReject:
+		return "reject"
+	}
+	return "allow"
+}
+
+// FilterReason: Почему этап фильтра не пропустил текст
+type FilterReason struct {
+	Stage   string
+	Verdict FilterVerdict
+	Code    ModerationReasonCode
+	Message string
+}
+
+// FilterResult: Итог проверки текста всеми этапами фильтра
+type FilterResult struct {
+	Reasons []FilterReason
+}
+
+// Verdict returns the strictest verdict of stages, text without reasons is allowed
+func (r FilterResult) Verdict() FilterVerdict {
+	verdict := FilterAllow
+	for _, reason := range r.Reasons {
+		if reason.Verdict > verdict {
+			verdict = reason.Verdict
+		}
+	}
+	return verdict
+}
+
+// Summary joins messages of reasons with the verdict
+func (r FilterResult) Summary(verdict FilterVerdict) string {
+	messages := make([]string, 0, len(r.Reasons))
+	for _, reason := range r.Reasons {
+		if reason.Verdict == verdict {
+			messages = append(messages, reason.Message)
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Code returns reason code of the first reason with the verdict
+func (r FilterResult) Code(verdict FilterVerdict) ModerationReasonCode {
+	for _, reason := range r.Reasons {
+		if reason.Verdict == verdict {
+			return reason.Code
+		}
+	}
+	return ReasonOther
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFilterResult(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, FilterAllow, FilterResult{}.Verdict())
+
+	result := FilterResult{Reasons: []FilterReason{
+		{Stage: "links", Verdict: FilterFlag, Code: ReasonSpam, Message: "too many links"},
+		{Stage: "profanity", Verdict: FilterReject, Code: ReasonOffensive, Message: "profanity"},
+		{Stage: "repeats", Verdict: FilterFlag, Code: ReasonSpam, Message: "repeated characters"},
+	}}
+
+	assert.Equal(t, FilterReject, result.Verdict())
+	assert.Equal(t, "too many links; repeated characters", result.Summary(FilterFlag))
+	assert.Equal(t, ReasonOffensive, result.Code(FilterReject))
+	assert.Equal(t, ReasonOther, FilterResult{}.Code(FilterFlag))
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+// DiffChunk: Участок пословного диффа, Text содержит слова вместе с пробелами между ними
+type DiffChunk struct {
+	Op   string // "equal", "insert", "delete"
+	Text string
+}
+
+// WordDiff returns word-level diff transforming from into to,
+// joined texts of equal and delete chunks give from, of equal and insert chunks give to
+func WordDiff(from, to string) []DiffChunk {
+	a, b := splitWords(from), splitWords(to)
+
+	ops := myersDiff(a, b)
+
+	var chunks []DiffChunk
+	ai, bi := 0, 0
+	for _, op := range ops {
+		var word string
+		switch op {
+		case DiffEqual:
+			word = a[ai]
+			ai++
+			bi++
+		case DiffDelete:
+			word = a[ai]
+			ai++
+		case DiffInsert:
+			word = b[bi]
+			bi++
+		}
+		if n := len(chunks); n > 0 && chunks[n-1].Op == op {
+			chunks[n-1].Text += word
+			continue
+		}
+		chunks = append(chunks, DiffChunk{Op: op, Text: word})
+	}
+	return chunks
+}
+
+// splitWords splits text into words and whitespace runs, so that joined tokens give the text back
+func splitWords(s string) []string {
+	var tokens []string
+	start := 0
+	inSpace := false
+	for i, r := range s {
+		space := unicode.IsSpace(r)
+		if i > start && space != inSpace {
+			tokens = append(tokens, s[start:i])
+			start = i
+		}
+		inSpace = space
+	}
+	if start < len(s) {
+		tokens = append(tokens, s[start:])
+	}
+	return tokens
+}
+
+// myersDiff returns shortest edit script of a into b, see E. Myers "An O(ND) Difference Algorithm"
+func myersDiff(a, b []string) []string {
+	n, m := len(a), len(b)
+	maxD := n + m
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	var trace [][]int
+
+	for d := 0; d <= maxD; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, offset, n, m)
+			}
+		}
+	}
+	return nil
+}
+
+func backtrack(trace [][]int, offset, x, y int) []string {
+	var ops []string
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			ops = append(ops, DiffEqual)
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				ops = append(ops, DiffInsert)
+			} else {
+				ops = append(ops, DiffDelete)
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+// DiffText joins chunks of given operations
+func DiffText(chunks []DiffChunk, ops ...string) string {
+	var sb strings.Builder
+	for _, ch := range chunks {
+		for _, op := range ops {
+			if ch.Op == op {
+				sb.WriteString(ch.Text)
+				break
+			}
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWordDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		from   string
+		to     string
+		chunks []DiffChunk
+	}{
+		{name: "Empty", from: "", to: "", chunks: nil},
+		{name: "Same", from: "great album", to: "great album",
+			chunks: []DiffChunk{{Op: DiffEqual, Text: "great album"}}},
+		{name: "FromEmpty", from: "", to: "new text",
+			chunks: []DiffChunk{{Op: DiffInsert, Text: "new text"}}},
+		{name: "ReplaceWord", from: "a great album", to: "a boring album",
+			chunks: []DiffChunk{
+				{Op: DiffEqual, Text: "a "},
+				{Op: DiffDelete, Text: "great"},
+				{Op: DiffInsert, Text: "boring"},
+				{Op: DiffEqual, Text: " album"},
+			}},
+		{name: "AppendSentence", from: "Хороший альбом.", to: "Хороший альбом. Но затянут",
+			chunks: []DiffChunk{
+				{Op: DiffEqual, Text: "Хороший альбом."},
+				{Op: DiffInsert, Text: " Но затянут"},
+			}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			chunks := WordDiff(tt.from, tt.to)
+			assert.Equal(t, tt.chunks, chunks)
+			assert.Equal(t, tt.from, DiffText(chunks, DiffEqual, DiffDelete))
+			assert.Equal(t, tt.to, DiffText(chunks, DiffEqual, DiffInsert))
+		})
+	}
+}
+
+func TestWordDiffRestoresTexts(t *testing.T) {
+	t.Parallel()
+
+	pairs := [][2]string{
+		{"one two three four", "zero one three four five"},
+		{"  leading  spaces\nand lines ", "leading spaces\n\nand more lines"},
+		{"a b a b a", "b a b a b"},
+		{"removed entirely", ""},
+	}
+	for _, p := range pairs {
+		chunks := WordDiff(p[0], p[1])
+		assert.Equal(t, p[0], DiffText(chunks, DiffEqual, DiffDelete))
+		assert.Equal(t, p[1], DiffText(chunks, DiffEqual, DiffInsert))
+	}
+}
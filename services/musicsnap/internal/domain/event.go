@@ -1,18 +1,22 @@
 package domain
 
 import (
+	"errors"
 	"github.com/google/uuid"
+	"net/url"
+	"strings"
 	"time"
 )
 
 // Event: Событие (концерт)
 type Event struct {
-	ID   int
+	ID   uuid.UUID
 	Name string
+	// Date is stored in UTC, clients convert it to local time of the venue
 	Date time.Time
-	//CoverURL   string
 
-	Text     string
+	Text string
+	// PhotoURL is the cover of event
 	PhotoURL string
 
 	PhotosURLs   []string
@@ -20,20 +24,80 @@ type Event struct {
 	LocationLink string
 	Location     string
 
-	Participants []uuid.UUID
+	// Authors can update event
+	Authors []uuid.UUID
+	// Participants are loaded for a single event only, ParticipantsCount is always set
+	Participants      []uuid.UUID
+	ParticipantsCount int
+
+	// Sequence grows on every change of event, calendar apps replace their copy of event by it
+	Sequence int
+	// CancelledAt is set for cancelled event, it stays visible with the cancelled status
+	CancelledAt *time.Time
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+func (e Event) Validate() error {
+	if strings.TrimSpace(e.Name) == "" {
+		return errors.New("event name must not be empty")
+	}
+	if e.Date.IsZero() {
+		return errors.New("event date must be set")
+	}
+	for _, link := range append([]string{e.PhotoURL, e.TicketLink, e.LocationLink}, e.PhotosURLs...) {
+		if link == "" {
+			continue
+		}
+		u, err := url.Parse(link)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("event links must be absolute http or https urls")
+		}
+	}
+	return nil
+}
+
+func (e Event) HasAuthor(userID uuid.UUID) bool {
+	for _, id := range e.Authors {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOver is true when event started before now, nobody can join it then
+func (e Event) IsOver(now time.Time) bool {
+	return !e.Date.After(now)
+}
+
+func (e Event) IsCancelled() bool {
+	return e.CancelledAt != nil
+}
+
 // EventFilter: Фильтр для поиска событий
 type EventFilter struct {
-	ID        int
 	NameQuery string
 
+	// Zero bounds are not applied
 	DateLeftBound  time.Time
 	DateRightBound time.Time
 
+	// Events go by date unless sorted by the number of participants or by creation time
 	SortByCreatedAt bool
 	SortByAmount    bool
+
+	Limit  int
+	Offset int
+}
+
+func (f EventFilter) Validate() error {
+	if !f.DateLeftBound.IsZero() && !f.DateRightBound.IsZero() && f.DateRightBound.Before(f.DateLeftBound) {
+		return errors.New("right bound of date is before the left one")
+	}
+	if f.Limit < 0 || f.Offset < 0 {
+		return errors.New("limit and offset must not be negative")
+	}
+	return nil
 }
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestEventValidate(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2026, 5, 1, 19, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		event   Event
+		wantErr bool
+	}{
+		{name: "Minimal", event: Event{Name: "Concert", Date: date}},
+		{name: "Links", event: Event{Name: "Concert", Date: date, TicketLink: "https://tickets.example/1",
+			PhotosURLs: []string{"https://cdn.example/1.jpg"}}},
+		{name: "Blank name", event: Event{Name: "  ", Date: date}, wantErr: true},
+		{name: "No date", event: Event{Name: "Concert"}, wantErr: true},
+		{name: "Relative link", event: Event{Name: "Concert", Date: date, LocationLink: "/map"}, wantErr: true},
+		{name: "Bad photo", event: Event{Name: "Concert", Date: date, PhotosURLs: []string{"ftp://cdn.example/1.jpg"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.event.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEventAuthorsAndDate(t *testing.T) {
+	t.Parallel()
+
+	author := uuid.New()
+	now := time.Now().UTC()
+	event := Event{Authors: []uuid.UUID{author}, Date: now.Add(time.Hour)}
+
+	assert.True(t, event.HasAuthor(author))
+	assert.False(t, event.HasAuthor(uuid.New()))
+	assert.False(t, event.IsOver(now))
+	assert.True(t, event.IsOver(now.Add(time.Hour)))
+
+	assert.False(t, event.IsCancelled())
+	event.CancelledAt = &now
+	assert.True(t, event.IsCancelled())
+}
+
+func TestEventFilterValidate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	assert.NoError(t, EventFilter{DateLeftBound: now}.Validate())
+	assert.NoError(t, EventFilter{DateLeftBound: now, DateRightBound: now.Add(time.Hour)}.Validate())
+	assert.Error(t, EventFilter{DateLeftBound: now, DateRightBound: now.Add(-time.Hour)}.Validate())
+	assert.Error(t, EventFilter{Offset: -1}.Validate())
+}
@@ -1,3 +1,13 @@
 package keys
 
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
 const KeyRequestID = "RequestID"
+
+// WithRequestID puts new request id into ctx of background job
+func WithRequestID(ctx context.Context) context.Context {
+	return context.WithValue(ctx, KeyRequestID, uuid.New().String())
+}
@@ -0,0 +1,20 @@
+package domain
+
+// Языки, на которых сервер отображает уведомления
+const (
+	LocaleEN = "en"
+	LocaleRU = "ru"
+
+	DefaultLocale = LocaleEN
+)
+
+var Locales = []string{LocaleEN, LocaleRU}
+
+func ValidLocale(locale string) bool {
+	for _, l := range Locales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+// ModerationStatus: Решение модерации по рецензии
+type ModerationStatus string
+
+const (
+	ModerationPending  ModerationStatus = "pending"
+	ModerationApproved ModerationStatus = "approved"
+	ModerationRejected ModerationStatus = "rejected"
+)
+
+// ModerationReasonCode: Причина отклонения рецензии или жалобы пользователя
+type ModerationReasonCode string
+
+const (
+	ReasonSpam       ModerationReasonCode = "spam"
+	ReasonOffensive  ModerationReasonCode = "offensive"
+	ReasonHarassment ModerationReasonCode = "harassment"
+	ReasonOffTopic   ModerationReasonCode = "off_topic"
+	ReasonSpoilers   ModerationReasonCode = "spoilers"
+	ReasonCopyright  ModerationReasonCode = "copyright"
+	ReasonOther      ModerationReasonCode = "other"
+)
+
+func (c ModerationReasonCode) Valid() bool {
+	switch c {
+	case ReasonSpam, ReasonOffensive, ReasonHarassment, ReasonOffTopic, ReasonSpoilers, ReasonCopyright, ReasonOther:
+		return true
+	}
+	return false
+}
+
+// QueueReason: Почему рецензия попала в очередь модерации
+type QueueReason string
+
+const (
+	QueueReasonNew      QueueReason = "new"
+	QueueReasonEdited   QueueReason = "edited"
+	QueueReasonReported QueueReason = "reported"
+)
+
+// ModerationActionType: Действие модератора в журнале
+type ModerationActionType string
+
+const (
+	ModerationClaim   ModerationActionType = "claim"
+	ModerationRelease ModerationActionType = "release"
+	ModerationApprove ModerationActionType = "approve"
+	ModerationReject  ModerationActionType = "reject"
+)
+
+// ModerationQueueItem: Рецензия, ожидающая решения модератора
+type ModerationQueueItem struct {
+	Review Review
+
+	Reason     QueueReason
+	EnqueuedAt time.Time
+
+	// ClaimedBy is the moderator working on the review, claim expires after configured ttl
+	ClaimedBy *uuid.UUID
+	ClaimedAt *time.Time
+}
+
+// ClaimedByOther reports whether review is held by other moderator with claim not older than staleBefore
+func (i ModerationQueueItem) ClaimedByOther(moderatorID uuid.UUID, staleBefore time.Time) bool {
+	return i.ClaimedBy != nil && *i.ClaimedBy != moderatorID &&
+		i.ClaimedAt != nil && i.ClaimedAt.After(staleBefore)
+}
+
+type ModerationQueueFilter struct {
+	Reason    *QueueReason
+	ClaimedBy *uuid.UUID
+}
+
+// ModerationAction: Запись журнала модерации
+type ModerationAction struct {
+	ID          int
+	ReviewID    int
+	ModeratorID uuid.UUID
+
+	Action     ModerationActionType
+	ReasonCode *ModerationReasonCode
+	Comment    string
+
+	CreatedAt time.Time
+}
+
+// ValidDecision checks approve or reject action, rejection must be explained with reason code
+func (a ModerationAction) ValidDecision() error {
+	switch a.Action {
+	case ModerationApprove:
+		if a.ReasonCode != nil {
+			return fmt.Errorf("approve can't have reason code")
+		}
+	case ModerationReject:
+		if a.ReasonCode == nil {
+			return fmt.Errorf("reject requires reason code")
+		}
+		if !a.ReasonCode.Valid() {
+			return fmt.Errorf("unknown reason code %q", *a.ReasonCode)
+		}
+	default:
+		return fmt.Errorf("action %q is not a decision", a.Action)
+	}
+	return nil
+}
+
+// Status returns review status set by decision
+func (a ModerationAction) Status() ModerationStatus {
+	if a.Action == ModerationReject {
+		return ModerationRejected
+	}
+	return ModerationApproved
+}
+
+type ModerationActionFilter struct {
+	ModeratorID *uuid.UUID
+	ReviewID    *int
+}
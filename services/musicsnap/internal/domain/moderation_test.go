@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestReviewVisibleToPublic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		review        Review
+		postModerated bool
+		preModerated  bool
+	}{
+		{name: "Draft", review: Review{ModerationStatus: ModerationApproved}, postModerated: false, preModerated: false},
+		{name: "Pending", review: Review{Published: true, ModerationStatus: ModerationPending}, postModerated: true, preModerated: false},
+		{name: "Approved", review: Review{Published: true, ModerationStatus: ModerationApproved}, postModerated: true, preModerated: true},
+		{name: "Rejected", review: Review{Published: true, ModerationStatus: ModerationRejected}, postModerated: false, preModerated: false},
+		{name: "HiddenByReports", review: Review{Published: true, Hidden: true, ModerationStatus: ModerationApproved}, postModerated: false, preModerated: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.postModerated, tt.review.VisibleToPublic(false))
+			assert.Equal(t, tt.preModerated, tt.review.VisibleToPublic(true))
+		})
+	}
+}
+
+func TestModerationActionValidDecision(t *testing.T) {
+	t.Parallel()
+
+	spam := ReasonSpam
+	unknown := ModerationReasonCode("boring")
+
+	tests := []struct {
+		name    string
+		action  ModerationAction
+		wantErr bool
+	}{
+		{name: "Approve", action: ModerationAction{Action: ModerationApprove}},
+		{name: "ApproveWithReason", action: ModerationAction{Action: ModerationApprove, ReasonCode: &spam}, wantErr: true},
+		{name: "Reject", action: ModerationAction{Action: ModerationReject, ReasonCode: &spam}},
+		{name: "RejectWithoutReason", action: ModerationAction{Action: ModerationReject}, wantErr: true},
+		{name: "RejectUnknownReason", action: ModerationAction{Action: ModerationReject, ReasonCode: &unknown}, wantErr: true},
+		{name: "Claim", action: ModerationAction{Action: ModerationClaim}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.action.ValidDecision()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestModerationQueueItemClaimedByOther(t *testing.T) {
+	t.Parallel()
+
+	me, other := uuid.New(), uuid.New()
+	now := time.Now()
+	fresh := now.Add(-time.Minute)
+	stale := now.Add(-time.Hour)
+	staleBefore := now.Add(-15 * time.Minute)
+
+	assert.False(t, ModerationQueueItem{}.ClaimedByOther(me, staleBefore))
+	assert.False(t, ModerationQueueItem{ClaimedBy: &me, ClaimedAt: &fresh}.ClaimedByOther(me, staleBefore))
+	assert.True(t, ModerationQueueItem{ClaimedBy: &other, ClaimedAt: &fresh}.ClaimedByOther(me, staleBefore))
+	assert.False(t, ModerationQueueItem{ClaimedBy: &other, ClaimedAt: &stale}.ClaimedByOther(me, staleBefore))
+}
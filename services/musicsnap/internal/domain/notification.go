@@ -1,22 +1,205 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"time"
 )
 
+const (
+	// NotificationNewReview is sent to followers who enabled new review alert of subscription
+	NotificationNewReview = "new_review"
+	// NotificationNewPlaylist is sent to followers who enabled new playlist alert of subscription
+	NotificationNewPlaylist = "new_playlist"
+	// NotificationEvent is sent to followers who enabled event alert of subscription
+	NotificationEvent = "event"
+	// NotificationReviewReaction is sent to the author of review, reactions in window are collapsed
+	NotificationReviewReaction = "review_reaction"
+	// NotificationEventReminder is sent to participants before start of event
+	NotificationEventReminder = "event_reminder"
+)
+
+// NotificationTypes lists types which preferences can be set
+var NotificationTypes = []string{
+	NotificationMention, NotificationReviewComment, NotificationCommentReply, NotificationReportResolved,
+	NotificationNewReview, NotificationNewPlaylist, NotificationEvent, NotificationReviewReaction,
+	NotificationEventReminder,
+}
+
+// Каналы доставки уведомлений
+const (
+	InAppChannel = "in_app"
+	EmailChannel = "email"
+	PushChannel  = "push"
+)
+
+var NotificationChannels = []string{InAppChannel, EmailChannel, PushChannel}
+
+// defaultChannels must match notification_channels() of migrations
+var defaultChannels = map[string]bool{InAppChannel: true, EmailChannel: false, PushChannel: true}
+
 // Notification: Уведомление
 type Notification struct {
 	ID uuid.UUID
 
 	UserIDReceiver uuid.UUID
-	UserIDSender   *uuid.UUID
+	// UserIDSender is nil for system notifications
+	UserIDSender *uuid.UUID
 
-	Type    string
+	Type string
+	// Message is stored as JSONB payload, its schema depends on Type and Version
 	Message map[string]interface{}
 	//Message json.RawMessage
+	// Version of payload schema, zero means current NotificationPayloadVersion
+	Version int
+	// Text is rendered in locale of receiver when notification is read, it is not stored
+	Text string
+
+	// Channels are set by preferences of receiver when notification is saved
+	Channels []string
+
+	// GroupKey collapses unread notifications of the same type and key within aggregation window,
+	// empty key is never collapsed
+	GroupKey string
+	// Count is the number of collapsed events, UserIDSender is the latest of them
+	Count int
+	// ReviewID is set for alerts about review, receiver gets alert of the same type about review only once
+	ReviewID int
 
 	Read      bool
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+func (n Notification) Validate() error {
+	if n.UserIDReceiver == uuid.Nil {
+		return errors.New("receiver of notification cannot be empty")
+	}
+	if n.Type == "" {
+		return errors.New("notification type cannot be empty")
+	}
+	if _, err := n.Payload(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SelfSent reports whether user is notified about own action
+func (n Notification) SelfSent() bool {
+	return n.UserIDSender != nil && *n.UserIDSender == n.UserIDReceiver
+}
+
+// NotificationPreferences: Матрица тип уведомления x канал, отсутствующая ячейка - значение по умолчанию
+type NotificationPreferences map[string]map[string]bool
+
+func (p NotificationPreferences) Enabled(notificationType, channel string) bool {
+	enabled, ok := p[notificationType][channel]
+	if !ok {
+		return defaultChannels[channel]
+	}
+	return enabled
+}
+
+// Full returns matrix of every known type and channel
+func (p NotificationPreferences) Full() NotificationPreferences {
+	full := make(NotificationPreferences, len(NotificationTypes))
+	for _, t := range NotificationTypes {
+		full[t] = make(map[string]bool, len(NotificationChannels))
+		for _, channel := range NotificationChannels {
+			full[t][channel] = p.Enabled(t, channel)
+		}
+	}
+	return full
+}
+
+func (p NotificationPreferences) Validate() error {
+	for t, channels := range p {
+		if !knownNotificationType(t) {
+			return fmt.Errorf("unknown notification type %q", t)
+		}
+		for channel := range channels {
+			if _, ok := defaultChannels[channel]; !ok {
+				return fmt.Errorf("unknown notification channel %q", channel)
+			}
+		}
+	}
+	return nil
+}
+
+func knownNotificationType(notificationType string) bool {
+	for _, t := range NotificationTypes {
+		if t == notificationType {
+			return true
+		}
+	}
+	return false
+}
+
+// ReactionGroupKey collapses reactions of one type on review
+func ReactionGroupKey(reviewID int, reactionType string) string {
+	return fmt.Sprintf("review:%d:%s", reviewID, reactionType)
+}
+
+// Частота дайджеста уведомлений
+const (
+	DigestOff    = "off"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// DigestSettings: Настройка дайджеста уведомлений по почте
+type DigestSettings struct {
+	UserID     uuid.UUID
+	Frequency  string
+	LastSentAt *time.Time
+}
+
+func (s DigestSettings) Validate() error {
+	switch s.Frequency {
+	case DigestOff, DigestDaily, DigestWeekly:
+		return nil
+	}
+	return fmt.Errorf("unknown digest frequency %q", s.Frequency)
+}
+
+// Period is the time covered by one digest, zero for disabled digest
+func (s DigestSettings) Period() time.Duration {
+	switch s.Frequency {
+	case DigestDaily:
+		return 24 * time.Hour
+	case DigestWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// Digest: Сводка уведомлений пользователя за период
+type Digest struct {
+	UserID    uuid.UUID
+	Email     string
+	Nickname  string
+	Locale    string
+	Frequency string
+	// Attempts is the number of claims of digest since the last successful one
+	Attempts int
+	Since    time.Time
+	Until    time.Time
+	// Counts is the number of events by notification type, collapsed events are counted separately
+	Counts map[string]int
+}
+
+func (d Digest) Total() int {
+	total := 0
+	for _, count := range d.Counts {
+		total += count
+	}
+	return total
+}
+
+// Mail: Письмо для отправки через почтовый сервис
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNotificationValidate(t *testing.T) {
+	t.Parallel()
+
+	receiver := uuid.New()
+	sender := uuid.New()
+
+	tests := []struct {
+		name         string
+		notification Notification
+		wantErr      bool
+		selfSent     bool
+	}{
+		{name: "System", notification: Notification{UserIDReceiver: receiver, Type: NotificationReportResolved}},
+		{name: "From user", notification: Notification{UserIDReceiver: receiver, UserIDSender: &sender, Type: NotificationMention}},
+		{name: "Self", notification: Notification{UserIDReceiver: receiver, UserIDSender: &receiver, Type: NotificationMention},
+			selfSent: true},
+		{name: "No receiver", notification: Notification{Type: NotificationMention}, wantErr: true},
+		{name: "No type", notification: Notification{UserIDReceiver: receiver}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.selfSent, tt.notification.SelfSent())
+			err := tt.notification.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	t.Parallel()
+
+	prefs := NotificationPreferences{
+		NotificationMention: {EmailChannel: true, PushChannel: false},
+	}
+
+	t.Run("Set cells", func(t *testing.T) {
+		assert.True(t, prefs.Enabled(NotificationMention, EmailChannel))
+		assert.False(t, prefs.Enabled(NotificationMention, PushChannel))
+	})
+
+	t.Run("Default cells", func(t *testing.T) {
+		assert.True(t, prefs.Enabled(NotificationMention, InAppChannel))
+		assert.False(t, prefs.Enabled(NotificationNewReview, EmailChannel))
+		assert.True(t, prefs.Enabled(NotificationNewReview, PushChannel))
+	})
+
+	t.Run("Full matrix", func(t *testing.T) {
+		full := prefs.Full()
+		assert.Len(t, full, len(NotificationTypes))
+		for _, cells := range full {
+			assert.Len(t, cells, len(NotificationChannels))
+		}
+		assert.True(t, full[NotificationMention][EmailChannel])
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		assert.NoError(t, prefs.Validate())
+		assert.Error(t, NotificationPreferences{"unknown": {InAppChannel: true}}.Validate())
+		assert.Error(t, NotificationPreferences{NotificationMention: {"sms": true}}.Validate())
+	})
+}
+
+func TestDigestSettings(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, DigestSettings{Frequency: DigestOff}.Validate())
+	assert.NoError(t, DigestSettings{Frequency: DigestWeekly}.Validate())
+	assert.Error(t, DigestSettings{Frequency: "hourly"}.Validate())
+
+	assert.Zero(t, DigestSettings{Frequency: DigestOff}.Period())
+	assert.Equal(t, 24*time.Hour, DigestSettings{Frequency: DigestDaily}.Period())
+	assert.Equal(t, 7*24*time.Hour, DigestSettings{Frequency: DigestWeekly}.Period())
+
+	digest := Digest{Counts: map[string]int{NotificationMention: 2, NotificationReviewReaction: 15}}
+	assert.Equal(t, 17, digest.Total())
+}
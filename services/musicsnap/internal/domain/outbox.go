@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Типы доменных событий outbox
+const (
+	EventReviewCreated  = "review_created"
+	EventReviewUpdated  = "review_updated"
+	EventSubscribed     = "subscribed"
+	EventReactionAdded  = "reaction_added"
+	EventConcertCreated = "event_created"
+	EventConcertJoined  = "event_joined"
+)
+
+// DomainEventSchemaVersion is sent with events to the bus, it is raised on incompatible change of payload
+const DomainEventSchemaVersion = 1
+
+// DomainEvent: Доменное событие, записанное в outbox в одной транзакции с изменением.
+// Обработчики получают событие хотя бы один раз, повторная доставка возможна
+type DomainEvent struct {
+	// ID is stable across redeliveries, handlers may use it for deduplication
+	ID uuid.UUID
+	// Seq orders events of outbox
+	Seq     int64
+	Type    string
+	Payload json.RawMessage
+	// Attempts is the number of times event was taken by relay
+	Attempts int
+
+	CreatedAt   time.Time
+	PublishedAt *time.Time
+	// HandledAt is set after handler of event type succeeds, subscribers may still be retried
+	HandledAt *time.Time
+}
+
+func NewDomainEvent(eventType string, payload interface{}) (DomainEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return DomainEvent{}, fmt.Errorf("encode %s event: %w", eventType, err)
+	}
+	return DomainEvent{ID: uuid.New(), Type: eventType, Payload: data}, nil
+}
+
+// Decode unmarshals payload into the struct of event type
+func (e DomainEvent) Decode(payload interface{}) error {
+	if err := json.Unmarshal(e.Payload, payload); err != nil {
+		return fmt.Errorf("decode %s event %s: %w", e.Type, e.ID, err)
+	}
+	return nil
+}
+
+// ReviewCreatedEvent: Рецензия создана
+type ReviewCreatedEvent struct {
+	ReviewID int       `json:"review_id"`
+	AuthorID uuid.UUID `json:"author_id"`
+}
+
+// ReviewUpdatedEvent: Рецензия изменена автором или администратором
+type ReviewUpdatedEvent struct {
+	ReviewID int       `json:"review_id"`
+	AuthorID uuid.UUID `json:"author_id"`
+	EditorID uuid.UUID `json:"editor_id"`
+}
+
+// SubscribedEvent: Пользователь подписался на другого
+type SubscribedEvent struct {
+	SubscriberID uuid.UUID `json:"subscriber_id"`
+	FollowedID   uuid.UUID `json:"followed_id"`
+}
+
+// ReactionAddedEvent: Реакция поставлена или изменена, снятие реакции события не создаёт
+type ReactionAddedEvent struct {
+	ReactionID int       `json:"reaction_id"`
+	ReviewID   int       `json:"review_id"`
+	AuthorID   uuid.UUID `json:"author_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Type       string    `json:"type"`
+}
+
+// ConcertCreatedEvent: Автор создал мероприятие
+type ConcertCreatedEvent struct {
+	EventID  uuid.UUID `json:"event_id"`
+	AuthorID uuid.UUID `json:"author_id"`
+	Name     string    `json:"name"`
+	Date     time.Time `json:"date"`
+}
+
+// ConcertJoinedEvent: Пользователь стал участником мероприятия
+type ConcertJoinedEvent struct {
+	EventID   uuid.UUID   `json:"event_id"`
+	UserID    uuid.UUID   `json:"user_id"`
+	AuthorIDs []uuid.UUID `json:"author_ids"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDomainEvent(t *testing.T) {
+	t.Parallel()
+
+	added := ReactionAddedEvent{ReactionID: 3, ReviewID: 7, AuthorID: uuid.New(), UserID: uuid.New(), Type: LikeReaction}
+	event, err := NewDomainEvent(EventReactionAdded, added)
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, event.ID)
+	assert.Equal(t, EventReactionAdded, event.Type)
+
+	var decoded ReactionAddedEvent
+	require.NoError(t, event.Decode(&decoded))
+	assert.Equal(t, added, decoded)
+
+	broken := DomainEvent{ID: uuid.New(), Type: EventSubscribed, Payload: []byte(`{"subscriber_id": 1}`)}
+	assert.Error(t, broken.Decode(&SubscribedEvent{}))
+}
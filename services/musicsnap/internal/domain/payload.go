@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+// NotificationPayloadVersion is the version of payload schemas written by this build,
+// payloads of older versions are upgraded when decoded
+const NotificationPayloadVersion = 1
+
+// MentionPayload: Упоминание пользователя в рецензии или комментарии
+type MentionPayload struct {
+	SourceType string    `json:"source_type"`
+	SourceID   string    `json:"source_id"`
+	ReviewID   int       `json:"review_id"`
+	UserID     uuid.UUID `json:"user_id"`
+}
+
+// CommentPayload: Комментарий к рецензии или ответ на комментарий, ParentID задан для ответа
+type CommentPayload struct {
+	ReviewID  int        `json:"review_id"`
+	CommentID uuid.UUID  `json:"comment_id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	ParentID  *uuid.UUID `json:"parent_id,omitempty"`
+}
+
+// ReportResolvedPayload: Решение модератора по жалобе
+type ReportResolvedPayload struct {
+	ReportID   int    `json:"report_id"`
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	Status     string `json:"status"`
+}
+
+// NewReviewPayload: Новая рецензия автора из подписок
+type NewReviewPayload struct {
+	ReviewID int    `json:"review_id"`
+	PieceID  string `json:"piece_id"`
+}
+
+// NewPlaylistPayload: Новый плейлист автора из подписок
+type NewPlaylistPayload struct {
+	PlaylistID int    `json:"playlist_id"`
+	Name       string `json:"name"`
+}
+
+// EventPayload: Новое событие автора из подписок
+type EventPayload struct {
+	EventID uuid.UUID `json:"event_id"`
+	Name    string    `json:"name"`
+	Date    time.Time `json:"date"`
+}
+
+// EventReminderPayload: Напоминание участнику о скором начале события
+type EventReminderPayload struct {
+	EventID  uuid.UUID `json:"event_id"`
+	Name     string    `json:"name"`
+	Date     time.Time `json:"date"`
+	Location string    `json:"location,omitempty"`
+}
+
+// ReviewReactionPayload: Реакция на рецензию, одинаковые реакции схлопываются
+type ReviewReactionPayload struct {
+	ReviewID int    `json:"review_id"`
+	Reaction string `json:"reaction"`
+}
+
+// notificationPayloads: Схемы полезной нагрузки по типам уведомлений
+var notificationPayloads = map[string]func() interface{}{
+	NotificationMention:        func() interface{} { return &MentionPayload{} },
+	NotificationReviewComment:  func() interface{} { return &CommentPayload{} },
+	NotificationCommentReply:   func() interface{} { return &CommentPayload{} },
+	NotificationReportResolved: func() interface{} { return &ReportResolvedPayload{} },
+	NotificationNewReview:      func() interface{} { return &NewReviewPayload{} },
+	NotificationNewPlaylist:    func() interface{} { return &NewPlaylistPayload{} },
+	NotificationEvent:          func() interface{} { return &EventPayload{} },
+	NotificationReviewReaction: func() interface{} { return &ReviewReactionPayload{} },
+	NotificationEventReminder:  func() interface{} { return &EventReminderPayload{} },
+}
+
+// NewNotificationMessage encodes typed payload into message of notification
+func NewNotificationMessage(payload interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	var message map[string]interface{}
+	err = json.Unmarshal(raw, &message)
+	return message, err
+}
+
+// Payload decodes message into pointer to typed payload of notification type,
+// message of type without schema is returned as is
+func (n Notification) Payload() (interface{}, error) {
+	if n.Version > NotificationPayloadVersion {
+		return nil, fmt.Errorf("payload version %d of %q is newer than supported %d",
+			n.Version, n.Type, NotificationPayloadVersion)
+	}
+	newPayload, ok := notificationPayloads[n.Type]
+	if !ok {
+		return n.Message, nil
+	}
+
+	raw, err := json.Marshal(n.Message)
+	if err != nil {
+		return nil, err
+	}
+	payload := newPayload()
+	err = json.Unmarshal(raw, payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload of %q: %w", n.Type, err)
+	}
+	return payload, nil
+}
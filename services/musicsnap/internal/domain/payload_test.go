@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNotificationPayload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Typed", func(t *testing.T) {
+		message, err := NewNotificationMessage(ReviewReactionPayload{ReviewID: 7, Reaction: LikeReaction})
+		require.NoError(t, err)
+
+		payload, err := Notification{Type: NotificationReviewReaction, Message: message}.Payload()
+		require.NoError(t, err)
+		assert.Equal(t, &ReviewReactionPayload{ReviewID: 7, Reaction: LikeReaction}, payload)
+	})
+
+	t.Run("Without schema", func(t *testing.T) {
+		message := map[string]interface{}{"any": "thing"}
+		payload, err := Notification{Type: "custom", Message: message}.Payload()
+		require.NoError(t, err)
+		assert.Equal(t, message, payload)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := Notification{Type: NotificationNewReview,
+			Message: map[string]interface{}{"review_id": "seven"}}.Payload()
+		assert.Error(t, err)
+	})
+
+	t.Run("Newer version", func(t *testing.T) {
+		_, err := Notification{Type: NotificationNewReview, Version: NotificationPayloadVersion + 1}.Payload()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	TrackPiece = "track"
+	AlbumPiece = "album"
+)
+
+const (
+	SpotifySource     = "spotify"
+	MusicBrainzSource = "musicbrainz"
+	YandexMusicSource = "yandex_music"
+)
+
+// UnknownArtistID: Исполнитель альбомов и треков, для которых исполнитель не указан, создается миграцией
+var UnknownArtistID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// Artist: Исполнитель
+type Artist struct {
+	ID   uuid.UUID
+	Name string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (a Artist) Validate() error {
+	if strings.TrimSpace(a.Name) == "" {
+		return errors.New("artist name cannot be empty")
+	}
+	return nil
+}
+
+// Piece: Произведение каталога с каноническим ID (трек или альбом)
+type Piece struct {
+	ID   uuid.UUID
+	Type string // "track", "album"
+
+	// Track is set for pieces of type "track"
+	Track *Track
+	// Album is set for pieces of type "album"
+	Album *Album
+
+	Aliases []PieceAlias
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (p Piece) Validate() error {
+	switch p.Type {
+	case TrackPiece:
+		if p.Track == nil {
+			return errors.New("track details are required for track piece")
+		}
+		if p.Album != nil {
+			return errors.New("track piece cannot have album details")
+		}
+		return p.Track.Validate()
+	case AlbumPiece:
+		if p.Album == nil {
+			return errors.New("album details are required for album piece")
+		}
+		if p.Track != nil {
+			return errors.New("album piece cannot have track details")
+		}
+		return p.Album.Validate()
+	default:
+		return errors.New("piece type must be 'track' or 'album'")
+	}
+}
+
+// Title returns title of the track or album behind the piece
+func (p Piece) Title() string {
+	if p.Track != nil {
+		return p.Track.Title
+	}
+	if p.Album != nil {
+		return p.Album.Title
+	}
+	return ""
+}
+
+// Track: Трек
+type Track struct {
+	Title      string
+	DurationMs int
+	Explicit   bool
+
+	ArtistID uuid.UUID
+	Artist   *Artist
+	// AlbumID references Album piece, uuid.Nil for singles
+	AlbumID uuid.UUID
+}
+
+func (t Track) Validate() error {
+	if strings.TrimSpace(t.Title) == "" {
+		return errors.New("track title cannot be empty")
+	}
+	if t.DurationMs < 0 {
+		return errors.New("track duration cannot be negative")
+	}
+	if t.ArtistID == uuid.Nil {
+		return errors.New("track artist ID cannot be empty")
+	}
+	return nil
+}
+
+// Album: Альбом
+type Album struct {
+	Title       string
+	CoverURL    string
+	ReleaseDate time.Time
+
+	ArtistID uuid.UUID
+	Artist   *Artist
+}
+
+func (a Album) Validate() error {
+	if strings.TrimSpace(a.Title) == "" {
+		return errors.New("album title cannot be empty")
+	}
+	if a.ArtistID == uuid.Nil {
+		return errors.New("album artist ID cannot be empty")
+	}
+	return nil
+}
+
+// PieceAlias: Внешний идентификатор произведения (Spotify, MusicBrainz, Яндекс Музыка)
+type PieceAlias struct {
+	Source     string // "spotify", "musicbrainz", "yandex_music"
+	ExternalID string
+	PieceID    uuid.UUID
+
+	CreatedAt time.Time
+}
+
+func (a PieceAlias) Validate() error {
+	if !IsAliasSource(a.Source) {
+		return errors.New("alias source must be 'spotify', 'musicbrainz' or 'yandex_music'")
+	}
+	if strings.TrimSpace(a.ExternalID) == "" {
+		return errors.New("alias external ID cannot be empty")
+	}
+	if a.PieceID == uuid.Nil {
+		return errors.New("alias piece ID cannot be empty")
+	}
+	return nil
+}
+
+func IsAliasSource(source string) bool {
+	return source == SpotifySource || source == MusicBrainzSource || source == YandexMusicSource
+}
+
+// PieceRef: Ссылка на произведение, канонический ID либо внешний ID в виде "source:external_id"
+type PieceRef struct {
+	ID uuid.UUID
+
+	Source     string
+	ExternalID string
+}
+
+func (r PieceRef) IsCanonical() bool {
+	return r.ID != uuid.Nil
+}
+
+// ParsePieceRef parses canonical piece UUID or alias of form "spotify:4uLU6hMCjMI75M1A2tKUQC"
+func ParsePieceRef(ref string) (PieceRef, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return PieceRef{}, errors.New("piece reference cannot be empty")
+	}
+
+	if id, err := uuid.Parse(ref); err == nil {
+		if id == uuid.Nil {
+			return PieceRef{}, errors.New("piece ID cannot be nil UUID")
+		}
+		return PieceRef{ID: id}, nil
+	}
+
+	source, externalID, found := strings.Cut(ref, ":")
+	if !found {
+		return PieceRef{}, errors.New("piece reference must be UUID or 'source:external_id'")
+	}
+	alias := PieceAlias{Source: source, ExternalID: externalID, PieceID: uuid.New()}
+	if err := alias.Validate(); err != nil {
+		return PieceRef{}, err
+	}
+
+	return PieceRef{Source: source, ExternalID: externalID}, nil
+}
+
+type PieceFilter struct {
+	TitleQuery string
+	Type       *string
+	ArtistID   *uuid.UUID
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParsePieceRef(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Canonical", func(t *testing.T) {
+		t.Parallel()
+
+		id := uuid.New()
+		ref, err := ParsePieceRef(id.String())
+		require.NoError(t, err)
+
+		assert.True(t, ref.IsCanonical())
+		assert.Equal(t, id, ref.ID)
+	})
+
+	t.Run("Alias", func(t *testing.T) {
+		t.Parallel()
+
+		ref, err := ParsePieceRef("spotify:4uLU6hMCjMI75M1A2tKUQC")
+		require.NoError(t, err)
+
+		assert.False(t, ref.IsCanonical())
+		assert.Equal(t, SpotifySource, ref.Source)
+		assert.Equal(t, "4uLU6hMCjMI75M1A2tKUQC", ref.ExternalID)
+	})
+
+	t.Run("AliasWithColonInExternalID", func(t *testing.T) {
+		t.Parallel()
+
+		ref, err := ParsePieceRef("yandex_music:123:456")
+		require.NoError(t, err)
+
+		assert.Equal(t, YandexMusicSource, ref.Source)
+		assert.Equal(t, "123:456", ref.ExternalID)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, in := range []string{"", "  ", "some track", "deezer:123", "spotify:", uuid.Nil.String()} {
+			_, err := ParsePieceRef(in)
+			assert.Error(t, err, in)
+		}
+	})
+}
+
+func TestPieceValidate(t *testing.T) {
+	t.Parallel()
+
+	artistID := uuid.New()
+
+	t.Run("Track", func(t *testing.T) {
+		t.Parallel()
+
+		p := Piece{Type: TrackPiece, Track: &Track{Title: "Song", ArtistID: artistID}}
+		assert.NoError(t, p.Validate())
+		assert.Equal(t, "Song", p.Title())
+
+		p.Album = &Album{Title: "Album", ArtistID: artistID}
+		assert.Error(t, p.Validate())
+	})
+
+	t.Run("Album", func(t *testing.T) {
+		t.Parallel()
+
+		p := Piece{Type: AlbumPiece, Album: &Album{Title: "Album", ArtistID: artistID}}
+		assert.NoError(t, p.Validate())
+
+		p.Album.ArtistID = uuid.Nil
+		assert.Error(t, p.Validate())
+	})
+
+	t.Run("MissingDetails", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Error(t, Piece{Type: TrackPiece}.Validate())
+		assert.Error(t, Piece{Type: AlbumPiece}.Validate())
+		assert.Error(t, Piece{Type: "single"}.Validate())
+	})
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Rating: Быстрая оценка произведения без рецензии, одна на пользователя и произведение
+type Rating struct {
+	ID      uuid.UUID
+	UserID  uuid.UUID
+	PieceID string
+
+	Rating int // 1-10
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (r Rating) Validate() error {
+	if r.UserID == uuid.Nil {
+		return errors.New("user id is required")
+	}
+	if r.PieceID == "" {
+		return errors.New("piece id is required")
+	}
+	if r.Rating < 1 || r.Rating > 10 {
+		return errors.New("rating must be between 1 and 10")
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"github.com/google/uuid"
+	"regexp"
 	"time"
 )
 
@@ -11,6 +12,11 @@ const (
 	DislikeReaction = "dislike"
 )
 
+// ReactionTypeMaxLength limits name of reaction type, the set of types is configured in service
+const ReactionTypeMaxLength = 32
+
+var reactionTypeRe = regexp.MustCompile(`^[a-z0-9_]+$`)
+
 // Reaction: Реакция на рецензию
 type Reaction struct {
 	ID int
@@ -19,14 +25,14 @@ type Reaction struct {
 	// ReviewID references Review(ID)
 	ReviewID int
 
-	Type      string // "like", "dislike" и т.д.
+	Type      string // "like", "dislike", "fire" и т.д.
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
 func (r Reaction) Validate() error {
-	if r.Type != LikeReaction && r.Type != DislikeReaction {
-		return errors.New("reaction type must be 'like' or 'dislike'")
+	if len(r.Type) == 0 || len(r.Type) > ReactionTypeMaxLength || !reactionTypeRe.MatchString(r.Type) {
+		return errors.New("reaction type must be a lowercase name of up to 32 letters, digits and '_'")
 	}
 	if r.ReviewID == 0 {
 		return errors.New("review ID cannot be empty")
@@ -37,7 +43,11 @@ func (r Reaction) Validate() error {
 	return nil
 }
 
-type ReactionCount struct {
-	likes    int
-	dislikes int
+// ReactionCounts: Количество реакций на рецензию по типам, типы без реакций отсутствуют
+type ReactionCounts map[string]int
+
+// ReactionFilter: Фильтр списка отреагировавших на рецензию
+type ReactionFilter struct {
+	ReviewID int
+	Type     *string
 }
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestReactionValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		typ     string
+		wantErr bool
+	}{
+		{name: "Like", typ: LikeReaction},
+		{name: "Emoji name", typ: "fire"},
+		{name: "Underscore and digits", typ: "plus_1"},
+		{name: "MaxLength", typ: strings.Repeat("a", ReactionTypeMaxLength)},
+		{name: "Empty", typ: "", wantErr: true},
+		{name: "Uppercase", typ: "Fire", wantErr: true},
+		{name: "Emoji", typ: "🔥", wantErr: true},
+		{name: "TooLong", typ: strings.Repeat("a", ReactionTypeMaxLength+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Reaction{UserID: uuid.New(), ReviewID: 1, Type: tt.typ}.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// EventReminder: Напоминание участнику о начале события
+type EventReminder struct {
+	ID      int64
+	EventID uuid.UUID
+	UserID  uuid.UUID
+	// Before is the time between reminder and start of event
+	Before   time.Duration
+	RemindAt time.Time
+	SentAt   *time.Time
+
+	// Event is set for claimed reminders, its date is the current one
+	Event Event
+}
+
+// Payload of notification about reminded event
+func (r EventReminder) Payload() EventReminderPayload {
+	return EventReminderPayload{
+		EventID:  r.EventID,
+		Name:     r.Event.Name,
+		Date:     r.Event.Date,
+		Location: r.Event.Location,
+	}
+}
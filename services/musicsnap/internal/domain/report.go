@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"strconv"
+	"time"
+)
+
+// ReportTargetType: Вид контента, на который можно пожаловаться
+type ReportTargetType string
+
+const (
+	ReportTargetReview     ReportTargetType = "review"
+	ReportTargetProfile    ReportTargetType = "profile"
+	ReportTargetNote       ReportTargetType = "note"
+	ReportTargetEventPhoto ReportTargetType = "event_photo"
+)
+
+// ValidID checks target type and id format: reviews and notes have int ids, profiles and photos - uuid
+func (t ReportTargetType) ValidID(id string) error {
+	switch t {
+	case ReportTargetReview, ReportTargetNote:
+		if _, err := strconv.Atoi(id); err != nil {
+			return fmt.Errorf("%s id must be integer: %w", t, err)
+		}
+	case ReportTargetProfile, ReportTargetEventPhoto:
+		if _, err := uuid.Parse(id); err != nil {
+			return fmt.Errorf("%s id must be uuid: %w", t, err)
+		}
+	default:
+		return fmt.Errorf("unknown report target type %q", t)
+	}
+	return nil
+}
+
+// ReportStatus: Состояние жалобы, закрытая жалоба подтверждена или отклонена модератором
+type ReportStatus string
+
+const (
+	ReportOpen      ReportStatus = "open"
+	ReportUpheld    ReportStatus = "upheld"
+	ReportDismissed ReportStatus = "dismissed"
+)
+
+func (s ReportStatus) Resolution() bool {
+	return s == ReportUpheld || s == ReportDismissed
+}
+
+// NotificationReportResolved is the type of notification sent to reporters when their report is resolved
+const NotificationReportResolved = "report_resolved"
+
+// Report: Жалоба пользователя на контент
+type Report struct {
+	ID int
+	// ReporterID is uuid.Nil for reports filed by content filter
+	ReporterID uuid.UUID
+
+	TargetType ReportTargetType
+	TargetID   string
+
+	Reason  ModerationReasonCode
+	Comment string
+
+	Status     ReportStatus
+	ResolvedBy *uuid.UUID
+	ResolvedAt *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ByFilter reports whether report was filed by content filter, not by user
+func (r Report) ByFilter() bool {
+	return r.ReporterID == uuid.Nil
+}
+
+func (r Report) Validate() error {
+	err := r.TargetType.ValidID(r.TargetID)
+	if err != nil {
+		return err
+	}
+	if !r.Reason.Valid() {
+		return fmt.Errorf("unknown report reason %q", r.Reason)
+	}
+	return nil
+}
+
+// ReportTarget: Сводка жалоб на объект, HiddenAt задан пока объект скрыт до решения модератора
+type ReportTarget struct {
+	TargetType  ReportTargetType
+	TargetID    string
+	OpenReports int
+	HiddenAt    *time.Time
+}
+
+func (t ReportTarget) Hidden() bool {
+	return t.HiddenAt != nil
+}
+
+type ReportFilter struct {
+	ReporterID *uuid.UUID
+	TargetType *ReportTargetType
+	TargetID   *string
+	Status     *ReportStatus
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReportValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		report  Report
+		wantErr bool
+	}{
+		{name: "Review", report: Report{TargetType: ReportTargetReview, TargetID: "42", Reason: ReasonSpam}},
+		{name: "Profile", report: Report{TargetType: ReportTargetProfile, TargetID: uuid.NewString(), Reason: ReasonHarassment}},
+		{name: "Note", report: Report{TargetType: ReportTargetNote, TargetID: "7", Reason: ReasonOffensive}},
+		{name: "EventPhoto", report: Report{TargetType: ReportTargetEventPhoto, TargetID: uuid.NewString(), Reason: ReasonCopyright}},
+		{name: "ReviewWithUUID", report: Report{TargetType: ReportTargetReview, TargetID: uuid.NewString(), Reason: ReasonSpam}, wantErr: true},
+		{name: "ProfileWithInt", report: Report{TargetType: ReportTargetProfile, TargetID: "42", Reason: ReasonSpam}, wantErr: true},
+		{name: "UnknownType", report: Report{TargetType: "playlist", TargetID: "42", Reason: ReasonSpam}, wantErr: true},
+		{name: "UnknownReason", report: Report{TargetType: ReportTargetReview, TargetID: "42", Reason: "boring"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.report.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
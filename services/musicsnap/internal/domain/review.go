@@ -18,11 +18,96 @@ type Review struct {
 	PhotoURL string
 
 	// For global
-	Moderated bool
+	// Moderated is true when moderation decided on review, kept in sync with ModerationStatus
+	Moderated        bool
+	ModerationStatus ModerationStatus
+	// ModerationReason explains rejection
+	ModerationReason *ModerationReasonCode
+	// Hidden is set when review got enough reports and waits for moderator
+	Hidden    bool
 	Published bool
+	// PublishAt is the scheduled publication time of unpublished review, draft has neither
+	PublishAt *time.Time
+	// PublishedAt is the time of the first publication
+	PublishedAt *time.Time
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// EditedAt is the time of the last change of review content, nil for never edited review
+	EditedAt *time.Time
+
+	// CommentsCount is the number of not deleted comments
+	CommentsCount int
+	// ReactionCounts and ActorReaction are filled in review lists, ActorReaction is nil without reaction
+	ReactionCounts ReactionCounts
+	ActorReaction  *Reaction
+}
+
+// IsDraft reports whether review is visible only to its author
+func (r Review) IsDraft() bool {
+	return !r.Published
+}
+
+func (r Review) Scheduled() bool {
+	return !r.Published && r.PublishAt != nil
+}
+
+// VisibleToPublic reports whether review can be shown to users other than author and moderators.
+// In pre-moderation mode review must be approved, otherwise only rejection hides it
+func (r Review) VisibleToPublic(preModeration bool) bool {
+	if !r.Published || r.Hidden || r.ModerationStatus == ModerationRejected {
+		return false
+	}
+	return !preModeration || r.ModerationStatus == ModerationApproved
+}
+
+func (r Review) Edited() bool {
+	return r.EditedAt != nil
+}
+
+// ContentChanged reports whether other review differs in fields kept in revisions
+func (r Review) ContentChanged(other Review) bool {
+	return r.PieceID != other.PieceID || r.Rating != other.Rating ||
+		r.Content != other.Content || r.PhotoURL != other.PhotoURL
+}
+
+// ReviewPublication: Первая публикация рецензии, побочные эффекты которой еще не обработаны
+type ReviewPublication struct {
+	Review Review
+	// Attempts counts claims of the publication including the current one
+	Attempts int
+}
+
+// ReviewRevision: Неизменяемый снимок содержимого рецензии, первая ревизия - исходный текст
+type ReviewRevision struct {
+	ID       int
+	ReviewID int
+	Revision int
+	// EditorID is the user who wrote the revision, author or admin
+	EditorID uuid.UUID
+
+	PieceID  string
+	Rating   int
+	Content  string
+	PhotoURL string
+
+	CreatedAt time.Time
+}
+
+// ReviewRevisionDiff: Пословный дифф текста между двумя ревизиями
+type ReviewRevisionDiff struct {
+	From ReviewRevision
+	To   ReviewRevision
+
+	Chunks []DiffChunk
+}
+
+func NewReviewRevisionDiff(from, to ReviewRevision) ReviewRevisionDiff {
+	return ReviewRevisionDiff{
+		From:   from,
+		To:     to,
+		Chunks: WordDiff(from.Content, to.Content),
+	}
 }
 
 type ReviewFilter struct {
@@ -33,6 +118,13 @@ type ReviewFilter struct {
 	Moderated *bool
 	Published *bool
 
+	ModerationStatus *ModerationStatus
+	// ExcludeModerationStatus hides reviews with the status, used to hide rejected reviews
+	ExcludeModerationStatus *ModerationStatus
+	Hidden                  *bool
+	// Tag lists reviews with the hashtag in their text
+	Tag *string
+
 	IncludeProfiles bool
 	OrderByRating   *bool
 	OrderAsc        bool
@@ -47,23 +139,3 @@ type ReviewFilter struct {
 //	CreatedAt time.Time
 //	UpdatedAt time.Time
 //}
-
-//// Comment: Комментарий в треде
-//type Comment struct {
-//	ID        uuid.UUID
-//	UserID    uuid.UUID
-//	ThreadID  uuid.UUID
-//	Content      string
-//	CreatedAt time.Time
-//	UpdatedAt time.Time
-//}
-//
-//// Rating: Оценка пользователя
-//type Rating struct {
-//	ID        uuid.UUID
-//	UserID    uuid.UUID
-//	PieceID   uuid.UUID
-//	Rating    int // 1-10
-//	CreatedAt time.Time
-//	UpdatedAt time.Time
-//}
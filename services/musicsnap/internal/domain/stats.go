@@ -16,10 +16,53 @@ type ProfileStats struct {
 }
 
 type TrackStats struct {
+	PieceID string `json:"piece_id"`
+
 	TotalLikesCount    int `json:"total_likes_count"`
 	TotalDislikesCount int `json:"total_dislikes_count"`
 
 	TotalReviewsCount int `json:"total_reviews_count"`
+	TotalRatingsCount int `json:"total_ratings_count"`
+
+	AverageRating float64         `json:"average_rating"`
+	MedianRating  float64         `json:"median_rating"`
+	Histogram     RatingHistogram `json:"histogram"`
 
 	TotalNotesCount int `json:"total_comments_count"`
 }
+
+// RatingHistogram: Количество оценок по значениям, RatingHistogram[0] - оценки 1, RatingHistogram[9] - оценки 10
+type RatingHistogram [10]int
+
+func (h RatingHistogram) Count() int {
+	total := 0
+	for _, n := range h {
+		total += n
+	}
+	return total
+}
+
+// Median returns median rating, the mean of two middle ratings for even count and 0 for empty histogram
+func (h RatingHistogram) Median() float64 {
+	total := h.Count()
+	if total == 0 {
+		return 0
+	}
+
+	// zero based positions of middle elements in sorted ratings
+	lo, hi := (total-1)/2, total/2
+	loRating, hiRating := 0, 0
+
+	seen := 0
+	for i, n := range h {
+		if loRating == 0 && lo < seen+n {
+			loRating = i + 1
+		}
+		if hi < seen+n {
+			hiRating = i + 1
+			break
+		}
+		seen += n
+	}
+	return float64(loRating+hiRating) / 2
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRatingHistogramMedian(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		histogram RatingHistogram
+		count     int
+		median    float64
+	}{
+		{name: "Empty", histogram: RatingHistogram{}, count: 0, median: 0},
+		{name: "Single", histogram: RatingHistogram{6: 1}, count: 1, median: 7},
+		{name: "Odd", histogram: RatingHistogram{0: 1, 4: 1, 9: 1}, count: 3, median: 5},
+		{name: "EvenBetweenBuckets", histogram: RatingHistogram{1: 2, 7: 2}, count: 4, median: 5},
+		{name: "EvenSameBucket", histogram: RatingHistogram{2: 1, 3: 4, 8: 1}, count: 6, median: 4},
+		{name: "AllTens", histogram: RatingHistogram{9: 5}, count: 5, median: 10},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.count, tt.histogram.Count())
+			assert.Equal(t, tt.median, tt.histogram.Median())
+		})
+	}
+}
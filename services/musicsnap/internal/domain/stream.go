@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	// NotificationStreamEvent carries new notification of receiver
+	NotificationStreamEvent = "notification"
+	// FeedStreamEvent carries review published by followed user
+	FeedStreamEvent = "feed"
+)
+
+// StreamEvent: Событие, доставляемое подключённому клиенту
+type StreamEvent struct {
+	// ID grows with every event, client resumes stream after the last received ID
+	ID     int64
+	UserID uuid.UUID
+	Kind   string
+	// Payload is sent to client as is
+	Payload   json.RawMessage
+	CreatedAt time.Time
+}
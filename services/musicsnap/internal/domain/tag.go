@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TagMaxLength limits hashtag in characters, longer ones are not indexed
+const TagMaxLength = 64
+
+// NotificationMention is sent to the user mentioned in review or comment
+const NotificationMention = "mention"
+
+// TextSourceType: Вид текста, в котором ищутся упоминания и хэштеги
+type TextSourceType string
+
+const (
+	TextSourceReview  TextSourceType = "review"
+	TextSourceComment TextSourceType = "comment"
+)
+
+// TextSource: Текст рецензии или комментария для индекса упоминаний и хэштегов
+type TextSource struct {
+	Type TextSourceType
+	// ID is review id or comment uuid
+	ID       string
+	ReviewID int
+	AuthorID uuid.UUID
+	Text     string
+}
+
+// TagTrend: Популярность хэштега за окно времени
+type TagTrend struct {
+	Tag string
+	// Uses is amount of reviews and comments with the tag
+	Uses int
+}
+
+// mention and hashtag start at the beginning of text or after a character which can't be a part of word,
+// so that emails and anchors in links are skipped
+var (
+	mentionRe = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@#/])@([\p{L}\p{N}_.]+)`)
+	hashtagRe = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@#/&])#([\p{L}\p{N}_]+)`)
+)
+
+// ParseMentions returns unique nicknames mentioned as @nickname in order of appearance
+func ParseMentions(text string) []string {
+	var nicknames []string
+	seen := make(map[string]bool)
+	for _, m := range mentionRe.FindAllStringSubmatch(text, -1) {
+		// точка в конце - конец предложения, а не часть никнейма
+		nickname := strings.TrimRight(m[1], ".")
+		if nickname == "" || seen[nickname] {
+			continue
+		}
+		seen[nickname] = true
+		nicknames = append(nicknames, nickname)
+	}
+	return nicknames
+}
+
+// ParseHashtags returns unique normalized hashtags in order of appearance
+func ParseHashtags(text string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, m := range hashtagRe.FindAllStringSubmatch(text, -1) {
+		tag, ok := NormalizeTag(m[1])
+		if !ok || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// NormalizeTag lowercases tag without leading '#', tag must contain a letter ("#1" is not a tag)
+func NormalizeTag(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimPrefix(tag, "#"))
+	if tag == "" || utf8.RuneCountInString(tag) > TagMaxLength {
+		return "", false
+	}
+	hasLetter := false
+	for _, r := range tag {
+		if unicode.IsLetter(r) {
+			hasLetter = true
+		} else if !unicode.IsDigit(r) && r != '_' {
+			return "", false
+		}
+	}
+	return tag, hasLetter
+}
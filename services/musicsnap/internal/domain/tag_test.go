@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "Single", text: "@bass_lover check this", want: []string{"bass_lover"}},
+		{name: "EndOfSentence", text: "Thanks, @dj.max.", want: []string{"dj.max"}},
+		{name: "Duplicates", text: "@a and @b, @a again", want: []string{"a", "b"}},
+		{name: "Cyrillic", text: "согласен с @меломан", want: []string{"меломан"}},
+		{name: "Email", text: "write to me@example.com", want: nil},
+		{name: "Empty", text: "@ nobody", want: nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, ParseMentions(tt.text))
+		})
+	}
+}
+
+func TestParseHashtags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "Single", text: "#shoegaze forever", want: []string{"shoegaze"}},
+		{name: "Normalized", text: "#Jazz and #JAZZ", want: []string{"jazz"}},
+		{name: "Cyrillic", text: "лучший #постпанк_2024", want: []string{"постпанк_2024"}},
+		{name: "DigitsOnly", text: "track #1", want: nil},
+		{name: "LinkAnchor", text: "see https://example.com/page#section", want: nil},
+		{name: "InsideWord", text: "C#minor", want: nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, ParseHashtags(tt.text))
+		})
+	}
+}
@@ -24,6 +24,8 @@ type User struct {
 	Email        string
 	PasswordHash string
 	Roles        Roles
+	// Locale is the language of notifications and digests
+	Locale string
 	//Password     string
 
 	CreatedAt time.Time
@@ -57,6 +59,7 @@ func (u *User) Copy() User {
 		Email:        u.Email,
 		PasswordHash: u.PasswordHash,
 		Roles:        u.Roles,
+		Locale:       u.Locale,
 		CreatedAt:    u.CreatedAt,
 		UpdatedAt:    u.UpdatedAt,
 	}
@@ -82,13 +85,29 @@ func (u *User) Valid() bool {
 	return true
 }
 
+// SubscriptionFlags: Уведомления о действиях отслеживаемого пользователя, включённые в подписке
+type SubscriptionFlags struct {
+	NewReview   bool
+	NewPlaylist bool
+	Event       bool
+}
+
+// IsSubscriptionAlert reports whether notification type is sent to followers by flag of subscription,
+// flag names match notification types
+func IsSubscriptionAlert(notificationType string) bool {
+	switch notificationType {
+	case NotificationNewReview, NotificationNewPlaylist, NotificationEvent:
+		return true
+	}
+	return false
+}
+
 // Subscription: Подписки пользователей
 type Subscription struct {
-	ID           int
-	SubscriberID uuid.UUID // Ссылка на User.ID
-	FollowedID   uuid.UUID // Ссылка на User.ID
-	//NotificationFlags map[string]interface{} // JSON-флаги
-	NotificationFlag  bool // JSON-флаги
+	ID                int
+	SubscriberID      uuid.UUID // Ссылка на User.ID
+	FollowedID        uuid.UUID // Ссылка на User.ID
+	NotificationFlags SubscriptionFlags
 	ProfileOfInterest Profile
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
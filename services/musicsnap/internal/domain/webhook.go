@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Типы событий исходящих вебхуков
+const (
+	WebhookReviewPublished = "review.published"
+	WebhookUserFollowed    = "user.followed"
+	WebhookEventJoined     = "event.joined"
+)
+
+var WebhookEventTypes = []string{WebhookReviewPublished, WebhookUserFollowed, WebhookEventJoined}
+
+// Статусы доставки вебхука
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	// DeliveryDead is set after the last failed attempt, such deliveries form the dead-letter list
+	DeliveryDead = "dead"
+)
+
+var DeliveryStatuses = []string{DeliveryPending, DeliveryDelivered, DeliveryDead}
+
+// Webhook: Подписка внешней интеграции на события
+type Webhook struct {
+	ID int
+
+	// UserID is nil for webhook of the app, it receives events of every user
+	UserID *uuid.UUID
+
+	URL string
+	// Secret signs payloads with HMAC-SHA256, it is generated by the service
+	Secret     string
+	EventTypes []string
+	Active     bool
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("webhook url must be an absolute http or https url")
+	}
+	// имена хостов проверяются ещё раз при отправке, когда известен адрес
+	host := u.Hostname()
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return errors.New("webhook url must not point to local host")
+	}
+	if ip := net.ParseIP(host); ip != nil && !PublicAddress(ip) {
+		return errors.New("webhook url must not point to loopback, private or link-local address")
+	}
+	if len(w.EventTypes) == 0 {
+		return errors.New("webhook must be subscribed to at least one event type")
+	}
+	for _, t := range w.EventTypes {
+		if !validWebhookEventType(t) {
+			return fmt.Errorf("unknown webhook event type %q", t)
+		}
+	}
+	return nil
+}
+
+// PublicAddress reports whether webhook may be delivered to ip:
+// loopback, private, link-local and unspecified addresses belong to the service network
+func PublicAddress(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
+
+func validWebhookEventType(eventType string) bool {
+	for _, t := range WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEvent: Событие для рассылки по вебхукам
+type WebhookEvent struct {
+	ID   uuid.UUID
+	Type string
+	// SubjectID is the user whose webhooks get the event besides webhooks of the app
+	SubjectID uuid.UUID
+	Data      map[string]interface{}
+	CreatedAt time.Time
+}
+
+// Body is the JSON posted to receivers
+func (e WebhookEvent) Body() ([]byte, error) {
+	return json.Marshal(struct {
+		ID        uuid.UUID              `json:"id"`
+		Type      string                 `json:"type"`
+		CreatedAt time.Time              `json:"created_at"`
+		Data      map[string]interface{} `json:"data"`
+	}{e.ID, e.Type, e.CreatedAt, e.Data})
+}
+
+// WebhookDelivery: Доставка события на вебхук
+type WebhookDelivery struct {
+	ID        int64
+	WebhookID int
+
+	EventID   uuid.UUID
+	EventType string
+	Payload   json.RawMessage
+
+	Status   string
+	Attempts int
+	// NextAttemptAt is nil when delivery is finished
+	NextAttemptAt *time.Time
+
+	// URL and Secret of webhook are set when delivery is claimed for sending
+	URL    string
+	Secret string
+
+	// Log of attempts from oldest to newest, it is loaded for a single delivery only
+	Log []WebhookAttempt
+
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeliveredAt *time.Time
+}
+
+// WebhookAttempt: Попытка доставки вебхука
+type WebhookAttempt struct {
+	ID         int64
+	DeliveryID int64
+	// StatusCode is zero when receiver did not respond
+	StatusCode  int
+	Error       string
+	Duration    time.Duration
+	AttemptedAt time.Time
+}
+
+func (a WebhookAttempt) Succeeded() bool {
+	return a.Error == "" && a.StatusCode >= 200 && a.StatusCode < 300
+}
+
+// WebhookDeliveryFilter: Фильтр журнала доставок вебхука
+type WebhookDeliveryFilter struct {
+	WebhookID int
+	Status    *string
+}
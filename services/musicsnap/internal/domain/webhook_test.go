@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWebhookValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		url     string
+		types   []string
+		wantErr bool
+	}{
+		{name: "Https", url: "https://partner.example/hooks", types: []string{WebhookReviewPublished}},
+		{name: "All types", url: "http://partner.example:8080/hook", types: WebhookEventTypes},
+		{name: "Public ip", url: "http://203.0.113.7/hook", types: []string{WebhookReviewPublished}},
+		{name: "Localhost", url: "http://localhost:8080/hook", types: []string{WebhookReviewPublished}, wantErr: true},
+		{name: "Loopback", url: "http://127.0.0.1/hook", types: []string{WebhookReviewPublished}, wantErr: true},
+		{name: "Loopback v6", url: "http://[::1]/hook", types: []string{WebhookReviewPublished}, wantErr: true},
+		{name: "Private", url: "http://10.0.0.5/hook", types: []string{WebhookReviewPublished}, wantErr: true},
+		{name: "Metadata", url: "http://169.254.169.254/latest", types: []string{WebhookReviewPublished}, wantErr: true},
+		{name: "Unspecified", url: "http://0.0.0.0:8080/hook", types: []string{WebhookReviewPublished}, wantErr: true},
+		{name: "Relative url", url: "/hook", types: []string{WebhookReviewPublished}, wantErr: true},
+		{name: "Other scheme", url: "ftp://partner.example", types: []string{WebhookReviewPublished}, wantErr: true},
+		{name: "No types", url: "https://partner.example", wantErr: true},
+		{name: "Unknown type", url: "https://partner.example", types: []string{"review.deleted"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Webhook{URL: tt.url, EventTypes: tt.types}.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWebhookAttemptSucceeded(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, WebhookAttempt{StatusCode: 204}.Succeeded())
+	assert.False(t, WebhookAttempt{StatusCode: 500}.Succeeded())
+	assert.False(t, WebhookAttempt{StatusCode: 301}.Succeeded())
+	assert.False(t, WebhookAttempt{Error: "connection refused"}.Succeeded())
+}
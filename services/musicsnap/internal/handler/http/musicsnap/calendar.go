@@ -0,0 +1,113 @@
+package musicsnap
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/handler/http/musicsnap/oapi"
+	"music-snap/services/musicsnap/internal/service/icalendar"
+	"net/http"
+	"strings"
+)
+
+func (h MusicsnapHandler) GetEventsEventIdIcs(c *gin.Context, eventId oapi.UUID) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetEventsEventIdIcs"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	calendar, err := h.s.Calendar.ExportEvent(ctx, eventId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="event-%s.ics"`, eventId))
+	c.Data(http.StatusOK, icalendar.ContentType, calendar)
+}
+
+func (h MusicsnapHandler) GetCalendarFeed(c *gin.Context, params oapi.GetCalendarFeedParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetCalendarFeed"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	feed, err := h.s.Calendar.GetFeed(ctx, actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oapi.ToCalendarFeedResponse(feed))
+}
+
+func (h MusicsnapHandler) PostCalendarFeed(c *gin.Context, params oapi.PostCalendarFeedParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostCalendarFeed"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	feed, err := h.s.Calendar.RotateFeed(ctx, actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, oapi.ToCalendarFeedResponse(feed))
+}
+
+func (h MusicsnapHandler) DeleteCalendarFeed(c *gin.Context, params oapi.DeleteCalendarFeedParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("DeleteCalendarFeed"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	if err = h.s.Calendar.DeleteFeed(ctx, actor); err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetCalendarFeedsToken is polled by calendar apps, they don't send actor
+func (h MusicsnapHandler) GetCalendarFeedsToken(c *gin.Context, token string) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetCalendarFeedsToken"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	calendar, err := h.s.Calendar.RenderFeed(ctx, strings.TrimSuffix(token, ".ics"))
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, icalendar.ContentType, calendar)
+}
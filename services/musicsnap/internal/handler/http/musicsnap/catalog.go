@@ -0,0 +1,235 @@
+package musicsnap
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/handler/http/musicsnap/oapi"
+	"net/http"
+)
+
+func (h MusicsnapHandler) PostArtists(c *gin.Context, params oapi.PostArtistsParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostArtists"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PostArtistsJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+
+	artistPayload, err := payload.ToDomain()
+	if err != nil {
+		h.abortWithAutoResponse(c, app.NewError(http.StatusBadRequest, "invalid request body", "request body", err))
+		return
+	}
+
+	artist, err := h.s.Catalog.CreateArtist(ctx, actor, artistPayload)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToArtistResponse(artist)
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h MusicsnapHandler) GetArtistsArtistId(c *gin.Context, artistId oapi.UUID, params oapi.GetArtistsArtistIdParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetArtistsArtistId"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	artist, err := h.s.Catalog.GetArtist(ctx, actor, artistId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToArtistResponse(artist)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h MusicsnapHandler) PostPieces(c *gin.Context, params oapi.PostPiecesParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostPieces"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PostPiecesJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+
+	piecePayload, err := payload.ToDomain()
+	if err != nil {
+		h.abortWithAutoResponse(c, app.NewError(http.StatusBadRequest, "invalid request body", "request body", err))
+		return
+	}
+
+	piece, err := h.s.Catalog.CreatePiece(ctx, actor, piecePayload)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToPieceResponse(piece)
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h MusicsnapHandler) GetPieces(c *gin.Context, params oapi.GetPiecesParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetPieces"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	filter := params.ToDomain()
+	pagination := oapi.ToUUIDPaginationDomain(params.Limit, params.LastUuid)
+
+	pieces, pagination, err := h.s.Catalog.ListPieces(ctx, actor, filter, pagination)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	type Response struct {
+		Pieces     []oapi.Piece        `json:"pieces"`
+		Pagination oapi.UUIDPagination `json:"pagination"`
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Pieces:     oapi.ToPiecesResponse(pieces),
+		Pagination: oapi.ToUUIDPaginationResponse(pagination),
+	})
+}
+
+func (h MusicsnapHandler) GetPiecesPieceId(c *gin.Context, pieceId string, params oapi.GetPiecesPieceIdParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetPiecesPieceId"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	piece, err := h.s.Catalog.GetPiece(ctx, actor, pieceId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToPieceResponse(piece)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h MusicsnapHandler) PutPiecesPieceId(c *gin.Context, pieceId string, params oapi.PutPiecesPieceIdParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PutPiecesPieceId"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PutPiecesPieceIdJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+
+	piecePayload, err := payload.ToDomain()
+	if err != nil {
+		h.abortWithAutoResponse(c, app.NewError(http.StatusBadRequest, "invalid request body", "request body", err))
+		return
+	}
+
+	// path id may be an alias, so it is resolved to canonical id first
+	existing, err := h.s.Catalog.GetPiece(ctx, actor, pieceId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+	piecePayload.ID = existing.ID
+
+	piece, err := h.s.Catalog.UpdatePiece(ctx, actor, piecePayload)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToPieceResponse(piece)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h MusicsnapHandler) PostPiecesPieceIdAliases(c *gin.Context, pieceId oapi.UUID, params oapi.PostPiecesPieceIdAliasesParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostPiecesPieceIdAliases"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PostPiecesPieceIdAliasesJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+
+	aliasPayload, err := payload.ToDomain()
+	if err != nil {
+		h.abortWithAutoResponse(c, app.NewError(http.StatusBadRequest, "invalid request body", "request body", err))
+		return
+	}
+	aliasPayload.PieceID = pieceId
+
+	alias, err := h.s.Catalog.AddAlias(ctx, actor, aliasPayload)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToPieceAliasResponse(alias)
+	c.JSON(http.StatusCreated, resp)
+}
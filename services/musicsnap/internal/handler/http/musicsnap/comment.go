@@ -0,0 +1,143 @@
+package musicsnap
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/handler/http/musicsnap/oapi"
+	"net/http"
+)
+
+func (h MusicsnapHandler) PostReviewsReviewIdComments(c *gin.Context, reviewId int, params oapi.PostReviewsReviewIdCommentsParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostReviewsReviewIdComments"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PostReviewsReviewIdCommentsJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+
+	comment, err := h.s.Comment.CreateComment(ctx, actor, payload.ToDomain(reviewId))
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToCommentResponse(comment)
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h MusicsnapHandler) GetReviewsReviewIdComments(c *gin.Context, reviewId int, params oapi.GetReviewsReviewIdCommentsParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetReviewsReviewIdComments"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	pagination := oapi.ToUUIDPaginationDomain(params.Limit, params.LastUuid)
+
+	comments, pagination, err := h.s.Comment.ListComments(ctx, actor, reviewId, params.ParentId, pagination)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	type Response struct {
+		Comments   []oapi.Comment      `json:"comments"`
+		Pagination oapi.UUIDPagination `json:"pagination"`
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Comments:   oapi.ToCommentsResponse(comments),
+		Pagination: oapi.ToUUIDPaginationResponse(pagination),
+	})
+}
+
+func (h MusicsnapHandler) GetCommentsCommentId(c *gin.Context, commentId oapi.UUID, params oapi.GetCommentsCommentIdParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetCommentsCommentId"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	comment, err := h.s.Comment.GetComment(ctx, actor, commentId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToCommentResponse(comment)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h MusicsnapHandler) PutCommentsCommentId(c *gin.Context, commentId oapi.UUID, params oapi.PutCommentsCommentIdParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PutCommentsCommentId"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PutCommentsCommentIdJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+
+	comment, err := h.s.Comment.UpdateComment(ctx, actor, commentId, payload.Text)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToCommentResponse(comment)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h MusicsnapHandler) DeleteCommentsCommentId(c *gin.Context, commentId oapi.UUID, params oapi.DeleteCommentsCommentIdParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("DeleteCommentsCommentId"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	err = h.s.Comment.DeleteComment(ctx, actor, commentId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,175 @@
+package musicsnap
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/handler/http/musicsnap/oapi"
+	"net/http"
+)
+
+func (h MusicsnapHandler) GetEvents(c *gin.Context, params oapi.GetEventsParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetEvents"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	events, err := h.s.Event.ListEvents(ctx, actor, params.ToDomain())
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oapi.ToEventsResponse(events))
+}
+
+func (h MusicsnapHandler) PostEvents(c *gin.Context, params oapi.PostEventsParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostEvents"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PostEventsJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+
+	event, err := h.s.Event.CreateEvent(ctx, actor, oapi.EventInput(payload).ToDomain(uuid.Nil))
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, oapi.ToEventResponse(event))
+}
+
+func (h MusicsnapHandler) GetEventsEventId(c *gin.Context, eventId oapi.UUID, params oapi.GetEventsEventIdParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetEventsEventId"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	// гость тоже видит событие, но невалидный токен отклоняется
+	if _, err := h.ReceiveActor(ctx, params.Actor); err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	event, err := h.s.Event.GetEvent(ctx, eventId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oapi.ToEventResponse(event))
+}
+
+func (h MusicsnapHandler) PutEventsEventId(c *gin.Context, eventId oapi.UUID, params oapi.PutEventsEventIdParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PutEventsEventId"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PutEventsEventIdJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+
+	event, err := h.s.Event.UpdateEvent(ctx, actor, oapi.EventInput(payload).ToDomain(eventId))
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oapi.ToEventResponse(event))
+}
+
+func (h MusicsnapHandler) PostEventsEventIdParticipate(c *gin.Context, eventId oapi.UUID, params oapi.PostEventsEventIdParticipateParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostEventsEventIdParticipate"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	event, err := h.s.Event.Participate(ctx, actor, eventId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oapi.ToEventResponse(event))
+}
+
+func (h MusicsnapHandler) DeleteEventsEventIdParticipate(c *gin.Context, eventId oapi.UUID, params oapi.DeleteEventsEventIdParticipateParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("DeleteEventsEventIdParticipate"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	event, err := h.s.Event.CancelParticipation(ctx, actor, eventId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oapi.ToEventResponse(event))
+}
+
+func (h MusicsnapHandler) PostEventsEventIdCancel(c *gin.Context, eventId oapi.UUID, params oapi.PostEventsEventIdCancelParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostEventsEventIdCancel"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	event, err := h.s.Event.CancelEvent(ctx, actor, eventId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oapi.ToEventResponse(event))
+}
@@ -0,0 +1,211 @@
+package musicsnap
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/handler/http/musicsnap/oapi"
+	"net/http"
+)
+
+func (h MusicsnapHandler) GetModerationQueue(c *gin.Context, params oapi.GetModerationQueueParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetModerationQueue"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	pagination := oapi.ToIDPaginationDomain(params.Limit, params.LastId)
+
+	items, pagination, err := h.s.Moderation.ListQueue(ctx, actor, params.ToDomain(), pagination)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	type Response struct {
+		Items      []oapi.ModerationQueueItem `json:"items"`
+		Pagination oapi.IDPagination          `json:"pagination"`
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Items:      oapi.ToModerationQueueResponse(items),
+		Pagination: oapi.ToIDPaginationResponse(pagination),
+	})
+}
+
+func (h MusicsnapHandler) PostModerationQueueClaim(c *gin.Context, params oapi.PostModerationQueueClaimParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostModerationQueueClaim"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PostModerationQueueClaimJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+
+	limit := 1
+	if payload.Limit != nil {
+		limit = *payload.Limit
+	}
+
+	items, err := h.s.Moderation.ClaimNext(ctx, actor, limit)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToModerationQueueResponse(items)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h MusicsnapHandler) PostModerationReviewsReviewIdClaim(c *gin.Context, reviewId int, params oapi.PostModerationReviewsReviewIdClaimParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostModerationReviewsReviewIdClaim"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	item, err := h.s.Moderation.Claim(ctx, actor, reviewId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToModerationQueueItemResponse(item)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h MusicsnapHandler) PostModerationReviewsReviewIdRelease(c *gin.Context, reviewId int, params oapi.PostModerationReviewsReviewIdReleaseParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostModerationReviewsReviewIdRelease"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	err = h.s.Moderation.Release(ctx, actor, reviewId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h MusicsnapHandler) PostModerationReviewsReviewIdApprove(c *gin.Context, reviewId int, params oapi.PostModerationReviewsReviewIdApproveParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostModerationReviewsReviewIdApprove"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	// тело необязательно: одобрение может быть без комментария
+	var payload oapi.PostModerationReviewsReviewIdApproveJSONRequestBody
+	if c.Request.ContentLength != 0 && !h.bindRequestBody(c, &payload) {
+		return
+	}
+	_, comment := payload.ToDomain()
+
+	review, err := h.s.Moderation.Approve(ctx, actor, reviewId, comment)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToReviewResponse(review)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h MusicsnapHandler) PostModerationReviewsReviewIdReject(c *gin.Context, reviewId int, params oapi.PostModerationReviewsReviewIdRejectParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostModerationReviewsReviewIdReject"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PostModerationReviewsReviewIdRejectJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+	reason, comment := payload.ToDomain()
+
+	review, err := h.s.Moderation.Reject(ctx, actor, reviewId, reason, comment)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToReviewResponse(review)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h MusicsnapHandler) GetModerationActions(c *gin.Context, params oapi.GetModerationActionsParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetModerationActions"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	pagination := oapi.ToIDPaginationDomain(params.Limit, params.LastId)
+
+	actions, pagination, err := h.s.Moderation.ListActions(ctx, actor, params.ToDomain(), pagination)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	type Response struct {
+		Actions    []oapi.ModerationAction `json:"actions"`
+		Pagination oapi.IDPagination       `json:"pagination"`
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Actions:    oapi.ToModerationActionsResponse(actions),
+		Pagination: oapi.ToIDPaginationResponse(pagination),
+	})
+}
@@ -0,0 +1,216 @@
+package musicsnap
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/handler/http/musicsnap/oapi"
+	"net/http"
+)
+
+func (h MusicsnapHandler) GetNotifications(c *gin.Context, params oapi.GetNotificationsParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetNotifications"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	unreadOnly := params.UnreadOnly != nil && *params.UnreadOnly
+	pagination := oapi.ToUUIDPaginationDomain(params.Limit, params.LastUuid)
+
+	notifications, pagination, err := h.s.Notification.GetNotifications(ctx, actor, unreadOnly, pagination)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	type Response struct {
+		Notifications []oapi.Notification `json:"notifications"`
+		Pagination    oapi.UUIDPagination `json:"pagination"`
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Notifications: oapi.ToNotificationsResponse(notifications),
+		Pagination:    oapi.ToUUIDPaginationResponse(pagination),
+	})
+}
+
+func (h MusicsnapHandler) GetNotificationsUnreadCount(c *gin.Context, params oapi.GetNotificationsUnreadCountParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetNotificationsUnreadCount"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	count, err := h.s.Notification.CountUnread(ctx, actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	type Response struct {
+		Count int `json:"count"`
+	}
+
+	c.JSON(http.StatusOK, Response{Count: count})
+}
+
+func (h MusicsnapHandler) PostNotificationsRead(c *gin.Context, params oapi.PostNotificationsReadParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostNotificationsRead"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	marked, err := h.s.Notification.MarkAllAsRead(ctx, actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	type Response struct {
+		Marked int `json:"marked"`
+	}
+
+	c.JSON(http.StatusOK, Response{Marked: marked})
+}
+
+func (h MusicsnapHandler) PostNotificationsNotificationIdRead(c *gin.Context, notificationId oapi.UUID, params oapi.PostNotificationsNotificationIdReadParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostNotificationsNotificationIdRead"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	err = h.s.Notification.MarkAsRead(ctx, actor, notificationId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h MusicsnapHandler) GetNotificationsPreferences(c *gin.Context, params oapi.GetNotificationsPreferencesParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetNotificationsPreferences"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	prefs, err := h.s.Notification.GetPreferences(ctx, actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oapi.NotificationPreferences(prefs))
+}
+
+func (h MusicsnapHandler) PutNotificationsPreferences(c *gin.Context, params oapi.PutNotificationsPreferencesParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PutNotificationsPreferences"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PutNotificationsPreferencesJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+
+	prefs, err := h.s.Notification.UpdatePreferences(ctx, actor, domain.NotificationPreferences(payload))
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oapi.NotificationPreferences(prefs))
+}
+
+func (h MusicsnapHandler) GetNotificationsDigest(c *gin.Context, params oapi.GetNotificationsDigestParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetNotificationsDigest"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	settings, err := h.s.Notification.GetDigestSettings(ctx, actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oapi.ToDigestSettingsResponse(settings))
+}
+
+func (h MusicsnapHandler) PutNotificationsDigest(c *gin.Context, params oapi.PutNotificationsDigestParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PutNotificationsDigest"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PutNotificationsDigestJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+
+	settings, err := h.s.Notification.UpdateDigestSettings(ctx, actor,
+		domain.DigestSettings{Frequency: string(payload.Frequency)})
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oapi.ToDigestSettingsResponse(settings))
+}
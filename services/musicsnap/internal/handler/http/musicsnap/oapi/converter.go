@@ -1,10 +1,12 @@
 package oapi
 
 import (
+	"fmt"
 	"github.com/google/uuid"
 	"music-snap/pkg/app"
 	"music-snap/services/musicsnap/internal/domain"
 	"net/http"
+	"strconv"
 )
 
 func (act Actor) ToValidDomain() (domain.Actor, error) {
@@ -75,6 +77,9 @@ func (p User) ToValidDomain() (domain.User, error) {
 	if p.Roles != nil {
 		user.Roles = domain.NewRoles(*p.Roles)
 	}
+	if p.Locale != nil {
+		user.Locale = string(*p.Locale)
+	}
 
 	return user, nil
 
@@ -124,6 +129,42 @@ func ToUUIDPaginationDomain(l *int, lastUUID *UUID) domain.UUIDPagination {
 	return p
 }
 
+// ToDomain treats absent flag as disabled
+func (f SubscriptionFlags) ToDomain() domain.SubscriptionFlags {
+	flags := domain.SubscriptionFlags{}
+	if f.NewReview != nil {
+		flags.NewReview = *f.NewReview
+	}
+	if f.NewPlaylist != nil {
+		flags.NewPlaylist = *f.NewPlaylist
+	}
+	if f.Event != nil {
+		flags.Event = *f.Event
+	}
+	return flags
+}
+
+// ToLastEventIDDomain prefers Last-Event-ID header sent by EventSource on reconnect, 0 streams from the start
+func ToLastEventIDDomain(header *string, query *int64) (int64, error) {
+	lastEventID := int64(0)
+	if header != nil && *header != "" {
+		parsed, err := strconv.ParseInt(*header, 10, 64)
+		if err != nil {
+			return 0, app.NewError(http.StatusBadRequest, "invalid last event id",
+				fmt.Sprintf("Last-Event-ID %q is not a number", *header), err)
+		}
+		lastEventID = parsed
+	} else if query != nil {
+		lastEventID = *query
+	}
+
+	if lastEventID < 0 {
+		return 0, app.NewError(http.StatusBadRequest, "invalid last event id",
+			fmt.Sprintf("last event id %d is negative", lastEventID), nil)
+	}
+	return lastEventID, nil
+}
+
 func ToIDPaginationDomain(l *int, lastID *int) domain.IDPagination {
 	p := domain.IDPagination{}
 
@@ -191,10 +232,12 @@ func (r Review) ToDomain() (domain.Review, error) {
 		review.PhotoURL = *r.PhotoUrl
 	}
 
+	review.PublishAt = r.PublishAt
 	if r.Published != nil {
 		review.Published = *r.Published
 	} else {
-		review.Published = true
+		// отложенная рецензия до своего времени не опубликована
+		review.Published = r.PublishAt == nil
 	}
 
 	if r.Moderated != nil {
@@ -256,30 +299,215 @@ func (r GetReviewsSubscriptionsParams) ToDomain() (domain.ReviewFilter, error) {
 	return filter, nil
 }
 
-func (r Reaction) ToDomain() (domain.Reaction, error) {
-	var review domain.Reaction
+// ToDomain takes review from path, author defaults to actor in service
+func (r Reaction) ToDomain(reviewID int) (domain.Reaction, error) {
+	reaction := domain.Reaction{ReviewID: reviewID}
 
-	if r.UserId == nil {
-		return domain.Reaction{}, app.NewError(http.StatusBadRequest, "author of reaction is required", "userId is nil in reaction", nil)
+	if r.UserId != nil {
+		reaction.UserID = *r.UserId
+	}
+
+	if r.Type == nil {
+		return domain.Reaction{}, app.NewError(http.StatusBadRequest, "reaction type is required", "type is nil in reaction", nil)
+	}
+	reaction.Type = *r.Type
+
+	return reaction, nil
+}
+
+func (a Artist) ToDomain() (domain.Artist, error) {
+	var artist domain.Artist
+
+	if a.Name == nil {
+		return domain.Artist{}, app.NewError(http.StatusBadRequest, "artist name is required", "name is nil in artist", nil)
 	} else {
-		review.UserID = *r.UserId
+		artist.Name = *a.Name
 	}
+	if a.Id != nil {
+		artist.ID = *a.Id
+	}
+
+	return artist, nil
+}
+
+func (t TrackDetails) ToDomain() (domain.Track, error) {
+	var track domain.Track
 
-	if r.ReviewId == nil {
-		return domain.Reaction{}, app.NewError(http.StatusBadRequest, "review target is required", "review_id is nil in reaction", nil)
+	if t.Title == nil {
+		return domain.Track{}, app.NewError(http.StatusBadRequest, "track title is required", "title is nil in track", nil)
 	} else {
-		review.ReviewID = *r.ReviewId
+		track.Title = *t.Title
+	}
+	if t.ArtistId == nil {
+		return domain.Track{}, app.NewError(http.StatusBadRequest, "track artist is required", "artist_id is nil in track", nil)
+	} else {
+		track.ArtistID = *t.ArtistId
 	}
 
-	if r.Type == nil {
-		return domain.Reaction{}, app.NewError(http.StatusBadRequest, "reaction type is required", "type is nil in reaction", nil)
-	} else if *r.Type != domain.LikeReaction && *r.Type != domain.DislikeReaction {
-		return domain.Reaction{}, app.NewError(http.StatusBadRequest, "reaction type is invalid", "type is not like or dislike in reaction", nil)
+	if t.AlbumId != nil {
+		track.AlbumID = *t.AlbumId
+	}
+	if t.DurationMs != nil {
+		track.DurationMs = *t.DurationMs
+	}
+	if t.Explicit != nil {
+		track.Explicit = *t.Explicit
+	}
+
+	return track, nil
+}
+
+func (a AlbumDetails) ToDomain() (domain.Album, error) {
+	var album domain.Album
+
+	if a.Title == nil {
+		return domain.Album{}, app.NewError(http.StatusBadRequest, "album title is required", "title is nil in album", nil)
 	} else {
-		review.Type = (string)(*r.Type)
+		album.Title = *a.Title
+	}
+	if a.ArtistId == nil {
+		return domain.Album{}, app.NewError(http.StatusBadRequest, "album artist is required", "artist_id is nil in album", nil)
+	} else {
+		album.ArtistID = *a.ArtistId
 	}
 
-	return review, nil
+	if a.CoverUrl != nil {
+		album.CoverURL = *a.CoverUrl
+	}
+	if a.ReleaseDate != nil {
+		album.ReleaseDate = *a.ReleaseDate
+	}
+
+	return album, nil
+}
+
+func (a PieceAlias) ToDomain() (domain.PieceAlias, error) {
+	var alias domain.PieceAlias
+
+	if a.Source == nil {
+		return domain.PieceAlias{}, app.NewError(http.StatusBadRequest, "alias source is required", "source is nil in alias", nil)
+	} else {
+		alias.Source = *a.Source
+	}
+	if a.ExternalId == nil {
+		return domain.PieceAlias{}, app.NewError(http.StatusBadRequest, "alias external id is required", "external_id is nil in alias", nil)
+	} else {
+		alias.ExternalID = *a.ExternalId
+	}
+	if a.PieceId != nil {
+		alias.PieceID = *a.PieceId
+	}
+
+	return alias, nil
+}
+
+func (p Piece) ToDomain() (domain.Piece, error) {
+	var piece domain.Piece
+
+	if p.Type == nil {
+		return domain.Piece{}, app.NewError(http.StatusBadRequest, "piece type is required", "type is nil in piece", nil)
+	} else {
+		piece.Type = *p.Type
+	}
+	if p.Id != nil {
+		piece.ID = *p.Id
+	}
+
+	if p.Track != nil {
+		track, err := p.Track.ToDomain()
+		if err != nil {
+			return domain.Piece{}, err
+		}
+		piece.Track = &track
+	}
+	if p.Album != nil {
+		album, err := p.Album.ToDomain()
+		if err != nil {
+			return domain.Piece{}, err
+		}
+		piece.Album = &album
+	}
+
+	if p.Aliases != nil {
+		piece.Aliases = make([]domain.PieceAlias, 0, len(*p.Aliases))
+		for _, a := range *p.Aliases {
+			alias, err := a.ToDomain()
+			if err != nil {
+				return domain.Piece{}, err
+			}
+			piece.Aliases = append(piece.Aliases, alias)
+		}
+	}
+
+	return piece, nil
+}
+
+func (r GetPiecesParams) ToDomain() domain.PieceFilter {
+	filter := domain.PieceFilter{
+		Type:     r.Type,
+		ArtistID: r.ArtistId,
+	}
+	if r.TitleQuery != nil {
+		filter.TitleQuery = *r.TitleQuery
+	}
+	return filter
+}
+
+// ToDomain takes only rating value, user and piece are set from actor and path
+func (r Rating) ToDomain() (domain.Rating, error) {
+	if r.Rating < 1 || r.Rating > 10 {
+		return domain.Rating{}, app.NewError(http.StatusBadRequest, "rating must be between 1 and 10", "invalid rating value", nil)
+	}
+	return domain.Rating{Rating: r.Rating}, nil
+}
+
+func (r GetModerationQueueParams) ToDomain() domain.ModerationQueueFilter {
+	return domain.ModerationQueueFilter{
+		Reason:    (*domain.QueueReason)(r.Reason),
+		ClaimedBy: r.ClaimedBy,
+	}
+}
+
+func (r GetModerationActionsParams) ToDomain() domain.ModerationActionFilter {
+	return domain.ModerationActionFilter{
+		ModeratorID: r.ModeratorId,
+		ReviewID:    r.ReviewId,
+	}
+}
+
+// ToDomain returns reason code and comment of the decision, absent reason is empty code
+func (d ModerationDecision) ToDomain() (domain.ModerationReasonCode, string) {
+	var reason domain.ModerationReasonCode
+	if d.ReasonCode != nil {
+		reason = domain.ModerationReasonCode(*d.ReasonCode)
+	}
+	var comment string
+	if d.Comment != nil {
+		comment = *d.Comment
+	}
+	return reason, comment
+}
+
+func (r Report) ToDomain() domain.Report {
+	var comment string
+	if r.Comment != nil {
+		comment = *r.Comment
+	}
+	return domain.Report{
+		TargetType: domain.ReportTargetType(r.TargetType),
+		TargetID:   r.TargetId,
+		Reason:     domain.ModerationReasonCode(r.Reason),
+		Comment:    comment,
+	}
+}
+
+func (r GetReportsParams) ToDomain() domain.ReportFilter {
+	return domain.ReportFilter{
+		ReporterID: r.ReporterId,
+		TargetType: (*domain.ReportTargetType)(r.TargetType),
+		TargetID:   r.TargetId,
+		Status:     (*domain.ReportStatus)(r.Status),
+	}
 }
 
 //func (f GetBannerParams) ToValidDomain() domain.BannerFilter {
@@ -305,3 +533,107 @@ func (r Reaction) ToDomain() (domain.Reaction, error) {
 //	s := []string{a.Roles}
 //	return domain.NewActor(a.ID, s)
 //}
+
+func (r Comment) ToDomain(reviewID int) domain.Comment {
+	return domain.Comment{
+		ReviewID: reviewID,
+		ParentID: r.ParentId,
+		Text:     r.Text,
+	}
+}
+
+func (r GetTagsTagReviewsParams) ToDomain(tag string) (domain.ReviewFilter, error) {
+	normalized, ok := domain.NormalizeTag(tag)
+	if !ok {
+		return domain.ReviewFilter{}, app.NewError(http.StatusBadRequest, "invalid tag",
+			fmt.Sprintf("tag %q is not a hashtag", tag), nil)
+	}
+
+	var includeProfiles bool
+	if r.IncludeProfiles != nil {
+		includeProfiles = *r.IncludeProfiles
+	}
+	return domain.ReviewFilter{
+		Tag:             &normalized,
+		IncludeProfiles: includeProfiles,
+	}, nil
+}
+
+// ToDomain returns webhook of the app when App is set, otherwise of given user or of the actor
+func (r PostWebhooksJSONBody) ToDomain(actorID uuid.UUID) domain.Webhook {
+	webhook := domain.Webhook{URL: r.Url, EventTypes: webhookEventTypesToDomain(r.EventTypes)}
+	switch {
+	case r.App != nil && *r.App:
+	case r.UserId != nil:
+		webhook.UserID = r.UserId
+	default:
+		webhook.UserID = &actorID
+	}
+	return webhook
+}
+
+func (r PutWebhooksWebhookIdJSONBody) ToDomain(webhookID int) domain.Webhook {
+	return domain.Webhook{
+		ID:         webhookID,
+		URL:        r.Url,
+		EventTypes: webhookEventTypesToDomain(r.EventTypes),
+		Active:     r.Active,
+	}
+}
+
+func webhookEventTypesToDomain(types []WebhookEventType) []string {
+	res := make([]string, len(types))
+	for i, t := range types {
+		res[i] = string(t)
+	}
+	return res
+}
+
+// ToDomain returns event with co-authors, the actor is added to authors by service
+func (r EventInput) ToDomain(eventID uuid.UUID) domain.Event {
+	event := domain.Event{
+		ID:           eventID,
+		Name:         r.Name,
+		Date:         r.Date,
+		Text:         stringValue(r.Text),
+		PhotoURL:     stringValue(r.PhotoUrl),
+		TicketLink:   stringValue(r.TicketLink),
+		LocationLink: stringValue(r.LocationLink),
+		Location:     stringValue(r.Location),
+	}
+	if r.PhotosUrls != nil {
+		event.PhotosURLs = *r.PhotosUrls
+	}
+	if r.CoAuthors != nil {
+		event.Authors = *r.CoAuthors
+	}
+	return event
+}
+
+func (r GetEventsParams) ToDomain() domain.EventFilter {
+	filter := domain.EventFilter{
+		NameQuery:       stringValue(r.NameQuery),
+		SortByCreatedAt: r.SortByCreatedAt != nil && *r.SortByCreatedAt,
+		SortByAmount:    r.SortByAmount != nil && *r.SortByAmount,
+	}
+	if r.DateLeftBound != nil {
+		filter.DateLeftBound = *r.DateLeftBound
+	}
+	if r.DateRightBound != nil {
+		filter.DateRightBound = *r.DateRightBound
+	}
+	if r.Limit != nil {
+		filter.Limit = *r.Limit
+	}
+	if r.Offset != nil {
+		filter.Offset = *r.Offset
+	}
+	return filter
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
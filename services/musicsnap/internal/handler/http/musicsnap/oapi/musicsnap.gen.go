@@ -18,10 +18,98 @@ const (
 	ActorAuthScopes = "actorAuth.Scopes"
 )
 
-// Defines values for ReactionType.
+// Defines values for DiffChunkOp.
 const (
-	Dislike ReactionType = "dislike"
-	Like    ReactionType = "like"
+	Delete DiffChunkOp = "delete"
+	Equal  DiffChunkOp = "equal"
+	Insert DiffChunkOp = "insert"
+)
+
+// Defines values for DigestFrequency.
+const (
+	Daily  DigestFrequency = "daily"
+	Off    DigestFrequency = "off"
+	Weekly DigestFrequency = "weekly"
+)
+
+// Defines values for ModerationActionAction.
+const (
+	Approve ModerationActionAction = "approve"
+	Claim   ModerationActionAction = "claim"
+	Reject  ModerationActionAction = "reject"
+	Release ModerationActionAction = "release"
+)
+
+// Defines values for ModerationQueueReason.
+const (
+	Edited   ModerationQueueReason = "edited"
+	New      ModerationQueueReason = "new"
+	Reported ModerationQueueReason = "reported"
+)
+
+// Defines values for ModerationReasonCode.
+const (
+	Copyright  ModerationReasonCode = "copyright"
+	Harassment ModerationReasonCode = "harassment"
+	OffTopic   ModerationReasonCode = "off_topic"
+	Offensive  ModerationReasonCode = "offensive"
+	Other      ModerationReasonCode = "other"
+	Spam       ModerationReasonCode = "spam"
+	Spoilers   ModerationReasonCode = "spoilers"
+)
+
+// Defines values for ModerationStatus.
+const (
+	ModerationStatusApproved ModerationStatus = "approved"
+	ModerationStatusPending  ModerationStatus = "pending"
+	ModerationStatusRejected ModerationStatus = "rejected"
+)
+
+// Defines values for ReportResolutionStatus.
+const (
+	ReportResolutionStatusDismissed ReportResolutionStatus = "dismissed"
+	ReportResolutionStatusUpheld    ReportResolutionStatus = "upheld"
+)
+
+// Defines values for ReportStatus.
+const (
+	ReportStatusDismissed ReportStatus = "dismissed"
+	ReportStatusOpen      ReportStatus = "open"
+	ReportStatusUpheld    ReportStatus = "upheld"
+)
+
+// Defines values for ReportTargetType.
+const (
+	ReportTargetTypeEventPhoto ReportTargetType = "event_photo"
+	ReportTargetTypeNote       ReportTargetType = "note"
+	ReportTargetTypeProfile    ReportTargetType = "profile"
+	ReportTargetTypeReview     ReportTargetType = "review"
+)
+
+// Defines values for StreamEventKind.
+const (
+	StreamEventKindFeed         StreamEventKind = "feed"
+	StreamEventKindNotification StreamEventKind = "notification"
+)
+
+// Defines values for UserLocale.
+const (
+	En UserLocale = "en"
+	Ru UserLocale = "ru"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	WebhookDeliveryStatusDead      WebhookDeliveryStatus = "dead"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+)
+
+// Defines values for WebhookEventType.
+const (
+	EventJoined     WebhookEventType = "event.joined"
+	ReviewPublished WebhookEventType = "review.published"
+	UserFollowed    WebhookEventType = "user.followed"
 )
 
 // Actor defines model for Actor.
@@ -33,6 +121,72 @@ type Actor struct {
 	Roles    *[]string            `json:"roles,omitempty"`
 }
 
+// AlbumDetails defines model for AlbumDetails.
+type AlbumDetails struct {
+	Artist      *Artist    `json:"artist,omitempty"`
+	ArtistId    *UUID      `json:"artist_id,omitempty"`
+	CoverUrl    *string    `json:"cover_url,omitempty"`
+	ReleaseDate *time.Time `json:"release_date,omitempty"`
+	Title       *string    `json:"title,omitempty"`
+}
+
+// Artist defines model for Artist.
+type Artist struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Id        *UUID      `json:"id,omitempty"`
+	Name      *string    `json:"name,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// CalendarFeed defines model for CalendarFeed.
+type CalendarFeed struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Url Address of feed for calendar apps, it contains the secret token
+	Url *string `json:"url,omitempty"`
+}
+
+// Comment defines model for Comment.
+type Comment struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Deleted   *bool      `json:"deleted,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// Depth 0 for top-level comment
+	Depth    *int       `json:"depth,omitempty"`
+	EditedAt *time.Time `json:"edited_at,omitempty"`
+	Id       *UUID      `json:"id,omitempty"`
+
+	// ParentId Comment to reply to, absent for top-level comment. Ignored on edit
+	ParentId *UUID `json:"parent_id,omitempty"`
+	ReviewId *int  `json:"review_id,omitempty"`
+
+	// Text Empty for deleted comment
+	Text      string     `json:"text"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	UserId    *UUID      `json:"user_id,omitempty"`
+}
+
+// DiffChunk defines model for DiffChunk.
+type DiffChunk struct {
+	Op   *DiffChunkOp `json:"op,omitempty"`
+	Text *string      `json:"text,omitempty"`
+}
+
+// DiffChunkOp defines model for DiffChunk.Op.
+type DiffChunkOp string
+
+// DigestFrequency defines model for DigestFrequency.
+type DigestFrequency string
+
+// DigestSettings defines model for DigestSettings.
+type DigestSettings struct {
+	Frequency *DigestFrequency `json:"frequency,omitempty"`
+
+	// LastSentAt Absent until the first digest is sent
+	LastSentAt *time.Time `json:"last_sent_at,omitempty"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	// Code HTTP status code
@@ -44,18 +198,44 @@ type Error struct {
 
 // Event defines model for Event.
 type Event struct {
+	Authors *[]UUID `json:"authors,omitempty"`
+
+	// CancelledAt Set for cancelled event
+	CancelledAt  *time.Time `json:"cancelled_at,omitempty"`
 	CreatedAt    *time.Time `json:"created_at,omitempty"`
 	Date         *time.Time `json:"date,omitempty"`
-	Id           *int       `json:"id,omitempty"`
+	Id           *UUID      `json:"id,omitempty"`
 	Location     *string    `json:"location,omitempty"`
 	LocationLink *string    `json:"location_link,omitempty"`
 	Name         *string    `json:"name,omitempty"`
-	Participants *[]UUID    `json:"participants,omitempty"`
-	PhotoUrl     *string    `json:"photo_url,omitempty"`
-	PhotosUrls   *[]string  `json:"photos_urls,omitempty"`
-	Text         *string    `json:"text,omitempty"`
-	TicketLink   *string    `json:"ticket_link,omitempty"`
-	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+
+	// Participants Participants are returned for a single event only
+	Participants      *[]UUID   `json:"participants,omitempty"`
+	ParticipantsCount *int      `json:"participants_count,omitempty"`
+	PhotoUrl          *string   `json:"photo_url,omitempty"`
+	PhotosUrls        *[]string `json:"photos_urls,omitempty"`
+
+	// Sequence Grows on every change of event
+	Sequence   *int       `json:"sequence,omitempty"`
+	Text       *string    `json:"text,omitempty"`
+	TicketLink *string    `json:"ticket_link,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// EventInput defines model for EventInput.
+type EventInput struct {
+	// CoAuthors Co-authors besides the actor, used on creation only
+	CoAuthors    *[]UUID   `json:"co_authors,omitempty"`
+	Date         time.Time `json:"date"`
+	Location     *string   `json:"location,omitempty"`
+	LocationLink *string   `json:"location_link,omitempty"`
+	Name         string    `json:"name"`
+
+	// PhotoUrl Cover of event
+	PhotoUrl   *string   `json:"photo_url,omitempty"`
+	PhotosUrls *[]string `json:"photos_urls,omitempty"`
+	Text       *string   `json:"text,omitempty"`
+	TicketLink *string   `json:"ticket_link,omitempty"`
 }
 
 // IDPagination defines model for IDPagination.
@@ -64,6 +244,49 @@ type IDPagination struct {
 	Limit  *int `json:"limit,omitempty"`
 }
 
+// ModerationAction defines model for ModerationAction.
+type ModerationAction struct {
+	Action      *ModerationActionAction `json:"action,omitempty"`
+	Comment     *string                 `json:"comment,omitempty"`
+	CreatedAt   *time.Time              `json:"created_at,omitempty"`
+	Id          *int                    `json:"id,omitempty"`
+	ModeratorId *UUID                   `json:"moderator_id,omitempty"`
+	ReasonCode  *ModerationReasonCode   `json:"reason_code,omitempty"`
+	ReviewId    *int                    `json:"review_id,omitempty"`
+}
+
+// ModerationActionAction defines model for ModerationAction.Action.
+type ModerationActionAction string
+
+// ModerationClaimRequest defines model for ModerationClaimRequest.
+type ModerationClaimRequest struct {
+	Limit *int `json:"limit,omitempty"`
+}
+
+// ModerationDecision defines model for ModerationDecision.
+type ModerationDecision struct {
+	Comment    *string               `json:"comment,omitempty"`
+	ReasonCode *ModerationReasonCode `json:"reason_code,omitempty"`
+}
+
+// ModerationQueueItem defines model for ModerationQueueItem.
+type ModerationQueueItem struct {
+	ClaimedAt  *time.Time             `json:"claimed_at,omitempty"`
+	ClaimedBy  *UUID                  `json:"claimed_by,omitempty"`
+	EnqueuedAt *time.Time             `json:"enqueued_at,omitempty"`
+	Reason     *ModerationQueueReason `json:"reason,omitempty"`
+	Review     *Review                `json:"review,omitempty"`
+}
+
+// ModerationQueueReason defines model for ModerationQueueReason.
+type ModerationQueueReason string
+
+// ModerationReasonCode defines model for ModerationReasonCode.
+type ModerationReasonCode string
+
+// ModerationStatus defines model for ModerationStatus.
+type ModerationStatus string
+
 // Note defines model for Note.
 type Note struct {
 	CreatedAt  *time.Time `json:"created_at,omitempty"`
@@ -75,6 +298,35 @@ type Note struct {
 	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
 }
 
+// Notification defines model for Notification.
+type Notification struct {
+	// Count Number of similar events collapsed into notification, e.g. reactions to review
+	Count     *int       `json:"count,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Id        *UUID      `json:"id,omitempty"`
+
+	// Message Payload, its schema depends on type and version
+	Message *map[string]interface{} `json:"message,omitempty"`
+	Read    *bool                   `json:"read,omitempty"`
+
+	// SenderId Absent for system notifications
+	SenderId *UUID `json:"sender_id,omitempty"`
+
+	// Text Text rendered on server in locale of receiver
+	Text *string `json:"text,omitempty"`
+
+	// Type Kind of notification, e.g. mention, comment_reply, report_resolved
+	Type   *string `json:"type,omitempty"`
+	UserId *UUID   `json:"user_id,omitempty"`
+
+	// Version Version of payload schema
+	Version *int `json:"version,omitempty"`
+}
+
+// NotificationPreferences Notification type (mention, review_comment, comment_reply, report_resolved, new_review,
+// new_playlist, event, review_reaction, event_reminder) to delivery channel (in_app, email, push) to enabled flag
+type NotificationPreferences map[string]map[string]bool
+
 // Photo defines model for Photo.
 type Photo struct {
 	CreatedAt *time.Time `json:"created_at,omitempty"`
@@ -83,6 +335,29 @@ type Photo struct {
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
+// Piece defines model for Piece.
+type Piece struct {
+	Album     *AlbumDetails `json:"album,omitempty"`
+	Aliases   *[]PieceAlias `json:"aliases,omitempty"`
+	CreatedAt *time.Time    `json:"created_at,omitempty"`
+	Id        *UUID         `json:"id,omitempty"`
+	Track     *TrackDetails `json:"track,omitempty"`
+
+	// Type track or album
+	Type      *string    `json:"type,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// PieceAlias defines model for PieceAlias.
+type PieceAlias struct {
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	ExternalId *string    `json:"external_id,omitempty"`
+	PieceId    *UUID      `json:"piece_id,omitempty"`
+
+	// Source spotify, musicbrainz or yandex_music
+	Source *string `json:"source,omitempty"`
+}
+
 // Profile defines model for Profile.
 type Profile struct {
 	AvatarUrl     *string    `json:"avatar_url,omitempty"`
@@ -105,57 +380,211 @@ type ProfileStats struct {
 	TotalReviewsCount   *int `json:"total_reviews_count,omitempty"`
 }
 
+// Rating defines model for Rating.
+type Rating struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Id        *UUID      `json:"id,omitempty"`
+
+	// PieceId Canonical piece id
+	PieceId   *string    `json:"piece_id,omitempty"`
+	Rating    int        `json:"rating"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	UserId    *UUID      `json:"user_id,omitempty"`
+}
+
 // Reaction defines model for Reaction.
 type Reaction struct {
-	CreatedAt *time.Time    `json:"created_at,omitempty"`
-	Id        *int          `json:"id,omitempty"`
-	ReviewId  *int          `json:"review_id,omitempty"`
-	Type      *ReactionType `json:"type,omitempty"`
-	UpdatedAt *time.Time    `json:"updated_at,omitempty"`
-	UserId    *UUID         `json:"user_id,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Id        *int       `json:"id,omitempty"`
+	ReviewId  *int       `json:"review_id,omitempty"`
+
+	// Type One of reaction types configured in service, e.g. like, dislike, fire
+	Type      *string    `json:"type,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	UserId    *UUID      `json:"user_id,omitempty"`
+}
+
+// ReactionCounts Amount of reactions by type, types without reactions are omitted
+type ReactionCounts map[string]int
+
+// Report defines model for Report.
+type Report struct {
+	Comment   *string              `json:"comment,omitempty"`
+	CreatedAt *time.Time           `json:"created_at,omitempty"`
+	Id        *int                 `json:"id,omitempty"`
+	Reason    ModerationReasonCode `json:"reason"`
+
+	// ReporterId Absent for reports filed by content filter
+	ReporterId *UUID         `json:"reporter_id,omitempty"`
+	ResolvedAt *time.Time    `json:"resolved_at,omitempty"`
+	ResolvedBy *UUID         `json:"resolved_by,omitempty"`
+	Status     *ReportStatus `json:"status,omitempty"`
+
+	// TargetId Integer id of review or note, uuid of profile or event photo
+	TargetId   string           `json:"target_id"`
+	TargetType ReportTargetType `json:"target_type"`
+	UpdatedAt  *time.Time       `json:"updated_at,omitempty"`
 }
 
-// ReactionType defines model for Reaction.Type.
-type ReactionType string
+// ReportResolution defines model for ReportResolution.
+type ReportResolution struct {
+	Status ReportResolutionStatus `json:"status"`
+}
+
+// ReportResolutionStatus defines model for ReportResolution.Status.
+type ReportResolutionStatus string
 
-// ReactionCount defines model for ReactionCount.
-type ReactionCount struct {
-	Dislikes *int `json:"dislikes,omitempty"`
-	Likes    *int `json:"likes,omitempty"`
+// ReportStatus defines model for ReportStatus.
+type ReportStatus string
+
+// ReportTarget defines model for ReportTarget.
+type ReportTarget struct {
+	Hidden      *bool             `json:"hidden,omitempty"`
+	HiddenAt    *time.Time        `json:"hidden_at,omitempty"`
+	OpenReports *int              `json:"open_reports,omitempty"`
+	TargetId    *string           `json:"target_id,omitempty"`
+	TargetType  *ReportTargetType `json:"target_type,omitempty"`
 }
 
+// ReportTargetType defines model for ReportTargetType.
+type ReportTargetType string
+
 // Review defines model for Review.
 type Review struct {
+	// CommentsCount Amount of not deleted comments
+	CommentsCount *int       `json:"comments_count,omitempty"`
+	Content       *string    `json:"content,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+
+	// Edited Review content was changed after creation
+	Edited   *bool      `json:"edited,omitempty"`
+	EditedAt *time.Time `json:"edited_at,omitempty"`
+
+	// Hidden Hidden after reports until moderator decision
+	Hidden *bool `json:"hidden,omitempty"`
+	Id     *int  `json:"id,omitempty"`
+
+	// Moderated Moderator made a decision on the review
+	Moderated *bool `json:"moderated,omitempty"`
+
+	// ModerationReason Reason of rejection
+	ModerationReason *ModerationReasonCode `json:"moderation_reason,omitempty"`
+	ModerationStatus *ModerationStatus     `json:"moderation_status,omitempty"`
+
+	// MyReaction Reaction of the actor in review lists, absent when actor has not reacted
+	MyReaction *Reaction `json:"my_reaction,omitempty"`
+	PhotoUrl   *string   `json:"photo_url,omitempty"`
+	PieceId    *string   `json:"piece_id,omitempty"`
+	Profile    *Profile  `json:"profile,omitempty"`
+
+	// PublishAt Scheduled publication time of unpublished review
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+
+	// Published False for drafts and scheduled reviews, visible only to the author. Defaults to true unless publish_at is set
+	Published *bool `json:"published,omitempty"`
+
+	// PublishedAt Time of the first publication
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	Rating      *int       `json:"rating,omitempty"`
+
+	// ReactionCounts Amount of reactions by type, types without reactions are omitted
+	ReactionCounts *ReactionCounts `json:"reaction_counts,omitempty"`
+	UpdatedAt      *time.Time      `json:"updated_at,omitempty"`
+	UserId         *UUID           `json:"user_id,omitempty"`
+}
+
+// ReviewRevision defines model for ReviewRevision.
+type ReviewRevision struct {
 	Content   *string    `json:"content,omitempty"`
 	CreatedAt *time.Time `json:"created_at,omitempty"`
-	Id        *int       `json:"id,omitempty"`
-	Moderated *bool      `json:"moderated,omitempty"`
+	EditorId  *UUID      `json:"editor_id,omitempty"`
 	PhotoUrl  *string    `json:"photo_url,omitempty"`
 	PieceId   *string    `json:"piece_id,omitempty"`
-	Profile   *Profile   `json:"profile,omitempty"`
-	Published *bool      `json:"published,omitempty"`
 	Rating    *int       `json:"rating,omitempty"`
-	UpdatedAt *time.Time `json:"updated_at,omitempty"`
-	UserId    *UUID      `json:"user_id,omitempty"`
+	ReviewId  *int       `json:"review_id,omitempty"`
+
+	// Revision Revision number starting from 1
+	Revision *int `json:"revision,omitempty"`
 }
 
+// ReviewRevisionDiff defines model for ReviewRevisionDiff.
+type ReviewRevisionDiff struct {
+	Chunks *[]DiffChunk    `json:"chunks,omitempty"`
+	From   *ReviewRevision `json:"from,omitempty"`
+	To     *ReviewRevision `json:"to,omitempty"`
+}
+
+// StreamEvent defines model for StreamEvent.
+type StreamEvent struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Id Position in stream, used to resume
+	Id   *int64           `json:"id,omitempty"`
+	Kind *StreamEventKind `json:"kind,omitempty"`
+
+	// Payload Notification for notification kind with text rendered in locale of receiver,
+	// published review summary for feed kind
+	Payload *map[string]interface{} `json:"payload,omitempty"`
+}
+
+// StreamEventKind defines model for StreamEvent.Kind.
+type StreamEventKind string
+
 // Subscription defines model for Subscription.
 type Subscription struct {
-	CreatedAt         *time.Time `json:"created_at,omitempty"`
-	Id                *int       `json:"id,omitempty"`
-	NotificationFlag  *bool      `json:"notification_flag,omitempty"`
-	ProfileOfInterest *Profile   `json:"profile_of_interest,omitempty"`
-	SubscribedToId    *UUID      `json:"subscribed_to_id,omitempty"`
-	SubscriberId      *UUID      `json:"subscriber_id,omitempty"`
-	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Id        *int       `json:"id,omitempty"`
+
+	// NotificationFlags Alerts about actions of followed user
+	NotificationFlags *SubscriptionFlags `json:"notification_flags,omitempty"`
+	ProfileOfInterest *Profile           `json:"profile_of_interest,omitempty"`
+	SubscribedToId    *UUID              `json:"subscribed_to_id,omitempty"`
+	SubscriberId      *UUID              `json:"subscriber_id,omitempty"`
+	UpdatedAt         *time.Time         `json:"updated_at,omitempty"`
+}
+
+// SubscriptionFlags Alerts about actions of followed user
+type SubscriptionFlags struct {
+	Event       *bool `json:"event,omitempty"`
+	NewPlaylist *bool `json:"new_playlist,omitempty"`
+	NewReview   *bool `json:"new_review,omitempty"`
+}
+
+// TagTrend defines model for TagTrend.
+type TagTrend struct {
+	Tag string `json:"tag"`
+
+	// Uses Amount of reviews and comments with the tag
+	Uses int `json:"uses"`
+}
+
+// TrackDetails defines model for TrackDetails.
+type TrackDetails struct {
+	AlbumId    *UUID   `json:"album_id,omitempty"`
+	Artist     *Artist `json:"artist,omitempty"`
+	ArtistId   *UUID   `json:"artist_id,omitempty"`
+	DurationMs *int    `json:"duration_ms,omitempty"`
+	Explicit   *bool   `json:"explicit,omitempty"`
+	Title      *string `json:"title,omitempty"`
 }
 
 // TrackStats defines model for TrackStats.
 type TrackStats struct {
-	TotalCommentsCount *int `json:"total_comments_count,omitempty"`
-	TotalDislikesCount *int `json:"total_dislikes_count,omitempty"`
-	TotalLikesCount    *int `json:"total_likes_count,omitempty"`
-	TotalReviewsCount  *int `json:"total_reviews_count,omitempty"`
+	AverageRating *float64 `json:"average_rating,omitempty"`
+
+	// Histogram Amount of ratings per value, histogram[0] for rating 1 and histogram[9] for rating 10
+	Histogram    *[]int   `json:"histogram,omitempty"`
+	MedianRating *float64 `json:"median_rating,omitempty"`
+
+	// PieceId Canonical catalog id of the piece
+	PieceId            *string `json:"piece_id,omitempty"`
+	TotalCommentsCount *int    `json:"total_comments_count,omitempty"`
+	TotalDislikesCount *int    `json:"total_dislikes_count,omitempty"`
+	TotalLikesCount    *int    `json:"total_likes_count,omitempty"`
+
+	// TotalRatingsCount Ratings of published reviews and quick ratings
+	TotalRatingsCount *int `json:"total_ratings_count,omitempty"`
+	TotalReviewsCount *int `json:"total_reviews_count,omitempty"`
 }
 
 // UUID defines model for UUID.
@@ -175,9 +604,74 @@ type User struct {
 	CreatedAt     *time.Time           `json:"created_at,omitempty"`
 	Email         *openapi_types.Email `json:"email,omitempty"`
 	Id            *UUID                `json:"id,omitempty"`
-	Nickname      *string              `json:"nickname,omitempty"`
-	Roles         *[]string            `json:"roles,omitempty"`
-	UpdatedAt     *time.Time           `json:"updated_at,omitempty"`
+
+	// Locale Language of notifications and digests, kept unchanged when absent in update
+	Locale    *UserLocale `json:"locale,omitempty"`
+	Nickname  *string     `json:"nickname,omitempty"`
+	Roles     *[]string   `json:"roles,omitempty"`
+	UpdatedAt *time.Time  `json:"updated_at,omitempty"`
+}
+
+// UserLocale Language of notifications and digests, kept unchanged when absent in update
+type UserLocale string
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	Active     *bool               `json:"active,omitempty"`
+	CreatedAt  *time.Time          `json:"created_at,omitempty"`
+	EventTypes *[]WebhookEventType `json:"event_types,omitempty"`
+	Id         *int                `json:"id,omitempty"`
+
+	// Secret Returned only on creation
+	Secret    *string    `json:"secret,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	Url       *string    `json:"url,omitempty"`
+
+	// UserId Absent for webhook of the app
+	UserId *openapi_types.UUID `json:"user_id,omitempty"`
+}
+
+// WebhookAttempt defines model for WebhookAttempt.
+type WebhookAttempt struct {
+	AttemptedAt *time.Time `json:"attempted_at,omitempty"`
+	DurationMs  *int       `json:"duration_ms,omitempty"`
+	Error       *string    `json:"error,omitempty"`
+
+	// StatusCode Zero when receiver did not respond
+	StatusCode *int `json:"status_code,omitempty"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts    *int                `json:"attempts,omitempty"`
+	CreatedAt   *time.Time          `json:"created_at,omitempty"`
+	DeliveredAt *time.Time          `json:"delivered_at,omitempty"`
+	EventId     *openapi_types.UUID `json:"event_id,omitempty"`
+	EventType   *WebhookEventType   `json:"event_type,omitempty"`
+	Id          *int64              `json:"id,omitempty"`
+
+	// Log Attempts from oldest to newest, only in a single delivery
+	Log           *[]WebhookAttempt       `json:"log,omitempty"`
+	NextAttemptAt *time.Time              `json:"next_attempt_at,omitempty"`
+	Payload       *map[string]interface{} `json:"payload,omitempty"`
+	Status        *WebhookDeliveryStatus  `json:"status,omitempty"`
+	WebhookId     *int                    `json:"webhook_id,omitempty"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDeliveryStatus.
+type WebhookDeliveryStatus string
+
+// WebhookEventType defines model for WebhookEventType.
+type WebhookEventType string
+
+// PostArtistsParams defines parameters for PostArtists.
+type PostArtistsParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// GetArtistsArtistIdParams defines parameters for GetArtistsArtistId.
+type GetArtistsArtistIdParams struct {
+	Actor *Actor `json:"actor,omitempty"`
 }
 
 // PostAuthLoginJSONBody defines parameters for PostAuthLogin.
@@ -202,6 +696,36 @@ type PostAuthRegisterJSONBody struct {
 	User     *User   `json:"user,omitempty"`
 }
 
+// DeleteCalendarFeedParams defines parameters for DeleteCalendarFeed.
+type DeleteCalendarFeedParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// GetCalendarFeedParams defines parameters for GetCalendarFeed.
+type GetCalendarFeedParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PostCalendarFeedParams defines parameters for PostCalendarFeed.
+type PostCalendarFeedParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// DeleteCommentsCommentIdParams defines parameters for DeleteCommentsCommentId.
+type DeleteCommentsCommentIdParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// GetCommentsCommentIdParams defines parameters for GetCommentsCommentId.
+type GetCommentsCommentIdParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PutCommentsCommentIdParams defines parameters for PutCommentsCommentId.
+type PutCommentsCommentIdParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
 // GetEventsParams defines parameters for GetEvents.
 type GetEventsParams struct {
 	NameQuery       *string    `form:"name_query,omitempty" json:"name_query,omitempty"`
@@ -209,6 +733,8 @@ type GetEventsParams struct {
 	DateRightBound  *time.Time `form:"date_right_bound,omitempty" json:"date_right_bound,omitempty"`
 	SortByCreatedAt *bool      `form:"sort_by_created_at,omitempty" json:"sort_by_created_at,omitempty"`
 	SortByAmount    *bool      `form:"sort_by_amount,omitempty" json:"sort_by_amount,omitempty"`
+	Limit           *int       `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset          *int       `form:"offset,omitempty" json:"offset,omitempty"`
 	Actor           *Actor     `json:"actor,omitempty"`
 }
 
@@ -227,11 +753,64 @@ type PutEventsEventIdParams struct {
 	Actor *Actor `json:"actor,omitempty"`
 }
 
+// PostEventsEventIdCancelParams defines parameters for PostEventsEventIdCancel.
+type PostEventsEventIdCancelParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// DeleteEventsEventIdParticipateParams defines parameters for DeleteEventsEventIdParticipate.
+type DeleteEventsEventIdParticipateParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
 // PostEventsEventIdParticipateParams defines parameters for PostEventsEventIdParticipate.
 type PostEventsEventIdParticipateParams struct {
 	Actor *Actor `json:"actor,omitempty"`
 }
 
+// GetModerationActionsParams defines parameters for GetModerationActions.
+type GetModerationActionsParams struct {
+	ModeratorId *UUID  `form:"moderator_id,omitempty" json:"moderator_id,omitempty"`
+	ReviewId    *int   `form:"review_id,omitempty" json:"review_id,omitempty"`
+	Limit       *int   `form:"limit,omitempty" json:"limit,omitempty"`
+	LastId      *int   `form:"last_id,omitempty" json:"last_id,omitempty"`
+	Actor       *Actor `json:"actor,omitempty"`
+}
+
+// GetModerationQueueParams defines parameters for GetModerationQueue.
+type GetModerationQueueParams struct {
+	Reason    *ModerationQueueReason `form:"reason,omitempty" json:"reason,omitempty"`
+	ClaimedBy *UUID                  `form:"claimed_by,omitempty" json:"claimed_by,omitempty"`
+	Limit     *int                   `form:"limit,omitempty" json:"limit,omitempty"`
+	LastId    *int                   `form:"last_id,omitempty" json:"last_id,omitempty"`
+	Actor     *Actor                 `json:"actor,omitempty"`
+}
+
+// PostModerationQueueClaimParams defines parameters for PostModerationQueueClaim.
+type PostModerationQueueClaimParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PostModerationReviewsReviewIdApproveParams defines parameters for PostModerationReviewsReviewIdApprove.
+type PostModerationReviewsReviewIdApproveParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PostModerationReviewsReviewIdClaimParams defines parameters for PostModerationReviewsReviewIdClaim.
+type PostModerationReviewsReviewIdClaimParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PostModerationReviewsReviewIdRejectParams defines parameters for PostModerationReviewsReviewIdReject.
+type PostModerationReviewsReviewIdRejectParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PostModerationReviewsReviewIdReleaseParams defines parameters for PostModerationReviewsReviewIdRelease.
+type PostModerationReviewsReviewIdReleaseParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
 // PostNotesParams defines parameters for PostNotes.
 type PostNotesParams struct {
 	Actor *Actor `json:"actor,omitempty"`
@@ -252,6 +831,67 @@ type PutNotesNoteIdParams struct {
 	Actor *Actor `json:"actor,omitempty"`
 }
 
+// GetNotificationsParams defines parameters for GetNotifications.
+type GetNotificationsParams struct {
+	UnreadOnly *bool  `form:"unread_only,omitempty" json:"unread_only,omitempty"`
+	Limit      *int   `form:"limit,omitempty" json:"limit,omitempty"`
+	LastUuid   *UUID  `form:"last_uuid,omitempty" json:"last_uuid,omitempty"`
+	Actor      *Actor `json:"actor,omitempty"`
+}
+
+// GetNotificationsDigestParams defines parameters for GetNotificationsDigest.
+type GetNotificationsDigestParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PutNotificationsDigestJSONBody defines parameters for PutNotificationsDigest.
+type PutNotificationsDigestJSONBody struct {
+	Frequency DigestFrequency `json:"frequency"`
+}
+
+// PutNotificationsDigestParams defines parameters for PutNotificationsDigest.
+type PutNotificationsDigestParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// GetNotificationsPreferencesParams defines parameters for GetNotificationsPreferences.
+type GetNotificationsPreferencesParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PutNotificationsPreferencesParams defines parameters for PutNotificationsPreferences.
+type PutNotificationsPreferencesParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PostNotificationsReadParams defines parameters for PostNotificationsRead.
+type PostNotificationsReadParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// GetNotificationsStreamParams defines parameters for GetNotificationsStream.
+type GetNotificationsStreamParams struct {
+	LastEventId *int64  `form:"last_event_id,omitempty" json:"last_event_id,omitempty"`
+	Actor       *Actor  `json:"actor,omitempty"`
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// GetNotificationsUnreadCountParams defines parameters for GetNotificationsUnreadCount.
+type GetNotificationsUnreadCountParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// GetNotificationsWsParams defines parameters for GetNotificationsWs.
+type GetNotificationsWsParams struct {
+	LastEventId *int64 `form:"last_event_id,omitempty" json:"last_event_id,omitempty"`
+	Actor       *Actor `json:"actor,omitempty"`
+}
+
+// PostNotificationsNotificationIdReadParams defines parameters for PostNotificationsNotificationIdRead.
+type PostNotificationsNotificationIdReadParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
 // PostPhotosMultipartBody defines parameters for PostPhotos.
 type PostPhotosMultipartBody struct {
 	File openapi_types.File `json:"file"`
@@ -272,23 +912,94 @@ type GetPhotosPhotoIdParams struct {
 	Actor *Actor `json:"actor,omitempty"`
 }
 
-// GetPlaylistsPlaylistIdNotesParams defines parameters for GetPlaylistsPlaylistIdNotes.
-type GetPlaylistsPlaylistIdNotesParams struct {
+// GetPiecesParams defines parameters for GetPieces.
+type GetPiecesParams struct {
+	TitleQuery *string `form:"title_query,omitempty" json:"title_query,omitempty"`
+	Type       *string `form:"type,omitempty" json:"type,omitempty"`
+	ArtistId   *UUID   `form:"artist_id,omitempty" json:"artist_id,omitempty"`
+	Limit      *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	LastUuid   *UUID   `form:"last_uuid,omitempty" json:"last_uuid,omitempty"`
+	Actor      *Actor  `json:"actor,omitempty"`
+}
+
+// PostPiecesParams defines parameters for PostPieces.
+type PostPiecesParams struct {
 	Actor *Actor `json:"actor,omitempty"`
 }
 
-// DeleteReactionsReactionIdParams defines parameters for DeleteReactionsReactionId.
-type DeleteReactionsReactionIdParams struct {
+// GetPiecesPieceIdParams defines parameters for GetPiecesPieceId.
+type GetPiecesPieceIdParams struct {
 	Actor *Actor `json:"actor,omitempty"`
 }
 
-// PutReactionsReactionIdParams defines parameters for PutReactionsReactionId.
+// PutPiecesPieceIdParams defines parameters for PutPiecesPieceId.
+type PutPiecesPieceIdParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PostPiecesPieceIdAliasesParams defines parameters for PostPiecesPieceIdAliases.
+type PostPiecesPieceIdAliasesParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// DeletePiecesPieceIdRatingsMeParams defines parameters for DeletePiecesPieceIdRatingsMe.
+type DeletePiecesPieceIdRatingsMeParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// GetPiecesPieceIdRatingsMeParams defines parameters for GetPiecesPieceIdRatingsMe.
+type GetPiecesPieceIdRatingsMeParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PutPiecesPieceIdRatingsMeParams defines parameters for PutPiecesPieceIdRatingsMe.
+type PutPiecesPieceIdRatingsMeParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// GetPlaylistsPlaylistIdNotesParams defines parameters for GetPlaylistsPlaylistIdNotes.
+type GetPlaylistsPlaylistIdNotesParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// DeleteReactionsReactionIdParams defines parameters for DeleteReactionsReactionId.
+type DeleteReactionsReactionIdParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PutReactionsReactionIdParams defines parameters for PutReactionsReactionId.
 type PutReactionsReactionIdParams struct {
-	// Type Type of reaction to add ("like" or "dislike")
+	// Type Type of reaction, one of configured reaction types
 	Type  string `form:"type" json:"type"`
 	Actor *Actor `json:"actor,omitempty"`
 }
 
+// GetReportsParams defines parameters for GetReports.
+type GetReportsParams struct {
+	ReporterId *UUID             `form:"reporter_id,omitempty" json:"reporter_id,omitempty"`
+	TargetType *ReportTargetType `form:"target_type,omitempty" json:"target_type,omitempty"`
+	TargetId   *string           `form:"target_id,omitempty" json:"target_id,omitempty"`
+	Status     *ReportStatus     `form:"status,omitempty" json:"status,omitempty"`
+	Limit      *int              `form:"limit,omitempty" json:"limit,omitempty"`
+	LastId     *int              `form:"last_id,omitempty" json:"last_id,omitempty"`
+	Actor      *Actor            `json:"actor,omitempty"`
+}
+
+// PostReportsParams defines parameters for PostReports.
+type PostReportsParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// GetReportsTargetsTargetTypeTargetIdParams defines parameters for GetReportsTargetsTargetTypeTargetId.
+type GetReportsTargetsTargetTypeTargetIdParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PostReportsTargetsTargetTypeTargetIdResolveParams defines parameters for PostReportsTargetsTargetTypeTargetIdResolve.
+type PostReportsTargetsTargetTypeTargetIdResolveParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
 // PostReviewsParams defines parameters for PostReviews.
 type PostReviewsParams struct {
 	Actor *Actor `json:"actor,omitempty"`
@@ -334,6 +1045,19 @@ type PutReviewsReviewIdParams struct {
 	Actor *Actor `json:"actor,omitempty"`
 }
 
+// GetReviewsReviewIdCommentsParams defines parameters for GetReviewsReviewIdComments.
+type GetReviewsReviewIdCommentsParams struct {
+	ParentId *UUID  `form:"parent_id,omitempty" json:"parent_id,omitempty"`
+	Limit    *int   `form:"limit,omitempty" json:"limit,omitempty"`
+	LastUuid *UUID  `form:"last_uuid,omitempty" json:"last_uuid,omitempty"`
+	Actor    *Actor `json:"actor,omitempty"`
+}
+
+// PostReviewsReviewIdCommentsParams defines parameters for PostReviewsReviewIdComments.
+type PostReviewsReviewIdCommentsParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
 // GetReviewsReviewIdNotesParams defines parameters for GetReviewsReviewIdNotes.
 type GetReviewsReviewIdNotesParams struct {
 	Limit  *int   `form:"limit,omitempty" json:"limit,omitempty"`
@@ -356,6 +1080,27 @@ type GetReviewsReviewIdReactionsMeParams struct {
 	Actor *Actor `json:"actor,omitempty"`
 }
 
+// GetReviewsReviewIdReactionsUsersParams defines parameters for GetReviewsReviewIdReactionsUsers.
+type GetReviewsReviewIdReactionsUsersParams struct {
+	// Type Only reactions of this type
+	Type   *string `form:"type,omitempty" json:"type,omitempty"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	LastId *int    `form:"last_id,omitempty" json:"last_id,omitempty"`
+	Actor  *Actor  `json:"actor,omitempty"`
+}
+
+// GetReviewsReviewIdRevisionsParams defines parameters for GetReviewsReviewIdRevisions.
+type GetReviewsReviewIdRevisionsParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// GetReviewsReviewIdRevisionsDiffParams defines parameters for GetReviewsReviewIdRevisionsDiff.
+type GetReviewsReviewIdRevisionsDiffParams struct {
+	From  *int   `form:"from,omitempty" json:"from,omitempty"`
+	To    *int   `form:"to,omitempty" json:"to,omitempty"`
+	Actor *Actor `json:"actor,omitempty"`
+}
+
 // PostSubscriptionsParams defines parameters for PostSubscriptions.
 type PostSubscriptionsParams struct {
 	Actor *Actor `json:"actor,omitempty"`
@@ -373,7 +1118,8 @@ type GetSubscriptionsFollowedIdParams struct {
 
 // PutSubscriptionsFollowedIdJSONBody defines parameters for PutSubscriptionsFollowedId.
 type PutSubscriptionsFollowedIdJSONBody struct {
-	NotificationFlag *bool `json:"notification_flag,omitempty"`
+	// NotificationFlags Alerts about actions of followed user
+	NotificationFlags SubscriptionFlags `json:"notification_flags"`
 }
 
 // PutSubscriptionsFollowedIdParams defines parameters for PutSubscriptionsFollowedId.
@@ -381,6 +1127,20 @@ type PutSubscriptionsFollowedIdParams struct {
 	Actor *Actor `json:"actor,omitempty"`
 }
 
+// GetTagsTrendingParams defines parameters for GetTagsTrending.
+type GetTagsTrendingParams struct {
+	Limit *int   `form:"limit,omitempty" json:"limit,omitempty"`
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// GetTagsTagReviewsParams defines parameters for GetTagsTagReviews.
+type GetTagsTagReviewsParams struct {
+	IncludeProfiles *bool  `form:"include_profiles,omitempty" json:"include_profiles,omitempty"`
+	Limit           *int   `form:"limit,omitempty" json:"limit,omitempty"`
+	LastId          *int   `form:"last_id,omitempty" json:"last_id,omitempty"`
+	Actor           *Actor `json:"actor,omitempty"`
+}
+
 // GetTracksTrackIdStatsParams defines parameters for GetTracksTrackIdStats.
 type GetTracksTrackIdStatsParams struct {
 	Actor *Actor `json:"actor,omitempty"`
@@ -430,6 +1190,13 @@ type PutUsersUserIdProfileParams struct {
 	Actor *Actor `json:"actor,omitempty"`
 }
 
+// GetUsersUserIdRatingsParams defines parameters for GetUsersUserIdRatings.
+type GetUsersUserIdRatingsParams struct {
+	Limit    *int   `form:"limit,omitempty" json:"limit,omitempty"`
+	LastUuid *UUID  `form:"last_uuid,omitempty" json:"last_uuid,omitempty"`
+	Actor    *Actor `json:"actor,omitempty"`
+}
+
 // GetUsersUserIdStatsParams defines parameters for GetUsersUserIdStats.
 type GetUsersUserIdStatsParams struct {
 	Actor *Actor `json:"actor,omitempty"`
@@ -449,17 +1216,93 @@ type GetUsersUserIdSubscriptionsParams struct {
 	Actor  *Actor `json:"actor,omitempty"`
 }
 
+// GetWebhooksParams defines parameters for GetWebhooks.
+type GetWebhooksParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PostWebhooksJSONBody defines parameters for PostWebhooks.
+type PostWebhooksJSONBody struct {
+	// App Create webhook of the app instead of the user one
+	App        *bool              `json:"app,omitempty"`
+	EventTypes []WebhookEventType `json:"event_types"`
+	Url        string             `json:"url"`
+
+	// UserId Owner of webhook, the actor by default
+	UserId *openapi_types.UUID `json:"user_id,omitempty"`
+}
+
+// PostWebhooksParams defines parameters for PostWebhooks.
+type PostWebhooksParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// DeleteWebhooksWebhookIdParams defines parameters for DeleteWebhooksWebhookId.
+type DeleteWebhooksWebhookIdParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// GetWebhooksWebhookIdParams defines parameters for GetWebhooksWebhookId.
+type GetWebhooksWebhookIdParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PutWebhooksWebhookIdJSONBody defines parameters for PutWebhooksWebhookId.
+type PutWebhooksWebhookIdJSONBody struct {
+	Active     bool               `json:"active"`
+	EventTypes []WebhookEventType `json:"event_types"`
+	Url        string             `json:"url"`
+}
+
+// PutWebhooksWebhookIdParams defines parameters for PutWebhooksWebhookId.
+type PutWebhooksWebhookIdParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// GetWebhooksWebhookIdDeliveriesParams defines parameters for GetWebhooksWebhookIdDeliveries.
+type GetWebhooksWebhookIdDeliveriesParams struct {
+	Status *WebhookDeliveryStatus `form:"status,omitempty" json:"status,omitempty"`
+	Limit  *int                   `form:"limit,omitempty" json:"limit,omitempty"`
+	LastId *int                   `form:"last_id,omitempty" json:"last_id,omitempty"`
+	Actor  *Actor                 `json:"actor,omitempty"`
+}
+
+// GetWebhooksWebhookIdDeliveriesDeliveryIdParams defines parameters for GetWebhooksWebhookIdDeliveriesDeliveryId.
+type GetWebhooksWebhookIdDeliveriesDeliveryIdParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PostWebhooksWebhookIdDeliveriesDeliveryIdRedeliverParams defines parameters for PostWebhooksWebhookIdDeliveriesDeliveryIdRedeliver.
+type PostWebhooksWebhookIdDeliveriesDeliveryIdRedeliverParams struct {
+	Actor *Actor `json:"actor,omitempty"`
+}
+
+// PostArtistsJSONRequestBody defines body for PostArtists for application/json ContentType.
+type PostArtistsJSONRequestBody = Artist
+
 // PostAuthLoginJSONRequestBody defines body for PostAuthLogin for application/json ContentType.
 type PostAuthLoginJSONRequestBody PostAuthLoginJSONBody
 
 // PostAuthRegisterJSONRequestBody defines body for PostAuthRegister for application/json ContentType.
 type PostAuthRegisterJSONRequestBody PostAuthRegisterJSONBody
 
+// PutCommentsCommentIdJSONRequestBody defines body for PutCommentsCommentId for application/json ContentType.
+type PutCommentsCommentIdJSONRequestBody = Comment
+
 // PostEventsJSONRequestBody defines body for PostEvents for application/json ContentType.
-type PostEventsJSONRequestBody = Event
+type PostEventsJSONRequestBody = EventInput
 
 // PutEventsEventIdJSONRequestBody defines body for PutEventsEventId for application/json ContentType.
-type PutEventsEventIdJSONRequestBody = Event
+type PutEventsEventIdJSONRequestBody = EventInput
+
+// PostModerationQueueClaimJSONRequestBody defines body for PostModerationQueueClaim for application/json ContentType.
+type PostModerationQueueClaimJSONRequestBody = ModerationClaimRequest
+
+// PostModerationReviewsReviewIdApproveJSONRequestBody defines body for PostModerationReviewsReviewIdApprove for application/json ContentType.
+type PostModerationReviewsReviewIdApproveJSONRequestBody = ModerationDecision
+
+// PostModerationReviewsReviewIdRejectJSONRequestBody defines body for PostModerationReviewsReviewIdReject for application/json ContentType.
+type PostModerationReviewsReviewIdRejectJSONRequestBody = ModerationDecision
 
 // PostNotesJSONRequestBody defines body for PostNotes for application/json ContentType.
 type PostNotesJSONRequestBody = Note
@@ -467,15 +1310,42 @@ type PostNotesJSONRequestBody = Note
 // PutNotesNoteIdJSONRequestBody defines body for PutNotesNoteId for application/json ContentType.
 type PutNotesNoteIdJSONRequestBody = Note
 
+// PutNotificationsDigestJSONRequestBody defines body for PutNotificationsDigest for application/json ContentType.
+type PutNotificationsDigestJSONRequestBody PutNotificationsDigestJSONBody
+
+// PutNotificationsPreferencesJSONRequestBody defines body for PutNotificationsPreferences for application/json ContentType.
+type PutNotificationsPreferencesJSONRequestBody = NotificationPreferences
+
 // PostPhotosMultipartRequestBody defines body for PostPhotos for multipart/form-data ContentType.
 type PostPhotosMultipartRequestBody PostPhotosMultipartBody
 
+// PostPiecesJSONRequestBody defines body for PostPieces for application/json ContentType.
+type PostPiecesJSONRequestBody = Piece
+
+// PutPiecesPieceIdJSONRequestBody defines body for PutPiecesPieceId for application/json ContentType.
+type PutPiecesPieceIdJSONRequestBody = Piece
+
+// PostPiecesPieceIdAliasesJSONRequestBody defines body for PostPiecesPieceIdAliases for application/json ContentType.
+type PostPiecesPieceIdAliasesJSONRequestBody = PieceAlias
+
+// PutPiecesPieceIdRatingsMeJSONRequestBody defines body for PutPiecesPieceIdRatingsMe for application/json ContentType.
+type PutPiecesPieceIdRatingsMeJSONRequestBody = Rating
+
+// PostReportsJSONRequestBody defines body for PostReports for application/json ContentType.
+type PostReportsJSONRequestBody = Report
+
+// PostReportsTargetsTargetTypeTargetIdResolveJSONRequestBody defines body for PostReportsTargetsTargetTypeTargetIdResolve for application/json ContentType.
+type PostReportsTargetsTargetTypeTargetIdResolveJSONRequestBody = ReportResolution
+
 // PostReviewsJSONRequestBody defines body for PostReviews for application/json ContentType.
 type PostReviewsJSONRequestBody = Review
 
 // PutReviewsReviewIdJSONRequestBody defines body for PutReviewsReviewId for application/json ContentType.
 type PutReviewsReviewIdJSONRequestBody = Review
 
+// PostReviewsReviewIdCommentsJSONRequestBody defines body for PostReviewsReviewIdComments for application/json ContentType.
+type PostReviewsReviewIdCommentsJSONRequestBody = Comment
+
 // PostReviewsReviewIdReactionsJSONRequestBody defines body for PostReviewsReviewIdReactions for application/json ContentType.
 type PostReviewsReviewIdReactionsJSONRequestBody = Reaction
 
@@ -494,8 +1364,20 @@ type PutUsersUserIdJSONRequestBody = User
 // PutUsersUserIdProfileJSONRequestBody defines body for PutUsersUserIdProfile for application/json ContentType.
 type PutUsersUserIdProfileJSONRequestBody = Profile
 
+// PostWebhooksJSONRequestBody defines body for PostWebhooks for application/json ContentType.
+type PostWebhooksJSONRequestBody PostWebhooksJSONBody
+
+// PutWebhooksWebhookIdJSONRequestBody defines body for PutWebhooksWebhookId for application/json ContentType.
+type PutWebhooksWebhookIdJSONRequestBody PutWebhooksWebhookIdJSONBody
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Create artist
+	// (POST /artists)
+	PostArtists(c *gin.Context, params PostArtistsParams)
+	// Get artist
+	// (GET /artists/{artist_id})
+	GetArtistsArtistId(c *gin.Context, artistId UUID, params GetArtistsArtistIdParams)
 	// Login user
 	// (POST /auth/login)
 	PostAuthLogin(c *gin.Context, params PostAuthLoginParams)
@@ -505,6 +1387,27 @@ type ServerInterface interface {
 	// Register a new user
 	// (POST /auth/register)
 	PostAuthRegister(c *gin.Context)
+	// Delete calendar feed
+	// (DELETE /calendar/feed)
+	DeleteCalendarFeed(c *gin.Context, params DeleteCalendarFeedParams)
+	// Get calendar feed
+	// (GET /calendar/feed)
+	GetCalendarFeed(c *gin.Context, params GetCalendarFeedParams)
+	// Create or rotate calendar feed
+	// (POST /calendar/feed)
+	PostCalendarFeed(c *gin.Context, params PostCalendarFeedParams)
+	// Calendar feed
+	// (GET /calendar/feeds/{token})
+	GetCalendarFeedsToken(c *gin.Context, token string)
+	// Delete comment
+	// (DELETE /comments/{comment_id})
+	DeleteCommentsCommentId(c *gin.Context, commentId UUID, params DeleteCommentsCommentIdParams)
+	// Get comment
+	// (GET /comments/{comment_id})
+	GetCommentsCommentId(c *gin.Context, commentId UUID, params GetCommentsCommentIdParams)
+	// Edit comment
+	// (PUT /comments/{comment_id})
+	PutCommentsCommentId(c *gin.Context, commentId UUID, params PutCommentsCommentIdParams)
 	// List events
 	// (GET /events)
 	GetEvents(c *gin.Context, params GetEventsParams)
@@ -513,13 +1416,43 @@ type ServerInterface interface {
 	PostEvents(c *gin.Context, params PostEventsParams)
 	// Get event
 	// (GET /events/{event_id})
-	GetEventsEventId(c *gin.Context, eventId int, params GetEventsEventIdParams)
+	GetEventsEventId(c *gin.Context, eventId UUID, params GetEventsEventIdParams)
 	// Update event
 	// (PUT /events/{event_id})
-	PutEventsEventId(c *gin.Context, eventId int, params PutEventsEventIdParams)
+	PutEventsEventId(c *gin.Context, eventId UUID, params PutEventsEventIdParams)
+	// Cancel event
+	// (POST /events/{event_id}/cancel)
+	PostEventsEventIdCancel(c *gin.Context, eventId UUID, params PostEventsEventIdCancelParams)
+	// Export event to calendar
+	// (GET /events/{event_id}/ics)
+	GetEventsEventIdIcs(c *gin.Context, eventId UUID)
+	// Cancel participation
+	// (DELETE /events/{event_id}/participate)
+	DeleteEventsEventIdParticipate(c *gin.Context, eventId UUID, params DeleteEventsEventIdParticipateParams)
 	// Participate in event
 	// (POST /events/{event_id}/participate)
-	PostEventsEventIdParticipate(c *gin.Context, eventId int, params PostEventsEventIdParticipateParams)
+	PostEventsEventIdParticipate(c *gin.Context, eventId UUID, params PostEventsEventIdParticipateParams)
+	// List moderation actions
+	// (GET /moderation/actions)
+	GetModerationActions(c *gin.Context, params GetModerationActionsParams)
+	// List moderation queue
+	// (GET /moderation/queue)
+	GetModerationQueue(c *gin.Context, params GetModerationQueueParams)
+	// Claim next reviews
+	// (POST /moderation/queue/claim)
+	PostModerationQueueClaim(c *gin.Context, params PostModerationQueueClaimParams)
+	// Approve review
+	// (POST /moderation/reviews/{review_id}/approve)
+	PostModerationReviewsReviewIdApprove(c *gin.Context, reviewId int, params PostModerationReviewsReviewIdApproveParams)
+	// Claim review
+	// (POST /moderation/reviews/{review_id}/claim)
+	PostModerationReviewsReviewIdClaim(c *gin.Context, reviewId int, params PostModerationReviewsReviewIdClaimParams)
+	// Reject review
+	// (POST /moderation/reviews/{review_id}/reject)
+	PostModerationReviewsReviewIdReject(c *gin.Context, reviewId int, params PostModerationReviewsReviewIdRejectParams)
+	// Release review
+	// (POST /moderation/reviews/{review_id}/release)
+	PostModerationReviewsReviewIdRelease(c *gin.Context, reviewId int, params PostModerationReviewsReviewIdReleaseParams)
 	// Create note
 	// (POST /notes)
 	PostNotes(c *gin.Context, params PostNotesParams)
@@ -532,6 +1465,36 @@ type ServerInterface interface {
 	// Update note
 	// (PUT /notes/{note_id})
 	PutNotesNoteId(c *gin.Context, noteId int, params PutNotesNoteIdParams)
+	// List notifications
+	// (GET /notifications)
+	GetNotifications(c *gin.Context, params GetNotificationsParams)
+	// Get notification digest settings
+	// (GET /notifications/digest)
+	GetNotificationsDigest(c *gin.Context, params GetNotificationsDigestParams)
+	// Update notification digest settings
+	// (PUT /notifications/digest)
+	PutNotificationsDigest(c *gin.Context, params PutNotificationsDigestParams)
+	// Get notification preferences
+	// (GET /notifications/preferences)
+	GetNotificationsPreferences(c *gin.Context, params GetNotificationsPreferencesParams)
+	// Update notification preferences
+	// (PUT /notifications/preferences)
+	PutNotificationsPreferences(c *gin.Context, params PutNotificationsPreferencesParams)
+	// Mark all notifications as read
+	// (POST /notifications/read)
+	PostNotificationsRead(c *gin.Context, params PostNotificationsReadParams)
+	// Stream notifications and feed over Server-Sent Events
+	// (GET /notifications/stream)
+	GetNotificationsStream(c *gin.Context, params GetNotificationsStreamParams)
+	// Count unread notifications
+	// (GET /notifications/unread_count)
+	GetNotificationsUnreadCount(c *gin.Context, params GetNotificationsUnreadCountParams)
+	// Stream notifications and feed over WebSocket
+	// (GET /notifications/ws)
+	GetNotificationsWs(c *gin.Context, params GetNotificationsWsParams)
+	// Mark notification as read
+	// (POST /notifications/{notification_id}/read)
+	PostNotificationsNotificationIdRead(c *gin.Context, notificationId UUID, params PostNotificationsNotificationIdReadParams)
 	// Upload photo
 	// (POST /photos)
 	PostPhotos(c *gin.Context, params PostPhotosParams)
@@ -541,6 +1504,30 @@ type ServerInterface interface {
 	// Get photo
 	// (GET /photos/{photo_id})
 	GetPhotosPhotoId(c *gin.Context, photoId UUID, params GetPhotosPhotoIdParams)
+	// Search pieces
+	// (GET /pieces)
+	GetPieces(c *gin.Context, params GetPiecesParams)
+	// Create piece
+	// (POST /pieces)
+	PostPieces(c *gin.Context, params PostPiecesParams)
+	// Get piece
+	// (GET /pieces/{piece_id})
+	GetPiecesPieceId(c *gin.Context, pieceId string, params GetPiecesPieceIdParams)
+	// Update piece
+	// (PUT /pieces/{piece_id})
+	PutPiecesPieceId(c *gin.Context, pieceId string, params PutPiecesPieceIdParams)
+	// Add piece alias
+	// (POST /pieces/{piece_id}/aliases)
+	PostPiecesPieceIdAliases(c *gin.Context, pieceId UUID, params PostPiecesPieceIdAliasesParams)
+	// Remove actor's rating
+	// (DELETE /pieces/{piece_id}/ratings/me)
+	DeletePiecesPieceIdRatingsMe(c *gin.Context, pieceId string, params DeletePiecesPieceIdRatingsMeParams)
+	// Get actor's rating
+	// (GET /pieces/{piece_id}/ratings/me)
+	GetPiecesPieceIdRatingsMe(c *gin.Context, pieceId string, params GetPiecesPieceIdRatingsMeParams)
+	// Rate piece
+	// (PUT /pieces/{piece_id}/ratings/me)
+	PutPiecesPieceIdRatingsMe(c *gin.Context, pieceId string, params PutPiecesPieceIdRatingsMeParams)
 	// Get playlist notes
 	// (GET /playlists/{playlist_id}/notes)
 	GetPlaylistsPlaylistIdNotes(c *gin.Context, playlistId int, params GetPlaylistsPlaylistIdNotesParams)
@@ -550,6 +1537,18 @@ type ServerInterface interface {
 	// Change reaction to review
 	// (PUT /reactions/{reaction_id})
 	PutReactionsReactionId(c *gin.Context, reactionId int, params PutReactionsReactionIdParams)
+	// List reports
+	// (GET /reports)
+	GetReports(c *gin.Context, params GetReportsParams)
+	// Report content
+	// (POST /reports)
+	PostReports(c *gin.Context, params PostReportsParams)
+	// Get reports summary of content
+	// (GET /reports/targets/{target_type}/{target_id})
+	GetReportsTargetsTargetTypeTargetId(c *gin.Context, targetType ReportTargetType, targetId string, params GetReportsTargetsTargetTypeTargetIdParams)
+	// Resolve reports on content
+	// (POST /reports/targets/{target_type}/{target_id}/resolve)
+	PostReportsTargetsTargetTypeTargetIdResolve(c *gin.Context, targetType ReportTargetType, targetId string, params PostReportsTargetsTargetTypeTargetIdResolveParams)
 	// Create a new review
 	// (POST /reviews)
 	PostReviews(c *gin.Context, params PostReviewsParams)
@@ -568,18 +1567,33 @@ type ServerInterface interface {
 	// Update review
 	// (PUT /reviews/{review_id})
 	PutReviewsReviewId(c *gin.Context, reviewId int, params PutReviewsReviewIdParams)
+	// List comments of review
+	// (GET /reviews/{review_id}/comments)
+	GetReviewsReviewIdComments(c *gin.Context, reviewId int, params GetReviewsReviewIdCommentsParams)
+	// Comment review
+	// (POST /reviews/{review_id}/comments)
+	PostReviewsReviewIdComments(c *gin.Context, reviewId int, params PostReviewsReviewIdCommentsParams)
 	// Get review notes
 	// (GET /reviews/{review_id}/notes)
 	GetReviewsReviewIdNotes(c *gin.Context, reviewId int, params GetReviewsReviewIdNotesParams)
-	// Get reaction count
+	// Get reaction counts
 	// (GET /reviews/{review_id}/reactions)
 	GetReviewsReviewIdReactions(c *gin.Context, reviewId int, params GetReviewsReviewIdReactionsParams)
-	// Add reaction to review
+	// Toggle reaction to review
 	// (POST /reviews/{review_id}/reactions)
 	PostReviewsReviewIdReactions(c *gin.Context, reviewId int, params PostReviewsReviewIdReactionsParams)
 	// Get user's reaction
 	// (GET /reviews/{review_id}/reactions/me)
 	GetReviewsReviewIdReactionsMe(c *gin.Context, reviewId int, params GetReviewsReviewIdReactionsMeParams)
+	// List who reacted
+	// (GET /reviews/{review_id}/reactions/users)
+	GetReviewsReviewIdReactionsUsers(c *gin.Context, reviewId int, params GetReviewsReviewIdReactionsUsersParams)
+	// List review revisions
+	// (GET /reviews/{review_id}/revisions)
+	GetReviewsReviewIdRevisions(c *gin.Context, reviewId int, params GetReviewsReviewIdRevisionsParams)
+	// Diff review revisions
+	// (GET /reviews/{review_id}/revisions/diff)
+	GetReviewsReviewIdRevisionsDiff(c *gin.Context, reviewId int, params GetReviewsReviewIdRevisionsDiffParams)
 	// Create a new subscription
 	// (POST /subscriptions)
 	PostSubscriptions(c *gin.Context, params PostSubscriptionsParams)
@@ -592,6 +1606,12 @@ type ServerInterface interface {
 	// Update subscription
 	// (PUT /subscriptions/{followed_id})
 	PutSubscriptionsFollowedId(c *gin.Context, followedId UUID, params PutSubscriptionsFollowedIdParams)
+	// Trending hashtags
+	// (GET /tags/trending)
+	GetTagsTrending(c *gin.Context, params GetTagsTrendingParams)
+	// List reviews with hashtag
+	// (GET /tags/{tag}/reviews)
+	GetTagsTagReviews(c *gin.Context, tag string, params GetTagsTagReviewsParams)
 	// Get track statistics
 	// (GET /tracks/{track_id}/stats)
 	GetTracksTrackIdStats(c *gin.Context, trackId string, params GetTracksTrackIdStatsParams)
@@ -616,6 +1636,9 @@ type ServerInterface interface {
 	// Update user profile
 	// (PUT /users/{user_id}/profile)
 	PutUsersUserIdProfile(c *gin.Context, userId UUID, params PutUsersUserIdProfileParams)
+	// List user's ratings
+	// (GET /users/{user_id}/ratings)
+	GetUsersUserIdRatings(c *gin.Context, userId UUID, params GetUsersUserIdRatingsParams)
 	// Get user profile statistics
 	// (GET /users/{user_id}/stats)
 	GetUsersUserIdStats(c *gin.Context, userId UUID, params GetUsersUserIdStatsParams)
@@ -625,6 +1648,30 @@ type ServerInterface interface {
 	// Get user subscriptions
 	// (GET /users/{user_id}/subscriptions)
 	GetUsersUserIdSubscriptions(c *gin.Context, userId UUID, params GetUsersUserIdSubscriptionsParams)
+	// List webhooks
+	// (GET /webhooks)
+	GetWebhooks(c *gin.Context, params GetWebhooksParams)
+	// Create webhook
+	// (POST /webhooks)
+	PostWebhooks(c *gin.Context, params PostWebhooksParams)
+	// Delete webhook
+	// (DELETE /webhooks/{webhook_id})
+	DeleteWebhooksWebhookId(c *gin.Context, webhookId int, params DeleteWebhooksWebhookIdParams)
+	// Get webhook
+	// (GET /webhooks/{webhook_id})
+	GetWebhooksWebhookId(c *gin.Context, webhookId int, params GetWebhooksWebhookIdParams)
+	// Update webhook
+	// (PUT /webhooks/{webhook_id})
+	PutWebhooksWebhookId(c *gin.Context, webhookId int, params PutWebhooksWebhookIdParams)
+	// List webhook deliveries
+	// (GET /webhooks/{webhook_id}/deliveries)
+	GetWebhooksWebhookIdDeliveries(c *gin.Context, webhookId int, params GetWebhooksWebhookIdDeliveriesParams)
+	// Get webhook delivery
+	// (GET /webhooks/{webhook_id}/deliveries/{delivery_id})
+	GetWebhooksWebhookIdDeliveriesDeliveryId(c *gin.Context, webhookId int, deliveryId int64, params GetWebhooksWebhookIdDeliveriesDeliveryIdParams)
+	// Redeliver webhook delivery
+	// (POST /webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver)
+	PostWebhooksWebhookIdDeliveriesDeliveryIdRedeliver(c *gin.Context, webhookId int, deliveryId int64, params PostWebhooksWebhookIdDeliveriesDeliveryIdRedeliverParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -636,6 +1683,97 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
+// PostArtists operation middleware
+func (siw *ServerInterfaceWrapper) PostArtists(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostArtistsParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostArtists(c, params)
+}
+
+// GetArtistsArtistId operation middleware
+func (siw *ServerInterfaceWrapper) GetArtistsArtistId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "artist_id" -------------
+	var artistId UUID
+
+	err = runtime.BindStyledParameter("simple", false, "artist_id", c.Param("artist_id"), &artistId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter artist_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetArtistsArtistIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetArtistsArtistId(c, artistId, params)
+}
+
 // PostAuthLogin operation middleware
 func (siw *ServerInterfaceWrapper) PostAuthLogin(c *gin.Context) {
 
@@ -729,56 +1867,2657 @@ func (siw *ServerInterfaceWrapper) PostAuthRegister(c *gin.Context) {
 	siw.Handler.PostAuthRegister(c)
 }
 
-// GetEvents operation middleware
-func (siw *ServerInterfaceWrapper) GetEvents(c *gin.Context) {
+// DeleteCalendarFeed operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCalendarFeed(c *gin.Context) {
 
 	var err error
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetEventsParams
+	var params DeleteCalendarFeedParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteCalendarFeed(c, params)
+}
+
+// GetCalendarFeed operation middleware
+func (siw *ServerInterfaceWrapper) GetCalendarFeed(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCalendarFeedParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCalendarFeed(c, params)
+}
+
+// PostCalendarFeed operation middleware
+func (siw *ServerInterfaceWrapper) PostCalendarFeed(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostCalendarFeedParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostCalendarFeed(c, params)
+}
+
+// GetCalendarFeedsToken operation middleware
+func (siw *ServerInterfaceWrapper) GetCalendarFeedsToken(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "token" -------------
+	var token string
+
+	err = runtime.BindStyledParameter("simple", false, "token", c.Param("token"), &token)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter token: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCalendarFeedsToken(c, token)
+}
+
+// DeleteCommentsCommentId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCommentsCommentId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "comment_id" -------------
+	var commentId UUID
+
+	err = runtime.BindStyledParameter("simple", false, "comment_id", c.Param("comment_id"), &commentId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter comment_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteCommentsCommentIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteCommentsCommentId(c, commentId, params)
+}
+
+// GetCommentsCommentId operation middleware
+func (siw *ServerInterfaceWrapper) GetCommentsCommentId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "comment_id" -------------
+	var commentId UUID
+
+	err = runtime.BindStyledParameter("simple", false, "comment_id", c.Param("comment_id"), &commentId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter comment_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCommentsCommentIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCommentsCommentId(c, commentId, params)
+}
+
+// PutCommentsCommentId operation middleware
+func (siw *ServerInterfaceWrapper) PutCommentsCommentId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "comment_id" -------------
+	var commentId UUID
+
+	err = runtime.BindStyledParameter("simple", false, "comment_id", c.Param("comment_id"), &commentId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter comment_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutCommentsCommentIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutCommentsCommentId(c, commentId, params)
+}
+
+// GetEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetEvents(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEventsParams
+
+	// ------------- Optional query parameter "name_query" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name_query", c.Request.URL.Query(), &params.NameQuery)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name_query: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "date_left_bound" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date_left_bound", c.Request.URL.Query(), &params.DateLeftBound)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter date_left_bound: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "date_right_bound" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date_right_bound", c.Request.URL.Query(), &params.DateRightBound)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter date_right_bound: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort_by_created_at" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_by_created_at", c.Request.URL.Query(), &params.SortByCreatedAt)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort_by_created_at: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort_by_amount" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_by_amount", c.Request.URL.Query(), &params.SortByAmount)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort_by_amount: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetEvents(c, params)
+}
+
+// PostEvents operation middleware
+func (siw *ServerInterfaceWrapper) PostEvents(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostEventsParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostEvents(c, params)
+}
+
+// GetEventsEventId operation middleware
+func (siw *ServerInterfaceWrapper) GetEventsEventId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "event_id" -------------
+	var eventId UUID
+
+	err = runtime.BindStyledParameter("simple", false, "event_id", c.Param("event_id"), &eventId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter event_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEventsEventIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetEventsEventId(c, eventId, params)
+}
+
+// PutEventsEventId operation middleware
+func (siw *ServerInterfaceWrapper) PutEventsEventId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "event_id" -------------
+	var eventId UUID
+
+	err = runtime.BindStyledParameter("simple", false, "event_id", c.Param("event_id"), &eventId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter event_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutEventsEventIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutEventsEventId(c, eventId, params)
+}
+
+// PostEventsEventIdCancel operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsEventIdCancel(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "event_id" -------------
+	var eventId UUID
+
+	err = runtime.BindStyledParameter("simple", false, "event_id", c.Param("event_id"), &eventId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter event_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostEventsEventIdCancelParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostEventsEventIdCancel(c, eventId, params)
+}
+
+// GetEventsEventIdIcs operation middleware
+func (siw *ServerInterfaceWrapper) GetEventsEventIdIcs(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "event_id" -------------
+	var eventId UUID
+
+	err = runtime.BindStyledParameter("simple", false, "event_id", c.Param("event_id"), &eventId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter event_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetEventsEventIdIcs(c, eventId)
+}
+
+// DeleteEventsEventIdParticipate operation middleware
+func (siw *ServerInterfaceWrapper) DeleteEventsEventIdParticipate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "event_id" -------------
+	var eventId UUID
+
+	err = runtime.BindStyledParameter("simple", false, "event_id", c.Param("event_id"), &eventId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter event_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteEventsEventIdParticipateParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteEventsEventIdParticipate(c, eventId, params)
+}
+
+// PostEventsEventIdParticipate operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsEventIdParticipate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "event_id" -------------
+	var eventId UUID
+
+	err = runtime.BindStyledParameter("simple", false, "event_id", c.Param("event_id"), &eventId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter event_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostEventsEventIdParticipateParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostEventsEventIdParticipate(c, eventId, params)
+}
+
+// GetModerationActions operation middleware
+func (siw *ServerInterfaceWrapper) GetModerationActions(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetModerationActionsParams
+
+	// ------------- Optional query parameter "moderator_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "moderator_id", c.Request.URL.Query(), &params.ModeratorId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter moderator_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "review_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "review_id", c.Request.URL.Query(), &params.ReviewId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "last_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_id", c.Request.URL.Query(), &params.LastId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetModerationActions(c, params)
+}
+
+// GetModerationQueue operation middleware
+func (siw *ServerInterfaceWrapper) GetModerationQueue(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetModerationQueueParams
+
+	// ------------- Optional query parameter "reason" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reason", c.Request.URL.Query(), &params.Reason)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter reason: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "claimed_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "claimed_by", c.Request.URL.Query(), &params.ClaimedBy)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter claimed_by: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "last_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_id", c.Request.URL.Query(), &params.LastId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetModerationQueue(c, params)
+}
+
+// PostModerationQueueClaim operation middleware
+func (siw *ServerInterfaceWrapper) PostModerationQueueClaim(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostModerationQueueClaimParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostModerationQueueClaim(c, params)
+}
+
+// PostModerationReviewsReviewIdApprove operation middleware
+func (siw *ServerInterfaceWrapper) PostModerationReviewsReviewIdApprove(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "review_id" -------------
+	var reviewId int
+
+	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostModerationReviewsReviewIdApproveParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostModerationReviewsReviewIdApprove(c, reviewId, params)
+}
+
+// PostModerationReviewsReviewIdClaim operation middleware
+func (siw *ServerInterfaceWrapper) PostModerationReviewsReviewIdClaim(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "review_id" -------------
+	var reviewId int
+
+	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostModerationReviewsReviewIdClaimParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostModerationReviewsReviewIdClaim(c, reviewId, params)
+}
+
+// PostModerationReviewsReviewIdReject operation middleware
+func (siw *ServerInterfaceWrapper) PostModerationReviewsReviewIdReject(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "review_id" -------------
+	var reviewId int
+
+	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostModerationReviewsReviewIdRejectParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostModerationReviewsReviewIdReject(c, reviewId, params)
+}
+
+// PostModerationReviewsReviewIdRelease operation middleware
+func (siw *ServerInterfaceWrapper) PostModerationReviewsReviewIdRelease(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "review_id" -------------
+	var reviewId int
+
+	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostModerationReviewsReviewIdReleaseParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostModerationReviewsReviewIdRelease(c, reviewId, params)
+}
+
+// PostNotes operation middleware
+func (siw *ServerInterfaceWrapper) PostNotes(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostNotesParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostNotes(c, params)
+}
+
+// DeleteNotesNoteId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteNotesNoteId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "note_id" -------------
+	var noteId int
+
+	err = runtime.BindStyledParameter("simple", false, "note_id", c.Param("note_id"), &noteId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter note_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteNotesNoteIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteNotesNoteId(c, noteId, params)
+}
+
+// GetNotesNoteId operation middleware
+func (siw *ServerInterfaceWrapper) GetNotesNoteId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "note_id" -------------
+	var noteId int
+
+	err = runtime.BindStyledParameter("simple", false, "note_id", c.Param("note_id"), &noteId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter note_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNotesNoteIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetNotesNoteId(c, noteId, params)
+}
+
+// PutNotesNoteId operation middleware
+func (siw *ServerInterfaceWrapper) PutNotesNoteId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "note_id" -------------
+	var noteId int
+
+	err = runtime.BindStyledParameter("simple", false, "note_id", c.Param("note_id"), &noteId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter note_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutNotesNoteIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutNotesNoteId(c, noteId, params)
+}
+
+// GetNotifications operation middleware
+func (siw *ServerInterfaceWrapper) GetNotifications(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNotificationsParams
+
+	// ------------- Optional query parameter "unread_only" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "unread_only", c.Request.URL.Query(), &params.UnreadOnly)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter unread_only: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "last_uuid" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_uuid", c.Request.URL.Query(), &params.LastUuid)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_uuid: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetNotifications(c, params)
+}
+
+// GetNotificationsDigest operation middleware
+func (siw *ServerInterfaceWrapper) GetNotificationsDigest(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNotificationsDigestParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetNotificationsDigest(c, params)
+}
+
+// PutNotificationsDigest operation middleware
+func (siw *ServerInterfaceWrapper) PutNotificationsDigest(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutNotificationsDigestParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutNotificationsDigest(c, params)
+}
+
+// GetNotificationsPreferences operation middleware
+func (siw *ServerInterfaceWrapper) GetNotificationsPreferences(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNotificationsPreferencesParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetNotificationsPreferences(c, params)
+}
+
+// PutNotificationsPreferences operation middleware
+func (siw *ServerInterfaceWrapper) PutNotificationsPreferences(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutNotificationsPreferencesParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutNotificationsPreferences(c, params)
+}
+
+// PostNotificationsRead operation middleware
+func (siw *ServerInterfaceWrapper) PostNotificationsRead(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostNotificationsReadParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostNotificationsRead(c, params)
+}
+
+// GetNotificationsStream operation middleware
+func (siw *ServerInterfaceWrapper) GetNotificationsStream(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNotificationsStreamParams
+
+	// ------------- Optional query parameter "last_event_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_event_id", c.Request.URL.Query(), &params.LastEventId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_event_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Last-Event-ID, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, valueList[0], &LastEventID)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Last-Event-ID: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetNotificationsStream(c, params)
+}
+
+// GetNotificationsUnreadCount operation middleware
+func (siw *ServerInterfaceWrapper) GetNotificationsUnreadCount(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNotificationsUnreadCountParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetNotificationsUnreadCount(c, params)
+}
+
+// GetNotificationsWs operation middleware
+func (siw *ServerInterfaceWrapper) GetNotificationsWs(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNotificationsWsParams
+
+	// ------------- Optional query parameter "last_event_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_event_id", c.Request.URL.Query(), &params.LastEventId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_event_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetNotificationsWs(c, params)
+}
+
+// PostNotificationsNotificationIdRead operation middleware
+func (siw *ServerInterfaceWrapper) PostNotificationsNotificationIdRead(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "notification_id" -------------
+	var notificationId UUID
+
+	err = runtime.BindStyledParameter("simple", false, "notification_id", c.Param("notification_id"), &notificationId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter notification_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostNotificationsNotificationIdReadParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostNotificationsNotificationIdRead(c, notificationId, params)
+}
+
+// PostPhotos operation middleware
+func (siw *ServerInterfaceWrapper) PostPhotos(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPhotosParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPhotos(c, params)
+}
+
+// DeletePhotosPhotoId operation middleware
+func (siw *ServerInterfaceWrapper) DeletePhotosPhotoId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "photo_id" -------------
+	var photoId UUID
+
+	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter photo_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeletePhotosPhotoIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeletePhotosPhotoId(c, photoId, params)
+}
+
+// GetPhotosPhotoId operation middleware
+func (siw *ServerInterfaceWrapper) GetPhotosPhotoId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "photo_id" -------------
+	var photoId UUID
+
+	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter photo_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPhotosPhotoIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPhotosPhotoId(c, photoId, params)
+}
+
+// GetPieces operation middleware
+func (siw *ServerInterfaceWrapper) GetPieces(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPiecesParams
+
+	// ------------- Optional query parameter "title_query" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "title_query", c.Request.URL.Query(), &params.TitleQuery)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter title_query: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "artist_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "artist_id", c.Request.URL.Query(), &params.ArtistId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter artist_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "last_uuid" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_uuid", c.Request.URL.Query(), &params.LastUuid)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_uuid: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPieces(c, params)
+}
+
+// PostPieces operation middleware
+func (siw *ServerInterfaceWrapper) PostPieces(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPiecesParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPieces(c, params)
+}
+
+// GetPiecesPieceId operation middleware
+func (siw *ServerInterfaceWrapper) GetPiecesPieceId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "piece_id" -------------
+	var pieceId string
+
+	err = runtime.BindStyledParameter("simple", false, "piece_id", c.Param("piece_id"), &pieceId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter piece_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPiecesPieceIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPiecesPieceId(c, pieceId, params)
+}
+
+// PutPiecesPieceId operation middleware
+func (siw *ServerInterfaceWrapper) PutPiecesPieceId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "piece_id" -------------
+	var pieceId string
+
+	err = runtime.BindStyledParameter("simple", false, "piece_id", c.Param("piece_id"), &pieceId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter piece_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutPiecesPieceIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutPiecesPieceId(c, pieceId, params)
+}
+
+// PostPiecesPieceIdAliases operation middleware
+func (siw *ServerInterfaceWrapper) PostPiecesPieceIdAliases(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "piece_id" -------------
+	var pieceId UUID
+
+	err = runtime.BindStyledParameter("simple", false, "piece_id", c.Param("piece_id"), &pieceId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter piece_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPiecesPieceIdAliasesParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPiecesPieceIdAliases(c, pieceId, params)
+}
+
+// DeletePiecesPieceIdRatingsMe operation middleware
+func (siw *ServerInterfaceWrapper) DeletePiecesPieceIdRatingsMe(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "piece_id" -------------
+	var pieceId string
+
+	err = runtime.BindStyledParameter("simple", false, "piece_id", c.Param("piece_id"), &pieceId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter piece_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeletePiecesPieceIdRatingsMeParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeletePiecesPieceIdRatingsMe(c, pieceId, params)
+}
+
+// GetPiecesPieceIdRatingsMe operation middleware
+func (siw *ServerInterfaceWrapper) GetPiecesPieceIdRatingsMe(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "piece_id" -------------
+	var pieceId string
+
+	err = runtime.BindStyledParameter("simple", false, "piece_id", c.Param("piece_id"), &pieceId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter piece_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPiecesPieceIdRatingsMeParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPiecesPieceIdRatingsMe(c, pieceId, params)
+}
+
+// PutPiecesPieceIdRatingsMe operation middleware
+func (siw *ServerInterfaceWrapper) PutPiecesPieceIdRatingsMe(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "piece_id" -------------
+	var pieceId string
+
+	err = runtime.BindStyledParameter("simple", false, "piece_id", c.Param("piece_id"), &pieceId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter piece_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutPiecesPieceIdRatingsMeParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutPiecesPieceIdRatingsMe(c, pieceId, params)
+}
+
+// GetPlaylistsPlaylistIdNotes operation middleware
+func (siw *ServerInterfaceWrapper) GetPlaylistsPlaylistIdNotes(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "playlist_id" -------------
+	var playlistId int
+
+	err = runtime.BindStyledParameter("simple", false, "playlist_id", c.Param("playlist_id"), &playlistId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter playlist_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPlaylistsPlaylistIdNotesParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPlaylistsPlaylistIdNotes(c, playlistId, params)
+}
+
+// DeleteReactionsReactionId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteReactionsReactionId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "reaction_id" -------------
+	var reactionId int
+
+	err = runtime.BindStyledParameter("simple", false, "reaction_id", c.Param("reaction_id"), &reactionId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter reaction_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteReactionsReactionIdParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteReactionsReactionId(c, reactionId, params)
+}
+
+// PutReactionsReactionId operation middleware
+func (siw *ServerInterfaceWrapper) PutReactionsReactionId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "reaction_id" -------------
+	var reactionId int
+
+	err = runtime.BindStyledParameter("simple", false, "reaction_id", c.Param("reaction_id"), &reactionId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter reaction_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutReactionsReactionIdParams
+
+	// ------------- Required query parameter "type" -------------
+
+	if paramValue := c.Query("type"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument type is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "type", c.Request.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutReactionsReactionId(c, reactionId, params)
+}
+
+// GetReports operation middleware
+func (siw *ServerInterfaceWrapper) GetReports(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReportsParams
+
+	// ------------- Optional query parameter "reporter_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reporter_id", c.Request.URL.Query(), &params.ReporterId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter reporter_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "target_type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target_type", c.Request.URL.Query(), &params.TargetType)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter target_type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "target_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target_id", c.Request.URL.Query(), &params.TargetId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter target_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "last_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_id", c.Request.URL.Query(), &params.LastId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetReports(c, params)
+}
+
+// PostReports operation middleware
+func (siw *ServerInterfaceWrapper) PostReports(c *gin.Context) {
+
+	var err error
+
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostReportsParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
 
-	// ------------- Optional query parameter "name_query" -------------
+		params.Actor = &Actor
 
-	err = runtime.BindQueryParameter("form", true, false, "name_query", c.Request.URL.Query(), &params.NameQuery)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter name_query: %w", err), http.StatusBadRequest)
-		return
 	}
 
-	// ------------- Optional query parameter "date_left_bound" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "date_left_bound", c.Request.URL.Query(), &params.DateLeftBound)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter date_left_bound: %w", err), http.StatusBadRequest)
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
-	// ------------- Optional query parameter "date_right_bound" -------------
+	siw.Handler.PostReports(c, params)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "date_right_bound", c.Request.URL.Query(), &params.DateRightBound)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter date_right_bound: %w", err), http.StatusBadRequest)
-		return
-	}
+// GetReportsTargetsTargetTypeTargetId operation middleware
+func (siw *ServerInterfaceWrapper) GetReportsTargetsTargetTypeTargetId(c *gin.Context) {
 
-	// ------------- Optional query parameter "sort_by_created_at" -------------
+	var err error
 
-	err = runtime.BindQueryParameter("form", true, false, "sort_by_created_at", c.Request.URL.Query(), &params.SortByCreatedAt)
+	// ------------- Path parameter "target_type" -------------
+	var targetType ReportTargetType
+
+	err = runtime.BindStyledParameter("simple", false, "target_type", c.Param("target_type"), &targetType)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort_by_created_at: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter target_type: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "sort_by_amount" -------------
+	// ------------- Path parameter "target_id" -------------
+	var targetId string
 
-	err = runtime.BindQueryParameter("form", true, false, "sort_by_amount", c.Request.URL.Query(), &params.SortByAmount)
+	err = runtime.BindStyledParameter("simple", false, "target_id", c.Param("target_id"), &targetId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort_by_amount: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter target_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReportsTargetsTargetTypeTargetIdParams
+
 	headers := c.Request.Header
 
 	// ------------- Optional header parameter "actor" -------------
@@ -807,18 +4546,36 @@ func (siw *ServerInterfaceWrapper) GetEvents(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetEvents(c, params)
+	siw.Handler.GetReportsTargetsTargetTypeTargetId(c, targetType, targetId, params)
 }
 
-// PostEvents operation middleware
-func (siw *ServerInterfaceWrapper) PostEvents(c *gin.Context) {
+// PostReportsTargetsTargetTypeTargetIdResolve operation middleware
+func (siw *ServerInterfaceWrapper) PostReportsTargetsTargetTypeTargetIdResolve(c *gin.Context) {
 
 	var err error
 
+	// ------------- Path parameter "target_type" -------------
+	var targetType ReportTargetType
+
+	err = runtime.BindStyledParameter("simple", false, "target_type", c.Param("target_type"), &targetType)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter target_type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "target_id" -------------
+	var targetId string
+
+	err = runtime.BindStyledParameter("simple", false, "target_id", c.Param("target_id"), &targetId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter target_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostEventsParams
+	var params PostReportsTargetsTargetTypeTargetIdResolveParams
 
 	headers := c.Request.Header
 
@@ -848,27 +4605,18 @@ func (siw *ServerInterfaceWrapper) PostEvents(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostEvents(c, params)
+	siw.Handler.PostReportsTargetsTargetTypeTargetIdResolve(c, targetType, targetId, params)
 }
 
-// GetEventsEventId operation middleware
-func (siw *ServerInterfaceWrapper) GetEventsEventId(c *gin.Context) {
+// PostReviews operation middleware
+func (siw *ServerInterfaceWrapper) PostReviews(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "event_id" -------------
-	var eventId int
-
-	err = runtime.BindStyledParameter("simple", false, "event_id", c.Param("event_id"), &eventId)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter event_id: %w", err), http.StatusBadRequest)
-		return
-	}
-
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetEventsEventIdParams
+	var params PostReviewsParams
 
 	headers := c.Request.Header
 
@@ -898,27 +4646,82 @@ func (siw *ServerInterfaceWrapper) GetEventsEventId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetEventsEventId(c, eventId, params)
+	siw.Handler.PostReviews(c, params)
 }
 
-// PutEventsEventId operation middleware
-func (siw *ServerInterfaceWrapper) PutEventsEventId(c *gin.Context) {
+// GetReviewsList operation middleware
+func (siw *ServerInterfaceWrapper) GetReviewsList(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "event_id" -------------
-	var eventId int
+	c.Set(ActorAuthScopes, []string{})
 
-	err = runtime.BindStyledParameter("simple", false, "event_id", c.Param("event_id"), &eventId)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReviewsListParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter event_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	c.Set(ActorAuthScopes, []string{})
+	// ------------- Optional query parameter "piece_id" -------------
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params PutEventsEventIdParams
+	err = runtime.BindQueryParameter("form", true, false, "piece_id", c.Request.URL.Query(), &params.PieceId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter piece_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "rating" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "rating", c.Request.URL.Query(), &params.Rating)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter rating: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "moderated" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "moderated", c.Request.URL.Query(), &params.Moderated)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter moderated: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "published" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "published", c.Request.URL.Query(), &params.Published)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter published: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "include_profiles" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_profiles", c.Request.URL.Query(), &params.IncludeProfiles)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter include_profiles: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "last_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_id", c.Request.URL.Query(), &params.LastId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	headers := c.Request.Header
 
@@ -948,27 +4751,74 @@ func (siw *ServerInterfaceWrapper) PutEventsEventId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PutEventsEventId(c, eventId, params)
+	siw.Handler.GetReviewsList(c, params)
 }
 
-// PostEventsEventIdParticipate operation middleware
-func (siw *ServerInterfaceWrapper) PostEventsEventIdParticipate(c *gin.Context) {
+// GetReviewsSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) GetReviewsSubscriptions(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "event_id" -------------
-	var eventId int
+	c.Set(ActorAuthScopes, []string{})
 
-	err = runtime.BindStyledParameter("simple", false, "event_id", c.Param("event_id"), &eventId)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReviewsSubscriptionsParams
+
+	// ------------- Optional query parameter "piece_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "piece_id", c.Request.URL.Query(), &params.PieceId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter event_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter piece_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	c.Set(ActorAuthScopes, []string{})
+	// ------------- Optional query parameter "rating" -------------
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params PostEventsEventIdParticipateParams
+	err = runtime.BindQueryParameter("form", true, false, "rating", c.Request.URL.Query(), &params.Rating)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter rating: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "moderated" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "moderated", c.Request.URL.Query(), &params.Moderated)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter moderated: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "published" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "published", c.Request.URL.Query(), &params.Published)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter published: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "include_profiles" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_profiles", c.Request.URL.Query(), &params.IncludeProfiles)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter include_profiles: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "last_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_id", c.Request.URL.Query(), &params.LastId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	headers := c.Request.Header
 
@@ -998,18 +4848,27 @@ func (siw *ServerInterfaceWrapper) PostEventsEventIdParticipate(c *gin.Context)
 		}
 	}
 
-	siw.Handler.PostEventsEventIdParticipate(c, eventId, params)
+	siw.Handler.GetReviewsSubscriptions(c, params)
 }
 
-// PostNotes operation middleware
-func (siw *ServerInterfaceWrapper) PostNotes(c *gin.Context) {
+// DeleteReviewsReviewId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteReviewsReviewId(c *gin.Context) {
 
 	var err error
 
+	// ------------- Path parameter "review_id" -------------
+	var reviewId int
+
+	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostNotesParams
+	var params DeleteReviewsReviewIdParams
 
 	headers := c.Request.Header
 
@@ -1039,27 +4898,27 @@ func (siw *ServerInterfaceWrapper) PostNotes(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostNotes(c, params)
+	siw.Handler.DeleteReviewsReviewId(c, reviewId, params)
 }
 
-// DeleteNotesNoteId operation middleware
-func (siw *ServerInterfaceWrapper) DeleteNotesNoteId(c *gin.Context) {
+// GetReviewsReviewId operation middleware
+func (siw *ServerInterfaceWrapper) GetReviewsReviewId(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "note_id" -------------
-	var noteId int
+	// ------------- Path parameter "review_id" -------------
+	var reviewId int
 
-	err = runtime.BindStyledParameter("simple", false, "note_id", c.Param("note_id"), &noteId)
+	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter note_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params DeleteNotesNoteIdParams
+	var params GetReviewsReviewIdParams
 
 	headers := c.Request.Header
 
@@ -1089,27 +4948,27 @@ func (siw *ServerInterfaceWrapper) DeleteNotesNoteId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DeleteNotesNoteId(c, noteId, params)
+	siw.Handler.GetReviewsReviewId(c, reviewId, params)
 }
 
-// GetNotesNoteId operation middleware
-func (siw *ServerInterfaceWrapper) GetNotesNoteId(c *gin.Context) {
+// PutReviewsReviewId operation middleware
+func (siw *ServerInterfaceWrapper) PutReviewsReviewId(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "note_id" -------------
-	var noteId int
+	// ------------- Path parameter "review_id" -------------
+	var reviewId int
 
-	err = runtime.BindStyledParameter("simple", false, "note_id", c.Param("note_id"), &noteId)
+	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter note_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetNotesNoteIdParams
+	var params PutReviewsReviewIdParams
 
 	headers := c.Request.Header
 
@@ -1139,27 +4998,51 @@ func (siw *ServerInterfaceWrapper) GetNotesNoteId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetNotesNoteId(c, noteId, params)
+	siw.Handler.PutReviewsReviewId(c, reviewId, params)
 }
 
-// PutNotesNoteId operation middleware
-func (siw *ServerInterfaceWrapper) PutNotesNoteId(c *gin.Context) {
+// GetReviewsReviewIdComments operation middleware
+func (siw *ServerInterfaceWrapper) GetReviewsReviewIdComments(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "note_id" -------------
-	var noteId int
+	// ------------- Path parameter "review_id" -------------
+	var reviewId int
 
-	err = runtime.BindStyledParameter("simple", false, "note_id", c.Param("note_id"), &noteId)
+	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter note_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PutNotesNoteIdParams
+	var params GetReviewsReviewIdCommentsParams
+
+	// ------------- Optional query parameter "parent_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "parent_id", c.Request.URL.Query(), &params.ParentId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter parent_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "last_uuid" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_uuid", c.Request.URL.Query(), &params.LastUuid)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_uuid: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	headers := c.Request.Header
 
@@ -1189,18 +5072,27 @@ func (siw *ServerInterfaceWrapper) PutNotesNoteId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PutNotesNoteId(c, noteId, params)
+	siw.Handler.GetReviewsReviewIdComments(c, reviewId, params)
 }
 
-// PostPhotos operation middleware
-func (siw *ServerInterfaceWrapper) PostPhotos(c *gin.Context) {
+// PostReviewsReviewIdComments operation middleware
+func (siw *ServerInterfaceWrapper) PostReviewsReviewIdComments(c *gin.Context) {
 
 	var err error
 
+	// ------------- Path parameter "review_id" -------------
+	var reviewId int
+
+	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostPhotosParams
+	var params PostReviewsReviewIdCommentsParams
 
 	headers := c.Request.Header
 
@@ -1230,27 +5122,43 @@ func (siw *ServerInterfaceWrapper) PostPhotos(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostPhotos(c, params)
+	siw.Handler.PostReviewsReviewIdComments(c, reviewId, params)
 }
 
-// DeletePhotosPhotoId operation middleware
-func (siw *ServerInterfaceWrapper) DeletePhotosPhotoId(c *gin.Context) {
+// GetReviewsReviewIdNotes operation middleware
+func (siw *ServerInterfaceWrapper) GetReviewsReviewIdNotes(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "photo_id" -------------
-	var photoId UUID
+	// ------------- Path parameter "review_id" -------------
+	var reviewId int
 
-	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
+	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter photo_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params DeletePhotosPhotoIdParams
+	var params GetReviewsReviewIdNotesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "last_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_id", c.Request.URL.Query(), &params.LastId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	headers := c.Request.Header
 
@@ -1280,27 +5188,27 @@ func (siw *ServerInterfaceWrapper) DeletePhotosPhotoId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DeletePhotosPhotoId(c, photoId, params)
+	siw.Handler.GetReviewsReviewIdNotes(c, reviewId, params)
 }
 
-// GetPhotosPhotoId operation middleware
-func (siw *ServerInterfaceWrapper) GetPhotosPhotoId(c *gin.Context) {
+// GetReviewsReviewIdReactions operation middleware
+func (siw *ServerInterfaceWrapper) GetReviewsReviewIdReactions(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "photo_id" -------------
-	var photoId UUID
+	// ------------- Path parameter "review_id" -------------
+	var reviewId int
 
-	err = runtime.BindStyledParameter("simple", false, "photo_id", c.Param("photo_id"), &photoId)
+	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter photo_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetPhotosPhotoIdParams
+	var params GetReviewsReviewIdReactionsParams
 
 	headers := c.Request.Header
 
@@ -1330,27 +5238,27 @@ func (siw *ServerInterfaceWrapper) GetPhotosPhotoId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetPhotosPhotoId(c, photoId, params)
+	siw.Handler.GetReviewsReviewIdReactions(c, reviewId, params)
 }
 
-// GetPlaylistsPlaylistIdNotes operation middleware
-func (siw *ServerInterfaceWrapper) GetPlaylistsPlaylistIdNotes(c *gin.Context) {
+// PostReviewsReviewIdReactions operation middleware
+func (siw *ServerInterfaceWrapper) PostReviewsReviewIdReactions(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "playlist_id" -------------
-	var playlistId int
+	// ------------- Path parameter "review_id" -------------
+	var reviewId int
 
-	err = runtime.BindStyledParameter("simple", false, "playlist_id", c.Param("playlist_id"), &playlistId)
+	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter playlist_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetPlaylistsPlaylistIdNotesParams
+	var params PostReviewsReviewIdReactionsParams
 
 	headers := c.Request.Header
 
@@ -1380,27 +5288,27 @@ func (siw *ServerInterfaceWrapper) GetPlaylistsPlaylistIdNotes(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetPlaylistsPlaylistIdNotes(c, playlistId, params)
+	siw.Handler.PostReviewsReviewIdReactions(c, reviewId, params)
 }
 
-// DeleteReactionsReactionId operation middleware
-func (siw *ServerInterfaceWrapper) DeleteReactionsReactionId(c *gin.Context) {
+// GetReviewsReviewIdReactionsMe operation middleware
+func (siw *ServerInterfaceWrapper) GetReviewsReviewIdReactionsMe(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "reaction_id" -------------
-	var reactionId int
+	// ------------- Path parameter "review_id" -------------
+	var reviewId int
 
-	err = runtime.BindStyledParameter("simple", false, "reaction_id", c.Param("reaction_id"), &reactionId)
+	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter reaction_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params DeleteReactionsReactionIdParams
+	var params GetReviewsReviewIdReactionsMeParams
 
 	headers := c.Request.Header
 
@@ -1430,40 +5338,49 @@ func (siw *ServerInterfaceWrapper) DeleteReactionsReactionId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DeleteReactionsReactionId(c, reactionId, params)
+	siw.Handler.GetReviewsReviewIdReactionsMe(c, reviewId, params)
 }
 
-// PutReactionsReactionId operation middleware
-func (siw *ServerInterfaceWrapper) PutReactionsReactionId(c *gin.Context) {
+// GetReviewsReviewIdReactionsUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetReviewsReviewIdReactionsUsers(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "reaction_id" -------------
-	var reactionId int
+	// ------------- Path parameter "review_id" -------------
+	var reviewId int
 
-	err = runtime.BindStyledParameter("simple", false, "reaction_id", c.Param("reaction_id"), &reactionId)
+	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter reaction_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PutReactionsReactionIdParams
+	var params GetReviewsReviewIdReactionsUsersParams
 
-	// ------------- Required query parameter "type" -------------
+	// ------------- Optional query parameter "type" -------------
 
-	if paramValue := c.Query("type"); paramValue != "" {
+	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Query argument type is required, but not found"), http.StatusBadRequest)
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "type", c.Request.URL.Query(), &params.Type)
+	// ------------- Optional query parameter "last_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_id", c.Request.URL.Query(), &params.LastId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -1495,18 +5412,27 @@ func (siw *ServerInterfaceWrapper) PutReactionsReactionId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PutReactionsReactionId(c, reactionId, params)
+	siw.Handler.GetReviewsReviewIdReactionsUsers(c, reviewId, params)
 }
 
-// PostReviews operation middleware
-func (siw *ServerInterfaceWrapper) PostReviews(c *gin.Context) {
+// GetReviewsReviewIdRevisions operation middleware
+func (siw *ServerInterfaceWrapper) GetReviewsReviewIdRevisions(c *gin.Context) {
 
 	var err error
 
+	// ------------- Path parameter "review_id" -------------
+	var reviewId int
+
+	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostReviewsParams
+	var params GetReviewsReviewIdRevisionsParams
 
 	headers := c.Request.Header
 
@@ -1536,80 +5462,41 @@ func (siw *ServerInterfaceWrapper) PostReviews(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostReviews(c, params)
+	siw.Handler.GetReviewsReviewIdRevisions(c, reviewId, params)
 }
 
-// GetReviewsList operation middleware
-func (siw *ServerInterfaceWrapper) GetReviewsList(c *gin.Context) {
+// GetReviewsReviewIdRevisionsDiff operation middleware
+func (siw *ServerInterfaceWrapper) GetReviewsReviewIdRevisionsDiff(c *gin.Context) {
 
 	var err error
 
-	c.Set(ActorAuthScopes, []string{})
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetReviewsListParams
-
-	// ------------- Optional query parameter "user_id" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "user_id", c.Request.URL.Query(), &params.UserId)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
-		return
-	}
-
-	// ------------- Optional query parameter "piece_id" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "piece_id", c.Request.URL.Query(), &params.PieceId)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter piece_id: %w", err), http.StatusBadRequest)
-		return
-	}
-
-	// ------------- Optional query parameter "rating" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "rating", c.Request.URL.Query(), &params.Rating)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter rating: %w", err), http.StatusBadRequest)
-		return
-	}
-
-	// ------------- Optional query parameter "moderated" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "moderated", c.Request.URL.Query(), &params.Moderated)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter moderated: %w", err), http.StatusBadRequest)
-		return
-	}
-
-	// ------------- Optional query parameter "published" -------------
+	// ------------- Path parameter "review_id" -------------
+	var reviewId int
 
-	err = runtime.BindQueryParameter("form", true, false, "published", c.Request.URL.Query(), &params.Published)
+	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter published: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "include_profiles" -------------
+	c.Set(ActorAuthScopes, []string{})
 
-	err = runtime.BindQueryParameter("form", true, false, "include_profiles", c.Request.URL.Query(), &params.IncludeProfiles)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter include_profiles: %w", err), http.StatusBadRequest)
-		return
-	}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReviewsReviewIdRevisionsDiffParams
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Optional query parameter "from" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "last_id" -------------
+	// ------------- Optional query parameter "to" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "last_id", c.Request.URL.Query(), &params.LastId)
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -1641,74 +5528,68 @@ func (siw *ServerInterfaceWrapper) GetReviewsList(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetReviewsList(c, params)
+	siw.Handler.GetReviewsReviewIdRevisionsDiff(c, reviewId, params)
 }
 
-// GetReviewsSubscriptions operation middleware
-func (siw *ServerInterfaceWrapper) GetReviewsSubscriptions(c *gin.Context) {
+// PostSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) PostSubscriptions(c *gin.Context) {
 
 	var err error
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetReviewsSubscriptionsParams
+	var params PostSubscriptionsParams
 
-	// ------------- Optional query parameter "piece_id" -------------
+	headers := c.Request.Header
 
-	err = runtime.BindQueryParameter("form", true, false, "piece_id", c.Request.URL.Query(), &params.PieceId)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter piece_id: %w", err), http.StatusBadRequest)
-		return
-	}
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
 
-	// ------------- Optional query parameter "rating" -------------
+		params.Actor = &Actor
 
-	err = runtime.BindQueryParameter("form", true, false, "rating", c.Request.URL.Query(), &params.Rating)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter rating: %w", err), http.StatusBadRequest)
-		return
 	}
 
-	// ------------- Optional query parameter "moderated" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "moderated", c.Request.URL.Query(), &params.Moderated)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter moderated: %w", err), http.StatusBadRequest)
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
-	// ------------- Optional query parameter "published" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "published", c.Request.URL.Query(), &params.Published)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter published: %w", err), http.StatusBadRequest)
-		return
-	}
+	siw.Handler.PostSubscriptions(c, params)
+}
 
-	// ------------- Optional query parameter "include_profiles" -------------
+// DeleteSubscriptionsFollowedId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSubscriptionsFollowedId(c *gin.Context) {
 
-	err = runtime.BindQueryParameter("form", true, false, "include_profiles", c.Request.URL.Query(), &params.IncludeProfiles)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter include_profiles: %w", err), http.StatusBadRequest)
-		return
-	}
+	var err error
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Path parameter "followed_id" -------------
+	var followedId UUID
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	err = runtime.BindStyledParameter("simple", false, "followed_id", c.Param("followed_id"), &followedId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter followed_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "last_id" -------------
+	c.Set(ActorAuthScopes, []string{})
 
-	err = runtime.BindQueryParameter("form", true, false, "last_id", c.Request.URL.Query(), &params.LastId)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_id: %w", err), http.StatusBadRequest)
-		return
-	}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteSubscriptionsFollowedIdParams
 
 	headers := c.Request.Header
 
@@ -1738,27 +5619,27 @@ func (siw *ServerInterfaceWrapper) GetReviewsSubscriptions(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetReviewsSubscriptions(c, params)
+	siw.Handler.DeleteSubscriptionsFollowedId(c, followedId, params)
 }
 
-// DeleteReviewsReviewId operation middleware
-func (siw *ServerInterfaceWrapper) DeleteReviewsReviewId(c *gin.Context) {
+// GetSubscriptionsFollowedId operation middleware
+func (siw *ServerInterfaceWrapper) GetSubscriptionsFollowedId(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "review_id" -------------
-	var reviewId int
+	// ------------- Path parameter "followed_id" -------------
+	var followedId UUID
 
-	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
+	err = runtime.BindStyledParameter("simple", false, "followed_id", c.Param("followed_id"), &followedId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter followed_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params DeleteReviewsReviewIdParams
+	var params GetSubscriptionsFollowedIdParams
 
 	headers := c.Request.Header
 
@@ -1788,27 +5669,27 @@ func (siw *ServerInterfaceWrapper) DeleteReviewsReviewId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DeleteReviewsReviewId(c, reviewId, params)
+	siw.Handler.GetSubscriptionsFollowedId(c, followedId, params)
 }
 
-// GetReviewsReviewId operation middleware
-func (siw *ServerInterfaceWrapper) GetReviewsReviewId(c *gin.Context) {
+// PutSubscriptionsFollowedId operation middleware
+func (siw *ServerInterfaceWrapper) PutSubscriptionsFollowedId(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "review_id" -------------
-	var reviewId int
+	// ------------- Path parameter "followed_id" -------------
+	var followedId UUID
 
-	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
+	err = runtime.BindStyledParameter("simple", false, "followed_id", c.Param("followed_id"), &followedId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter followed_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetReviewsReviewIdParams
+	var params PutSubscriptionsFollowedIdParams
 
 	headers := c.Request.Header
 
@@ -1838,28 +5719,27 @@ func (siw *ServerInterfaceWrapper) GetReviewsReviewId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetReviewsReviewId(c, reviewId, params)
+	siw.Handler.PutSubscriptionsFollowedId(c, followedId, params)
 }
 
-// PutReviewsReviewId operation middleware
-func (siw *ServerInterfaceWrapper) PutReviewsReviewId(c *gin.Context) {
+// GetTagsTrending operation middleware
+func (siw *ServerInterfaceWrapper) GetTagsTrending(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "review_id" -------------
-	var reviewId int
+	c.Set(ActorAuthScopes, []string{})
 
-	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTagsTrendingParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	c.Set(ActorAuthScopes, []string{})
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params PutReviewsReviewIdParams
-
 	headers := c.Request.Header
 
 	// ------------- Optional header parameter "actor" -------------
@@ -1888,27 +5768,35 @@ func (siw *ServerInterfaceWrapper) PutReviewsReviewId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PutReviewsReviewId(c, reviewId, params)
+	siw.Handler.GetTagsTrending(c, params)
 }
 
-// GetReviewsReviewIdNotes operation middleware
-func (siw *ServerInterfaceWrapper) GetReviewsReviewIdNotes(c *gin.Context) {
+// GetTagsTagReviews operation middleware
+func (siw *ServerInterfaceWrapper) GetTagsTagReviews(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "review_id" -------------
-	var reviewId int
+	// ------------- Path parameter "tag" -------------
+	var tag string
 
-	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
+	err = runtime.BindStyledParameter("simple", false, "tag", c.Param("tag"), &tag)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tag: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetReviewsReviewIdNotesParams
+	var params GetTagsTagReviewsParams
+
+	// ------------- Optional query parameter "include_profiles" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_profiles", c.Request.URL.Query(), &params.IncludeProfiles)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter include_profiles: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	// ------------- Optional query parameter "limit" -------------
 
@@ -1954,27 +5842,27 @@ func (siw *ServerInterfaceWrapper) GetReviewsReviewIdNotes(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetReviewsReviewIdNotes(c, reviewId, params)
+	siw.Handler.GetTagsTagReviews(c, tag, params)
 }
 
-// GetReviewsReviewIdReactions operation middleware
-func (siw *ServerInterfaceWrapper) GetReviewsReviewIdReactions(c *gin.Context) {
+// GetTracksTrackIdStats operation middleware
+func (siw *ServerInterfaceWrapper) GetTracksTrackIdStats(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "review_id" -------------
-	var reviewId int
+	// ------------- Path parameter "track_id" -------------
+	var trackId string
 
-	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
+	err = runtime.BindStyledParameter("simple", false, "track_id", c.Param("track_id"), &trackId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter track_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetReviewsReviewIdReactionsParams
+	var params GetTracksTrackIdStatsParams
 
 	headers := c.Request.Header
 
@@ -2004,27 +5892,83 @@ func (siw *ServerInterfaceWrapper) GetReviewsReviewIdReactions(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetReviewsReviewIdReactions(c, reviewId, params)
+	siw.Handler.GetTracksTrackIdStats(c, trackId, params)
 }
 
-// PostReviewsReviewIdReactions operation middleware
-func (siw *ServerInterfaceWrapper) PostReviewsReviewIdReactions(c *gin.Context) {
+// PostUsers operation middleware
+func (siw *ServerInterfaceWrapper) PostUsers(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "review_id" -------------
-	var reviewId int
+	c.Set(ActorAuthScopes, []string{})
 
-	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
-		return
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostUsersParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
+	siw.Handler.PostUsers(c, params)
+}
+
+// GetUsersProfiles operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersProfiles(c *gin.Context) {
+
+	var err error
+
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostReviewsReviewIdReactionsParams
+	var params GetUsersProfilesParams
+
+	// ------------- Optional query parameter "nickname_query" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "nickname_query", c.Request.URL.Query(), &params.NicknameQuery)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter nickname_query: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "last_uuid" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_uuid", c.Request.URL.Query(), &params.LastUuid)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_uuid: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	headers := c.Request.Header
 
@@ -2054,27 +5998,27 @@ func (siw *ServerInterfaceWrapper) PostReviewsReviewIdReactions(c *gin.Context)
 		}
 	}
 
-	siw.Handler.PostReviewsReviewIdReactions(c, reviewId, params)
+	siw.Handler.GetUsersProfiles(c, params)
 }
 
-// GetReviewsReviewIdReactionsMe operation middleware
-func (siw *ServerInterfaceWrapper) GetReviewsReviewIdReactionsMe(c *gin.Context) {
+// GetUsersUserId operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserId(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "review_id" -------------
-	var reviewId int
+	// ------------- Path parameter "user_id" -------------
+	var userId UUID
 
-	err = runtime.BindStyledParameter("simple", false, "review_id", c.Param("review_id"), &reviewId)
+	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter review_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetReviewsReviewIdReactionsMeParams
+	var params GetUsersUserIdParams
 
 	headers := c.Request.Header
 
@@ -2104,18 +6048,27 @@ func (siw *ServerInterfaceWrapper) GetReviewsReviewIdReactionsMe(c *gin.Context)
 		}
 	}
 
-	siw.Handler.GetReviewsReviewIdReactionsMe(c, reviewId, params)
+	siw.Handler.GetUsersUserId(c, userId, params)
 }
 
-// PostSubscriptions operation middleware
-func (siw *ServerInterfaceWrapper) PostSubscriptions(c *gin.Context) {
+// PutUsersUserId operation middleware
+func (siw *ServerInterfaceWrapper) PutUsersUserId(c *gin.Context) {
 
 	var err error
 
+	// ------------- Path parameter "user_id" -------------
+	var userId UUID
+
+	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostSubscriptionsParams
+	var params PutUsersUserIdParams
 
 	headers := c.Request.Header
 
@@ -2145,27 +6098,27 @@ func (siw *ServerInterfaceWrapper) PostSubscriptions(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostSubscriptions(c, params)
+	siw.Handler.PutUsersUserId(c, userId, params)
 }
 
-// DeleteSubscriptionsFollowedId operation middleware
-func (siw *ServerInterfaceWrapper) DeleteSubscriptionsFollowedId(c *gin.Context) {
+// PostUsersUserIdBlock operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUserIdBlock(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "followed_id" -------------
-	var followedId UUID
+	// ------------- Path parameter "user_id" -------------
+	var userId UUID
 
-	err = runtime.BindStyledParameter("simple", false, "followed_id", c.Param("followed_id"), &followedId)
+	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter followed_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params DeleteSubscriptionsFollowedIdParams
+	var params PostUsersUserIdBlockParams
 
 	headers := c.Request.Header
 
@@ -2195,27 +6148,27 @@ func (siw *ServerInterfaceWrapper) DeleteSubscriptionsFollowedId(c *gin.Context)
 		}
 	}
 
-	siw.Handler.DeleteSubscriptionsFollowedId(c, followedId, params)
+	siw.Handler.PostUsersUserIdBlock(c, userId, params)
 }
 
-// GetSubscriptionsFollowedId operation middleware
-func (siw *ServerInterfaceWrapper) GetSubscriptionsFollowedId(c *gin.Context) {
+// GetUsersUserIdProfile operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserIdProfile(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "followed_id" -------------
-	var followedId UUID
+	// ------------- Path parameter "user_id" -------------
+	var userId UUID
 
-	err = runtime.BindStyledParameter("simple", false, "followed_id", c.Param("followed_id"), &followedId)
+	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter followed_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetSubscriptionsFollowedIdParams
+	var params GetUsersUserIdProfileParams
 
 	headers := c.Request.Header
 
@@ -2245,27 +6198,27 @@ func (siw *ServerInterfaceWrapper) GetSubscriptionsFollowedId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetSubscriptionsFollowedId(c, followedId, params)
+	siw.Handler.GetUsersUserIdProfile(c, userId, params)
 }
 
-// PutSubscriptionsFollowedId operation middleware
-func (siw *ServerInterfaceWrapper) PutSubscriptionsFollowedId(c *gin.Context) {
+// PutUsersUserIdProfile operation middleware
+func (siw *ServerInterfaceWrapper) PutUsersUserIdProfile(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "followed_id" -------------
-	var followedId UUID
+	// ------------- Path parameter "user_id" -------------
+	var userId UUID
 
-	err = runtime.BindStyledParameter("simple", false, "followed_id", c.Param("followed_id"), &followedId)
+	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter followed_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PutSubscriptionsFollowedIdParams
+	var params PutUsersUserIdProfileParams
 
 	headers := c.Request.Header
 
@@ -2295,27 +6248,43 @@ func (siw *ServerInterfaceWrapper) PutSubscriptionsFollowedId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PutSubscriptionsFollowedId(c, followedId, params)
+	siw.Handler.PutUsersUserIdProfile(c, userId, params)
 }
 
-// GetTracksTrackIdStats operation middleware
-func (siw *ServerInterfaceWrapper) GetTracksTrackIdStats(c *gin.Context) {
+// GetUsersUserIdRatings operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserIdRatings(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "track_id" -------------
-	var trackId string
+	// ------------- Path parameter "user_id" -------------
+	var userId UUID
 
-	err = runtime.BindStyledParameter("simple", false, "track_id", c.Param("track_id"), &trackId)
+	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter track_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetTracksTrackIdStatsParams
+	var params GetUsersUserIdRatingsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "last_uuid" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_uuid", c.Request.URL.Query(), &params.LastUuid)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_uuid: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	headers := c.Request.Header
 
@@ -2345,18 +6314,27 @@ func (siw *ServerInterfaceWrapper) GetTracksTrackIdStats(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetTracksTrackIdStats(c, trackId, params)
+	siw.Handler.GetUsersUserIdRatings(c, userId, params)
 }
 
-// PostUsers operation middleware
-func (siw *ServerInterfaceWrapper) PostUsers(c *gin.Context) {
+// GetUsersUserIdStats operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserIdStats(c *gin.Context) {
 
 	var err error
 
+	// ------------- Path parameter "user_id" -------------
+	var userId UUID
+
+	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostUsersParams
+	var params GetUsersUserIdStatsParams
 
 	headers := c.Request.Header
 
@@ -2386,27 +6364,28 @@ func (siw *ServerInterfaceWrapper) PostUsers(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostUsers(c, params)
+	siw.Handler.GetUsersUserIdStats(c, userId, params)
 }
 
-// GetUsersProfiles operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersProfiles(c *gin.Context) {
+// GetUsersUserIdSubscribers operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserIdSubscribers(c *gin.Context) {
 
 	var err error
 
-	c.Set(ActorAuthScopes, []string{})
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUsersProfilesParams
-
-	// ------------- Optional query parameter "nickname_query" -------------
+	// ------------- Path parameter "user_id" -------------
+	var userId UUID
 
-	err = runtime.BindQueryParameter("form", true, false, "nickname_query", c.Request.URL.Query(), &params.NicknameQuery)
+	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter nickname_query: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersUserIdSubscribersParams
+
 	// ------------- Optional query parameter "limit" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
@@ -2415,11 +6394,11 @@ func (siw *ServerInterfaceWrapper) GetUsersProfiles(c *gin.Context) {
 		return
 	}
 
-	// ------------- Optional query parameter "last_uuid" -------------
+	// ------------- Optional query parameter "last_id" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "last_uuid", c.Request.URL.Query(), &params.LastUuid)
+	err = runtime.BindQueryParameter("form", true, false, "last_id", c.Request.URL.Query(), &params.LastId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_uuid: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -2451,11 +6430,11 @@ func (siw *ServerInterfaceWrapper) GetUsersProfiles(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetUsersProfiles(c, params)
+	siw.Handler.GetUsersUserIdSubscribers(c, userId, params)
 }
 
-// GetUsersUserId operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersUserId(c *gin.Context) {
+// GetUsersUserIdSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserIdSubscriptions(c *gin.Context) {
 
 	var err error
 
@@ -2471,7 +6450,23 @@ func (siw *ServerInterfaceWrapper) GetUsersUserId(c *gin.Context) {
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUsersUserIdParams
+	var params GetUsersUserIdSubscriptionsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "last_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_id", c.Request.URL.Query(), &params.LastId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_id: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	headers := c.Request.Header
 
@@ -2501,27 +6496,18 @@ func (siw *ServerInterfaceWrapper) GetUsersUserId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetUsersUserId(c, userId, params)
+	siw.Handler.GetUsersUserIdSubscriptions(c, userId, params)
 }
 
-// PutUsersUserId operation middleware
-func (siw *ServerInterfaceWrapper) PutUsersUserId(c *gin.Context) {
+// GetWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooks(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId UUID
-
-	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
-		return
-	}
-
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PutUsersUserIdParams
+	var params GetWebhooksParams
 
 	headers := c.Request.Header
 
@@ -2551,27 +6537,18 @@ func (siw *ServerInterfaceWrapper) PutUsersUserId(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PutUsersUserId(c, userId, params)
+	siw.Handler.GetWebhooks(c, params)
 }
 
-// PostUsersUserIdBlock operation middleware
-func (siw *ServerInterfaceWrapper) PostUsersUserIdBlock(c *gin.Context) {
+// PostWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhooks(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId UUID
-
-	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
-		return
-	}
-
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PostUsersUserIdBlockParams
+	var params PostWebhooksParams
 
 	headers := c.Request.Header
 
@@ -2601,27 +6578,27 @@ func (siw *ServerInterfaceWrapper) PostUsersUserIdBlock(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostUsersUserIdBlock(c, userId, params)
+	siw.Handler.PostWebhooks(c, params)
 }
 
-// GetUsersUserIdProfile operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersUserIdProfile(c *gin.Context) {
+// DeleteWebhooksWebhookId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhooksWebhookId(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId UUID
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId int
 
-	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
+	err = runtime.BindStyledParameter("simple", false, "webhook_id", c.Param("webhook_id"), &webhookId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhook_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUsersUserIdProfileParams
+	var params DeleteWebhooksWebhookIdParams
 
 	headers := c.Request.Header
 
@@ -2651,27 +6628,27 @@ func (siw *ServerInterfaceWrapper) GetUsersUserIdProfile(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetUsersUserIdProfile(c, userId, params)
+	siw.Handler.DeleteWebhooksWebhookId(c, webhookId, params)
 }
 
-// PutUsersUserIdProfile operation middleware
-func (siw *ServerInterfaceWrapper) PutUsersUserIdProfile(c *gin.Context) {
+// GetWebhooksWebhookId operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooksWebhookId(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId UUID
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId int
 
-	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
+	err = runtime.BindStyledParameter("simple", false, "webhook_id", c.Param("webhook_id"), &webhookId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhook_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params PutUsersUserIdProfileParams
+	var params GetWebhooksWebhookIdParams
 
 	headers := c.Request.Header
 
@@ -2701,27 +6678,27 @@ func (siw *ServerInterfaceWrapper) PutUsersUserIdProfile(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PutUsersUserIdProfile(c, userId, params)
+	siw.Handler.GetWebhooksWebhookId(c, webhookId, params)
 }
 
-// GetUsersUserIdStats operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersUserIdStats(c *gin.Context) {
+// PutWebhooksWebhookId operation middleware
+func (siw *ServerInterfaceWrapper) PutWebhooksWebhookId(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId UUID
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId int
 
-	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
+	err = runtime.BindStyledParameter("simple", false, "webhook_id", c.Param("webhook_id"), &webhookId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhook_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUsersUserIdStatsParams
+	var params PutWebhooksWebhookIdParams
 
 	headers := c.Request.Header
 
@@ -2751,27 +6728,35 @@ func (siw *ServerInterfaceWrapper) GetUsersUserIdStats(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetUsersUserIdStats(c, userId, params)
+	siw.Handler.PutWebhooksWebhookId(c, webhookId, params)
 }
 
-// GetUsersUserIdSubscribers operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersUserIdSubscribers(c *gin.Context) {
+// GetWebhooksWebhookIdDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooksWebhookIdDeliveries(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId UUID
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId int
 
-	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
+	err = runtime.BindStyledParameter("simple", false, "webhook_id", c.Param("webhook_id"), &webhookId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhook_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUsersUserIdSubscribersParams
+	var params GetWebhooksWebhookIdDeliveriesParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	// ------------- Optional query parameter "limit" -------------
 
@@ -2817,44 +6802,96 @@ func (siw *ServerInterfaceWrapper) GetUsersUserIdSubscribers(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetUsersUserIdSubscribers(c, userId, params)
+	siw.Handler.GetWebhooksWebhookIdDeliveries(c, webhookId, params)
 }
 
-// GetUsersUserIdSubscriptions operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersUserIdSubscriptions(c *gin.Context) {
+// GetWebhooksWebhookIdDeliveriesDeliveryId operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooksWebhookIdDeliveriesDeliveryId(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "user_id" -------------
-	var userId UUID
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId int
 
-	err = runtime.BindStyledParameter("simple", false, "user_id", c.Param("user_id"), &userId)
+	err = runtime.BindStyledParameter("simple", false, "webhook_id", c.Param("webhook_id"), &webhookId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhook_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "delivery_id" -------------
+	var deliveryId int64
+
+	err = runtime.BindStyledParameter("simple", false, "delivery_id", c.Param("delivery_id"), &deliveryId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter delivery_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
 	c.Set(ActorAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUsersUserIdSubscriptionsParams
+	var params GetWebhooksWebhookIdDeliveriesDeliveryIdParams
 
-	// ------------- Optional query parameter "limit" -------------
+	headers := c.Request.Header
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	// ------------- Optional header parameter "actor" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("actor")]; found {
+		var Actor Actor
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for actor, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "actor", runtime.ParamLocationHeader, valueList[0], &Actor)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter actor: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.Actor = &Actor
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetWebhooksWebhookIdDeliveriesDeliveryId(c, webhookId, deliveryId, params)
+}
+
+// PostWebhooksWebhookIdDeliveriesDeliveryIdRedeliver operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhooksWebhookIdDeliveriesDeliveryIdRedeliver(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId int
+
+	err = runtime.BindStyledParameter("simple", false, "webhook_id", c.Param("webhook_id"), &webhookId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhook_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "last_id" -------------
+	// ------------- Path parameter "delivery_id" -------------
+	var deliveryId int64
 
-	err = runtime.BindQueryParameter("form", true, false, "last_id", c.Request.URL.Query(), &params.LastId)
+	err = runtime.BindStyledParameter("simple", false, "delivery_id", c.Param("delivery_id"), &deliveryId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter last_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter delivery_id: %w", err), http.StatusBadRequest)
 		return
 	}
 
+	c.Set(ActorAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostWebhooksWebhookIdDeliveriesDeliveryIdRedeliverParams
+
 	headers := c.Request.Header
 
 	// ------------- Optional header parameter "actor" -------------
@@ -2883,7 +6920,7 @@ func (siw *ServerInterfaceWrapper) GetUsersUserIdSubscriptions(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetUsersUserIdSubscriptions(c, userId, params)
+	siw.Handler.PostWebhooksWebhookIdDeliveriesDeliveryIdRedeliver(c, webhookId, deliveryId, params)
 }
 
 // GinServerOptions provides options for the Gin server.
@@ -2913,38 +6950,86 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.POST(options.BaseURL+"/artists", wrapper.PostArtists)
+	router.GET(options.BaseURL+"/artists/:artist_id", wrapper.GetArtistsArtistId)
 	router.POST(options.BaseURL+"/auth/login", wrapper.PostAuthLogin)
 	router.POST(options.BaseURL+"/auth/logout", wrapper.PostAuthLogout)
 	router.POST(options.BaseURL+"/auth/register", wrapper.PostAuthRegister)
+	router.DELETE(options.BaseURL+"/calendar/feed", wrapper.DeleteCalendarFeed)
+	router.GET(options.BaseURL+"/calendar/feed", wrapper.GetCalendarFeed)
+	router.POST(options.BaseURL+"/calendar/feed", wrapper.PostCalendarFeed)
+	router.GET(options.BaseURL+"/calendar/feeds/:token", wrapper.GetCalendarFeedsToken)
+	router.DELETE(options.BaseURL+"/comments/:comment_id", wrapper.DeleteCommentsCommentId)
+	router.GET(options.BaseURL+"/comments/:comment_id", wrapper.GetCommentsCommentId)
+	router.PUT(options.BaseURL+"/comments/:comment_id", wrapper.PutCommentsCommentId)
 	router.GET(options.BaseURL+"/events", wrapper.GetEvents)
 	router.POST(options.BaseURL+"/events", wrapper.PostEvents)
 	router.GET(options.BaseURL+"/events/:event_id", wrapper.GetEventsEventId)
 	router.PUT(options.BaseURL+"/events/:event_id", wrapper.PutEventsEventId)
+	router.POST(options.BaseURL+"/events/:event_id/cancel", wrapper.PostEventsEventIdCancel)
+	router.GET(options.BaseURL+"/events/:event_id/ics", wrapper.GetEventsEventIdIcs)
+	router.DELETE(options.BaseURL+"/events/:event_id/participate", wrapper.DeleteEventsEventIdParticipate)
 	router.POST(options.BaseURL+"/events/:event_id/participate", wrapper.PostEventsEventIdParticipate)
+	router.GET(options.BaseURL+"/moderation/actions", wrapper.GetModerationActions)
+	router.GET(options.BaseURL+"/moderation/queue", wrapper.GetModerationQueue)
+	router.POST(options.BaseURL+"/moderation/queue/claim", wrapper.PostModerationQueueClaim)
+	router.POST(options.BaseURL+"/moderation/reviews/:review_id/approve", wrapper.PostModerationReviewsReviewIdApprove)
+	router.POST(options.BaseURL+"/moderation/reviews/:review_id/claim", wrapper.PostModerationReviewsReviewIdClaim)
+	router.POST(options.BaseURL+"/moderation/reviews/:review_id/reject", wrapper.PostModerationReviewsReviewIdReject)
+	router.POST(options.BaseURL+"/moderation/reviews/:review_id/release", wrapper.PostModerationReviewsReviewIdRelease)
 	router.POST(options.BaseURL+"/notes", wrapper.PostNotes)
 	router.DELETE(options.BaseURL+"/notes/:note_id", wrapper.DeleteNotesNoteId)
 	router.GET(options.BaseURL+"/notes/:note_id", wrapper.GetNotesNoteId)
 	router.PUT(options.BaseURL+"/notes/:note_id", wrapper.PutNotesNoteId)
+	router.GET(options.BaseURL+"/notifications", wrapper.GetNotifications)
+	router.GET(options.BaseURL+"/notifications/digest", wrapper.GetNotificationsDigest)
+	router.PUT(options.BaseURL+"/notifications/digest", wrapper.PutNotificationsDigest)
+	router.GET(options.BaseURL+"/notifications/preferences", wrapper.GetNotificationsPreferences)
+	router.PUT(options.BaseURL+"/notifications/preferences", wrapper.PutNotificationsPreferences)
+	router.POST(options.BaseURL+"/notifications/read", wrapper.PostNotificationsRead)
+	router.GET(options.BaseURL+"/notifications/stream", wrapper.GetNotificationsStream)
+	router.GET(options.BaseURL+"/notifications/unread_count", wrapper.GetNotificationsUnreadCount)
+	router.GET(options.BaseURL+"/notifications/ws", wrapper.GetNotificationsWs)
+	router.POST(options.BaseURL+"/notifications/:notification_id/read", wrapper.PostNotificationsNotificationIdRead)
 	router.POST(options.BaseURL+"/photos", wrapper.PostPhotos)
 	router.DELETE(options.BaseURL+"/photos/:photo_id", wrapper.DeletePhotosPhotoId)
 	router.GET(options.BaseURL+"/photos/:photo_id", wrapper.GetPhotosPhotoId)
+	router.GET(options.BaseURL+"/pieces", wrapper.GetPieces)
+	router.POST(options.BaseURL+"/pieces", wrapper.PostPieces)
+	router.GET(options.BaseURL+"/pieces/:piece_id", wrapper.GetPiecesPieceId)
+	router.PUT(options.BaseURL+"/pieces/:piece_id", wrapper.PutPiecesPieceId)
+	router.POST(options.BaseURL+"/pieces/:piece_id/aliases", wrapper.PostPiecesPieceIdAliases)
+	router.DELETE(options.BaseURL+"/pieces/:piece_id/ratings/me", wrapper.DeletePiecesPieceIdRatingsMe)
+	router.GET(options.BaseURL+"/pieces/:piece_id/ratings/me", wrapper.GetPiecesPieceIdRatingsMe)
+	router.PUT(options.BaseURL+"/pieces/:piece_id/ratings/me", wrapper.PutPiecesPieceIdRatingsMe)
 	router.GET(options.BaseURL+"/playlists/:playlist_id/notes", wrapper.GetPlaylistsPlaylistIdNotes)
 	router.DELETE(options.BaseURL+"/reactions/:reaction_id", wrapper.DeleteReactionsReactionId)
 	router.PUT(options.BaseURL+"/reactions/:reaction_id", wrapper.PutReactionsReactionId)
+	router.GET(options.BaseURL+"/reports", wrapper.GetReports)
+	router.POST(options.BaseURL+"/reports", wrapper.PostReports)
+	router.GET(options.BaseURL+"/reports/targets/:target_type/:target_id", wrapper.GetReportsTargetsTargetTypeTargetId)
+	router.POST(options.BaseURL+"/reports/targets/:target_type/:target_id/resolve", wrapper.PostReportsTargetsTargetTypeTargetIdResolve)
 	router.POST(options.BaseURL+"/reviews", wrapper.PostReviews)
 	router.GET(options.BaseURL+"/reviews/list", wrapper.GetReviewsList)
 	router.GET(options.BaseURL+"/reviews/subscriptions", wrapper.GetReviewsSubscriptions)
 	router.DELETE(options.BaseURL+"/reviews/:review_id", wrapper.DeleteReviewsReviewId)
 	router.GET(options.BaseURL+"/reviews/:review_id", wrapper.GetReviewsReviewId)
 	router.PUT(options.BaseURL+"/reviews/:review_id", wrapper.PutReviewsReviewId)
+	router.GET(options.BaseURL+"/reviews/:review_id/comments", wrapper.GetReviewsReviewIdComments)
+	router.POST(options.BaseURL+"/reviews/:review_id/comments", wrapper.PostReviewsReviewIdComments)
 	router.GET(options.BaseURL+"/reviews/:review_id/notes", wrapper.GetReviewsReviewIdNotes)
 	router.GET(options.BaseURL+"/reviews/:review_id/reactions", wrapper.GetReviewsReviewIdReactions)
 	router.POST(options.BaseURL+"/reviews/:review_id/reactions", wrapper.PostReviewsReviewIdReactions)
 	router.GET(options.BaseURL+"/reviews/:review_id/reactions/me", wrapper.GetReviewsReviewIdReactionsMe)
+	router.GET(options.BaseURL+"/reviews/:review_id/reactions/users", wrapper.GetReviewsReviewIdReactionsUsers)
+	router.GET(options.BaseURL+"/reviews/:review_id/revisions", wrapper.GetReviewsReviewIdRevisions)
+	router.GET(options.BaseURL+"/reviews/:review_id/revisions/diff", wrapper.GetReviewsReviewIdRevisionsDiff)
 	router.POST(options.BaseURL+"/subscriptions", wrapper.PostSubscriptions)
 	router.DELETE(options.BaseURL+"/subscriptions/:followed_id", wrapper.DeleteSubscriptionsFollowedId)
 	router.GET(options.BaseURL+"/subscriptions/:followed_id", wrapper.GetSubscriptionsFollowedId)
 	router.PUT(options.BaseURL+"/subscriptions/:followed_id", wrapper.PutSubscriptionsFollowedId)
+	router.GET(options.BaseURL+"/tags/trending", wrapper.GetTagsTrending)
+	router.GET(options.BaseURL+"/tags/:tag/reviews", wrapper.GetTagsTagReviews)
 	router.GET(options.BaseURL+"/tracks/:track_id/stats", wrapper.GetTracksTrackIdStats)
 	router.POST(options.BaseURL+"/users", wrapper.PostUsers)
 	router.GET(options.BaseURL+"/users/profiles", wrapper.GetUsersProfiles)
@@ -2953,7 +7038,16 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	router.POST(options.BaseURL+"/users/:user_id/block", wrapper.PostUsersUserIdBlock)
 	router.GET(options.BaseURL+"/users/:user_id/profile", wrapper.GetUsersUserIdProfile)
 	router.PUT(options.BaseURL+"/users/:user_id/profile", wrapper.PutUsersUserIdProfile)
+	router.GET(options.BaseURL+"/users/:user_id/ratings", wrapper.GetUsersUserIdRatings)
 	router.GET(options.BaseURL+"/users/:user_id/stats", wrapper.GetUsersUserIdStats)
 	router.GET(options.BaseURL+"/users/:user_id/subscribers", wrapper.GetUsersUserIdSubscribers)
 	router.GET(options.BaseURL+"/users/:user_id/subscriptions", wrapper.GetUsersUserIdSubscriptions)
+	router.GET(options.BaseURL+"/webhooks", wrapper.GetWebhooks)
+	router.POST(options.BaseURL+"/webhooks", wrapper.PostWebhooks)
+	router.DELETE(options.BaseURL+"/webhooks/:webhook_id", wrapper.DeleteWebhooksWebhookId)
+	router.GET(options.BaseURL+"/webhooks/:webhook_id", wrapper.GetWebhooksWebhookId)
+	router.PUT(options.BaseURL+"/webhooks/:webhook_id", wrapper.PutWebhooksWebhookId)
+	router.GET(options.BaseURL+"/webhooks/:webhook_id/deliveries", wrapper.GetWebhooksWebhookIdDeliveries)
+	router.GET(options.BaseURL+"/webhooks/:webhook_id/deliveries/:delivery_id", wrapper.GetWebhooksWebhookIdDeliveriesDeliveryId)
+	router.POST(options.BaseURL+"/webhooks/:webhook_id/deliveries/:delivery_id/redeliver", wrapper.PostWebhooksWebhookIdDeliveriesDeliveryIdRedeliver)
 }
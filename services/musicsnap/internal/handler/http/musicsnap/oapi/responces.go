@@ -1,6 +1,8 @@
 package oapi
 
 import (
+	"encoding/json"
+	"github.com/google/uuid"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 	"music-snap/services/musicsnap/internal/domain"
 )
@@ -8,6 +10,7 @@ import (
 func ToUserResponse(user domain.User) User {
 	roles := user.Roles.ToSlice()
 	email := (openapi_types.Email)(user.Email)
+	locale := UserLocale(user.Locale)
 	return User{
 		AvatarUrl:     &user.AvatarURL,
 		BackgroundUrl: &user.BackgroundURL,
@@ -15,6 +18,7 @@ func ToUserResponse(user domain.User) User {
 		CreatedAt:     &user.CreatedAt,
 		Email:         &email,
 		Id:            &user.ID,
+		Locale:        &locale,
 		Nickname:      &user.Nickname,
 		Roles:         &roles,
 	}
@@ -62,9 +66,11 @@ func ToIDPaginationResponse(pag domain.IDPagination) IDPagination {
 
 func ToSubscriptionResponse(subs domain.Subscription) Subscription {
 	pr := ToProfileResponse(subs.ProfileOfInterest)
+	flags := ToSubscriptionFlagsResponse(subs.NotificationFlags)
 	res := Subscription{
 		CreatedAt:         &subs.CreatedAt,
 		Id:                &subs.ID,
+		NotificationFlags: &flags,
 		ProfileOfInterest: &pr,
 		SubscribedToId:    &subs.FollowedID,
 		SubscriberId:      &subs.SubscriberID,
@@ -72,13 +78,23 @@ func ToSubscriptionResponse(subs domain.Subscription) Subscription {
 	return res
 }
 
+func ToSubscriptionFlagsResponse(flags domain.SubscriptionFlags) SubscriptionFlags {
+	return SubscriptionFlags{
+		NewReview:   &flags.NewReview,
+		NewPlaylist: &flags.NewPlaylist,
+		Event:       &flags.Event,
+	}
+}
+
 func ToSubsResponse(subs []domain.Subscription) []Subscription {
 	res := make([]Subscription, len(subs))
 	for i, s := range subs {
 		pr := ToProfileResponse(s.ProfileOfInterest)
+		flags := ToSubscriptionFlagsResponse(s.NotificationFlags)
 		res[i] = Subscription{
 			CreatedAt:         &s.CreatedAt,
 			Id:                &s.ID,
+			NotificationFlags: &flags,
 			ProfileOfInterest: &pr,
 		}
 	}
@@ -90,6 +106,12 @@ func ToReviewResponse(review domain.Review) Review {
 	if review.Profile != nil {
 		pr = ToProfileResponse(*review.Profile)
 	}
+	edited := review.Edited()
+	var myReaction *Reaction
+	if review.ActorReaction != nil {
+		reaction := ToReactionResponse(*review.ActorReaction)
+		myReaction = &reaction
+	}
 	return Review{
 		Id: &review.ID,
 
@@ -101,11 +123,22 @@ func ToReviewResponse(review domain.Review) Review {
 		Content:  &review.Content,
 		PhotoUrl: &review.PhotoURL,
 
-		Moderated: &review.Moderated,
-		Published: &review.Published,
+		Moderated:        &review.Moderated,
+		ModerationStatus: (*ModerationStatus)(&review.ModerationStatus),
+		ModerationReason: (*ModerationReasonCode)(review.ModerationReason),
+		Hidden:           &review.Hidden,
+		Published:        &review.Published,
+		PublishAt:        review.PublishAt,
+		PublishedAt:      review.PublishedAt,
 
 		CreatedAt: &review.CreatedAt,
 		UpdatedAt: &review.UpdatedAt,
+		Edited:    &edited,
+		EditedAt:  review.EditedAt,
+
+		CommentsCount:  &review.CommentsCount,
+		ReactionCounts: ToReactionCountsResponse(review.ReactionCounts),
+		MyReaction:     myReaction,
 	}
 }
 func ToReviewsResponse(reviews []domain.Review) []Review {
@@ -117,13 +150,13 @@ func ToReviewsResponse(reviews []domain.Review) []Review {
 }
 
 func ToReactionResponse(reaction domain.Reaction) Reaction {
-	reactT := ReactionType((string)(reaction.Type))
 	return Reaction{
 		Id:        &reaction.ID,
 		UserId:    &reaction.UserID,
 		ReviewId:  &reaction.ReviewID,
-		Type:      &reactT,
+		Type:      &reaction.Type,
 		CreatedAt: &reaction.CreatedAt,
+		UpdatedAt: &reaction.UpdatedAt,
 	}
 }
 
@@ -134,3 +167,446 @@ func ToReactionsResponse(reactions []domain.Reaction) []Reaction {
 	}
 	return res
 }
+
+// ToReactionCountsResponse omits counts which were not loaded
+func ToReactionCountsResponse(counts domain.ReactionCounts) *ReactionCounts {
+	if counts == nil {
+		return nil
+	}
+	res := ReactionCounts(counts)
+	return &res
+}
+
+func ToArtistResponse(artist domain.Artist) Artist {
+	return Artist{
+		Id:        &artist.ID,
+		Name:      &artist.Name,
+		CreatedAt: &artist.CreatedAt,
+		UpdatedAt: &artist.UpdatedAt,
+	}
+}
+
+func ToPieceAliasResponse(alias domain.PieceAlias) PieceAlias {
+	return PieceAlias{
+		Source:     &alias.Source,
+		ExternalId: &alias.ExternalID,
+		PieceId:    &alias.PieceID,
+		CreatedAt:  &alias.CreatedAt,
+	}
+}
+
+func ToPieceResponse(piece domain.Piece) Piece {
+	aliases := make([]PieceAlias, len(piece.Aliases))
+	for i, a := range piece.Aliases {
+		aliases[i] = ToPieceAliasResponse(a)
+	}
+
+	res := Piece{
+		Id:        &piece.ID,
+		Type:      &piece.Type,
+		Aliases:   &aliases,
+		CreatedAt: &piece.CreatedAt,
+		UpdatedAt: &piece.UpdatedAt,
+	}
+
+	if t := piece.Track; t != nil {
+		track := TrackDetails{
+			Title:      &t.Title,
+			DurationMs: &t.DurationMs,
+			Explicit:   &t.Explicit,
+			ArtistId:   &t.ArtistID,
+		}
+		if t.Artist != nil {
+			artist := ToArtistResponse(*t.Artist)
+			track.Artist = &artist
+		}
+		if t.AlbumID != uuid.Nil {
+			track.AlbumId = &t.AlbumID
+		}
+		res.Track = &track
+	}
+
+	if a := piece.Album; a != nil {
+		album := AlbumDetails{
+			Title:    &a.Title,
+			CoverUrl: &a.CoverURL,
+			ArtistId: &a.ArtistID,
+		}
+		if a.Artist != nil {
+			artist := ToArtistResponse(*a.Artist)
+			album.Artist = &artist
+		}
+		if !a.ReleaseDate.IsZero() {
+			album.ReleaseDate = &a.ReleaseDate
+		}
+		res.Album = &album
+	}
+
+	return res
+}
+
+func ToPiecesResponse(pieces []domain.Piece) []Piece {
+	res := make([]Piece, len(pieces))
+	for i, p := range pieces {
+		res[i] = ToPieceResponse(p)
+	}
+	return res
+}
+
+func ToTrackStatsResponse(stats domain.TrackStats) TrackStats {
+	histogram := stats.Histogram[:]
+	return TrackStats{
+		PieceId:            &stats.PieceID,
+		TotalRatingsCount:  &stats.TotalRatingsCount,
+		TotalReviewsCount:  &stats.TotalReviewsCount,
+		AverageRating:      &stats.AverageRating,
+		MedianRating:       &stats.MedianRating,
+		Histogram:          &histogram,
+		TotalLikesCount:    &stats.TotalLikesCount,
+		TotalDislikesCount: &stats.TotalDislikesCount,
+		TotalCommentsCount: &stats.TotalNotesCount,
+	}
+}
+
+func ToRatingResponse(rating domain.Rating) Rating {
+	return Rating{
+		Id:        &rating.ID,
+		UserId:    &rating.UserID,
+		PieceId:   &rating.PieceID,
+		Rating:    rating.Rating,
+		CreatedAt: &rating.CreatedAt,
+		UpdatedAt: &rating.UpdatedAt,
+	}
+}
+
+func ToRatingsResponse(ratings []domain.Rating) []Rating {
+	res := make([]Rating, len(ratings))
+	for i, r := range ratings {
+		res[i] = ToRatingResponse(r)
+	}
+	return res
+}
+
+func ToReviewRevisionResponse(rev domain.ReviewRevision) ReviewRevision {
+	return ReviewRevision{
+		ReviewId:  &rev.ReviewID,
+		Revision:  &rev.Revision,
+		EditorId:  &rev.EditorID,
+		PieceId:   &rev.PieceID,
+		Rating:    &rev.Rating,
+		Content:   &rev.Content,
+		PhotoUrl:  &rev.PhotoURL,
+		CreatedAt: &rev.CreatedAt,
+	}
+}
+
+func ToReviewRevisionsResponse(revisions []domain.ReviewRevision) []ReviewRevision {
+	res := make([]ReviewRevision, len(revisions))
+	for i, r := range revisions {
+		res[i] = ToReviewRevisionResponse(r)
+	}
+	return res
+}
+
+func ToReviewRevisionDiffResponse(diff domain.ReviewRevisionDiff) ReviewRevisionDiff {
+	chunks := make([]DiffChunk, len(diff.Chunks))
+	for i, ch := range diff.Chunks {
+		op := DiffChunkOp(ch.Op)
+		text := ch.Text
+		chunks[i] = DiffChunk{Op: &op, Text: &text}
+	}
+	from := ToReviewRevisionResponse(diff.From)
+	to := ToReviewRevisionResponse(diff.To)
+	return ReviewRevisionDiff{
+		From:   &from,
+		To:     &to,
+		Chunks: &chunks,
+	}
+}
+
+func ToModerationQueueItemResponse(item domain.ModerationQueueItem) ModerationQueueItem {
+	review := ToReviewResponse(item.Review)
+	reason := ModerationQueueReason(item.Reason)
+	return ModerationQueueItem{
+		Review:     &review,
+		Reason:     &reason,
+		EnqueuedAt: &item.EnqueuedAt,
+		ClaimedBy:  item.ClaimedBy,
+		ClaimedAt:  item.ClaimedAt,
+	}
+}
+
+func ToModerationQueueResponse(items []domain.ModerationQueueItem) []ModerationQueueItem {
+	res := make([]ModerationQueueItem, len(items))
+	for i, item := range items {
+		res[i] = ToModerationQueueItemResponse(item)
+	}
+	return res
+}
+
+func ToModerationActionResponse(action domain.ModerationAction) ModerationAction {
+	actionType := ModerationActionAction(action.Action)
+	return ModerationAction{
+		Id:          &action.ID,
+		ReviewId:    &action.ReviewID,
+		ModeratorId: &action.ModeratorID,
+		Action:      &actionType,
+		ReasonCode:  (*ModerationReasonCode)(action.ReasonCode),
+		Comment:     &action.Comment,
+		CreatedAt:   &action.CreatedAt,
+	}
+}
+
+func ToModerationActionsResponse(actions []domain.ModerationAction) []ModerationAction {
+	res := make([]ModerationAction, len(actions))
+	for i, a := range actions {
+		res[i] = ToModerationActionResponse(a)
+	}
+	return res
+}
+
+func ToReportResponse(report domain.Report) Report {
+	status := ReportStatus(report.Status)
+	var reporterID *UUID
+	if !report.ByFilter() {
+		reporterID = &report.ReporterID
+	}
+	return Report{
+		Id:         &report.ID,
+		ReporterId: reporterID,
+		TargetType: ReportTargetType(report.TargetType),
+		TargetId:   report.TargetID,
+		Reason:     ModerationReasonCode(report.Reason),
+		Comment:    &report.Comment,
+		Status:     &status,
+		ResolvedBy: report.ResolvedBy,
+		ResolvedAt: report.ResolvedAt,
+		CreatedAt:  &report.CreatedAt,
+		UpdatedAt:  &report.UpdatedAt,
+	}
+}
+
+func ToReportsResponse(reports []domain.Report) []Report {
+	res := make([]Report, len(reports))
+	for i, r := range reports {
+		res[i] = ToReportResponse(r)
+	}
+	return res
+}
+
+func ToReportTargetResponse(target domain.ReportTarget) ReportTarget {
+	targetType := ReportTargetType(target.TargetType)
+	hidden := target.Hidden()
+	return ReportTarget{
+		TargetType:  &targetType,
+		TargetId:    &target.TargetID,
+		OpenReports: &target.OpenReports,
+		Hidden:      &hidden,
+		HiddenAt:    target.HiddenAt,
+	}
+}
+
+func ToCommentResponse(comment domain.Comment) Comment {
+	deleted := comment.Deleted()
+	return Comment{
+		Id:        &comment.ID,
+		ReviewId:  &comment.ReviewID,
+		UserId:    &comment.UserID,
+		ParentId:  comment.ParentID,
+		Depth:     &comment.Depth,
+		Text:      comment.Text,
+		Deleted:   &deleted,
+		EditedAt:  comment.EditedAt,
+		DeletedAt: comment.DeletedAt,
+		CreatedAt: &comment.CreatedAt,
+		UpdatedAt: &comment.UpdatedAt,
+	}
+}
+
+func ToCommentsResponse(comments []domain.Comment) []Comment {
+	res := make([]Comment, len(comments))
+	for i, comment := range comments {
+		res[i] = ToCommentResponse(comment)
+	}
+	return res
+}
+
+func ToTagTrendsResponse(trends []domain.TagTrend) []TagTrend {
+	res := make([]TagTrend, len(trends))
+	for i, trend := range trends {
+		res[i] = TagTrend{Tag: trend.Tag, Uses: trend.Uses}
+	}
+	return res
+}
+
+func ToNotificationResponse(notification domain.Notification) Notification {
+	message := notification.Message
+	if message == nil {
+		message = map[string]interface{}{}
+	}
+	return Notification{
+		Id:        &notification.ID,
+		UserId:    &notification.UserIDReceiver,
+		SenderId:  notification.UserIDSender,
+		Type:      &notification.Type,
+		Message:   &message,
+		Version:   &notification.Version,
+		Text:      &notification.Text,
+		Read:      &notification.Read,
+		Count:     &notification.Count,
+		CreatedAt: &notification.CreatedAt,
+	}
+}
+
+func ToNotificationsResponse(notifications []domain.Notification) []Notification {
+	res := make([]Notification, len(notifications))
+	for i, notification := range notifications {
+		res[i] = ToNotificationResponse(notification)
+	}
+	return res
+}
+
+func ToDigestSettingsResponse(settings domain.DigestSettings) DigestSettings {
+	frequency := DigestFrequency(settings.Frequency)
+	return DigestSettings{
+		Frequency:  &frequency,
+		LastSentAt: settings.LastSentAt,
+	}
+}
+
+func ToStreamEventResponse(event domain.StreamEvent) (StreamEvent, error) {
+	payload := map[string]interface{}{}
+	if len(event.Payload) > 0 {
+		err := json.Unmarshal(event.Payload, &payload)
+		if err != nil {
+			return StreamEvent{}, err
+		}
+	}
+	kind := StreamEventKind(event.Kind)
+	return StreamEvent{
+		Id:        &event.ID,
+		Kind:      &kind,
+		Payload:   &payload,
+		CreatedAt: &event.CreatedAt,
+	}, nil
+}
+
+// ToWebhookResponse omits secret, it is set only on creation
+func ToWebhookResponse(webhook domain.Webhook) Webhook {
+	eventTypes := make([]WebhookEventType, len(webhook.EventTypes))
+	for i, t := range webhook.EventTypes {
+		eventTypes[i] = WebhookEventType(t)
+	}
+	res := Webhook{
+		Id:         &webhook.ID,
+		UserId:     webhook.UserID,
+		Url:        &webhook.URL,
+		EventTypes: &eventTypes,
+		Active:     &webhook.Active,
+		CreatedAt:  &webhook.CreatedAt,
+		UpdatedAt:  &webhook.UpdatedAt,
+	}
+	if webhook.Secret != "" {
+		res.Secret = &webhook.Secret
+	}
+	return res
+}
+
+func ToWebhooksResponse(webhooks []domain.Webhook) []Webhook {
+	res := make([]Webhook, len(webhooks))
+	for i, w := range webhooks {
+		res[i] = ToWebhookResponse(w)
+	}
+	return res
+}
+
+// ToWebhookDeliveryResponse includes log of attempts when it was loaded
+func ToWebhookDeliveryResponse(delivery domain.WebhookDelivery) (WebhookDelivery, error) {
+	payload := map[string]interface{}{}
+	if len(delivery.Payload) > 0 {
+		err := json.Unmarshal(delivery.Payload, &payload)
+		if err != nil {
+			return WebhookDelivery{}, err
+		}
+	}
+	eventType := WebhookEventType(delivery.EventType)
+	status := WebhookDeliveryStatus(delivery.Status)
+	res := WebhookDelivery{
+		Id:            &delivery.ID,
+		WebhookId:     &delivery.WebhookID,
+		EventId:       &delivery.EventID,
+		EventType:     &eventType,
+		Payload:       &payload,
+		Status:        &status,
+		Attempts:      &delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt,
+		DeliveredAt:   delivery.DeliveredAt,
+		CreatedAt:     &delivery.CreatedAt,
+	}
+	if delivery.Log != nil {
+		log := make([]WebhookAttempt, len(delivery.Log))
+		for i, a := range delivery.Log {
+			a := a
+			durationMs := int(a.Duration.Milliseconds())
+			log[i] = WebhookAttempt{
+				StatusCode:  &a.StatusCode,
+				Error:       &a.Error,
+				DurationMs:  &durationMs,
+				AttemptedAt: &a.AttemptedAt,
+			}
+		}
+		res.Log = &log
+	}
+	return res, nil
+}
+
+func ToWebhookDeliveriesResponse(deliveries []domain.WebhookDelivery) ([]WebhookDelivery, error) {
+	res := make([]WebhookDelivery, len(deliveries))
+	for i, d := range deliveries {
+		var err error
+		res[i], err = ToWebhookDeliveryResponse(d)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func ToEventResponse(event domain.Event) Event {
+	res := Event{
+		Id:                &event.ID,
+		Name:              &event.Name,
+		Date:              &event.Date,
+		Text:              &event.Text,
+		PhotoUrl:          &event.PhotoURL,
+		PhotosUrls:        &event.PhotosURLs,
+		TicketLink:        &event.TicketLink,
+		LocationLink:      &event.LocationLink,
+		Location:          &event.Location,
+		Authors:           &event.Authors,
+		ParticipantsCount: &event.ParticipantsCount,
+		Sequence:          &event.Sequence,
+		CancelledAt:       event.CancelledAt,
+		CreatedAt:         &event.CreatedAt,
+		UpdatedAt:         &event.UpdatedAt,
+	}
+	if event.Participants != nil {
+		res.Participants = &event.Participants
+	}
+	return res
+}
+
+func ToEventsResponse(events []domain.Event) []Event {
+	res := make([]Event, len(events))
+	for i, e := range events {
+		res[i] = ToEventResponse(e)
+	}
+	return res
+}
+
+func ToCalendarFeedResponse(feed domain.CalendarFeed) CalendarFeed {
+	return CalendarFeed{
+		Url:       &feed.URL,
+		CreatedAt: &feed.CreatedAt,
+	}
+}
@@ -0,0 +1,125 @@
+package musicsnap
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/handler/http/musicsnap/oapi"
+	"net/http"
+)
+
+func (h MusicsnapHandler) PutPiecesPieceIdRatingsMe(c *gin.Context, pieceId string, params oapi.PutPiecesPieceIdRatingsMeParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PutPiecesPieceIdRatingsMe"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PutPiecesPieceIdRatingsMeJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+
+	ratingPayload, err := payload.ToDomain()
+	if err != nil {
+		h.abortWithAutoResponse(c, app.NewError(http.StatusBadRequest, "invalid request body", "request body", err))
+		return
+	}
+	ratingPayload.UserID = actor.ID
+	ratingPayload.PieceID = pieceId
+
+	rating, err := h.s.Rating.SetRating(ctx, actor, ratingPayload)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToRatingResponse(rating)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h MusicsnapHandler) GetPiecesPieceIdRatingsMe(c *gin.Context, pieceId string, params oapi.GetPiecesPieceIdRatingsMeParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetPiecesPieceIdRatingsMe"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	rating, err := h.s.Rating.GetRating(ctx, actor, actor.ID, pieceId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToRatingResponse(rating)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h MusicsnapHandler) DeletePiecesPieceIdRatingsMe(c *gin.Context, pieceId string, params oapi.DeletePiecesPieceIdRatingsMeParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("DeletePiecesPieceIdRatingsMe"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	rating, err := h.s.Rating.DeleteRating(ctx, actor, actor.ID, pieceId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToRatingResponse(rating)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h MusicsnapHandler) GetUsersUserIdRatings(c *gin.Context, userId oapi.UUID, params oapi.GetUsersUserIdRatingsParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetUsersUserIdRatings"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	pagination := oapi.ToUUIDPaginationDomain(params.Limit, params.LastUuid)
+
+	ratings, pagination, err := h.s.Rating.ListUserRatings(ctx, actor, userId, pagination)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	type Response struct {
+		Ratings    []oapi.Rating       `json:"ratings"`
+		Pagination oapi.UUIDPagination `json:"pagination"`
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Ratings:    oapi.ToRatingsResponse(ratings),
+		Pagination: oapi.ToUUIDPaginationResponse(pagination),
+	})
+}
@@ -27,20 +27,78 @@ func (h MusicsnapHandler) PostReviewsReviewIdReactions(c *gin.Context, reviewId
 	var payload oapi.PostReviewsReviewIdReactionsJSONRequestBody
 	h.bindRequestBody(c, &payload)
 
-	reactionPayload, err := payload.ToDomain()
+	reactionPayload, err := payload.ToDomain(reviewId)
 	if err != nil {
 		h.abortWithAutoResponse(c, app.NewError(http.StatusBadRequest, "invalid request body", "request body", err))
 		return
 	}
 
-	reviewReaction, err := h.s.Reaction.CreateReaction(ctx, actor, reactionPayload)
+	reviewReaction, removed, err := h.s.Reaction.ToggleReaction(ctx, actor, reactionPayload)
 	if err != nil {
 		h.abortWithAutoResponse(c, err)
 		return
 	}
+	if removed {
+		c.Status(http.StatusNoContent)
+		return
+	}
 	resp := oapi.ToReactionResponse(reviewReaction)
 	c.JSON(http.StatusOK, resp)
 }
+
+func (h MusicsnapHandler) GetReviewsReviewIdReactions(c *gin.Context, reviewId int, params oapi.GetReviewsReviewIdReactionsParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetReviewsReviewIdReactions"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	counts, err := h.s.Reaction.CountReactions(ctx, actor, reviewId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, oapi.ToReactionCountsResponse(counts))
+}
+
+func (h MusicsnapHandler) GetReviewsReviewIdReactionsUsers(c *gin.Context, reviewId int, params oapi.GetReviewsReviewIdReactionsUsersParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetReviewsReviewIdReactionsUsers"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	pagination := oapi.ToIDPaginationDomain(params.Limit, params.LastId)
+	filter := domain.ReactionFilter{ReviewID: reviewId, Type: params.Type}
+
+	reactions, pagination, err := h.s.Reaction.ListReactions(ctx, actor, filter, pagination)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	type Response struct {
+		Reactions  []oapi.Reaction   `json:"reactions"`
+		Pagination oapi.IDPagination `json:"pagination"`
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Reactions:  oapi.ToReactionsResponse(reactions),
+		Pagination: oapi.ToIDPaginationResponse(pagination),
+	})
+}
 func (h MusicsnapHandler) PutReactionsReactionId(c *gin.Context, reactionId int, params oapi.PutReactionsReactionIdParams) {
 	tr := global.Tracer(domain.ServiceName)
 	ctxTrace, span := tr.Start(c, h.spanName("PutReactionsReactionId"))
@@ -58,10 +116,6 @@ func (h MusicsnapHandler) PutReactionsReactionId(c *gin.Context, reactionId int,
 		h.abortWithAutoResponse(c, app.NewError(http.StatusBadRequest, "invalid request params", "reaction type is required", nil))
 		return
 	}
-	if params.Type != domain.DislikeReaction && params.Type != domain.LikeReaction {
-		h.abortWithAutoResponse(c, app.NewError(http.StatusBadRequest, "invalid request params", "reaction type must be like or dislike", nil))
-		return
-	}
 
 	reaction := domain.Reaction{ID: reactionId, Type: params.Type}
 
@@ -0,0 +1,125 @@
+package musicsnap
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/handler/http/musicsnap/oapi"
+	"net/http"
+)
+
+func (h MusicsnapHandler) PostReports(c *gin.Context, params oapi.PostReportsParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostReports"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PostReportsJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+
+	report, err := h.s.Report.CreateReport(ctx, actor, payload.ToDomain())
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToReportResponse(report)
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h MusicsnapHandler) GetReports(c *gin.Context, params oapi.GetReportsParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetReports"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	pagination := oapi.ToIDPaginationDomain(params.Limit, params.LastId)
+
+	reports, pagination, err := h.s.Report.ListReports(ctx, actor, params.ToDomain(), pagination)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	type Response struct {
+		Reports    []oapi.Report     `json:"reports"`
+		Pagination oapi.IDPagination `json:"pagination"`
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Reports:    oapi.ToReportsResponse(reports),
+		Pagination: oapi.ToIDPaginationResponse(pagination),
+	})
+}
+
+func (h MusicsnapHandler) GetReportsTargetsTargetTypeTargetId(c *gin.Context, targetType oapi.ReportTargetType, targetId string, params oapi.GetReportsTargetsTargetTypeTargetIdParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetReportsTargetsTargetTypeTargetId"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	target, err := h.s.Report.GetReportTarget(ctx, actor, domain.ReportTargetType(targetType), targetId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToReportTargetResponse(target)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h MusicsnapHandler) PostReportsTargetsTargetTypeTargetIdResolve(c *gin.Context, targetType oapi.ReportTargetType, targetId string, params oapi.PostReportsTargetsTargetTypeTargetIdResolveParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostReportsTargetsTargetTypeTargetIdResolve"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PostReportsTargetsTargetTypeTargetIdResolveJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+
+	resolved, err := h.s.Report.ResolveReports(ctx, actor, domain.ReportTargetType(targetType), targetId,
+		domain.ReportStatus(payload.Status))
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	type Response struct {
+		Resolved int `json:"resolved"`
+	}
+
+	c.JSON(http.StatusOK, Response{Resolved: resolved})
+}
@@ -197,3 +197,49 @@ func (h MusicsnapHandler) PutReviewsReviewId(c *gin.Context, reviewId int, param
 	resp := oapi.ToReviewResponse(reviewUpdated)
 	c.JSON(http.StatusOK, resp)
 }
+
+func (h MusicsnapHandler) GetReviewsReviewIdRevisions(c *gin.Context, reviewId int, params oapi.GetReviewsReviewIdRevisionsParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetReviewsReviewIdRevisions"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	revisions, err := h.s.Review.ListRevisions(ctx, actor, reviewId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToReviewRevisionsResponse(revisions)
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h MusicsnapHandler) GetReviewsReviewIdRevisionsDiff(c *gin.Context, reviewId int, params oapi.GetReviewsReviewIdRevisionsDiffParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetReviewsReviewIdRevisionsDiff"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	diff, err := h.s.Review.DiffRevisions(ctx, actor, reviewId, params.From, params.To)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp := oapi.ToReviewRevisionDiffResponse(diff)
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,177 @@
+package musicsnap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/handler/http/musicsnap/oapi"
+	"net/http"
+	"time"
+)
+
+const (
+	// streamWriteTimeout limits one write to client, stream itself outlives write timeout of server
+	streamWriteTimeout = 10 * time.Second
+	// wsReadLimit is enough for control frames, client does not send messages
+	wsReadLimit = 512
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+func (h MusicsnapHandler) GetNotificationsStream(c *gin.Context, params oapi.GetNotificationsStreamParams) {
+	tr := global.Tracer(domain.ServiceName)
+	// контекст запроса отменяется при отключении клиента, в отличие от gin.Context
+	ctxTrace, span := tr.Start(c.Request.Context(), h.spanName("GetNotificationsStream"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	lastEventID, err := oapi.ToLastEventIDDomain(params.LastEventID, params.LastEventId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	rc := http.NewResponseController(c.Writer)
+	err = rc.SetWriteDeadline(time.Time{})
+	if err != nil {
+		h.abortWithAutoResponse(c, app.NewError(http.StatusInternalServerError, "streaming is not supported",
+			"can't reset write deadline of response", err))
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	err = h.s.Stream.Stream(ctx, actor, lastEventID, sseSink{w: c.Writer, rc: rc})
+	if err != nil {
+		zapctx.Logger(ctx).Debug("event stream finished with error", zap.Error(err))
+	}
+}
+
+func (h MusicsnapHandler) GetNotificationsWs(c *gin.Context, params oapi.GetNotificationsWsParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c.Request.Context(), h.spanName("GetNotificationsWs"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	lastEventID, err := oapi.ToLastEventIDDomain(nil, params.LastEventId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	// при ошибке рукопожатия upgrader сам отвечает клиенту
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		zapctx.Logger(ctx).Debug("websocket handshake failed", zap.Error(err))
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// чтение обрабатывает pong и close от клиента, обрыв соединения завершает поток
+	conn.SetReadLimit(wsReadLimit)
+	go func() {
+		defer cancel()
+		for {
+			_, _, err := conn.NextReader()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	closeCode := websocket.CloseGoingAway
+	err = h.s.Stream.Stream(ctx, actor, lastEventID, wsSink{conn: conn})
+	if err != nil {
+		zapctx.Logger(ctx).Debug("websocket stream finished with error", zap.Error(err))
+		closeCode = websocket.CloseInternalServerErr
+	}
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(closeCode, ""),
+		time.Now().Add(streamWriteTimeout))
+}
+
+// sseSink writes Server-Sent Events, heartbeat is a comment ignored by EventSource
+type sseSink struct {
+	w  gin.ResponseWriter
+	rc *http.ResponseController
+}
+
+func (s sseSink) Send(event domain.StreamEvent) error {
+	res, err := oapi.ToStreamEventResponse(event)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	return s.write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Kind, data))
+}
+
+func (s sseSink) Heartbeat() error {
+	return s.write(": heartbeat\n\n")
+}
+
+func (s sseSink) write(message string) error {
+	err := s.rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	if err != nil {
+		return err
+	}
+	_, err = s.w.WriteString(message)
+	if err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+// wsSink writes every event as text message, heartbeat is a ping frame
+type wsSink struct {
+	conn *websocket.Conn
+}
+
+func (s wsSink) Send(event domain.StreamEvent) error {
+	res, err := oapi.ToStreamEventResponse(event)
+	if err != nil {
+		return err
+	}
+	err = s.conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	if err != nil {
+		return err
+	}
+	return s.conn.WriteJSON(res)
+}
+
+func (s wsSink) Heartbeat() error {
+	return s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout))
+}
@@ -195,15 +195,11 @@ func (h MusicsnapHandler) PutSubscriptionsFollowedId(c *gin.Context, followedId
 	h.bindRequestBody(c, &payload)
 
 	newSub := domain.Subscription{
-		SubscriberID: actor.ID,
-		FollowedID:   followedId,
+		SubscriberID:      actor.ID,
+		FollowedID:        followedId,
+		NotificationFlags: payload.NotificationFlags.ToDomain(),
 	}
 
-	if payload.NotificationFlag == nil {
-		h.abortWithAutoResponse(c, app.NewError(http.StatusBadRequest, "invalid request body notification flag is empty", "request body notification flag shouldn't be nil", err))
-	}
-	newSub.NotificationFlag = *payload.NotificationFlag
-
 	subscription, err := h.s.Subscription.Update(ctx, actor, newSub)
 	if err != nil {
 		h.abortWithAutoResponse(c, err)
@@ -0,0 +1,79 @@
+package musicsnap
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/handler/http/musicsnap/oapi"
+	"net/http"
+)
+
+func (h MusicsnapHandler) GetTagsTagReviews(c *gin.Context, tag string, params oapi.GetTagsTagReviewsParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetTagsTagReviews"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	filter, err := params.ToDomain(tag)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	pag := oapi.ToIDPaginationDomain(params.Limit, params.LastId)
+
+	reviews, pag, err := h.s.Review.ListReviews(ctx, actor, filter, pag)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	type Response struct {
+		Reviews    []oapi.Review     `json:"reviews"`
+		Pagination oapi.IDPagination `json:"pagination"`
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Reviews:    oapi.ToReviewsResponse(reviews),
+		Pagination: oapi.ToIDPaginationResponse(pag),
+	})
+}
+
+func (h MusicsnapHandler) GetTagsTrending(c *gin.Context, params oapi.GetTagsTrendingParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetTagsTrending"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var limit int
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	trends, err := h.s.Tag.TrendingTags(ctx, actor, limit)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	type Response struct {
+		Tags []oapi.TagTrend `json:"tags"`
+	}
+
+	c.JSON(http.StatusOK, Response{Tags: oapi.ToTagTrendsResponse(trends)})
+}
@@ -0,0 +1,226 @@
+package musicsnap
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/handler/http/musicsnap/oapi"
+	"net/http"
+)
+
+func (h MusicsnapHandler) GetWebhooks(c *gin.Context, params oapi.GetWebhooksParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetWebhooks"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	webhooks, err := h.s.Webhook.ListWebhooks(ctx, actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oapi.ToWebhooksResponse(webhooks))
+}
+
+func (h MusicsnapHandler) PostWebhooks(c *gin.Context, params oapi.PostWebhooksParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostWebhooks"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PostWebhooksJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+
+	webhook, err := h.s.Webhook.CreateWebhook(ctx, actor, oapi.PostWebhooksJSONBody(payload).ToDomain(actor.ID))
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, oapi.ToWebhookResponse(webhook))
+}
+
+func (h MusicsnapHandler) GetWebhooksWebhookId(c *gin.Context, webhookId int, params oapi.GetWebhooksWebhookIdParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetWebhooksWebhookId"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	webhook, err := h.s.Webhook.GetWebhook(ctx, actor, webhookId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oapi.ToWebhookResponse(webhook))
+}
+
+func (h MusicsnapHandler) PutWebhooksWebhookId(c *gin.Context, webhookId int, params oapi.PutWebhooksWebhookIdParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PutWebhooksWebhookId"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	var payload oapi.PutWebhooksWebhookIdJSONRequestBody
+	if !h.bindRequestBody(c, &payload) {
+		return
+	}
+
+	webhook, err := h.s.Webhook.UpdateWebhook(ctx, actor, oapi.PutWebhooksWebhookIdJSONBody(payload).ToDomain(webhookId))
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, oapi.ToWebhookResponse(webhook))
+}
+
+func (h MusicsnapHandler) DeleteWebhooksWebhookId(c *gin.Context, webhookId int, params oapi.DeleteWebhooksWebhookIdParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("DeleteWebhooksWebhookId"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	err = h.s.Webhook.DeleteWebhook(ctx, actor, webhookId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h MusicsnapHandler) GetWebhooksWebhookIdDeliveries(c *gin.Context, webhookId int, params oapi.GetWebhooksWebhookIdDeliveriesParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetWebhooksWebhookIdDeliveries"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	pagination := oapi.ToIDPaginationDomain(params.Limit, params.LastId)
+	filter := domain.WebhookDeliveryFilter{WebhookID: webhookId, Status: (*string)(params.Status)}
+
+	deliveries, pagination, err := h.s.Webhook.ListDeliveries(ctx, actor, filter, pagination)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp, err := oapi.ToWebhookDeliveriesResponse(deliveries)
+	if err != nil {
+		h.abortWithAutoResponse(c, app.NewError(http.StatusInternalServerError, "unknown error", "failed to decode delivery payload", err))
+		return
+	}
+
+	type Response struct {
+		Deliveries []oapi.WebhookDelivery `json:"deliveries"`
+		Pagination oapi.IDPagination      `json:"pagination"`
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Deliveries: resp,
+		Pagination: oapi.ToIDPaginationResponse(pagination),
+	})
+}
+
+func (h MusicsnapHandler) GetWebhooksWebhookIdDeliveriesDeliveryId(c *gin.Context, webhookId int, deliveryId int64, params oapi.GetWebhooksWebhookIdDeliveriesDeliveryIdParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("GetWebhooksWebhookIdDeliveriesDeliveryId"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	delivery, err := h.s.Webhook.GetDelivery(ctx, actor, webhookId, deliveryId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp, err := oapi.ToWebhookDeliveryResponse(delivery)
+	if err != nil {
+		h.abortWithAutoResponse(c, app.NewError(http.StatusInternalServerError, "unknown error", "failed to decode delivery payload", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h MusicsnapHandler) PostWebhooksWebhookIdDeliveriesDeliveryIdRedeliver(c *gin.Context, webhookId int, deliveryId int64, params oapi.PostWebhooksWebhookIdDeliveriesDeliveryIdRedeliverParams) {
+	tr := global.Tracer(domain.ServiceName)
+	ctxTrace, span := tr.Start(c, h.spanName("PostWebhooksWebhookIdDeliveriesDeliveryIdRedeliver"))
+	defer span.End()
+
+	ctx := zapctx.WithLogger(ctxTrace, h.logger)
+
+	actor, err := h.ReceiveActor(ctx, params.Actor)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	delivery, err := h.s.Webhook.Redeliver(ctx, actor, webhookId, deliveryId)
+	if err != nil {
+		h.abortWithAutoResponse(c, err)
+		return
+	}
+
+	resp, err := oapi.ToWebhookDeliveryResponse(delivery)
+	if err != nil {
+		h.abortWithAutoResponse(c, app.NewError(http.StatusInternalServerError, "unknown error", "failed to decode delivery payload", err))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, resp)
+}
@@ -0,0 +1,133 @@
+package postgre
+
+import (
+	c "context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/repository/postgre/models"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+)
+
+var _ ports.CalendarFeedRepository = &calendarFeedRepository{}
+
+func NewCalendarFeedRepository(db *sqlx.DB) ports.CalendarFeedRepository {
+	return &calendarFeedRepository{db: db,
+		spanName: spanBaseName + "calendarFeedRepository."}
+}
+
+func newCalendarFeedRepository(db *sqlx.DB) calendarFeedRepository {
+	return calendarFeedRepository{db: db,
+		spanName: spanBaseName + "calendarFeedRepository."}
+}
+
+type calendarFeedRepository struct {
+	db       *sqlx.DB
+	spanName string
+}
+
+func (r calendarFeedRepository) Get(ctx c.Context, userID uuid.UUID) (domain.CalendarFeed, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Get")
+	defer span.End()
+
+	q := `
+	SELECT * FROM calendar_feeds
+	WHERE user_id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var feed models.CalendarFeedModel
+	err := r.db.GetContext(ctx, &feed, q, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.CalendarFeed{}, app.NewError(http.StatusNotFound, "calendar feed not found",
+				"user has no calendar feed", err)
+		}
+		return domain.CalendarFeed{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return feed.ToDomain(), nil
+}
+
+func (r calendarFeedRepository) GetByToken(ctx c.Context, token string) (domain.CalendarFeed, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"GetByToken")
+	defer span.End()
+
+	q := `
+	SELECT * FROM calendar_feeds
+	WHERE token = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var feed models.CalendarFeedModel
+	err := r.db.GetContext(ctx, &feed, q, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.CalendarFeed{}, app.NewError(http.StatusNotFound, "calendar feed not found",
+				"calendar feed with given token does not exist", err)
+		}
+		return domain.CalendarFeed{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return feed.ToDomain(), nil
+}
+
+func (r calendarFeedRepository) Save(ctx c.Context, feed domain.CalendarFeed) (domain.CalendarFeed, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Save")
+	defer span.End()
+
+	q := `
+	INSERT INTO calendar_feeds (user_id, token)
+	VALUES ($1, $2)
+	ON CONFLICT (user_id) DO UPDATE SET token = EXCLUDED.token, created_at = NOW()
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var saved models.CalendarFeedModel
+	err := r.db.GetContext(ctx, &saved, q, feed.UserID, feed.Token)
+	if err != nil {
+		if pqErrorCode(err) == foreignKeyViolationCode {
+			return domain.CalendarFeed{}, app.NewError(http.StatusNotFound, "user not found", "user with given id does not exist", err)
+		}
+		return domain.CalendarFeed{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return saved.ToDomain(), nil
+}
+
+func (r calendarFeedRepository) Delete(ctx c.Context, userID uuid.UUID) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Delete")
+	defer span.End()
+
+	q := `
+	DELETE FROM calendar_feeds
+	WHERE user_id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	res, err := r.db.ExecContext(ctx, q, userID)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return app.NewError(http.StatusNotFound, "calendar feed not found", "user has no calendar feed", nil)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package postgre
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"net/http"
+	"testing"
+)
+
+func TestCalendarFeedRepository(t *testing.T) {
+	repo, closeDB, cleanDB, err := initializeRepository()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer closeDB()
+	defer cleanDB()
+
+	ctx := context.Background()
+
+	user, err := repo.user.Create(ctx, domain.User{
+		Profile:      domain.Profile{ID: uuid.New(), Nickname: "listener"},
+		Email:        "listener@example.com",
+		PasswordHash: "hashedpassword",
+		Roles:        domain.NewRoles([]string{domain.UserRole}),
+	})
+	require.NoError(t, err)
+
+	t.Run("Test feed without token", func(t *testing.T) {
+		_, err := repo.calendarFeed.Get(ctx, user.ID)
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+		_, err = repo.calendarFeed.GetByToken(ctx, "missing")
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+	})
+
+	t.Run("Test feed rotation", func(t *testing.T) {
+		created, err := repo.calendarFeed.Save(ctx, domain.CalendarFeed{UserID: user.ID, Token: "first"})
+		require.NoError(t, err)
+		assert.Equal(t, "first", created.Token)
+
+		_, err = repo.calendarFeed.Save(ctx, domain.CalendarFeed{UserID: user.ID, Token: "second"})
+		require.NoError(t, err)
+
+		_, err = repo.calendarFeed.GetByToken(ctx, "first")
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err), "old token stops working")
+		byToken, err := repo.calendarFeed.GetByToken(ctx, "second")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, byToken.UserID)
+
+		_, err = repo.calendarFeed.Save(ctx, domain.CalendarFeed{UserID: uuid.New(), Token: "ghost"})
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+	})
+
+	t.Run("Test feed delete", func(t *testing.T) {
+		require.NoError(t, repo.calendarFeed.Delete(ctx, user.ID))
+		err := repo.calendarFeed.Delete(ctx, user.ID)
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+	})
+}
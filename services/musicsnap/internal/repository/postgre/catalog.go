@@ -0,0 +1,501 @@
+package postgre
+
+import (
+	c "context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/juju/zaputil/zapctx"
+	"github.com/lib/pq"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/repository/postgre/models"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+)
+
+var _ ports.CatalogRepository = &catalogRepository{}
+
+func NewCatalogRepository(db *sqlx.DB) ports.CatalogRepository {
+	return &catalogRepository{db: db,
+		spanName: spanBaseName + "catalogRepository."}
+}
+
+func newCatalogRepository(db *sqlx.DB) catalogRepository {
+	return catalogRepository{db: db,
+		spanName: spanBaseName + "catalogRepository."}
+}
+
+type catalogRepository struct {
+	db       *sqlx.DB
+	spanName string
+}
+
+func (r catalogRepository) CreateArtist(ctx c.Context, artist domain.Artist) (domain.Artist, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"CreateArtist")
+	defer span.End()
+
+	q := `
+	INSERT INTO artists (id, name)
+	VALUES ($1, $2)
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	artistToWrite := models.ToArtistModel(artist)
+	if artistToWrite.ID == uuid.Nil {
+		artistToWrite.ID = uuid.New()
+	}
+
+	var createdArtist models.ArtistModel
+	err := r.db.GetContext(ctx, &createdArtist, q, artistToWrite.ID, artistToWrite.Name)
+	if err != nil {
+		if pqErrorCode(err) == uniqueViolationCode {
+			return domain.Artist{}, app.NewError(http.StatusConflict, "artist already exists", "artist id conflict", err)
+		}
+		return domain.Artist{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	return createdArtist.ToDomain(), nil
+}
+
+func (r catalogRepository) GetArtist(ctx c.Context, id uuid.UUID) (domain.Artist, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"GetArtist")
+	defer span.End()
+
+	q := `
+	SELECT * FROM artists
+	WHERE id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var artist models.ArtistModel
+	err := r.db.GetContext(ctx, &artist, q, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Artist{}, app.NewError(http.StatusNotFound, "artist not found", "artist not found", err)
+		}
+		return domain.Artist{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	return artist.ToDomain(), nil
+}
+
+func (r catalogRepository) CreatePiece(ctx c.Context, piece domain.Piece) (domain.Piece, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"CreatePiece")
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return domain.Piece{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to start transaction", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	q := `
+	INSERT INTO pieces (id, type)
+	VALUES ($1, $2)
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	pieceToWrite := models.ToPieceModel(piece)
+	if pieceToWrite.ID == uuid.Nil {
+		pieceToWrite.ID = uuid.New()
+	}
+
+	var createdPiece models.PieceModel
+	err = tx.GetContext(ctx, &createdPiece, q, pieceToWrite.ID, pieceToWrite.Type)
+	if err != nil {
+		if pqErrorCode(err) == uniqueViolationCode {
+			return domain.Piece{}, app.NewError(http.StatusConflict, "piece already exists", "piece id conflict", err)
+		}
+		return domain.Piece{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	err = r.writeDetails(ctx, tx, createdPiece.ID, piece, false)
+	if err != nil {
+		return domain.Piece{}, err
+	}
+
+	qAlias := `
+	INSERT INTO piece_aliases (source, external_id, piece_id)
+	VALUES ($1, $2, $3);
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qAlias)))
+
+	for _, alias := range piece.Aliases {
+		_, err = tx.ExecContext(ctx, qAlias, alias.Source, alias.ExternalID, createdPiece.ID)
+		if err != nil {
+			if pqErrorCode(err) == uniqueViolationCode {
+				return domain.Piece{}, app.NewError(http.StatusConflict, "alias already taken", "piece alias conflict", err)
+			}
+			return domain.Piece{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+		}
+	}
+
+	pieces, err := r.loadDetails(ctx, tx, []models.PieceModel{createdPiece})
+	if err != nil {
+		return domain.Piece{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return domain.Piece{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to commit transaction", err)
+	}
+
+	return pieces[0], nil
+}
+
+func (r catalogRepository) UpdatePiece(ctx c.Context, piece domain.Piece) (domain.Piece, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"UpdatePiece")
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return domain.Piece{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to start transaction", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	// type of piece can't be changed, so it is a part of the condition
+	q := `
+	UPDATE pieces SET updated_at = NOW()
+	WHERE id = $1 AND type = $2
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var updatedPiece models.PieceModel
+	err = tx.GetContext(ctx, &updatedPiece, q, piece.ID, piece.Type)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Piece{}, app.NewError(http.StatusNotFound, "piece not found", "piece with such id and type not found", err)
+		}
+		return domain.Piece{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	err = r.writeDetails(ctx, tx, updatedPiece.ID, piece, true)
+	if err != nil {
+		return domain.Piece{}, err
+	}
+
+	pieces, err := r.loadDetails(ctx, tx, []models.PieceModel{updatedPiece})
+	if err != nil {
+		return domain.Piece{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return domain.Piece{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to commit transaction", err)
+	}
+
+	return pieces[0], nil
+}
+
+func (r catalogRepository) GetPiece(ctx c.Context, id uuid.UUID) (domain.Piece, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"GetPiece")
+	defer span.End()
+
+	q := `
+	SELECT * FROM pieces
+	WHERE id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var piece models.PieceModel
+	err := r.db.GetContext(ctx, &piece, q, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Piece{}, app.NewError(http.StatusNotFound, "piece not found", "piece not found", err)
+		}
+		return domain.Piece{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	pieces, err := r.loadDetails(ctx, r.db, []models.PieceModel{piece})
+	if err != nil {
+		return domain.Piece{}, err
+	}
+
+	return pieces[0], nil
+}
+
+func (r catalogRepository) GetPieceByAlias(ctx c.Context, source, externalID string) (domain.Piece, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"GetPieceByAlias")
+	defer span.End()
+
+	q := `
+	SELECT pieces.* FROM pieces
+	JOIN piece_aliases ON piece_aliases.piece_id = pieces.id
+	WHERE piece_aliases.source = $1 AND piece_aliases.external_id = $2;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var piece models.PieceModel
+	err := r.db.GetContext(ctx, &piece, q, source, externalID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Piece{}, app.NewError(http.StatusNotFound, "piece not found", "piece alias not found", err)
+		}
+		return domain.Piece{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	pieces, err := r.loadDetails(ctx, r.db, []models.PieceModel{piece})
+	if err != nil {
+		return domain.Piece{}, err
+	}
+
+	return pieces[0], nil
+}
+
+func (r catalogRepository) ListPieces(ctx c.Context, filter domain.PieceFilter, pag domain.UUIDPagination) ([]domain.Piece, domain.UUIDPagination, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"ListPieces")
+	defer span.End()
+
+	q := `
+	SELECT pieces.* FROM pieces
+	LEFT JOIN tracks ON tracks.id = pieces.id
+	LEFT JOIN albums ON albums.id = pieces.id
+	WHERE COALESCE(tracks.title, albums.title) ILIKE $1
+	  AND ($2::TEXT IS NULL OR pieces.type = $2)
+	  AND ($3::UUID IS NULL OR COALESCE(tracks.artist_id, albums.artist_id) = $3)
+	  AND pieces.id > $4
+	ORDER BY pieces.id ASC
+	LIMIT $5;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var pieceRows []models.PieceModel
+	err := r.db.SelectContext(ctx, &pieceRows, q, "%"+filter.TitleQuery+"%", filter.Type, filter.ArtistID, pag.LastUUID, pag.Limit)
+	if err != nil {
+		return nil, pag, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	if len(pieceRows) == 0 {
+		pag.LastUUID = uuid.Nil
+		return []domain.Piece{}, pag, nil
+	}
+
+	pieces, err := r.loadDetails(ctx, r.db, pieceRows)
+	if err != nil {
+		return nil, pag, err
+	}
+
+	pag.LastUUID = pieces[len(pieces)-1].ID
+	return pieces, pag, nil
+}
+
+func (r catalogRepository) AddAlias(ctx c.Context, alias domain.PieceAlias) (domain.PieceAlias, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"AddAlias")
+	defer span.End()
+
+	q := `
+	INSERT INTO piece_aliases (source, external_id, piece_id)
+	VALUES ($1, $2, $3)
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	aliasToWrite := models.ToPieceAliasModel(alias)
+
+	var createdAlias models.PieceAliasModel
+	err := r.db.GetContext(ctx, &createdAlias, q, aliasToWrite.Source, aliasToWrite.ExternalID, aliasToWrite.PieceID)
+	if err != nil {
+		switch pqErrorCode(err) {
+		case uniqueViolationCode:
+			return domain.PieceAlias{}, app.NewError(http.StatusConflict, "alias already taken", "piece alias conflict", err)
+		case foreignKeyViolationCode:
+			return domain.PieceAlias{}, app.NewError(http.StatusNotFound, "piece not found", "alias references missing piece", err)
+		}
+		return domain.PieceAlias{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	return createdAlias.ToDomain(), nil
+}
+
+// writeDetails inserts or updates track or album row of the piece
+func (r catalogRepository) writeDetails(ctx c.Context, tx *sqlx.Tx, pieceID uuid.UUID, piece domain.Piece, update bool) error {
+	logger := zapctx.Logger(ctx)
+
+	var (
+		q    string
+		args []interface{}
+	)
+	switch piece.Type {
+	case domain.TrackPiece:
+		q = `
+		INSERT INTO tracks (id, artist_id, album_id, title, duration_ms, explicit)
+		VALUES ($1, $2, $3, $4, $5, $6);
+		`
+		if update {
+			q = `
+			UPDATE tracks SET artist_id = $2, album_id = $3, title = $4, duration_ms = $5, explicit = $6
+			WHERE id = $1;
+			`
+		}
+		m := models.ToTrackModel(pieceID, *piece.Track)
+		args = []interface{}{m.ID, m.ArtistID, m.AlbumID, m.Title, m.DurationMs, m.Explicit}
+	case domain.AlbumPiece:
+		q = `
+		INSERT INTO albums (id, artist_id, title, cover_url, release_date)
+		VALUES ($1, $2, $3, $4, $5);
+		`
+		if update {
+			q = `
+			UPDATE albums SET artist_id = $2, title = $3, cover_url = $4, release_date = $5
+			WHERE id = $1;
+			`
+		}
+		m := models.ToAlbumModel(pieceID, *piece.Album)
+		args = []interface{}{m.ID, m.ArtistID, m.Title, m.CoverURL, m.ReleaseDate}
+	default:
+		return app.NewError(http.StatusBadRequest, "invalid piece type", "unknown piece type "+piece.Type, nil)
+	}
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	_, err := tx.ExecContext(ctx, q, args...)
+	if err != nil {
+		if pqErrorCode(err) == foreignKeyViolationCode {
+			return app.NewError(http.StatusBadRequest, "artist or album not found", "piece details reference missing row", err)
+		}
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return nil
+}
+
+// loadDetails attaches tracks, albums, artists and aliases to pieces with one query per table
+func (r catalogRepository) loadDetails(ctx c.Context, db sqlx.QueryerContext, pieceRows []models.PieceModel) ([]domain.Piece, error) {
+	logger := zapctx.Logger(ctx)
+
+	ids := make([]string, len(pieceRows))
+	for i, p := range pieceRows {
+		ids[i] = p.ID.String()
+	}
+
+	qTracks := `
+	SELECT * FROM tracks WHERE id = ANY($1::UUID[]);
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qTracks)))
+
+	var tracks []models.TrackModel
+	err := sqlx.SelectContext(ctx, db, &tracks, qTracks, pq.Array(ids))
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	qAlbums := `
+	SELECT * FROM albums WHERE id = ANY($1::UUID[]);
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qAlbums)))
+
+	var albums []models.AlbumModel
+	err = sqlx.SelectContext(ctx, db, &albums, qAlbums, pq.Array(ids))
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	qAliases := `
+	SELECT * FROM piece_aliases WHERE piece_id = ANY($1::UUID[]) ORDER BY source, external_id;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qAliases)))
+
+	var aliases []models.PieceAliasModel
+	err = sqlx.SelectContext(ctx, db, &aliases, qAliases, pq.Array(ids))
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	artistIDs := make([]string, 0, len(tracks)+len(albums))
+	for _, t := range tracks {
+		if t.ArtistID != nil {
+			artistIDs = append(artistIDs, t.ArtistID.String())
+		}
+	}
+	for _, a := range albums {
+		if a.ArtistID != nil {
+			artistIDs = append(artistIDs, a.ArtistID.String())
+		}
+	}
+
+	qArtists := `
+	SELECT * FROM artists WHERE id = ANY($1::UUID[]);
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qArtists)))
+
+	var artists []models.ArtistModel
+	err = sqlx.SelectContext(ctx, db, &artists, qArtists, pq.Array(artistIDs))
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	artistByID := make(map[uuid.UUID]domain.Artist, len(artists))
+	for _, a := range artists {
+		artistByID[a.ID] = a.ToDomain()
+	}
+	trackByID := make(map[uuid.UUID]domain.Track, len(tracks))
+	for _, t := range tracks {
+		track := t.ToDomain()
+		if artist, ok := artistByID[track.ArtistID]; ok {
+			track.Artist = &artist
+		}
+		trackByID[t.ID] = track
+	}
+	albumByID := make(map[uuid.UUID]domain.Album, len(albums))
+	for _, a := range albums {
+		album := a.ToDomain()
+		if artist, ok := artistByID[album.ArtistID]; ok {
+			album.Artist = &artist
+		}
+		albumByID[a.ID] = album
+	}
+	aliasesByID := make(map[uuid.UUID][]domain.PieceAlias, len(pieceRows))
+	for _, a := range aliases {
+		aliasesByID[a.PieceID] = append(aliasesByID[a.PieceID], a.ToDomain())
+	}
+
+	res := make([]domain.Piece, len(pieceRows))
+	for i, p := range pieceRows {
+		piece := p.ToDomain()
+		if track, ok := trackByID[p.ID]; ok {
+			piece.Track = &track
+		}
+		if album, ok := albumByID[p.ID]; ok {
+			piece.Album = &album
+		}
+		piece.Aliases = aliasesByID[p.ID]
+		if piece.Aliases == nil {
+			piece.Aliases = []domain.PieceAlias{}
+		}
+		res[i] = piece
+	}
+	return res, nil
+}
@@ -0,0 +1,132 @@
+package postgre
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCatalogRepository(t *testing.T) {
+	repo, closeDB, cleanDB, err := initializeRepository()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer closeDB()
+	defer cleanDB()
+
+	ctx := context.Background()
+
+	artist, err := repo.catalog.CreateArtist(ctx, domain.Artist{Name: "Кино"})
+	require.NoError(t, err)
+	require.NotEqual(t, uuid.Nil, artist.ID)
+
+	album := domain.Piece{
+		Type: domain.AlbumPiece,
+		Album: &domain.Album{
+			Title:       "Группа крови",
+			ArtistID:    artist.ID,
+			ReleaseDate: time.Date(1988, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Aliases: []domain.PieceAlias{
+			{Source: domain.MusicBrainzSource, ExternalID: "b5c5e3a1-0000-4000-8000-000000000001"},
+		},
+	}
+
+	t.Run("Test piece create", func(t *testing.T) {
+		createdAlbum, err := repo.catalog.CreatePiece(ctx, album)
+		require.NoError(t, err)
+		require.NotNil(t, createdAlbum.Album)
+		assert.Equal(t, album.Album.Title, createdAlbum.Album.Title)
+		require.NotNil(t, createdAlbum.Album.Artist)
+		assert.Equal(t, artist.Name, createdAlbum.Album.Artist.Name)
+		require.Len(t, createdAlbum.Aliases, 1)
+		album = createdAlbum
+
+		track := domain.Piece{
+			Type: domain.TrackPiece,
+			Track: &domain.Track{
+				Title:      "Кукушка",
+				DurationMs: 396000,
+				ArtistID:   artist.ID,
+				AlbumID:    album.ID,
+			},
+		}
+		createdTrack, err := repo.catalog.CreatePiece(ctx, track)
+		require.NoError(t, err)
+		require.NotNil(t, createdTrack.Track)
+		assert.Equal(t, album.ID, createdTrack.Track.AlbumID)
+		assert.Empty(t, createdTrack.Aliases)
+	})
+
+	t.Run("Test piece create with missing artist", func(t *testing.T) {
+		_, err := repo.catalog.CreatePiece(ctx, domain.Piece{
+			Type:  domain.TrackPiece,
+			Track: &domain.Track{Title: "Ghost", ArtistID: uuid.New()},
+		})
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, app.GetCode(err))
+	})
+
+	t.Run("Test piece get by alias", func(t *testing.T) {
+		alias := album.Aliases[0]
+		got, err := repo.catalog.GetPieceByAlias(ctx, alias.Source, alias.ExternalID)
+		require.NoError(t, err)
+		assert.Equal(t, album.ID, got.ID)
+
+		_, err = repo.catalog.GetPieceByAlias(ctx, domain.SpotifySource, "missing")
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+	})
+
+	t.Run("Test alias conflict", func(t *testing.T) {
+		_, err := repo.catalog.AddAlias(ctx, domain.PieceAlias{
+			Source:     domain.SpotifySource,
+			ExternalID: "7GhIk7Il098yCjg4BQjzvb",
+			PieceID:    album.ID,
+		})
+		require.NoError(t, err)
+
+		_, err = repo.catalog.AddAlias(ctx, domain.PieceAlias{
+			Source:     domain.SpotifySource,
+			ExternalID: "7GhIk7Il098yCjg4BQjzvb",
+			PieceID:    album.ID,
+		})
+		require.Error(t, err)
+		assert.Equal(t, http.StatusConflict, app.GetCode(err))
+	})
+
+	t.Run("Test piece update", func(t *testing.T) {
+		album.Album.Title = "Группа крови (Remastered)"
+		updated, err := repo.catalog.UpdatePiece(ctx, album)
+		require.NoError(t, err)
+		assert.Equal(t, album.Album.Title, updated.Album.Title)
+
+		wrongType := album
+		wrongType.Type = domain.TrackPiece
+		_, err = repo.catalog.UpdatePiece(ctx, wrongType)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+	})
+
+	t.Run("Test piece list", func(t *testing.T) {
+		trackType := domain.TrackPiece
+		pieces, pag, err := repo.catalog.ListPieces(ctx, domain.PieceFilter{Type: &trackType},
+			domain.UUIDPagination{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, pieces, 1)
+		assert.Equal(t, "Кукушка", pieces[0].Title())
+		assert.Equal(t, pieces[0].ID, pag.LastUUID)
+
+		pieces, _, err = repo.catalog.ListPieces(ctx, domain.PieceFilter{TitleQuery: "крови", ArtistID: &artist.ID},
+			domain.UUIDPagination{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, pieces, 1)
+		assert.Equal(t, album.ID, pieces[0].ID)
+	})
+}
@@ -0,0 +1,275 @@
+package postgre
+
+import (
+	c "context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/repository/postgre/models"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+)
+
+var _ ports.CommentRepository = &commentRepository{}
+
+func NewCommentRepository(db *sqlx.DB) ports.CommentRepository {
+	return &commentRepository{db: db,
+		spanName: spanBaseName + "commentRepository."}
+}
+
+func newCommentRepository(db *sqlx.DB) commentRepository {
+	return commentRepository{db: db,
+		spanName: spanBaseName + "commentRepository."}
+}
+
+type commentRepository struct {
+	db       *sqlx.DB
+	spanName string
+}
+
+func (r commentRepository) Create(ctx c.Context, comment domain.Comment) (domain.Comment, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Create")
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return domain.Comment{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to start transaction", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	qThread := `
+	INSERT INTO threads (id, review_id, created_at)
+	VALUES (gen_random_uuid(), $1, NOW())
+	ON CONFLICT (review_id) DO UPDATE SET review_id = EXCLUDED.review_id
+	RETURNING id;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qThread)))
+
+	var threadID uuid.UUID
+	err = tx.GetContext(ctx, &threadID, qThread, comment.ReviewID)
+	if err != nil {
+		if pqErrorCode(err) == foreignKeyViolationCode {
+			return domain.Comment{}, app.NewError(http.StatusNotFound, "review not found",
+				fmt.Sprintf("review %d not found", comment.ReviewID), err)
+		}
+		return domain.Comment{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	// path ответа продолжает path родителя, поэтому seq берётся до вставки
+	q := `
+	WITH parent AS (
+	    SELECT id, depth, path FROM comments
+	    WHERE id = $3 AND thread_id = $2
+	), next AS (
+	    SELECT nextval(pg_get_serial_sequence('comments', 'seq')) AS seq
+	)
+	INSERT INTO comments (id, user_id, thread_id, parent_id, depth, seq, path, text, created_at)
+	SELECT gen_random_uuid(), $1, $2, $3, COALESCE(parent.depth + 1, 0), next.seq,
+	       COALESCE(parent.path, '{}') || next.seq, $4, NOW()
+	FROM next
+	LEFT JOIN parent ON TRUE
+	WHERE $3::uuid IS NULL OR parent.id IS NOT NULL
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var created models.CommentModel
+	err = tx.GetContext(ctx, &created, q, comment.UserID, threadID, comment.ParentID, comment.Text)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Comment{}, app.NewError(http.StatusNotFound, "parent comment not found",
+				fmt.Sprintf("comment %s not found in thread of review %d", comment.ParentID, comment.ReviewID), err)
+		}
+		if pqErrorCode(err) == foreignKeyViolationCode {
+			return domain.Comment{}, app.NewError(http.StatusNotFound, "user not found",
+				fmt.Sprintf("user %s not found", comment.UserID), err)
+		}
+		return domain.Comment{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	created.ReviewID = comment.ReviewID
+
+	// автор ответа на свой комментарий или рецензию уведомление не получает
+	qNotify := `
+	INSERT INTO notifications (id, user_id, sender_id, type, message, created_at)
+	SELECT gen_random_uuid(), user_id, $3, $4,
+	       jsonb_build_object('review_id', $1::int, 'comment_id', $2::uuid, 'user_id', $3::uuid),
+	       NOW()
+	FROM reviews
+	WHERE id = $1 AND user_id <> $3;
+	`
+	args := []any{comment.ReviewID, created.ID, comment.UserID, domain.NotificationReviewComment}
+	if comment.ParentID != nil {
+		qNotify = `
+		INSERT INTO notifications (id, user_id, sender_id, type, message, created_at)
+		SELECT gen_random_uuid(), user_id, $3, $4,
+		       jsonb_build_object('review_id', $1::int, 'comment_id', $2::uuid, 'user_id', $3::uuid,
+		                          'parent_id', $5::uuid),
+		       NOW()
+		FROM comments
+		WHERE id = $5 AND user_id <> $3;
+		`
+		args = []any{comment.ReviewID, created.ID, comment.UserID, domain.NotificationCommentReply, *comment.ParentID}
+	}
+	logger.With(zap.String("PSQL query", formatQuery(qNotify)))
+
+	_, err = tx.ExecContext(ctx, qNotify, args...)
+	if err != nil {
+		return domain.Comment{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return domain.Comment{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to commit transaction", err)
+	}
+
+	return created.ToDomain(), nil
+}
+
+func (r commentRepository) GetByID(ctx c.Context, id uuid.UUID) (domain.Comment, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"GetByID")
+	defer span.End()
+
+	q := `
+	SELECT comments.*, threads.review_id FROM comments
+	JOIN threads ON threads.id = comments.thread_id
+	WHERE comments.id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var comment models.CommentModel
+	err := r.db.GetContext(ctx, &comment, q, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Comment{}, app.NewError(http.StatusNotFound, "comment not found",
+				fmt.Sprintf("comment %s not found", id), err)
+		}
+		return domain.Comment{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	return comment.ToDomain(), nil
+}
+
+func (r commentRepository) UpdateText(ctx c.Context, id uuid.UUID, text string) (domain.Comment, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"UpdateText")
+	defer span.End()
+
+	q := `
+	WITH updated AS (
+	    UPDATE comments
+	    SET text = $2, edited_at = NOW()
+	    WHERE id = $1 AND deleted_at IS NULL
+	    RETURNING *
+	)
+	SELECT updated.*, threads.review_id FROM updated
+	JOIN threads ON threads.id = updated.thread_id;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var comment models.CommentModel
+	err := r.db.GetContext(ctx, &comment, q, id, text)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Comment{}, app.NewError(http.StatusNotFound, "comment not found",
+				fmt.Sprintf("comment %s not found or deleted", id), err)
+		}
+		return domain.Comment{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	return comment.ToDomain(), nil
+}
+
+func (r commentRepository) Delete(ctx c.Context, id uuid.UUID) (domain.Comment, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Delete")
+	defer span.End()
+
+	q := `
+	WITH deleted AS (
+	    UPDATE comments
+	    SET text = '', deleted_at = NOW()
+	    WHERE id = $1 AND deleted_at IS NULL
+	    RETURNING *
+	)
+	SELECT deleted.*, threads.review_id FROM deleted
+	JOIN threads ON threads.id = deleted.thread_id;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var comment models.CommentModel
+	err := r.db.GetContext(ctx, &comment, q, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Comment{}, app.NewError(http.StatusNotFound, "comment not found",
+				fmt.Sprintf("comment %s not found or already deleted", id), err)
+		}
+		return domain.Comment{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	return comment.ToDomain(), nil
+}
+
+func (r commentRepository) List(ctx c.Context, reviewID int, parentID *uuid.UUID, pag domain.UUIDPagination) ([]domain.Comment, domain.UUIDPagination, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"List")
+	defer span.End()
+
+	// seq уникален, поэтому path содержит seq родителя только у его потомков;
+	// курсор - path последнего комментария предыдущей страницы
+	q := `
+	SELECT comments.*, threads.review_id FROM comments
+	JOIN threads ON threads.id = comments.thread_id
+	WHERE threads.review_id = $1
+	  AND ($2::uuid IS NULL OR (comments.path @> ARRAY [(SELECT seq FROM comments WHERE id = $2)]
+	                            AND comments.id <> $2))
+	  AND ($3::uuid IS NULL OR comments.path > (SELECT path FROM comments WHERE id = $3))
+	ORDER BY comments.path
+	LIMIT $4;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var lastUUID *uuid.UUID
+	if pag.LastUUID != uuid.Nil {
+		lastUUID = &pag.LastUUID
+	}
+
+	var rows []models.CommentModel
+	err := r.db.SelectContext(ctx, &rows, q, reviewID, parentID, lastUUID, pag.Limit)
+	if err != nil {
+		return nil, pag, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	if len(rows) == 0 {
+		pag.LastUUID = uuid.Nil
+		return []domain.Comment{}, pag, nil
+	}
+
+	comments := make([]domain.Comment, len(rows))
+	for i, row := range rows {
+		comments[i] = row.ToDomain()
+	}
+
+	pag.LastUUID = comments[len(comments)-1].ID
+	return comments, pag, nil
+}
@@ -0,0 +1,119 @@
+package postgre
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestCommentRepository(t *testing.T) {
+	repo, closeDB, cleanDB, err := initializeRepository()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer closeDB()
+	defer cleanDB()
+
+	ctx := context.Background()
+
+	users := make([]domain.User, 2)
+	for i := range users {
+		users[i], err = repo.user.Create(ctx, domain.User{
+			Profile:      domain.Profile{ID: uuid.New(), Nickname: "commenter" + strconv.Itoa(i)},
+			Email:        "commenter" + strconv.Itoa(i) + "@example.com",
+			PasswordHash: "hashedpassword",
+			Roles:        domain.NewRoles([]string{domain.UserRole}),
+		})
+		require.NoError(t, err)
+	}
+	author, commenter := users[0], users[1]
+
+	review, err := repo.review.Create(ctx, domain.Review{
+		UserID:    author.ID,
+		PieceID:   createPiece(t, repo),
+		Rating:    8,
+		Content:   "solid record",
+		Published: true,
+	})
+	require.NoError(t, err)
+
+	t.Run("Test comment on missing review", func(t *testing.T) {
+		_, err := repo.comment.Create(ctx, domain.Comment{ReviewID: 0, UserID: commenter.ID, Text: "hi"})
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+	})
+
+	top, err := repo.comment.Create(ctx, domain.Comment{ReviewID: review.ID, UserID: commenter.ID, Text: "agree"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, top.Depth)
+	assert.Equal(t, review.ID, top.ReviewID)
+
+	reply, err := repo.comment.Create(ctx, domain.Comment{ReviewID: review.ID, UserID: author.ID, ParentID: &top.ID, Text: "thanks"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, reply.Depth)
+	assert.Equal(t, top.ThreadID, reply.ThreadID)
+
+	second, err := repo.comment.Create(ctx, domain.Comment{ReviewID: review.ID, UserID: commenter.ID, Text: "one more"})
+	require.NoError(t, err)
+
+	t.Run("Test reply to missing parent", func(t *testing.T) {
+		parentID := uuid.New()
+		_, err := repo.comment.Create(ctx, domain.Comment{ReviewID: review.ID, UserID: author.ID, ParentID: &parentID, Text: "?"})
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+	})
+
+	t.Run("Test thread is listed depth-first by pages", func(t *testing.T) {
+		page, pag, err := repo.comment.List(ctx, review.ID, nil, domain.UUIDPagination{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+		assert.Equal(t, top.ID, page[0].ID)
+		assert.Equal(t, reply.ID, page[1].ID)
+
+		page, _, err = repo.comment.List(ctx, review.ID, nil, pag)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, second.ID, page[0].ID)
+	})
+
+	t.Run("Test replies of parent", func(t *testing.T) {
+		page, _, err := repo.comment.List(ctx, review.ID, &top.ID, domain.UUIDPagination{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, reply.ID, page[0].ID)
+	})
+
+	t.Run("Test edit and tombstone", func(t *testing.T) {
+		edited, err := repo.comment.UpdateText(ctx, top.ID, "agree completely")
+		require.NoError(t, err)
+		assert.True(t, edited.Edited())
+		assert.Equal(t, "agree completely", edited.Text)
+
+		deleted, err := repo.comment.Delete(ctx, top.ID)
+		require.NoError(t, err)
+		assert.True(t, deleted.Deleted())
+		assert.Empty(t, deleted.Text)
+
+		_, err = repo.comment.UpdateText(ctx, top.ID, "back")
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+
+		// ответ остаётся под удалённым комментарием
+		page, _, err := repo.comment.List(ctx, review.ID, nil, domain.UUIDPagination{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, page, 3)
+		assert.True(t, page[0].Deleted())
+	})
+
+	t.Run("Test comments count skips tombstones", func(t *testing.T) {
+		got, err := repo.review.GetByID(ctx, review.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, got.CommentsCount)
+	})
+}
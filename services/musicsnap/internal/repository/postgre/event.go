@@ -0,0 +1,444 @@
+package postgre
+
+import (
+	c "context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/juju/zaputil/zapctx"
+	"github.com/lib/pq"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/repository/postgre/models"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"time"
+)
+
+var _ ports.EventRepository = &eventRepository{}
+
+func NewEventRepository(db *sqlx.DB) ports.EventRepository {
+	return &eventRepository{db: db,
+		spanName: spanBaseName + "eventRepository."}
+}
+
+func newEventRepository(db *sqlx.DB) eventRepository {
+	return eventRepository{db: db,
+		spanName: spanBaseName + "eventRepository."}
+}
+
+type eventRepository struct {
+	db       *sqlx.DB
+	spanName string
+}
+
+func (r eventRepository) Create(ctx c.Context, event domain.Event) (domain.Event, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Create")
+	defer span.End()
+
+	tx, commit, rollback, err := beginTx(ctx, r.db)
+	if err != nil {
+		return domain.Event{}, app.NewError(http.StatusInternalServerError, "unknown error", "can't begin transaction", err)
+	}
+	defer rollback()
+
+	q := `
+	INSERT INTO events (name, date, text, cover_url, ticket_link, map_link, location)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	toWrite := models.ToEventModel(event)
+
+	var created models.EventModel
+	err = tx.GetContext(ctx, &created, q, toWrite.Name, toWrite.Date, toWrite.Text, toWrite.CoverURL,
+		toWrite.TicketLink, toWrite.MapLink, toWrite.Location)
+	if err != nil {
+		return domain.Event{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	qAuthors := `
+	INSERT INTO event_authors (event_id, user_id)
+	SELECT $1, author FROM UNNEST($2::UUID[]) AS author;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qAuthors)))
+
+	_, err = tx.ExecContext(ctx, qAuthors, created.ID, pq.Array(event.Authors))
+	if err != nil {
+		if pqErrorCode(err) == foreignKeyViolationCode {
+			return domain.Event{}, app.NewError(http.StatusNotFound, "author not found",
+				fmt.Sprintf("one of authors %v of event not found", event.Authors), err)
+		}
+		return domain.Event{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	if err = r.replacePhotos(ctx, tx, created.ID, event.PhotosURLs); err != nil {
+		return domain.Event{}, err
+	}
+
+	result, err := r.get(ctx, tx, created.ID)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	if err = commit(); err != nil {
+		return domain.Event{}, app.NewError(http.StatusInternalServerError, "unknown error", "can't commit transaction", err)
+	}
+	return result, nil
+}
+
+func (r eventRepository) GetByID(ctx c.Context, id uuid.UUID) (domain.Event, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"GetByID")
+	defer span.End()
+
+	return r.get(ctx, executorFrom(ctx, r.db), id)
+}
+
+// get loads event with its authors, photos and participants
+func (r eventRepository) get(ctx c.Context, db sqlx.QueryerContext, id uuid.UUID) (domain.Event, error) {
+	logger := zapctx.Logger(ctx)
+
+	q := `
+	SELECT events.*, (SELECT COUNT(*) FROM event_participants WHERE event_id = events.id) AS participants_count
+	FROM events
+	WHERE id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var event models.EventModel
+	err := sqlx.GetContext(ctx, db, &event, q, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Event{}, app.NewError(http.StatusNotFound, "event not found", "event with given id does not exist", err)
+		}
+		return domain.Event{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	events, err := r.loadDetails(ctx, db, []models.EventModel{event}, true)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	return events[0], nil
+}
+
+func (r eventRepository) Update(ctx c.Context, event domain.Event) (domain.Event, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Update")
+	defer span.End()
+
+	tx, commit, rollback, err := beginTx(ctx, r.db)
+	if err != nil {
+		return domain.Event{}, app.NewError(http.StatusInternalServerError, "unknown error", "can't begin transaction", err)
+	}
+	defer rollback()
+
+	q := `
+	UPDATE events
+	SET name = $2, date = $3, text = $4, cover_url = $5, ticket_link = $6, map_link = $7, location = $8,
+	    sequence = sequence + 1, updated_at = NOW()
+	WHERE id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	toWrite := models.ToEventModel(event)
+
+	res, err := tx.ExecContext(ctx, q, toWrite.ID, toWrite.Name, toWrite.Date, toWrite.Text, toWrite.CoverURL,
+		toWrite.TicketLink, toWrite.MapLink, toWrite.Location)
+	if err != nil {
+		return domain.Event{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return domain.Event{}, app.NewError(http.StatusNotFound, "event not found", "event with given id does not exist", nil)
+	}
+
+	if err = r.replacePhotos(ctx, tx, event.ID, event.PhotosURLs); err != nil {
+		return domain.Event{}, err
+	}
+
+	result, err := r.get(ctx, tx, event.ID)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	if err = commit(); err != nil {
+		return domain.Event{}, app.NewError(http.StatusInternalServerError, "unknown error", "can't commit transaction", err)
+	}
+	return result, nil
+}
+
+func (r eventRepository) Cancel(ctx c.Context, id uuid.UUID) (domain.Event, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Cancel")
+	defer span.End()
+
+	tx, commit, rollback, err := beginTx(ctx, r.db)
+	if err != nil {
+		return domain.Event{}, app.NewError(http.StatusInternalServerError, "unknown error", "can't begin transaction", err)
+	}
+	defer rollback()
+
+	q := `
+	UPDATE events
+	SET cancelled_at = NOW(), sequence = sequence + 1, updated_at = NOW()
+	WHERE id = $1 AND cancelled_at IS NULL;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	res, err := tx.ExecContext(ctx, q, id)
+	if err != nil {
+		return domain.Event{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	// событие могло быть отменено раньше, тогда get вернёт его как есть или not found
+	result, err := r.get(ctx, tx, id)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return domain.Event{}, app.NewError(http.StatusConflict, "event already cancelled",
+			fmt.Sprintf("event %s was cancelled at %s", id, result.CancelledAt), nil)
+	}
+	if err = commit(); err != nil {
+		return domain.Event{}, app.NewError(http.StatusInternalServerError, "unknown error", "can't commit transaction", err)
+	}
+	return result, nil
+}
+
+func (r eventRepository) replacePhotos(ctx c.Context, tx *sqlx.Tx, eventID uuid.UUID, photos []string) error {
+	logger := zapctx.Logger(ctx)
+
+	q := `
+	WITH removed AS (
+	    DELETE FROM event_photos WHERE event_id = $1
+	)
+	INSERT INTO event_photos (event_id, photo_url, position)
+	SELECT $1, photo.url, photo.position
+	FROM UNNEST($2::TEXT[]) WITH ORDINALITY AS photo(url, position);
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	_, err := tx.ExecContext(ctx, q, eventID, pq.Array(photos))
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return nil
+}
+
+func (r eventRepository) List(ctx c.Context, filter domain.EventFilter) ([]domain.Event, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"List")
+	defer span.End()
+
+	order := "events.date ASC, events.id ASC"
+	switch {
+	case filter.SortByAmount:
+		order = "participants_count DESC, events.date ASC, events.id ASC"
+	case filter.SortByCreatedAt:
+		order = "events.created_at DESC, events.id ASC"
+	}
+
+	q := fmt.Sprintf(`
+	SELECT events.*, COALESCE(participants.count, 0) AS participants_count
+	FROM events
+	LEFT JOIN (SELECT event_id, COUNT(*) AS count FROM event_participants GROUP BY event_id) participants
+	       ON participants.event_id = events.id
+	WHERE events.name ILIKE $1
+	  AND ($2::TIMESTAMP IS NULL OR events.date >= $2)
+	  AND ($3::TIMESTAMP IS NULL OR events.date <= $3)
+	ORDER BY %s
+	LIMIT $4 OFFSET $5;
+	`, order)
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var rows []models.EventModel
+	err := r.db.SelectContext(ctx, &rows, q, "%"+filter.NameQuery+"%", optionalTime(filter.DateLeftBound),
+		optionalTime(filter.DateRightBound), filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	if len(rows) == 0 {
+		return []domain.Event{}, nil
+	}
+	return r.loadDetails(ctx, r.db, rows, false)
+}
+
+func (r eventRepository) ListFeed(ctx c.Context, userID uuid.UUID, since time.Time, limit int) ([]domain.Event, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"ListFeed")
+	defer span.End()
+
+	q := `
+	SELECT events.*, COALESCE(participants.count, 0) AS participants_count
+	FROM events
+	LEFT JOIN (SELECT event_id, COUNT(*) AS count FROM event_participants GROUP BY event_id) participants
+	       ON participants.event_id = events.id
+	WHERE events.date >= $2
+	  AND (EXISTS (SELECT 1 FROM event_participants
+	               WHERE event_participants.event_id = events.id AND event_participants.user_id = $1)
+	    OR EXISTS (SELECT 1 FROM event_authors
+	               LEFT JOIN subscriptions ON subscriptions.followed_id = event_authors.user_id
+	                                      AND subscriptions.subscriber_id = $1
+	               WHERE event_authors.event_id = events.id
+	                 AND (event_authors.user_id = $1 OR subscriptions.subscriber_id IS NOT NULL)))
+	ORDER BY events.date ASC, events.id ASC
+	LIMIT $3;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var rows []models.EventModel
+	err := r.db.SelectContext(ctx, &rows, q, userID, since, limit)
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	if len(rows) == 0 {
+		return []domain.Event{}, nil
+	}
+	return r.loadDetails(ctx, r.db, rows, false)
+}
+
+// optionalTime passes zero time as NULL
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func (r eventRepository) AddParticipant(ctx c.Context, eventID uuid.UUID, userID uuid.UUID) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"AddParticipant")
+	defer span.End()
+
+	q := `
+	INSERT INTO event_participants (event_id, user_id)
+	VALUES ($1, $2);
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	_, err := executorFrom(ctx, r.db).ExecContext(ctx, q, eventID, userID)
+	if err != nil {
+		switch pqErrorCode(err) {
+		case uniqueViolationCode:
+			return app.NewError(http.StatusConflict, "already participating",
+				fmt.Sprintf("user %s already participates in event %s", userID, eventID), err)
+		case foreignKeyViolationCode:
+			return app.NewError(http.StatusNotFound, "event not found", "event or user with given id does not exist", err)
+		}
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return nil
+}
+
+func (r eventRepository) RemoveParticipant(ctx c.Context, eventID uuid.UUID, userID uuid.UUID) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"RemoveParticipant")
+	defer span.End()
+
+	q := `
+	DELETE FROM event_participants
+	WHERE event_id = $1 AND user_id = $2;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	res, err := executorFrom(ctx, r.db).ExecContext(ctx, q, eventID, userID)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return app.NewError(http.StatusNotFound, "not participating",
+			fmt.Sprintf("user %s does not participate in event %s", userID, eventID), nil)
+	}
+	return nil
+}
+
+// loadDetails sets authors and photos of events, participants are loaded for a single event only
+func (r eventRepository) loadDetails(ctx c.Context, db sqlx.QueryerContext, rows []models.EventModel, withParticipants bool) ([]domain.Event, error) {
+	logger := zapctx.Logger(ctx)
+
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID.String()
+	}
+
+	qAuthors := `
+	SELECT event_id, user_id FROM event_authors
+	WHERE event_id = ANY($1::UUID[])
+	ORDER BY created_at, user_id;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qAuthors)))
+
+	var authors []models.EventMemberModel
+	err := sqlx.SelectContext(ctx, db, &authors, qAuthors, pq.Array(ids))
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	qPhotos := `
+	SELECT event_id, photo_url FROM event_photos
+	WHERE event_id = ANY($1::UUID[])
+	ORDER BY position;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qPhotos)))
+
+	var photos []models.EventPhotoModel
+	err = sqlx.SelectContext(ctx, db, &photos, qPhotos, pq.Array(ids))
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	var participants []models.EventMemberModel
+	if withParticipants {
+		qParticipants := `
+		SELECT event_id, user_id FROM event_participants
+		WHERE event_id = ANY($1::UUID[])
+		ORDER BY created_at, user_id;
+		`
+		logger.With(zap.String("PSQL query", formatQuery(qParticipants)))
+
+		err = sqlx.SelectContext(ctx, db, &participants, qParticipants, pq.Array(ids))
+		if err != nil {
+			return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+		}
+	}
+
+	events := make([]domain.Event, len(rows))
+	index := make(map[uuid.UUID]int, len(rows))
+	for i, row := range rows {
+		events[i] = row.ToDomain()
+		events[i].Authors = []uuid.UUID{}
+		events[i].PhotosURLs = []string{}
+		if withParticipants {
+			events[i].Participants = []uuid.UUID{}
+		}
+		index[row.ID] = i
+	}
+	for _, a := range authors {
+		events[index[a.EventID]].Authors = append(events[index[a.EventID]].Authors, a.UserID)
+	}
+	for _, p := range photos {
+		events[index[p.EventID]].PhotosURLs = append(events[index[p.EventID]].PhotosURLs, p.PhotoURL)
+	}
+	for _, p := range participants {
+		events[index[p.EventID]].Participants = append(events[index[p.EventID]].Participants, p.UserID)
+	}
+	return events, nil
+}
@@ -0,0 +1,173 @@
+package postgre
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestEventRepository(t *testing.T) {
+	repo, closeDB, cleanDB, err := initializeRepository()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer closeDB()
+	defer cleanDB()
+
+	ctx := context.Background()
+
+	newUser := func(nickname string) domain.User {
+		user, err := repo.user.Create(ctx, domain.User{
+			Profile:      domain.Profile{ID: uuid.New(), Nickname: nickname},
+			Email:        nickname + "@example.com",
+			PasswordHash: "hashedpassword",
+			Roles:        domain.NewRoles([]string{domain.UserRole}),
+		})
+		require.NoError(t, err)
+		return user
+	}
+	author, coAuthor, fan := newUser("author"), newUser("coauthor"), newUser("fan")
+
+	date := time.Now().UTC().Add(48 * time.Hour).Truncate(time.Second)
+	var concert, festival domain.Event
+	t.Run("Test event create", func(t *testing.T) {
+		concert, err = repo.event.Create(ctx, domain.Event{
+			Name:       "Spring concert",
+			Date:       date,
+			Text:       "Acoustic set",
+			TicketLink: "https://tickets.example/spring",
+			Location:   "Main hall",
+			PhotosURLs: []string{"https://cdn.example/1.jpg", "https://cdn.example/2.jpg"},
+			Authors:    []uuid.UUID{author.ID, coAuthor.ID},
+		})
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, concert.ID)
+		assert.ElementsMatch(t, []uuid.UUID{author.ID, coAuthor.ID}, concert.Authors)
+		assert.Equal(t, []string{"https://cdn.example/1.jpg", "https://cdn.example/2.jpg"}, concert.PhotosURLs)
+		assert.Empty(t, concert.Participants)
+		assert.Equal(t, "https://tickets.example/spring", concert.TicketLink)
+		assert.Empty(t, concert.LocationLink)
+
+		festival, err = repo.event.Create(ctx, domain.Event{Name: "Summer festival", Date: date.Add(24 * time.Hour),
+			Authors: []uuid.UUID{author.ID}})
+		require.NoError(t, err)
+
+		_, err = repo.event.Create(ctx, domain.Event{Name: "Ghost", Date: date, Authors: []uuid.UUID{uuid.New()}})
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+	})
+
+	t.Run("Test event update replaces photos", func(t *testing.T) {
+		concert.Name = "Spring concert (moved)"
+		concert.Date = date.Add(time.Hour)
+		concert.PhotosURLs = []string{"https://cdn.example/3.jpg"}
+		updated, err := repo.event.Update(ctx, concert)
+		require.NoError(t, err)
+		assert.Equal(t, "Spring concert (moved)", updated.Name)
+		assert.Equal(t, date.Add(time.Hour), updated.Date)
+		assert.Equal(t, []string{"https://cdn.example/3.jpg"}, updated.PhotosURLs)
+		assert.Len(t, updated.Authors, 2)
+		assert.Equal(t, concert.Sequence+1, updated.Sequence)
+		concert = updated
+
+		_, err = repo.event.Update(ctx, domain.Event{ID: uuid.New(), Name: "None", Date: date})
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+	})
+
+	t.Run("Test participants", func(t *testing.T) {
+		require.NoError(t, repo.event.AddParticipant(ctx, festival.ID, fan.ID))
+		require.NoError(t, repo.event.AddParticipant(ctx, festival.ID, coAuthor.ID))
+		err := repo.event.AddParticipant(ctx, festival.ID, fan.ID)
+		assert.Equal(t, http.StatusConflict, app.GetCode(err))
+		err = repo.event.AddParticipant(ctx, uuid.New(), fan.ID)
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+
+		got, err := repo.event.GetByID(ctx, festival.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, got.ParticipantsCount)
+		assert.ElementsMatch(t, []uuid.UUID{fan.ID, coAuthor.ID}, got.Participants)
+
+		require.NoError(t, repo.event.RemoveParticipant(ctx, festival.ID, coAuthor.ID))
+		err = repo.event.RemoveParticipant(ctx, festival.ID, coAuthor.ID)
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+	})
+
+	t.Run("Test event list", func(t *testing.T) {
+		byDate, err := repo.event.List(ctx, domain.EventFilter{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, byDate, 2)
+		assert.Equal(t, concert.ID, byDate[0].ID)
+		assert.Nil(t, byDate[0].Participants, "participants are loaded for a single event only")
+
+		popular, err := repo.event.List(ctx, domain.EventFilter{SortByAmount: true, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, popular, 2)
+		assert.Equal(t, festival.ID, popular[0].ID)
+		assert.Equal(t, 1, popular[0].ParticipantsCount)
+
+		named, err := repo.event.List(ctx, domain.EventFilter{NameQuery: "FESTIVAL", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, named, 1)
+		assert.Equal(t, festival.ID, named[0].ID)
+
+		ranged, err := repo.event.List(ctx, domain.EventFilter{DateLeftBound: date, DateRightBound: date.Add(2 * time.Hour), Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, ranged, 1)
+		assert.Equal(t, concert.ID, ranged[0].ID)
+
+		paged, err := repo.event.List(ctx, domain.EventFilter{Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		require.Len(t, paged, 1)
+		assert.Equal(t, festival.ID, paged[0].ID)
+	})
+
+	t.Run("Test event feed", func(t *testing.T) {
+		follower, stranger := newUser("follower"), newUser("stranger")
+		_, err := repo.user.CreateSub(ctx, domain.Subscription{
+			SubscriberID: follower.ID,
+			FollowedID:   coAuthor.ID,
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+		})
+		require.NoError(t, err)
+
+		since := time.Now().UTC()
+		feedIDs := func(userID uuid.UUID, since time.Time) []uuid.UUID {
+			events, err := repo.event.ListFeed(ctx, userID, since, 10)
+			require.NoError(t, err)
+			ids := make([]uuid.UUID, len(events))
+			for i, e := range events {
+				ids[i] = e.ID
+			}
+			return ids
+		}
+
+		assert.Equal(t, []uuid.UUID{festival.ID}, feedIDs(fan.ID, since), "participant")
+		assert.Equal(t, []uuid.UUID{concert.ID}, feedIDs(follower.ID, since), "follower of co-author")
+		assert.Equal(t, []uuid.UUID{concert.ID, festival.ID}, feedIDs(author.ID, since), "author")
+		assert.Empty(t, feedIDs(stranger.ID, since))
+		assert.Empty(t, feedIDs(author.ID, date.Add(72*time.Hour)), "events before since are skipped")
+	})
+
+	t.Run("Test event cancel", func(t *testing.T) {
+		cancelled, err := repo.event.Cancel(ctx, concert.ID)
+		require.NoError(t, err)
+		assert.True(t, cancelled.IsCancelled())
+		assert.Equal(t, concert.Sequence+1, cancelled.Sequence)
+
+		_, err = repo.event.Cancel(ctx, concert.ID)
+		assert.Equal(t, http.StatusConflict, app.GetCode(err))
+		_, err = repo.event.Cancel(ctx, uuid.New())
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+
+		feed, err := repo.event.ListFeed(ctx, author.ID, time.Now().UTC(), 10)
+		require.NoError(t, err)
+		require.Len(t, feed, 2, "cancelled event stays in feed")
+		assert.True(t, feed[0].IsCancelled())
+	})
+}
@@ -0,0 +1,264 @@
+package postgre
+
+import (
+	c "context"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/juju/zaputil/zapctx"
+	"github.com/lib/pq"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/repository/postgre/models"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+)
+
+var (
+	_ ports.BatchLoaderFactory = &batchLoaderFactory{}
+	_ ports.BatchLoader        = &batchLoader{}
+)
+
+func NewBatchLoaderFactory(db *sqlx.DB) ports.BatchLoaderFactory {
+	return &batchLoaderFactory{db: db}
+}
+
+type batchLoaderFactory struct {
+	db *sqlx.DB
+}
+
+func (f batchLoaderFactory) NewBatchLoader(actorID uuid.UUID) ports.BatchLoader {
+	return newBatchLoader(f.db, actorID)
+}
+
+// newBatchLoader creates loader of one request, uuid.Nil actor has no own reactions
+func newBatchLoader(db *sqlx.DB, actorID uuid.UUID) *batchLoader {
+	return &batchLoader{db: db,
+		spanName: spanBaseName + "batchLoader.",
+		actorID:  actorID,
+
+		wantProfiles:  make(map[uuid.UUID]bool),
+		wantRoles:     make(map[uuid.UUID]bool),
+		wantReactions: make(map[int]bool),
+
+		profiles:        make(map[uuid.UUID]domain.Profile),
+		roles:           make(map[uuid.UUID][]models.RoleModel),
+		reactionCounts:  make(map[int]domain.ReactionCounts),
+		actorReactions:  make(map[int]domain.Reaction),
+		loadedReactions: make(map[int]bool),
+	}
+}
+
+type batchLoader struct {
+	db       *sqlx.DB
+	spanName string
+	actorID  uuid.UUID
+
+	// ids collected since the last Load
+	wantProfiles  map[uuid.UUID]bool
+	wantRoles     map[uuid.UUID]bool
+	wantReactions map[int]bool
+
+	profiles map[uuid.UUID]domain.Profile
+	// roles has entry for every loaded user, even without roles
+	roles          map[uuid.UUID][]models.RoleModel
+	reactionCounts map[int]domain.ReactionCounts
+	// actorReactions has no entry for review without actor's reaction
+	actorReactions map[int]domain.Reaction
+	// loadedReactions is the set of reviews which counts and actor's reactions are loaded
+	loadedReactions map[int]bool
+}
+
+func (l *batchLoader) WantProfiles(userIDs ...uuid.UUID) {
+	for _, id := range userIDs {
+		if _, ok := l.profiles[id]; !ok {
+			l.wantProfiles[id] = true
+		}
+	}
+}
+
+func (l *batchLoader) WantRoles(userIDs ...uuid.UUID) {
+	for _, id := range userIDs {
+		if _, ok := l.roles[id]; !ok {
+			l.wantRoles[id] = true
+		}
+	}
+}
+
+func (l *batchLoader) WantReactions(reviewIDs ...int) {
+	for _, id := range reviewIDs {
+		if !l.loadedReactions[id] {
+			l.wantReactions[id] = true
+		}
+	}
+}
+
+// Load runs one query for every kind of collected ids
+func (l *batchLoader) Load(ctx c.Context) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, l.spanName+"Load")
+	defer span.End()
+
+	if len(l.wantProfiles) > 0 {
+		err := l.loadProfiles(ctx, uuidKeys(l.wantProfiles))
+		if err != nil {
+			return err
+		}
+		l.wantProfiles = make(map[uuid.UUID]bool)
+	}
+	if len(l.wantRoles) > 0 {
+		err := l.loadRoles(ctx, uuidKeys(l.wantRoles))
+		if err != nil {
+			return err
+		}
+		l.wantRoles = make(map[uuid.UUID]bool)
+	}
+	if len(l.wantReactions) > 0 {
+		reviewIDs := make([]int, 0, len(l.wantReactions))
+		for id := range l.wantReactions {
+			reviewIDs = append(reviewIDs, id)
+		}
+		err := l.loadReactionCounts(ctx, reviewIDs)
+		if err != nil {
+			return err
+		}
+		err = l.loadActorReactions(ctx, reviewIDs)
+		if err != nil {
+			return err
+		}
+		for _, id := range reviewIDs {
+			l.loadedReactions[id] = true
+		}
+		l.wantReactions = make(map[int]bool)
+	}
+	return nil
+}
+
+func (l *batchLoader) loadProfiles(ctx c.Context, userIDs []uuid.UUID) error {
+	logger := zapctx.Logger(ctx)
+
+	q := `
+	SELECT * FROM users
+	WHERE id = ANY ($1);
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var users []models.UserModel
+	err := l.db.SelectContext(ctx, &users, q, pq.Array(userIDs))
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	for _, user := range users {
+		l.profiles[user.ID] = user.ToProfileDomain()
+	}
+	return nil
+}
+
+func (l *batchLoader) loadRoles(ctx c.Context, userIDs []uuid.UUID) error {
+	logger := zapctx.Logger(ctx)
+
+	q := `
+	SELECT * FROM user_roles
+	WHERE user_id = ANY ($1)
+	ORDER BY role ASC;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var roles []models.RoleModel
+	err := l.db.SelectContext(ctx, &roles, q, pq.Array(userIDs))
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	for _, id := range userIDs {
+		l.roles[id] = []models.RoleModel{}
+	}
+	for _, role := range roles {
+		l.roles[role.UserID] = append(l.roles[role.UserID], role)
+	}
+	return nil
+}
+
+func (l *batchLoader) loadReactionCounts(ctx c.Context, reviewIDs []int) error {
+	logger := zapctx.Logger(ctx)
+
+	q := `
+	SELECT review_id, type, COUNT(*) AS count FROM reactions
+	WHERE review_id = ANY ($1)
+	GROUP BY review_id, type;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var rows []struct {
+		ReviewID int    `db:"review_id"`
+		Type     string `db:"type"`
+		Count    int    `db:"count"`
+	}
+	err := l.db.SelectContext(ctx, &rows, q, pq.Array(reviewIDs))
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	for _, id := range reviewIDs {
+		l.reactionCounts[id] = domain.ReactionCounts{}
+	}
+	for _, row := range rows {
+		l.reactionCounts[row.ReviewID][row.Type] = row.Count
+	}
+	return nil
+}
+
+func (l *batchLoader) loadActorReactions(ctx c.Context, reviewIDs []int) error {
+	if l.actorID == uuid.Nil {
+		return nil
+	}
+	logger := zapctx.Logger(ctx)
+
+	q := `
+	SELECT * FROM reactions
+	WHERE user_id = $1 AND review_id = ANY ($2);
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var reactions []models.ReactionModel
+	err := l.db.SelectContext(ctx, &reactions, q, l.actorID, pq.Array(reviewIDs))
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	for _, reaction := range reactions {
+		l.actorReactions[reaction.ReviewID] = reaction.ToDomain()
+	}
+	return nil
+}
+
+func (l *batchLoader) Profile(userID uuid.UUID) (domain.Profile, bool) {
+	profile, ok := l.profiles[userID]
+	return profile, ok
+}
+
+func (l *batchLoader) Roles(userID uuid.UUID) domain.Roles {
+	return models.ToRolesDomain(l.roles[userID])
+}
+
+func (l *batchLoader) ReactionCounts(reviewID int) domain.ReactionCounts {
+	counts, ok := l.reactionCounts[reviewID]
+	if !ok {
+		return domain.ReactionCounts{}
+	}
+	return counts
+}
+
+func (l *batchLoader) ActorReaction(reviewID int) *domain.Reaction {
+	reaction, ok := l.actorReactions[reviewID]
+	if !ok {
+		return nil
+	}
+	return &reaction
+}
+
+func uuidKeys(set map[uuid.UUID]bool) []uuid.UUID {
+	keys := make([]uuid.UUID, 0, len(set))
+	for id := range set {
+		keys = append(keys, id)
+	}
+	return keys
+}
@@ -0,0 +1,87 @@
+package postgre
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"music-snap/services/musicsnap/internal/domain"
+	"testing"
+)
+
+func TestBatchLoader(t *testing.T) {
+	repo, closeDB, cleanDB, err := initializeRepository()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer closeDB()
+	defer cleanDB()
+
+	ctx := context.Background()
+
+	users := make([]domain.User, 2)
+	for i := range users {
+		users[i], err = repo.user.Create(ctx, domain.User{
+			Profile:      domain.Profile{ID: uuid.New(), Nickname: fmt.Sprintf("loaded%d", i)},
+			Email:        fmt.Sprintf("loaded%d@example.com", i),
+			PasswordHash: "hashedpassword",
+			Roles:        domain.NewRoles([]string{domain.UserRole}),
+		})
+		require.NoError(t, err)
+	}
+
+	reviews := make([]domain.Review, 2)
+	for i := range reviews {
+		reviews[i], err = repo.review.Create(ctx, domain.Review{
+			UserID:    users[i].ID,
+			PieceID:   createPiece(t, repo),
+			Rating:    8,
+			Content:   "Loaded review",
+			Published: true,
+		})
+		require.NoError(t, err)
+	}
+
+	_, _, err = repo.reaction.Toggle(ctx, domain.Reaction{UserID: users[0].ID, ReviewID: reviews[1].ID, Type: "fire"})
+	require.NoError(t, err)
+	_, _, err = repo.reaction.Toggle(ctx, domain.Reaction{UserID: users[1].ID, ReviewID: reviews[1].ID, Type: "fire"})
+	require.NoError(t, err)
+
+	loader := newBatchLoader(repo.user.db, users[0].ID)
+	loader.WantProfiles(users[0].ID, users[1].ID, uuid.New())
+	loader.WantRoles(users[0].ID, users[1].ID)
+	loader.WantReactions(reviews[0].ID, reviews[1].ID)
+	require.NoError(t, loader.Load(ctx))
+
+	t.Run("Test loader profiles", func(t *testing.T) {
+		profile, ok := loader.Profile(users[1].ID)
+		require.True(t, ok)
+		assert.Equal(t, "loaded1", profile.Nickname)
+
+		_, ok = loader.Profile(uuid.New())
+		assert.False(t, ok)
+	})
+
+	t.Run("Test loader roles", func(t *testing.T) {
+		roles := loader.Roles(users[0].ID)
+		assert.True(t, roles.Has(domain.UserRole))
+	})
+
+	t.Run("Test loader reactions", func(t *testing.T) {
+		assert.Empty(t, loader.ReactionCounts(reviews[0].ID))
+		assert.Equal(t, domain.ReactionCounts{"fire": 2}, loader.ReactionCounts(reviews[1].ID))
+
+		assert.Nil(t, loader.ActorReaction(reviews[0].ID))
+		own := loader.ActorReaction(reviews[1].ID)
+		require.NotNil(t, own)
+		assert.Equal(t, users[0].ID, own.UserID)
+	})
+
+	t.Run("Test loader skips loaded ids", func(t *testing.T) {
+		loader.WantProfiles(users[0].ID)
+		loader.WantReactions(reviews[1].ID)
+		assert.Empty(t, loader.wantProfiles)
+		assert.Empty(t, loader.wantReactions)
+	})
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"music-snap/services/musicsnap/internal/domain"
+	"time"
+)
+
+type CalendarFeedModel struct {
+	UserID    uuid.UUID `db:"user_id"`
+	Token     string    `db:"token"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (m *CalendarFeedModel) ToDomain() domain.CalendarFeed {
+	return domain.CalendarFeed{
+		UserID:    m.UserID,
+		Token:     m.Token,
+		CreatedAt: m.CreatedAt,
+	}
+}
@@ -1,32 +1,42 @@
 package models
 
-//type CommentModel struct {
-//	ID        uuid.UUID `db:"id"`
-//	UserID    uuid.UUID `db:"user_id"`
-//	ThreadID  uuid.UUID `db:"thread_id"`
-//	Content      string    `db:"text"`
-//	CreatedAt time.Time `db:"created_at"`
-//	UpdatedAt time.Time `db:"updated_at"`
-//}
-//
-//func (m *CommentModel) ToValidDomain() domain.Comment {
-//	return domain.Comment{
-//		ID:        m.ID,
-//		UserID:    m.UserID,
-//		ThreadID:  m.ThreadID,
-//		Content:      m.Content,
-//		CreatedAt: m.CreatedAt,
-//		UpdatedAt: m.UpdatedAt,
-//	}
-//}
-//
-//func ToCommentModel(c domain.Comment) CommentModel {
-//	return CommentModel{
-//		ID:        c.ID,
-//		UserID:    c.UserID,
-//		ThreadID:  c.ThreadID,
-//		Content:      c.Content,
-//		CreatedAt: c.CreatedAt,
-//		UpdatedAt: c.UpdatedAt,
-//	}
-//}
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"music-snap/services/musicsnap/internal/domain"
+	"time"
+)
+
+type CommentModel struct {
+	ID       uuid.UUID  `db:"id"`
+	UserID   uuid.UUID  `db:"user_id"`
+	ThreadID uuid.UUID  `db:"thread_id"`
+	ReviewID int        `db:"review_id"`
+	ParentID *uuid.UUID `db:"parent_id"`
+	Depth    int        `db:"depth"`
+	Seq      int64      `db:"seq"`
+	// Path is seq of ancestors and comment itself
+	Path pq.Int64Array `db:"path"`
+	Text string        `db:"text"`
+
+	EditedAt  *time.Time `db:"edited_at"`
+	DeletedAt *time.Time `db:"deleted_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+}
+
+func (m *CommentModel) ToDomain() domain.Comment {
+	return domain.Comment{
+		ID:        m.ID,
+		ReviewID:  m.ReviewID,
+		ThreadID:  m.ThreadID,
+		UserID:    m.UserID,
+		ParentID:  m.ParentID,
+		Depth:     m.Depth,
+		Text:      m.Text,
+		EditedAt:  m.EditedAt,
+		DeletedAt: m.DeletedAt,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
@@ -1,43 +1,72 @@
 package models
 
 import (
+	"database/sql"
+	"github.com/google/uuid"
 	"music-snap/services/musicsnap/internal/domain"
 	"time"
 )
 
 type EventModel struct {
-	ID   int       `db:"id"`
-	Name string    `db:"name"`
-	Date time.Time `db:"date"`
-	//CoverURL   string    `db:"cover_url"`
-	TicketLink string    `db:"ticket_link"`
-	MapLink    string    `db:"map_link"`
-	CreatedAt  time.Time `db:"created_at"`
-	UpdatedAt  time.Time `db:"updated_at"`
+	ID          uuid.UUID      `db:"id"`
+	Name        string         `db:"name"`
+	Date        time.Time      `db:"date"`
+	Text        string         `db:"text"`
+	CoverURL    sql.NullString `db:"cover_url"`
+	TicketLink  sql.NullString `db:"ticket_link"`
+	MapLink     sql.NullString `db:"map_link"`
+	Location    string         `db:"location"`
+	Sequence    int            `db:"sequence"`
+	CancelledAt *time.Time     `db:"cancelled_at"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+
+	// ParticipantsCount is selected by queries of events, it is not a column
+	ParticipantsCount int `db:"participants_count"`
 }
 
 func (m *EventModel) ToDomain() domain.Event {
 	return domain.Event{
-		ID:   m.ID,
-		Name: m.Name,
-		Date: m.Date,
-		//CoverURL:   m.CoverURL,
-		TicketLink:   m.TicketLink,
-		LocationLink: m.MapLink,
-		CreatedAt:    m.CreatedAt,
-		UpdatedAt:    m.UpdatedAt,
+		ID:                m.ID,
+		Name:              m.Name,
+		Date:              m.Date,
+		Text:              m.Text,
+		PhotoURL:          m.CoverURL.String,
+		TicketLink:        m.TicketLink.String,
+		LocationLink:      m.MapLink.String,
+		Location:          m.Location,
+		ParticipantsCount: m.ParticipantsCount,
+		Sequence:          m.Sequence,
+		CancelledAt:       m.CancelledAt,
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
 	}
 }
 
 func ToEventModel(e domain.Event) EventModel {
 	return EventModel{
-		ID:   e.ID,
-		Name: e.Name,
-		Date: e.Date,
-		//CoverURL:   e.CoverURL,
-		TicketLink: e.TicketLink,
-		MapLink:    e.LocationLink,
-		CreatedAt:  e.CreatedAt,
-		UpdatedAt:  e.UpdatedAt,
+		ID:          e.ID,
+		Name:        e.Name,
+		Date:        e.Date,
+		Text:        e.Text,
+		CoverURL:    sql.NullString{String: e.PhotoURL, Valid: e.PhotoURL != ""},
+		TicketLink:  sql.NullString{String: e.TicketLink, Valid: e.TicketLink != ""},
+		MapLink:     sql.NullString{String: e.LocationLink, Valid: e.LocationLink != ""},
+		Location:    e.Location,
+		Sequence:    e.Sequence,
+		CancelledAt: e.CancelledAt,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
 	}
 }
+
+// EventMemberModel is a row of authors or participants of event
+type EventMemberModel struct {
+	EventID uuid.UUID `db:"event_id"`
+	UserID  uuid.UUID `db:"user_id"`
+}
+
+type EventPhotoModel struct {
+	EventID  uuid.UUID `db:"event_id"`
+	PhotoURL string    `db:"photo_url"`
+}
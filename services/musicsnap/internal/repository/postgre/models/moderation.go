@@ -0,0 +1,61 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"music-snap/services/musicsnap/internal/domain"
+	"time"
+)
+
+type ModerationQueueModel struct {
+	ReviewID   int        `db:"review_id"`
+	Reason     string     `db:"reason"`
+	EnqueuedAt time.Time  `db:"enqueued_at"`
+	ClaimedBy  *uuid.UUID `db:"claimed_by"`
+	ClaimedAt  *time.Time `db:"claimed_at"`
+}
+
+func (m *ModerationQueueModel) ToDomain(review domain.Review) domain.ModerationQueueItem {
+	return domain.ModerationQueueItem{
+		Review:     review,
+		Reason:     domain.QueueReason(m.Reason),
+		EnqueuedAt: m.EnqueuedAt,
+		ClaimedBy:  m.ClaimedBy,
+		ClaimedAt:  m.ClaimedAt,
+	}
+}
+
+// ModerationQueueReviewModel is a queue row joined with its review
+type ModerationQueueReviewModel struct {
+	ModerationQueueModel
+	ReviewModel
+}
+
+func (m *ModerationQueueReviewModel) ToDomain() domain.ModerationQueueItem {
+	return m.ModerationQueueModel.ToDomain(m.ReviewModel.ToDomain())
+}
+
+type ModerationActionModel struct {
+	ID          int        `db:"id"`
+	ReviewID    int        `db:"review_id"`
+	ModeratorID *uuid.UUID `db:"moderator_id"`
+	Action      string     `db:"action"`
+	ReasonCode  *string    `db:"reason_code"`
+	Comment     string     `db:"comment"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+func (m *ModerationActionModel) ToDomain() domain.ModerationAction {
+	var moderatorID uuid.UUID
+	if m.ModeratorID != nil {
+		moderatorID = *m.ModeratorID
+	}
+	return domain.ModerationAction{
+		ID:          m.ID,
+		ReviewID:    m.ReviewID,
+		ModeratorID: moderatorID,
+		Action:      domain.ModerationActionType(m.Action),
+		ReasonCode:  (*domain.ModerationReasonCode)(m.ReasonCode),
+		Comment:     m.Comment,
+		CreatedAt:   m.CreatedAt,
+	}
+}
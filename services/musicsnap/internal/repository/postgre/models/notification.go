@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/json"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"music-snap/services/musicsnap/internal/domain"
 	"time"
 )
@@ -10,8 +11,14 @@ import (
 type NotificationModel struct {
 	ID        uuid.UUID       `db:"id"`
 	UserID    uuid.UUID       `db:"user_id"`
+	SenderID  *uuid.UUID      `db:"sender_id"`
 	Type      string          `db:"type"`
 	Message   json.RawMessage `db:"message"`
+	Version   int             `db:"payload_version"`
+	Channels  pq.StringArray  `db:"channels"`
+	GroupKey  *string         `db:"group_key"`
+	Count     int             `db:"aggregated_count"`
+	ReviewID  *int            `db:"review_id"`
 	Read      bool            `db:"read"`
 	CreatedAt time.Time       `db:"created_at"`
 	UpdatedAt time.Time       `db:"updated_at"`
@@ -22,11 +29,25 @@ func (m *NotificationModel) ToDomain() (domain.Notification, error) {
 	if err := json.Unmarshal(m.Message, &jsonObject); err != nil {
 		return domain.Notification{}, err
 	}
+	groupKey := ""
+	if m.GroupKey != nil {
+		groupKey = *m.GroupKey
+	}
+	reviewID := 0
+	if m.ReviewID != nil {
+		reviewID = *m.ReviewID
+	}
 	return domain.Notification{
 		ID:             m.ID,
 		UserIDReceiver: m.UserID,
+		UserIDSender:   m.SenderID,
 		Type:           m.Type,
 		Message:        jsonObject,
+		Version:        m.Version,
+		Channels:       m.Channels,
+		GroupKey:       groupKey,
+		Count:          m.Count,
+		ReviewID:       reviewID,
 		Read:           m.Read,
 		CreatedAt:      m.CreatedAt,
 		UpdatedAt:      m.UpdatedAt,
@@ -34,17 +55,76 @@ func (m *NotificationModel) ToDomain() (domain.Notification, error) {
 }
 
 func ToNotificationModel(n domain.Notification) (NotificationModel, error) {
-	message, err := json.Marshal(n.Message)
-	if err != nil {
-		return NotificationModel{}, err
+	// пустое сообщение хранится как объект, а не null
+	message := []byte("{}")
+	if n.Message != nil {
+		var err error
+		message, err = json.Marshal(n.Message)
+		if err != nil {
+			return NotificationModel{}, err
+		}
+	}
+	var groupKey *string
+	if n.GroupKey != "" {
+		groupKey = &n.GroupKey
+	}
+	var reviewID *int
+	if n.ReviewID != 0 {
+		reviewID = &n.ReviewID
+	}
+	version := n.Version
+	if version == 0 {
+		version = domain.NotificationPayloadVersion
 	}
 	return NotificationModel{
 		ID:        n.ID,
 		UserID:    n.UserIDReceiver,
+		SenderID:  n.UserIDSender,
 		Type:      n.Type,
 		Message:   message,
+		Version:   version,
+		Channels:  n.Channels,
+		GroupKey:  groupKey,
+		Count:     n.Count,
+		ReviewID:  reviewID,
 		Read:      n.Read,
 		CreatedAt: n.CreatedAt,
 		UpdatedAt: n.UpdatedAt,
 	}, nil
 }
+
+type NotificationPreferenceModel struct {
+	UserID    uuid.UUID `db:"user_id"`
+	Type      string    `db:"type"`
+	Channel   string    `db:"channel"`
+	Enabled   bool      `db:"enabled"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func ToNotificationPreferencesDomain(rows []NotificationPreferenceModel) domain.NotificationPreferences {
+	prefs := make(domain.NotificationPreferences)
+	for _, row := range rows {
+		if prefs[row.Type] == nil {
+			prefs[row.Type] = make(map[string]bool)
+		}
+		prefs[row.Type][row.Channel] = row.Enabled
+	}
+	return prefs
+}
+
+type NotificationDigestModel struct {
+	UserID      uuid.UUID  `db:"user_id"`
+	Frequency   string     `db:"frequency"`
+	LastSentAt  *time.Time `db:"last_sent_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+	AvailableAt time.Time  `db:"available_at"`
+	Attempts    int        `db:"attempts"`
+}
+
+func (m *NotificationDigestModel) ToDomain() domain.DigestSettings {
+	return domain.DigestSettings{
+		UserID:     m.UserID,
+		Frequency:  m.Frequency,
+		LastSentAt: m.LastSentAt,
+	}
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"music-snap/services/musicsnap/internal/domain"
+	"time"
+)
+
+type OutboxEventModel struct {
+	Seq         int64           `db:"seq"`
+	ID          uuid.UUID       `db:"id"`
+	Type        string          `db:"type"`
+	Payload     json.RawMessage `db:"payload"`
+	Attempts    int             `db:"attempts"`
+	LastError   string          `db:"last_error"`
+	AvailableAt time.Time       `db:"available_at"`
+	CreatedAt   time.Time       `db:"created_at"`
+	PublishedAt *time.Time      `db:"published_at"`
+	HandledAt   *time.Time      `db:"handled_at"`
+}
+
+func (m *OutboxEventModel) ToDomain() domain.DomainEvent {
+	return domain.DomainEvent{
+		ID:          m.ID,
+		Seq:         m.Seq,
+		Type:        m.Type,
+		Payload:     m.Payload,
+		Attempts:    m.Attempts,
+		CreatedAt:   m.CreatedAt,
+		PublishedAt: m.PublishedAt,
+		HandledAt:   m.HandledAt,
+	}
+}
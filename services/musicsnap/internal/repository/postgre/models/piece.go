@@ -1,26 +1,175 @@
 package models
 
-//type PieceModel struct {
-//	ID        uuid.UUID `db:"id"`
-//	Type      string    `db:"type"`
-//	CreatedAt time.Time `db:"created_at"`
-//	UpdatedAt time.Time `db:"updated_at"`
-//}
-//
-//func (m *PieceModel) ToValidDomain() domain.Piece {
-//	return domain.Piece{
-//		ID:        m.ID,
-//		Type:      m.Type,
-//		CreatedAt: m.CreatedAt,
-//		UpdatedAt: m.UpdatedAt,
-//	}
-//}
-//
-//func ToPieceModel(p domain.Piece) PieceModel {
-//	return PieceModel{
-//		ID:        p.ID,
-//		Type:      p.Type,
-//		CreatedAt: p.CreatedAt,
-//		UpdatedAt: p.UpdatedAt,
-//	}
-//}
+import (
+	"github.com/google/uuid"
+	"music-snap/services/musicsnap/internal/domain"
+	"time"
+)
+
+type ArtistModel struct {
+	ID        uuid.UUID `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (m *ArtistModel) ToDomain() domain.Artist {
+	return domain.Artist{
+		ID:        m.ID,
+		Name:      m.Name,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func ToArtistModel(a domain.Artist) ArtistModel {
+	return ArtistModel{
+		ID:        a.ID,
+		Name:      a.Name,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
+
+type PieceModel struct {
+	ID        uuid.UUID `db:"id"`
+	Type      string    `db:"type"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (m *PieceModel) ToDomain() domain.Piece {
+	return domain.Piece{
+		ID:        m.ID,
+		Type:      m.Type,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func ToPieceModel(p domain.Piece) PieceModel {
+	return PieceModel{
+		ID:        p.ID,
+		Type:      p.Type,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+type TrackModel struct {
+	ID         uuid.UUID  `db:"id"`
+	ArtistID   *uuid.UUID `db:"artist_id"`
+	AlbumID    *uuid.UUID `db:"album_id"`
+	Title      string     `db:"title"`
+	DurationMs int        `db:"duration_ms"`
+	Explicit   bool       `db:"explicit"`
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+}
+
+func (m *TrackModel) ToDomain() domain.Track {
+	t := domain.Track{
+		Title:      m.Title,
+		DurationMs: m.DurationMs,
+		Explicit:   m.Explicit,
+	}
+	if m.ArtistID != nil {
+		t.ArtistID = *m.ArtistID
+	}
+	if m.AlbumID != nil {
+		t.AlbumID = *m.AlbumID
+	}
+	return t
+}
+
+func ToTrackModel(pieceID uuid.UUID, t domain.Track) TrackModel {
+	m := TrackModel{
+		ID:         pieceID,
+		Title:      t.Title,
+		DurationMs: t.DurationMs,
+		Explicit:   t.Explicit,
+	}
+	if t.ArtistID != uuid.Nil {
+		m.ArtistID = &t.ArtistID
+	}
+	if t.AlbumID != uuid.Nil {
+		m.AlbumID = &t.AlbumID
+	}
+	return m
+}
+
+type AlbumModel struct {
+	ID          uuid.UUID  `db:"id"`
+	ArtistID    *uuid.UUID `db:"artist_id"`
+	Title       string     `db:"title"`
+	CoverURL    *string    `db:"cover_url"`
+	ReleaseDate *time.Time `db:"release_date"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+}
+
+func (m *AlbumModel) ToDomain() domain.Album {
+	a := domain.Album{
+		Title: m.Title,
+	}
+	if m.ArtistID != nil {
+		a.ArtistID = *m.ArtistID
+	}
+	if m.CoverURL != nil {
+		a.CoverURL = *m.CoverURL
+	}
+	if m.ReleaseDate != nil {
+		a.ReleaseDate = *m.ReleaseDate
+	}
+	return a
+}
+
+func ToAlbumModel(pieceID uuid.UUID, a domain.Album) AlbumModel {
+	m := AlbumModel{
+		ID:    pieceID,
+		Title: a.Title,
+	}
+	if a.ArtistID != uuid.Nil {
+		m.ArtistID = &a.ArtistID
+	}
+	if a.CoverURL != "" {
+		m.CoverURL = &a.CoverURL
+	}
+	if !a.ReleaseDate.IsZero() {
+		m.ReleaseDate = &a.ReleaseDate
+	}
+	return m
+}
+
+type PieceAliasModel struct {
+	Source     string    `db:"source"`
+	ExternalID string    `db:"external_id"`
+	PieceID    uuid.UUID `db:"piece_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func (m *PieceAliasModel) ToDomain() domain.PieceAlias {
+	return domain.PieceAlias{
+		Source:     m.Source,
+		ExternalID: m.ExternalID,
+		PieceID:    m.PieceID,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+func ToPieceAliasModel(a domain.PieceAlias) PieceAliasModel {
+	return PieceAliasModel{
+		Source:     a.Source,
+		ExternalID: a.ExternalID,
+		PieceID:    a.PieceID,
+		CreatedAt:  a.CreatedAt,
+	}
+}
+
+func ToPieceAliasesDomain(aliases []PieceAliasModel) []domain.PieceAlias {
+	res := make([]domain.PieceAlias, len(aliases))
+	for i, a := range aliases {
+		res[i] = a.ToDomain()
+	}
+	return res
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"music-snap/services/musicsnap/internal/domain"
+	"time"
+)
+
+// EventReminderModel is a row of event_reminders with name, date and location of its event
+type EventReminderModel struct {
+	ID            int64      `db:"id"`
+	EventID       uuid.UUID  `db:"event_id"`
+	UserID        uuid.UUID  `db:"user_id"`
+	OffsetSeconds int        `db:"offset_seconds"`
+	RemindAt      time.Time  `db:"remind_at"`
+	SentAt        *time.Time `db:"sent_at"`
+
+	EventName     string    `db:"event_name"`
+	EventDate     time.Time `db:"event_date"`
+	EventLocation string    `db:"event_location"`
+}
+
+func (m *EventReminderModel) ToDomain() domain.EventReminder {
+	return domain.EventReminder{
+		ID:       m.ID,
+		EventID:  m.EventID,
+		UserID:   m.UserID,
+		Before:   time.Duration(m.OffsetSeconds) * time.Second,
+		RemindAt: m.RemindAt,
+		SentAt:   m.SentAt,
+		Event: domain.Event{
+			ID:       m.EventID,
+			Name:     m.EventName,
+			Date:     m.EventDate,
+			Location: m.EventLocation,
+		},
+	}
+}
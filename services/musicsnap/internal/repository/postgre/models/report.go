@@ -0,0 +1,58 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"music-snap/services/musicsnap/internal/domain"
+	"time"
+)
+
+type ReportModel struct {
+	ID         int        `db:"id"`
+	ReporterID *uuid.UUID `db:"reporter_id"`
+	TargetType string     `db:"target_type"`
+	TargetID   string     `db:"target_id"`
+	Reason     string     `db:"reason"`
+	Comment    string     `db:"comment"`
+	Status     string     `db:"status"`
+	ResolvedBy *uuid.UUID `db:"resolved_by"`
+	ResolvedAt *time.Time `db:"resolved_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+}
+
+func (m *ReportModel) ToDomain() domain.Report {
+	var reporterID uuid.UUID
+	if m.ReporterID != nil {
+		reporterID = *m.ReporterID
+	}
+	return domain.Report{
+		ID:         m.ID,
+		ReporterID: reporterID,
+		TargetType: domain.ReportTargetType(m.TargetType),
+		TargetID:   m.TargetID,
+		Reason:     domain.ModerationReasonCode(m.Reason),
+		Comment:    m.Comment,
+		Status:     domain.ReportStatus(m.Status),
+		ResolvedBy: m.ResolvedBy,
+		ResolvedAt: m.ResolvedAt,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
+
+type ReportTargetModel struct {
+	TargetType  string     `db:"target_type"`
+	TargetID    string     `db:"target_id"`
+	OpenReports int        `db:"open_reports"`
+	HiddenAt    *time.Time `db:"hidden_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+}
+
+func (m *ReportTargetModel) ToDomain() domain.ReportTarget {
+	return domain.ReportTarget{
+		TargetType:  domain.ReportTargetType(m.TargetType),
+		TargetID:    m.TargetID,
+		OpenReports: m.OpenReports,
+		HiddenAt:    m.HiddenAt,
+	}
+}
@@ -17,56 +17,118 @@ type ReviewModel struct {
 	Content  string `db:"content"`
 
 	// For global
-	Moderated bool `db:"moderated"`
-	Published bool `db:"published"`
+	Moderated        bool    `db:"moderated"`
+	ModerationStatus string  `db:"moderation_status"`
+	ModerationReason *string `db:"moderation_reason"`
+	Hidden           bool    `db:"hidden"`
 
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	Published   bool       `db:"published"`
+	PublishAt   *time.Time `db:"publish_at"`
+	PublishedAt *time.Time `db:"published_at"`
+
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+	EditedAt  *time.Time `db:"edited_at"`
+
+	// CommentsCount is joined from threads
+	CommentsCount int `db:"comments_count"`
 }
 
 func (m *ReviewModel) ToDomain() domain.Review {
 	return domain.Review{
-		ID:        m.ID,
-		UserID:    m.UserID,
-		PieceID:   m.PieceID,
-		Rating:    m.Rating,
-		Content:   m.Content,
-		PhotoURL:  m.PhotoURL,
-		Moderated: m.Moderated,
-		Published: m.Published,
-		CreatedAt: m.CreatedAt,
-		UpdatedAt: m.UpdatedAt,
+		ID:               m.ID,
+		UserID:           m.UserID,
+		PieceID:          m.PieceID,
+		Rating:           m.Rating,
+		Content:          m.Content,
+		PhotoURL:         m.PhotoURL,
+		Moderated:        m.Moderated,
+		ModerationStatus: domain.ModerationStatus(m.ModerationStatus),
+		ModerationReason: (*domain.ModerationReasonCode)(m.ModerationReason),
+		Hidden:           m.Hidden,
+		Published:        m.Published,
+		PublishAt:        m.PublishAt,
+		PublishedAt:      m.PublishedAt,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+		EditedAt:         m.EditedAt,
+		CommentsCount:    m.CommentsCount,
 	}
 }
 
 func (m *ReviewModel) ToDomainProfile(p domain.Profile) domain.Review {
 	p.ID = m.UserID
 	return domain.Review{
+		ID:               m.ID,
+		UserID:           m.UserID,
+		PieceID:          m.PieceID,
+		Rating:           m.Rating,
+		Content:          m.Content,
+		PhotoURL:         m.PhotoURL,
+		Moderated:        m.Moderated,
+		ModerationStatus: domain.ModerationStatus(m.ModerationStatus),
+		ModerationReason: (*domain.ModerationReasonCode)(m.ModerationReason),
+		Hidden:           m.Hidden,
+		Published:        m.Published,
+		PublishAt:        m.PublishAt,
+		PublishedAt:      m.PublishedAt,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+		EditedAt:         m.EditedAt,
+		CommentsCount:    m.CommentsCount,
+		Profile:          &p,
+	}
+}
+
+func ToReviewModel(r domain.Review) ReviewModel {
+	return ReviewModel{
+		ID:               r.ID,
+		UserID:           r.UserID,
+		PieceID:          r.PieceID,
+		Rating:           r.Rating,
+		PhotoURL:         r.PhotoURL,
+		Content:          r.Content,
+		Moderated:        r.Moderated,
+		ModerationStatus: string(r.ModerationStatus),
+		ModerationReason: (*string)(r.ModerationReason),
+		Hidden:           r.Hidden,
+		Published:        r.Published,
+		PublishAt:        r.PublishAt,
+		PublishedAt:      r.PublishedAt,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+		EditedAt:         r.EditedAt,
+	}
+}
+
+type ReviewRevisionModel struct {
+	ID       int        `db:"id"`
+	ReviewID int        `db:"review_id"`
+	Revision int        `db:"revision"`
+	EditorID *uuid.UUID `db:"editor_id"`
+
+	PieceID  string `db:"piece_id"`
+	Rating   int    `db:"rating"`
+	Content  string `db:"content"`
+	PhotoURL string `db:"photo_url"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (m *ReviewRevisionModel) ToDomain() domain.ReviewRevision {
+	var editorID uuid.UUID
+	if m.EditorID != nil {
+		editorID = *m.EditorID
+	}
+	return domain.ReviewRevision{
 		ID:        m.ID,
-		UserID:    m.UserID,
+		ReviewID:  m.ReviewID,
+		Revision:  m.Revision,
+		EditorID:  editorID,
 		PieceID:   m.PieceID,
 		Rating:    m.Rating,
 		Content:   m.Content,
 		PhotoURL:  m.PhotoURL,
-		Moderated: m.Moderated,
-		Published: m.Published,
 		CreatedAt: m.CreatedAt,
-		UpdatedAt: m.UpdatedAt,
-		Profile:   &p,
-	}
-}
-
-func ToReviewModel(r domain.Review) ReviewModel {
-	return ReviewModel{
-		ID:        r.ID,
-		UserID:    r.UserID,
-		PieceID:   r.PieceID,
-		Rating:    r.Rating,
-		PhotoURL:  r.PhotoURL,
-		Content:   r.Content,
-		Moderated: r.Moderated,
-		Published: r.Published,
-		CreatedAt: r.CreatedAt,
-		UpdatedAt: r.UpdatedAt,
 	}
 }
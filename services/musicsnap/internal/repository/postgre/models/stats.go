@@ -0,0 +1,42 @@
+package models
+
+import (
+	"github.com/lib/pq"
+	"music-snap/services/musicsnap/internal/domain"
+	"time"
+)
+
+type PieceStatsModel struct {
+	PieceID       string        `db:"piece_id"`
+	RatingsCount  int           `db:"ratings_count"`
+	ReviewsCount  int           `db:"reviews_count"`
+	RatingSum     int64         `db:"rating_sum"`
+	Histogram     pq.Int64Array `db:"histogram"`
+	LikesCount    int           `db:"likes_count"`
+	DislikesCount int           `db:"dislikes_count"`
+	CreatedAt     time.Time     `db:"created_at"`
+	UpdatedAt     time.Time     `db:"updated_at"`
+}
+
+func (m *PieceStatsModel) ToDomain() domain.TrackStats {
+	var histogram domain.RatingHistogram
+	for i := 0; i < len(histogram) && i < len(m.Histogram); i++ {
+		histogram[i] = int(m.Histogram[i])
+	}
+
+	var average float64
+	if m.RatingsCount > 0 {
+		average = float64(m.RatingSum) / float64(m.RatingsCount)
+	}
+
+	return domain.TrackStats{
+		PieceID:            m.PieceID,
+		TotalLikesCount:    m.LikesCount,
+		TotalDislikesCount: m.DislikesCount,
+		TotalReviewsCount:  m.ReviewsCount,
+		TotalRatingsCount:  m.RatingsCount,
+		AverageRating:      average,
+		MedianRating:       histogram.Median(),
+		Histogram:          histogram,
+	}
+}
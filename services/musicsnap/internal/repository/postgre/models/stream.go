@@ -0,0 +1,32 @@
+package models
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"music-snap/services/musicsnap/internal/domain"
+	"time"
+)
+
+type StreamEventModel struct {
+	ID        int64           `db:"id"`
+	UserID    uuid.UUID       `db:"user_id"`
+	Kind      string          `db:"kind"`
+	Payload   json.RawMessage `db:"payload"`
+	CreatedAt time.Time       `db:"created_at"`
+	Position  *int64          `db:"position"`
+}
+
+func (m *StreamEventModel) ToDomain() domain.StreamEvent {
+	// клиент продолжает поток с позиции, а не с id вставки
+	id := m.ID
+	if m.Position != nil {
+		id = *m.Position
+	}
+	return domain.StreamEvent{
+		ID:        id,
+		UserID:    m.UserID,
+		Kind:      m.Kind,
+		Payload:   m.Payload,
+		CreatedAt: m.CreatedAt,
+	}
+}
@@ -1,30 +1,68 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"github.com/google/uuid"
 	"music-snap/services/musicsnap/internal/domain"
 	"time"
 )
 
 type SubscriptionModel struct {
-	ID               int       `db:"sub_id"`
-	SubscriberID     uuid.UUID `db:"subscriber_id"`
-	FollowedID       uuid.UUID `db:"followed_id"`
-	NotificationFlag bool      `db:"notification_flag"`
-	//NotificationFlags []byte          `db:"notification_flags" sql:"type:jsonb"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID                int                    `db:"sub_id"`
+	SubscriberID      uuid.UUID              `db:"subscriber_id"`
+	FollowedID        uuid.UUID              `db:"followed_id"`
+	NotificationFlags SubscriptionFlagsModel `db:"notification_flags"`
+	CreatedAt         time.Time              `db:"created_at"`
+	UpdatedAt         time.Time              `db:"updated_at"`
+}
+
+// SubscriptionFlagsModel is stored as JSONB, keys match notification types
+type SubscriptionFlagsModel struct {
+	NewReview   bool `json:"new_review"`
+	NewPlaylist bool `json:"new_playlist"`
+	Event       bool `json:"event"`
+}
+
+func (f *SubscriptionFlagsModel) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, f)
+	case string:
+		return json.Unmarshal([]byte(v), f)
+	case nil:
+		*f = SubscriptionFlagsModel{}
+		return nil
+	}
+	return errors.New("unsupported type of subscription notification flags")
+}
+
+func (f SubscriptionFlagsModel) Value() (driver.Value, error) {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (f SubscriptionFlagsModel) ToDomain() domain.SubscriptionFlags {
+	return domain.SubscriptionFlags{
+		NewReview:   f.NewReview,
+		NewPlaylist: f.NewPlaylist,
+		Event:       f.Event,
+	}
 }
 
 func (m *SubscriptionModel) ToLightDomain() domain.Subscription {
 
 	return domain.Subscription{
-		ID:               m.ID,
-		SubscriberID:     m.SubscriberID,
-		FollowedID:       m.FollowedID,
-		NotificationFlag: m.NotificationFlag,
-		CreatedAt:        m.CreatedAt,
-		UpdatedAt:        m.UpdatedAt,
+		ID:                m.ID,
+		SubscriberID:      m.SubscriberID,
+		FollowedID:        m.FollowedID,
+		NotificationFlags: m.NotificationFlags.ToDomain(),
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
 		//ProfileOfInterest: profile,
 	}
 }
@@ -35,7 +73,7 @@ func (m *SubscriptionModel) ToDomain(p domain.Profile) domain.Subscription {
 		ID:                m.ID,
 		SubscriberID:      m.SubscriberID,
 		FollowedID:        m.FollowedID,
-		NotificationFlag:  m.NotificationFlag,
+		NotificationFlags: m.NotificationFlags.ToDomain(),
 		CreatedAt:         m.CreatedAt,
 		UpdatedAt:         m.UpdatedAt,
 		ProfileOfInterest: p,
@@ -44,11 +82,15 @@ func (m *SubscriptionModel) ToDomain(p domain.Profile) domain.Subscription {
 func ToSubscriptionModel(s domain.Subscription) SubscriptionModel {
 
 	return SubscriptionModel{
-		ID:               s.ID,
-		SubscriberID:     s.SubscriberID,
-		FollowedID:       s.FollowedID,
-		NotificationFlag: s.NotificationFlag,
-		CreatedAt:        s.CreatedAt,
-		UpdatedAt:        s.UpdatedAt,
+		ID:           s.ID,
+		SubscriberID: s.SubscriberID,
+		FollowedID:   s.FollowedID,
+		NotificationFlags: SubscriptionFlagsModel{
+			NewReview:   s.NotificationFlags.NewReview,
+			NewPlaylist: s.NotificationFlags.NewPlaylist,
+			Event:       s.NotificationFlags.Event,
+		},
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
 	}
 }
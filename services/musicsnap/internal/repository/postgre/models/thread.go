@@ -1,24 +0,0 @@
-package models
-
-// TODO DEPRECATED
-//type ThreadModel struct {
-//	ID        uuid.UUID `db:"id"`
-//	CreatedAt time.Time `db:"created_at"`
-//	UpdatedAt time.Time `db:"updated_at"`
-//}
-//
-//func (m *ThreadModel) ToValidDomain() domain.Thread {
-//	return domain.Thread{
-//		ID:        m.ID,
-//		CreatedAt: m.CreatedAt,
-//		UpdatedAt: m.UpdatedAt,
-//	}
-//}
-//
-//func ToThreadModel(t domain.Thread) ThreadModel {
-//	return ThreadModel{
-//		ID:        t.ID,
-//		CreatedAt: t.CreatedAt,
-//		UpdatedAt: t.UpdatedAt,
-//	}
-//}
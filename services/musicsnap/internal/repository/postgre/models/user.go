@@ -17,6 +17,7 @@ type UserModel struct {
 	PasswordHash string    `db:"password_hash"`
 	CreatedAt    time.Time `db:"created_at"`
 	UpdatedAt    time.Time `db:"updated_at"`
+	Locale       string    `db:"locale"`
 }
 
 type RoleModel struct {
@@ -51,6 +52,7 @@ func (m *UserModel) ToDomain(roles []RoleModel) domain.User {
 		Email:        m.Email,
 		PasswordHash: m.PasswordHash,
 		Roles:        r,
+		Locale:       m.Locale,
 		CreatedAt:    m.CreatedAt,
 		UpdatedAt:    m.UpdatedAt,
 	}
@@ -70,6 +72,7 @@ func (m *UserModel) ToDomainWithoutRoles() domain.User {
 		Profile:      p,
 		Email:        m.Email,
 		PasswordHash: m.PasswordHash,
+		Locale:       m.Locale,
 	}
 }
 
@@ -97,5 +100,6 @@ func ToUserModel(u domain.User) UserModel {
 		PasswordHash: u.PasswordHash,
 		CreatedAt:    u.CreatedAt,
 		UpdatedAt:    u.UpdatedAt,
+		Locale:       u.Locale,
 	}
 }
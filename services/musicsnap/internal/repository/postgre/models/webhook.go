@@ -0,0 +1,107 @@
+package models
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"music-snap/services/musicsnap/internal/domain"
+	"time"
+)
+
+type WebhookModel struct {
+	ID         int            `db:"id"`
+	UserID     *uuid.UUID     `db:"user_id"`
+	URL        string         `db:"url"`
+	Secret     string         `db:"secret"`
+	EventTypes pq.StringArray `db:"event_types"`
+	Active     bool           `db:"active"`
+	CreatedAt  time.Time      `db:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at"`
+}
+
+func (m *WebhookModel) ToDomain() domain.Webhook {
+	return domain.Webhook{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		URL:        m.URL,
+		Secret:     m.Secret,
+		EventTypes: m.EventTypes,
+		Active:     m.Active,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
+
+func ToWebhookModel(w domain.Webhook) WebhookModel {
+	return WebhookModel{
+		ID:         w.ID,
+		UserID:     w.UserID,
+		URL:        w.URL,
+		Secret:     w.Secret,
+		EventTypes: w.EventTypes,
+		Active:     w.Active,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+	}
+}
+
+type WebhookDeliveryModel struct {
+	ID            int64           `db:"id"`
+	WebhookID     int             `db:"webhook_id"`
+	EventID       uuid.UUID       `db:"event_id"`
+	EventType     string          `db:"event_type"`
+	Payload       json.RawMessage `db:"payload"`
+	Status        string          `db:"status"`
+	Attempts      int             `db:"attempts"`
+	NextAttemptAt *time.Time      `db:"next_attempt_at"`
+	CreatedAt     time.Time       `db:"created_at"`
+	UpdatedAt     time.Time       `db:"updated_at"`
+	DeliveredAt   *time.Time      `db:"delivered_at"`
+}
+
+func (m *WebhookDeliveryModel) ToDomain() domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		ID:            m.ID,
+		WebhookID:     m.WebhookID,
+		EventID:       m.EventID,
+		EventType:     m.EventType,
+		Payload:       m.Payload,
+		Status:        m.Status,
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+		DeliveredAt:   m.DeliveredAt,
+	}
+}
+
+type WebhookAttemptModel struct {
+	ID          int64     `db:"id"`
+	DeliveryID  int64     `db:"delivery_id"`
+	StatusCode  int       `db:"status_code"`
+	Error       string    `db:"error"`
+	DurationMs  int       `db:"duration_ms"`
+	AttemptedAt time.Time `db:"attempted_at"`
+}
+
+func (m *WebhookAttemptModel) ToDomain() domain.WebhookAttempt {
+	return domain.WebhookAttempt{
+		ID:          m.ID,
+		DeliveryID:  m.DeliveryID,
+		StatusCode:  m.StatusCode,
+		Error:       m.Error,
+		Duration:    time.Duration(m.DurationMs) * time.Millisecond,
+		AttemptedAt: m.AttemptedAt,
+	}
+}
+
+func ToWebhookAttemptModel(a domain.WebhookAttempt) WebhookAttemptModel {
+	return WebhookAttemptModel{
+		ID:          a.ID,
+		DeliveryID:  a.DeliveryID,
+		StatusCode:  a.StatusCode,
+		Error:       a.Error,
+		DurationMs:  int(a.Duration / time.Millisecond),
+		AttemptedAt: a.AttemptedAt,
+	}
+}
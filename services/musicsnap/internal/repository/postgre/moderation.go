@@ -0,0 +1,404 @@
+package postgre
+
+import (
+	c "context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	qb "music-snap/pkg/querybuilder"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/repository/postgre/models"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var _ ports.ModerationRepository = &moderationRepository{}
+
+func NewModerationRepository(db *sqlx.DB) ports.ModerationRepository {
+	return &moderationRepository{db: db,
+		spanName: spanBaseName + "moderationRepository."}
+}
+
+func newModerationRepository(db *sqlx.DB) moderationRepository {
+	return moderationRepository{db: db,
+		spanName: spanBaseName + "moderationRepository."}
+}
+
+type moderationRepository struct {
+	db       *sqlx.DB
+	spanName string
+}
+
+func (r moderationRepository) Enqueue(ctx c.Context, reviewID int, reason domain.QueueReason) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Enqueue")
+	defer span.End()
+
+	return enqueueModeration(ctx, r.db, reviewID, reason)
+}
+
+// enqueueModeration puts review to moderation queue, report raises reason of already queued review
+func enqueueModeration(ctx c.Context, db sqlx.ExecerContext, reviewID int, reason domain.QueueReason) error {
+	logger := zapctx.Logger(ctx)
+
+	// жалоба важнее исходной причины и поднимает рецензию в очереди
+	q := `
+	INSERT INTO moderation_queue (review_id, reason)
+	VALUES ($1, $2)
+	ON CONFLICT (review_id) DO UPDATE SET reason = EXCLUDED.reason
+	WHERE EXCLUDED.reason = 'reported';
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	_, err := db.ExecContext(ctx, q, reviewID, string(reason))
+	if err != nil {
+		if pqErrorCode(err) == foreignKeyViolationCode {
+			return app.NewError(http.StatusNotFound, "review not found",
+				fmt.Sprintf("review %d not found", reviewID), err)
+		}
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return nil
+}
+
+func (r moderationRepository) ListQueue(ctx c.Context, filter domain.ModerationQueueFilter, pag domain.IDPagination) ([]domain.ModerationQueueItem, domain.IDPagination, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"ListQueue")
+	defer span.End()
+
+	var reason *string
+	if filter.Reason != nil {
+		reasonStr := string(*filter.Reason)
+		reason = &reasonStr
+	}
+
+	qBuild := qb.NewNamed().
+		Q("SELECT moderation_queue.*, reviews.* FROM moderation_queue").
+		Q("JOIN reviews ON reviews.id = moderation_queue.review_id").
+		WhereOptPart().
+		CompConnectorOpt("moderation_queue.reason", qb.EQ(), "reason", reason, qb.AND()).
+		CompConnectorOpt("moderation_queue.claimed_by", qb.EQ(), "claimed_by", filter.ClaimedBy, qb.AND()).
+		CompConnectorOpt("moderation_queue.review_id", qb.GT(), "last_id", pag.LastID, qb.AND()).
+		EndWhereOpt().
+		OrderBy("moderation_queue.review_id", true).
+		Limit("", pag.Limit)
+	q, args := qBuild.Build()
+
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	preparedQ, err := r.db.PrepareNamedContext(ctx, q)
+	if err != nil {
+		return nil, pag, app.NewError(http.StatusInternalServerError, "unknown error", "internal error preparing named query", err)
+	}
+	defer preparedQ.Close()
+
+	var rows []models.ModerationQueueReviewModel
+	err = preparedQ.SelectContext(ctx, &rows, args)
+	if err != nil {
+		return nil, pag, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	if len(rows) == 0 {
+		pag.LastID = 0
+		return []domain.ModerationQueueItem{}, pag, nil
+	}
+
+	items := make([]domain.ModerationQueueItem, len(rows))
+	for i, row := range rows {
+		items[i] = row.ToDomain()
+	}
+
+	pag.LastID = items[len(items)-1].Review.ID
+	return items, pag, nil
+}
+
+func (r moderationRepository) GetQueueItem(ctx c.Context, reviewID int) (domain.ModerationQueueItem, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"GetQueueItem")
+	defer span.End()
+
+	q := `
+	SELECT moderation_queue.*, reviews.* FROM moderation_queue
+	JOIN reviews ON reviews.id = moderation_queue.review_id
+	WHERE moderation_queue.review_id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var row models.ModerationQueueReviewModel
+	err := r.db.GetContext(ctx, &row, q, reviewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ModerationQueueItem{}, app.NewError(http.StatusNotFound, "review is not in moderation queue",
+				fmt.Sprintf("review %d not found in moderation queue", reviewID), err)
+		}
+		return domain.ModerationQueueItem{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return row.ToDomain(), nil
+}
+
+// ClaimNext claims oldest free reviews, reported ones first. Claim is written to the journal by the same statement
+func (r moderationRepository) ClaimNext(ctx c.Context, moderatorID uuid.UUID, staleBefore time.Time, limit int) ([]domain.ModerationQueueItem, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"ClaimNext")
+	defer span.End()
+
+	q := `
+	WITH claimed AS (
+	    UPDATE moderation_queue
+	    SET claimed_by = $1, claimed_at = NOW()
+	    WHERE review_id IN (SELECT review_id FROM moderation_queue
+	                        WHERE claimed_by IS NULL OR claimed_at < $2
+	                        ORDER BY reason = 'reported' DESC, enqueued_at
+	                        LIMIT $3
+	                        FOR UPDATE SKIP LOCKED)
+	    RETURNING *
+	), logged AS (
+	    INSERT INTO moderation_actions (review_id, moderator_id, action)
+	    SELECT review_id, claimed_by, 'claim' FROM claimed
+	)
+	SELECT claimed.*, reviews.* FROM claimed
+	JOIN reviews ON reviews.id = claimed.review_id
+	ORDER BY claimed.reason = 'reported' DESC, claimed.enqueued_at;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var rows []models.ModerationQueueReviewModel
+	err := r.db.SelectContext(ctx, &rows, q, moderatorID, staleBefore, limit)
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	items := make([]domain.ModerationQueueItem, len(rows))
+	for i, row := range rows {
+		items[i] = row.ToDomain()
+	}
+	return items, nil
+}
+
+func (r moderationRepository) Claim(ctx c.Context, reviewID int, moderatorID uuid.UUID, staleBefore time.Time) (domain.ModerationQueueItem, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Claim")
+	defer span.End()
+
+	q := `
+	WITH claimed AS (
+	    UPDATE moderation_queue
+	    SET claimed_by = $2, claimed_at = NOW()
+	    WHERE review_id = $1 AND (claimed_by IS NULL OR claimed_by = $2 OR claimed_at < $3)
+	    RETURNING *
+	), logged AS (
+	    INSERT INTO moderation_actions (review_id, moderator_id, action)
+	    SELECT review_id, claimed_by, 'claim' FROM claimed
+	)
+	SELECT claimed.*, reviews.* FROM claimed
+	JOIN reviews ON reviews.id = claimed.review_id;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var row models.ModerationQueueReviewModel
+	err := r.db.GetContext(ctx, &row, q, reviewID, moderatorID, staleBefore)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ModerationQueueItem{}, r.claimConflict(ctx, reviewID)
+		}
+		return domain.ModerationQueueItem{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return row.ToDomain(), nil
+}
+
+func (r moderationRepository) Release(ctx c.Context, reviewID int, moderatorID uuid.UUID, override bool) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Release")
+	defer span.End()
+
+	q := `
+	WITH released AS (
+	    UPDATE moderation_queue
+	    SET claimed_by = NULL, claimed_at = NULL
+	    WHERE review_id = $1 AND claimed_by IS NOT NULL AND (claimed_by = $2 OR $3)
+	    RETURNING review_id
+	)
+	INSERT INTO moderation_actions (review_id, moderator_id, action)
+	SELECT review_id, $2, 'release' FROM released;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	res, err := r.db.ExecContext(ctx, q, reviewID, moderatorID, override)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	released, err := res.RowsAffected()
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "failed to get affected rows", err)
+	}
+	if released == 0 {
+		return r.claimConflict(ctx, reviewID)
+	}
+	return nil
+}
+
+// claimConflict explains why claim operation did not affect the queue: review is not queued or held by other moderator
+func (r moderationRepository) claimConflict(ctx c.Context, reviewID int) error {
+	_, err := r.GetQueueItem(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+	return app.NewError(http.StatusConflict, "review is claimed by other moderator",
+		fmt.Sprintf("review %d is claimed by other moderator", reviewID), nil)
+}
+
+func (r moderationRepository) Decide(ctx c.Context, decision domain.ModerationAction, staleBefore time.Time, override bool) (domain.Review, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Decide")
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return domain.Review{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to start transaction", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	// рецензии может не быть в очереди: модератор вправе пересмотреть прошлое решение
+	qLock := `
+	SELECT * FROM moderation_queue
+	WHERE review_id = $1
+	FOR UPDATE;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qLock)))
+
+	var queued models.ModerationQueueModel
+	err = tx.GetContext(ctx, &queued, qLock, decision.ReviewID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return domain.Review{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	if err == nil && !override && queued.ToDomain(domain.Review{}).ClaimedByOther(decision.ModeratorID, staleBefore) {
+		return domain.Review{}, app.NewError(http.StatusConflict, "review is claimed by other moderator",
+			fmt.Sprintf("review %d is claimed by moderator %s", decision.ReviewID, queued.ClaimedBy), nil)
+	}
+
+	// решение модератора закрывает жалобы на рецензию: одобрение их отклоняет, отказ подтверждает
+	reportStatus := domain.ReportDismissed
+	if decision.Action == domain.ModerationReject {
+		reportStatus = domain.ReportUpheld
+	}
+	_, err = resolveReports(ctx, tx, domain.ReportTargetReview, strconv.Itoa(decision.ReviewID), reportStatus, decision.ModeratorID)
+	if err != nil {
+		return domain.Review{}, err
+	}
+
+	qReview := `
+	UPDATE reviews
+	SET moderation_status = $1, moderation_reason = $2
+	WHERE id = $3
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qReview)))
+
+	var review models.ReviewModel
+	err = tx.GetContext(ctx, &review, qReview, string(decision.Status()), (*string)(decision.ReasonCode), decision.ReviewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Review{}, app.NewError(http.StatusNotFound, "review not found",
+				fmt.Sprintf("review %d not found", decision.ReviewID), err)
+		}
+		return domain.Review{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	qDequeue := `
+	DELETE FROM moderation_queue
+	WHERE review_id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qDequeue)))
+
+	_, err = tx.ExecContext(ctx, qDequeue, decision.ReviewID)
+	if err != nil {
+		return domain.Review{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	qJournal := `
+	INSERT INTO moderation_actions (review_id, moderator_id, action, reason_code, comment)
+	VALUES ($1, $2, $3, $4, $5);
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qJournal)))
+
+	_, err = tx.ExecContext(ctx, qJournal, decision.ReviewID, decision.ModeratorID, string(decision.Action),
+		(*string)(decision.ReasonCode), decision.Comment)
+	if err != nil {
+		return domain.Review{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return domain.Review{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to commit transaction", err)
+	}
+
+	return review.ToDomain(), nil
+}
+
+func (r moderationRepository) ListActions(ctx c.Context, filter domain.ModerationActionFilter, pag domain.IDPagination) ([]domain.ModerationAction, domain.IDPagination, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"ListActions")
+	defer span.End()
+
+	qBuild := qb.NewNamed().
+		Q("SELECT * FROM moderation_actions").
+		WhereOptPart().
+		CompConnectorOpt("moderator_id", qb.EQ(), "moderator_id", filter.ModeratorID, qb.AND()).
+		CompConnectorOpt("review_id", qb.EQ(), "review_id", filter.ReviewID, qb.AND()).
+		CompConnectorOpt("id", qb.GT(), "last_id", pag.LastID, qb.AND()).
+		EndWhereOpt().
+		OrderBy("id", true).
+		Limit("", pag.Limit)
+	q, args := qBuild.Build()
+
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	preparedQ, err := r.db.PrepareNamedContext(ctx, q)
+	if err != nil {
+		return nil, pag, app.NewError(http.StatusInternalServerError, "unknown error", "internal error preparing named query", err)
+	}
+	defer preparedQ.Close()
+
+	var rows []models.ModerationActionModel
+	err = preparedQ.SelectContext(ctx, &rows, args)
+	if err != nil {
+		return nil, pag, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	if len(rows) == 0 {
+		pag.LastID = 0
+		return []domain.ModerationAction{}, pag, nil
+	}
+
+	actions := make([]domain.ModerationAction, len(rows))
+	for i, row := range rows {
+		actions[i] = row.ToDomain()
+	}
+
+	pag.LastID = actions[len(actions)-1].ID
+	return actions, pag, nil
+}
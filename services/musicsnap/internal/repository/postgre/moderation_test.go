@@ -0,0 +1,177 @@
+package postgre
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestModerationRepository(t *testing.T) {
+	repo, closeDB, cleanDB, err := initializeRepository()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer closeDB()
+	defer cleanDB()
+
+	ctx := context.Background()
+
+	author, err := repo.user.Create(ctx, domain.User{
+		Profile:      domain.Profile{ID: uuid.New(), Nickname: "author"},
+		Email:        "author@example.com",
+		PasswordHash: "hashedpassword",
+		Roles:        domain.NewRoles([]string{domain.UserRole}),
+	})
+	require.NoError(t, err)
+
+	moderator, err := repo.user.Create(ctx, domain.User{
+		Profile:      domain.Profile{ID: uuid.New(), Nickname: "moderator"},
+		Email:        "moderator@example.com",
+		PasswordHash: "hashedpassword",
+		Roles:        domain.NewRoles([]string{domain.ModeratorRole}),
+	})
+	require.NoError(t, err)
+
+	otherModerator, err := repo.user.Create(ctx, domain.User{
+		Profile:      domain.Profile{ID: uuid.New(), Nickname: "other_moderator"},
+		Email:        "other_moderator@example.com",
+		PasswordHash: "hashedpassword",
+		Roles:        domain.NewRoles([]string{domain.ModeratorRole}),
+	})
+	require.NoError(t, err)
+
+	review, err := repo.review.Create(ctx, domain.Review{
+		UserID:    author.ID,
+		PieceID:   createPiece(t, repo),
+		Rating:    3,
+		Content:   "buy cheap tickets here",
+		Published: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ModerationPending, review.ModerationStatus)
+
+	staleBefore := func() time.Time {
+		return time.Now().Add(-15 * time.Minute)
+	}
+
+	t.Run("Test new review is queued", func(t *testing.T) {
+		items, _, err := repo.moderation.ListQueue(ctx, domain.ModerationQueueFilter{}, domain.IDPagination{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, review.ID, items[0].Review.ID)
+		assert.Equal(t, domain.QueueReasonNew, items[0].Reason)
+		assert.Nil(t, items[0].ClaimedBy)
+	})
+
+	t.Run("Test claim is exclusive", func(t *testing.T) {
+		claimed, err := repo.moderation.ClaimNext(ctx, moderator.ID, staleBefore(), 5)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		require.NotNil(t, claimed[0].ClaimedBy)
+		assert.Equal(t, moderator.ID, *claimed[0].ClaimedBy)
+
+		none, err := repo.moderation.ClaimNext(ctx, otherModerator.ID, staleBefore(), 5)
+		require.NoError(t, err)
+		assert.Empty(t, none)
+
+		_, err = repo.moderation.Claim(ctx, review.ID, otherModerator.ID, staleBefore())
+		require.Error(t, err)
+		assert.Equal(t, http.StatusConflict, app.GetCode(err))
+
+		// истёкший захват может забрать другой модератор
+		_, err = repo.moderation.Claim(ctx, review.ID, otherModerator.ID, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+
+		err = repo.moderation.Release(ctx, review.ID, moderator.ID, false)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusConflict, app.GetCode(err))
+
+		err = repo.moderation.Release(ctx, review.ID, otherModerator.ID, false)
+		require.NoError(t, err)
+	})
+
+	t.Run("Test reject removes review from queue", func(t *testing.T) {
+		_, err := repo.moderation.Claim(ctx, review.ID, moderator.ID, staleBefore())
+		require.NoError(t, err)
+
+		spam := domain.ReasonSpam
+		_, err = repo.moderation.Decide(ctx, domain.ModerationAction{
+			ReviewID:    review.ID,
+			ModeratorID: otherModerator.ID,
+			Action:      domain.ModerationReject,
+			ReasonCode:  &spam,
+		}, staleBefore(), false)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusConflict, app.GetCode(err))
+
+		rejected, err := repo.moderation.Decide(ctx, domain.ModerationAction{
+			ReviewID:    review.ID,
+			ModeratorID: moderator.ID,
+			Action:      domain.ModerationReject,
+			ReasonCode:  &spam,
+			Comment:     "advertisement",
+		}, staleBefore(), false)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ModerationRejected, rejected.ModerationStatus)
+		assert.True(t, rejected.Moderated)
+		require.NotNil(t, rejected.ModerationReason)
+		assert.Equal(t, domain.ReasonSpam, *rejected.ModerationReason)
+
+		_, err = repo.moderation.GetQueueItem(ctx, review.ID)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+	})
+
+	t.Run("Test rejected review is hidden by filter", func(t *testing.T) {
+		rejected := domain.ModerationRejected
+		reviews, _, err := repo.review.GetList(ctx, domain.ReviewFilter{
+			UserID:                  &author.ID,
+			ExcludeModerationStatus: &rejected,
+		}, domain.IDPagination{})
+		require.NoError(t, err)
+		assert.Empty(t, reviews)
+	})
+
+	t.Run("Test edit of rejected review returns it to queue", func(t *testing.T) {
+		review.Content = "a thoughtful review"
+		edited, err := repo.review.Update(ctx, review, author.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ModerationPending, edited.ModerationStatus)
+		assert.Nil(t, edited.ModerationReason)
+
+		item, err := repo.moderation.GetQueueItem(ctx, review.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.QueueReasonEdited, item.Reason)
+	})
+
+	t.Run("Test report raises queue reason", func(t *testing.T) {
+		err := repo.moderation.Enqueue(ctx, review.ID, domain.QueueReasonReported)
+		require.NoError(t, err)
+
+		item, err := repo.moderation.GetQueueItem(ctx, review.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.QueueReasonReported, item.Reason)
+	})
+
+	t.Run("Test moderator audit trail", func(t *testing.T) {
+		actions, _, err := repo.moderation.ListActions(ctx, domain.ModerationActionFilter{ModeratorID: &moderator.ID},
+			domain.IDPagination{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, actions, 3)
+		assert.Equal(t, domain.ModerationClaim, actions[0].Action)
+		assert.Equal(t, domain.ModerationClaim, actions[1].Action)
+		assert.Equal(t, domain.ModerationReject, actions[2].Action)
+		assert.Equal(t, "advertisement", actions[2].Comment)
+
+		actions, _, err = repo.moderation.ListActions(ctx, domain.ModerationActionFilter{ReviewID: &review.ID},
+			domain.IDPagination{Limit: 10})
+		require.NoError(t, err)
+		assert.Len(t, actions, 5)
+	})
+}
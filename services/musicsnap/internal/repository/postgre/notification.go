@@ -0,0 +1,692 @@
+package postgre
+
+import (
+	c "context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/juju/zaputil/zapctx"
+	"github.com/lib/pq"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/repository/postgre/models"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"time"
+)
+
+// notificationBatchSize limits rows of one insert statement of fan-out
+const notificationBatchSize = 1000
+
+var _ ports.NotificationRepository = &notificationRepository{}
+
+func NewNotificationRepository(db *sqlx.DB) ports.NotificationRepository {
+	return &notificationRepository{db: db,
+		spanName: spanBaseName + "notificationRepository."}
+}
+
+func newNotificationRepository(db *sqlx.DB) notificationRepository {
+	return notificationRepository{db: db,
+		spanName: spanBaseName + "notificationRepository."}
+}
+
+type notificationRepository struct {
+	db       *sqlx.DB
+	spanName string
+}
+
+// Create returns empty notification when receiver disabled every channel of its type
+func (r notificationRepository) Create(ctx c.Context, notification domain.Notification) (domain.Notification, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Create")
+	defer span.End()
+
+	toWrite, err := models.ToNotificationModel(notification)
+	if err != nil {
+		return domain.Notification{}, app.NewError(http.StatusBadRequest, "invalid notification message",
+			"failed to marshal notification message", err)
+	}
+
+	q := `
+	INSERT INTO notifications (id, user_id, sender_id, type, message, payload_version, created_at)
+	VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, NOW())
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var created models.NotificationModel
+	err = r.db.GetContext(ctx, &created, q, toWrite.UserID, toWrite.SenderID, toWrite.Type, string(toWrite.Message),
+		toWrite.Version)
+	if err != nil {
+		// триггер настроек не сохраняет уведомление, если получатель отключил все каналы его типа
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Notification{}, nil
+		}
+		if pqErrorCode(err) == foreignKeyViolationCode {
+			return domain.Notification{}, app.NewError(http.StatusNotFound, "user not found",
+				fmt.Sprintf("receiver %s or sender of notification not found", toWrite.UserID), err)
+		}
+		return domain.Notification{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	res, err := created.ToDomain()
+	if err != nil {
+		return domain.Notification{}, app.NewError(http.StatusInternalServerError, "unknown error",
+			"failed to unmarshal notification message", err)
+	}
+	return res, nil
+}
+
+func (r notificationRepository) CreateMany(ctx c.Context, notifications []domain.Notification) (int, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"CreateMany")
+	defer span.End()
+
+	if len(notifications) == 0 {
+		return 0, nil
+	}
+
+	tx, commit, rollback, err := beginTx(ctx, r.db)
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "failed to start transaction", err)
+	}
+	defer rollback()
+
+	// получатели, удалённые во время рассылки, пропускаются
+	q := `
+	INSERT INTO notifications (id, user_id, sender_id, type, message, payload_version, created_at)
+	SELECT gen_random_uuid(), n.user_id, n.sender_id, n.type, n.message::jsonb, n.version, NOW()
+	FROM unnest($1::uuid[], $2::uuid[], $3::text[], $4::text[], $5::smallint[])
+	         AS n(user_id, sender_id, type, message, version)
+	WHERE EXISTS (SELECT 1 FROM users WHERE users.id = n.user_id);
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	created := 0
+	for start := 0; start < len(notifications); start += notificationBatchSize {
+		end := min(start+notificationBatchSize, len(notifications))
+		batch := notifications[start:end]
+
+		userIDs := make([]uuid.UUID, len(batch))
+		senderIDs := make([]*uuid.UUID, len(batch))
+		types := make([]string, len(batch))
+		messages := make([]string, len(batch))
+		versions := make([]int64, len(batch))
+		for i, notification := range batch {
+			toWrite, err := models.ToNotificationModel(notification)
+			if err != nil {
+				return 0, app.NewError(http.StatusBadRequest, "invalid notification message",
+					"failed to marshal notification message", err)
+			}
+			userIDs[i] = toWrite.UserID
+			senderIDs[i] = toWrite.SenderID
+			types[i] = toWrite.Type
+			messages[i] = string(toWrite.Message)
+			versions[i] = int64(toWrite.Version)
+		}
+
+		res, err := tx.ExecContext(ctx, q, pq.Array(userIDs), pq.Array(senderIDs), pq.Array(types), pq.Array(messages),
+			pq.Array(versions))
+		if err != nil {
+			if pqErrorCode(err) == foreignKeyViolationCode {
+				return 0, app.NewError(http.StatusNotFound, "user not found", "sender of notification not found", err)
+			}
+			return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+		}
+		created += int(rows)
+	}
+
+	err = commit()
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "failed to commit transaction", err)
+	}
+	return created, nil
+}
+
+func (r notificationRepository) MarkAsRead(ctx c.Context, userID uuid.UUID, id uuid.UUID) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"MarkAsRead")
+	defer span.End()
+
+	q := `
+	UPDATE notifications
+	SET read = true
+	WHERE id = $1 AND user_id = $2
+	RETURNING id;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var markedID uuid.UUID
+	err := r.db.GetContext(ctx, &markedID, q, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return app.NewError(http.StatusNotFound, "notification not found",
+				fmt.Sprintf("notification %s of user %s not found", id, userID), err)
+		}
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return nil
+}
+
+func (r notificationRepository) MarkAllAsRead(ctx c.Context, userID uuid.UUID) (int, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"MarkAllAsRead")
+	defer span.End()
+
+	q := `
+	UPDATE notifications
+	SET read = true
+	WHERE user_id = $1 AND NOT read AND 'in_app' = ANY (channels);
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	res, err := r.db.ExecContext(ctx, q, userID)
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	marked, err := res.RowsAffected()
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return int(marked), nil
+}
+
+func (r notificationRepository) ListByUser(ctx c.Context, userID uuid.UUID, unreadOnly bool, pag domain.UUIDPagination) ([]domain.Notification, domain.UUIDPagination, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"ListByUser")
+	defer span.End()
+
+	// курсор - последнее уведомление предыдущей страницы
+	q := `
+	SELECT * FROM notifications
+	WHERE user_id = $1 AND 'in_app' = ANY (channels)
+	  AND (NOT $2 OR NOT read)
+	  AND ($3::uuid IS NULL OR (created_at, id) < (SELECT created_at, id FROM notifications WHERE id = $3))
+	ORDER BY created_at DESC, id DESC
+	LIMIT $4;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var lastUUID *uuid.UUID
+	if pag.LastUUID != uuid.Nil {
+		lastUUID = &pag.LastUUID
+	}
+
+	var rows []models.NotificationModel
+	err := r.db.SelectContext(ctx, &rows, q, userID, unreadOnly, lastUUID, pag.Limit)
+	if err != nil {
+		return nil, pag, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	if len(rows) == 0 {
+		pag.LastUUID = uuid.Nil
+		return []domain.Notification{}, pag, nil
+	}
+
+	notifications := make([]domain.Notification, len(rows))
+	for i, row := range rows {
+		notifications[i], err = row.ToDomain()
+		if err != nil {
+			return nil, pag, app.NewError(http.StatusInternalServerError, "unknown error",
+				"failed to unmarshal notification message", err)
+		}
+	}
+
+	pag.LastUUID = notifications[len(notifications)-1].ID
+	return notifications, pag, nil
+}
+
+func (r notificationRepository) CountUnread(ctx c.Context, userID uuid.UUID) (int, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"CountUnread")
+	defer span.End()
+
+	q := `
+	SELECT COUNT(*) FROM notifications
+	WHERE user_id = $1 AND NOT read AND 'in_app' = ANY (channels);
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var count int
+	err := r.db.GetContext(ctx, &count, q, userID)
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return count, nil
+}
+
+// NotifySubscribers sends copy of notification to followers of sender with subscription flag of notification type
+func (r notificationRepository) NotifySubscribers(ctx c.Context, notification domain.Notification) (int, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"NotifySubscribers")
+	defer span.End()
+
+	toWrite, err := models.ToNotificationModel(notification)
+	if err != nil {
+		return 0, app.NewError(http.StatusBadRequest, "invalid notification message",
+			"failed to marshal notification message", err)
+	}
+
+	// ключи флагов подписки совпадают с типами уведомлений,
+	// повторное оповещение о той же рецензии пропускается
+	q := `
+	INSERT INTO notifications (id, user_id, sender_id, type, message, payload_version, review_id, created_at)
+	SELECT gen_random_uuid(), s.subscriber_id, s.followed_id, $2, $3::jsonb, $4, $5, NOW()
+	FROM subscriptions s
+	WHERE s.followed_id = $1 AND s.subscriber_id IS NOT NULL
+	  AND COALESCE((s.notification_flags ->> $2)::boolean, false)
+	ON CONFLICT (user_id, type, review_id) WHERE review_id IS NOT NULL DO NOTHING;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	res, err := executorFrom(ctx, r.db).ExecContext(ctx, q, toWrite.SenderID, toWrite.Type, string(toWrite.Message),
+		toWrite.Version, toWrite.ReviewID)
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	created, err := res.RowsAffected()
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return int(created), nil
+}
+
+// GetPreferences returns only cells set by user
+func (r notificationRepository) GetPreferences(ctx c.Context, userID uuid.UUID) (domain.NotificationPreferences, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"GetPreferences")
+	defer span.End()
+
+	q := `
+	SELECT * FROM notification_preferences
+	WHERE user_id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var rows []models.NotificationPreferenceModel
+	err := r.db.SelectContext(ctx, &rows, q, userID)
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return models.ToNotificationPreferencesDomain(rows), nil
+}
+
+// SetPreferences upserts given cells, other cells are kept
+func (r notificationRepository) SetPreferences(ctx c.Context, userID uuid.UUID, prefs domain.NotificationPreferences) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"SetPreferences")
+	defer span.End()
+
+	types := make([]string, 0)
+	channels := make([]string, 0)
+	enabled := make([]bool, 0)
+	for t, cells := range prefs {
+		for channel, on := range cells {
+			types = append(types, t)
+			channels = append(channels, channel)
+			enabled = append(enabled, on)
+		}
+	}
+	if len(types) == 0 {
+		return nil
+	}
+
+	q := `
+	INSERT INTO notification_preferences (user_id, type, channel, enabled, updated_at)
+	SELECT $1, p.type, p.channel, p.enabled, NOW()
+	FROM unnest($2::text[], $3::text[], $4::boolean[]) AS p(type, channel, enabled)
+	ON CONFLICT (user_id, type, channel) DO UPDATE
+	SET enabled = EXCLUDED.enabled, updated_at = NOW();
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	_, err := r.db.ExecContext(ctx, q, userID, pq.Array(types), pq.Array(channels), pq.Array(enabled))
+	if err != nil {
+		if pqErrorCode(err) == foreignKeyViolationCode {
+			return app.NewError(http.StatusNotFound, "user not found",
+				fmt.Sprintf("user %s of notification preferences not found", userID), err)
+		}
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return nil
+}
+
+// Aggregate collapses notification into unread one of the same group updated after since,
+// otherwise creates new one. Returns empty notification when receiver disabled every channel of its type
+func (r notificationRepository) Aggregate(ctx c.Context, notification domain.Notification, since time.Time) (domain.Notification, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Aggregate")
+	defer span.End()
+
+	toWrite, err := models.ToNotificationModel(notification)
+	if err != nil {
+		return domain.Notification{}, app.NewError(http.StatusBadRequest, "invalid notification message",
+			"failed to marshal notification message", err)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return domain.Notification{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to start transaction", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	// параллельные события одной группы не должны создать два уведомления
+	qLock := `
+	SELECT pg_advisory_xact_lock(hashtext($1));
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qLock)))
+
+	_, err = tx.ExecContext(ctx, qLock, fmt.Sprintf("notification:%s:%s:%s", toWrite.UserID, toWrite.Type, notification.GroupKey))
+	if err != nil {
+		return domain.Notification{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	// время схлопнутого уведомления - время последнего события, оно поднимается в начало списка
+	qUpdate := `
+	UPDATE notifications
+	SET sender_id = $4, message = $5::jsonb, payload_version = $7, aggregated_count = aggregated_count + 1,
+	    created_at = NOW(), updated_at = NOW()
+	WHERE id = (SELECT id FROM notifications
+	            WHERE user_id = $1 AND type = $2 AND group_key = $3 AND NOT read AND created_at >= $6
+	            ORDER BY created_at DESC
+	            LIMIT 1)
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qUpdate)))
+
+	var saved models.NotificationModel
+	err = tx.GetContext(ctx, &saved, qUpdate, toWrite.UserID, toWrite.Type, toWrite.GroupKey,
+		toWrite.SenderID, string(toWrite.Message), since, toWrite.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		qInsert := `
+		INSERT INTO notifications (id, user_id, sender_id, type, message, group_key, payload_version, created_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, NOW())
+		RETURNING *;
+		`
+		logger.With(zap.String("PSQL query", formatQuery(qInsert)))
+
+		err = tx.GetContext(ctx, &saved, qInsert, toWrite.UserID, toWrite.SenderID, toWrite.Type,
+			string(toWrite.Message), toWrite.GroupKey, toWrite.Version)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Notification{}, nil
+		}
+	}
+	if err != nil {
+		if pqErrorCode(err) == foreignKeyViolationCode {
+			return domain.Notification{}, app.NewError(http.StatusNotFound, "user not found",
+				fmt.Sprintf("receiver %s or sender of notification not found", toWrite.UserID), err)
+		}
+		return domain.Notification{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return domain.Notification{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to commit transaction", err)
+	}
+
+	res, err := saved.ToDomain()
+	if err != nil {
+		return domain.Notification{}, app.NewError(http.StatusInternalServerError, "unknown error",
+			"failed to unmarshal notification message", err)
+	}
+	return res, nil
+}
+
+// GetDigestSettings returns disabled digest for user without settings
+func (r notificationRepository) GetDigestSettings(ctx c.Context, userID uuid.UUID) (domain.DigestSettings, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"GetDigestSettings")
+	defer span.End()
+
+	q := `
+	SELECT * FROM notification_digests
+	WHERE user_id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var settings models.NotificationDigestModel
+	err := r.db.GetContext(ctx, &settings, q, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.DigestSettings{UserID: userID, Frequency: domain.DigestOff}, nil
+		}
+		return domain.DigestSettings{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return settings.ToDomain(), nil
+}
+
+func (r notificationRepository) SetDigestSettings(ctx c.Context, settings domain.DigestSettings) (domain.DigestSettings, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"SetDigestSettings")
+	defer span.End()
+
+	q := `
+	INSERT INTO notification_digests (user_id, frequency, updated_at)
+	VALUES ($1, $2, NOW())
+	ON CONFLICT (user_id) DO UPDATE
+	SET frequency = EXCLUDED.frequency, updated_at = NOW()
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var saved models.NotificationDigestModel
+	err := r.db.GetContext(ctx, &saved, q, settings.UserID, settings.Frequency)
+	if err != nil {
+		if pqErrorCode(err) == foreignKeyViolationCode {
+			return domain.DigestSettings{}, app.NewError(http.StatusNotFound, "user not found",
+				fmt.Sprintf("user %s of digest settings not found", settings.UserID), err)
+		}
+		return domain.DigestSettings{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return saved.ToDomain(), nil
+}
+
+// ClaimDueDigests claims digests which period has passed since the last one for lease,
+// first digest covers one period before now. Digests claimed by other replicas are skipped
+func (r notificationRepository) ClaimDueDigests(ctx c.Context, now time.Time, lease time.Duration, limit int) ([]domain.Digest, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"ClaimDueDigests")
+	defer span.End()
+
+	q := `
+	WITH due AS (
+	    SELECT nd.user_id, COALESCE(nd.last_sent_at, $1::timestamp - p.period) AS since
+	    FROM notification_digests nd
+	    CROSS JOIN LATERAL (SELECT CASE nd.frequency WHEN 'daily' THEN INTERVAL '1 day'
+	                                                 ELSE INTERVAL '7 days' END AS period) p
+	    WHERE nd.frequency <> 'off' AND nd.available_at <= $1
+	      AND (nd.last_sent_at IS NULL OR nd.last_sent_at <= $1::timestamp - p.period)
+	    ORDER BY nd.available_at, nd.last_sent_at ASC NULLS FIRST
+	    LIMIT $3
+	    FOR UPDATE OF nd SKIP LOCKED
+	)
+	UPDATE notification_digests nd
+	SET available_at = $1::timestamp + make_interval(secs => $2::float8), attempts = nd.attempts + 1
+	FROM due
+	JOIN users u ON u.id = due.user_id
+	WHERE nd.user_id = due.user_id
+	RETURNING nd.user_id, u.email, u.nickname, u.locale, nd.frequency, nd.attempts, due.since;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var due []struct {
+		UserID    uuid.UUID `db:"user_id"`
+		Email     string    `db:"email"`
+		Nickname  string    `db:"nickname"`
+		Locale    string    `db:"locale"`
+		Frequency string    `db:"frequency"`
+		Attempts  int       `db:"attempts"`
+		Since     time.Time `db:"since"`
+	}
+	err := r.db.SelectContext(ctx, &due, q, now, lease.Seconds(), limit)
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	if len(due) == 0 {
+		return []domain.Digest{}, nil
+	}
+
+	userIDs := make([]uuid.UUID, len(due))
+	since := make([]time.Time, len(due))
+	digests := make([]domain.Digest, len(due))
+	index := make(map[uuid.UUID]int, len(due))
+	for i, row := range due {
+		userIDs[i] = row.UserID
+		since[i] = row.Since
+		index[row.UserID] = i
+		digests[i] = domain.Digest{
+			UserID:    row.UserID,
+			Email:     row.Email,
+			Nickname:  row.Nickname,
+			Locale:    row.Locale,
+			Frequency: row.Frequency,
+			Attempts:  row.Attempts,
+			Since:     row.Since,
+			Until:     now,
+			Counts:    make(map[string]int),
+		}
+	}
+
+	// в сводку попадают непрочитанные события периода, схлопнутые считаются по отдельности
+	qCounts := `
+	SELECT n.user_id, n.type, SUM(n.aggregated_count) AS count
+	FROM notifications n
+	JOIN unnest($1::uuid[], $2::timestamp[]) AS d(user_id, since) ON d.user_id = n.user_id
+	WHERE n.created_at > d.since AND n.created_at <= $3 AND NOT n.read
+	GROUP BY n.user_id, n.type;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qCounts)))
+
+	var counts []struct {
+		UserID uuid.UUID `db:"user_id"`
+		Type   string    `db:"type"`
+		Count  int       `db:"count"`
+	}
+	err = r.db.SelectContext(ctx, &counts, qCounts, pq.Array(userIDs), pq.Array(since), now)
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	for _, row := range counts {
+		digests[index[row.UserID]].Counts[row.Type] = row.Count
+	}
+	return digests, nil
+}
+
+func (r notificationRepository) MarkDigestSent(ctx c.Context, userID uuid.UUID, sentAt time.Time) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"MarkDigestSent")
+	defer span.End()
+
+	q := `
+	UPDATE notification_digests
+	SET last_sent_at = $2, available_at = $2, attempts = 0
+	WHERE user_id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	_, err := r.db.ExecContext(ctx, q, userID, sentAt)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return nil
+}
+
+// RescheduleDigest postpones claimed digest which failed to send until retryAt
+func (r notificationRepository) RescheduleDigest(ctx c.Context, userID uuid.UUID, retryAt time.Time) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"RescheduleDigest")
+	defer span.End()
+
+	q := `
+	UPDATE notification_digests
+	SET available_at = $2
+	WHERE user_id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	_, err := r.db.ExecContext(ctx, q, userID, retryAt)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return nil
+}
+
+// DeleteOutdated deletes one batch of read notifications created before readBefore
+// and unread ones created before unreadBefore. Rows locked by other transactions are skipped
+func (r notificationRepository) DeleteOutdated(ctx c.Context, readBefore, unreadBefore time.Time, limit int) (int, int, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"DeleteOutdated")
+	defer span.End()
+
+	q := `
+	WITH outdated AS (
+	    SELECT id FROM notifications
+	    WHERE (read AND created_at < $1) OR (NOT read AND created_at < $2)
+	    LIMIT $3
+	    FOR UPDATE SKIP LOCKED
+	)
+	DELETE FROM notifications n
+	USING outdated
+	WHERE n.id = outdated.id
+	RETURNING n.read;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var deleted []bool
+	err := r.db.SelectContext(ctx, &deleted, q, readBefore, unreadBefore, limit)
+	if err != nil {
+		return 0, 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	read, unread := 0, 0
+	for _, wasRead := range deleted {
+		if wasRead {
+			read++
+		} else {
+			unread++
+		}
+	}
+	return read, unread, nil
+}
@@ -0,0 +1,257 @@
+package postgre
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"music-snap/services/musicsnap/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestNotificationRepository(t *testing.T) {
+	repo, closeDB, cleanDB, err := initializeRepository()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer closeDB()
+	defer cleanDB()
+
+	ctx := context.Background()
+
+	users := make([]domain.User, 2)
+	for i := range users {
+		users[i], err = repo.user.Create(ctx, domain.User{
+			Profile:      domain.Profile{ID: uuid.New(), Nickname: fmt.Sprintf("notified%d", i)},
+			Email:        fmt.Sprintf("notified%d@example.com", i),
+			PasswordHash: "hashedpassword",
+			Roles:        domain.NewRoles([]string{domain.UserRole}),
+		})
+		require.NoError(t, err)
+	}
+	receiver, sender := users[0], users[1]
+
+	var first domain.Notification
+	t.Run("Test notification create", func(t *testing.T) {
+		first, err = repo.notification.Create(ctx, domain.Notification{
+			UserIDReceiver: receiver.ID,
+			UserIDSender:   &sender.ID,
+			Type:           domain.NotificationMention,
+			Message:        map[string]interface{}{"review_id": 1},
+		})
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, first.ID)
+		assert.Equal(t, float64(1), first.Message["review_id"])
+		assert.Equal(t, sender.ID, *first.UserIDSender)
+		assert.Equal(t, domain.NotificationPayloadVersion, first.Version)
+		assert.False(t, first.Read)
+	})
+
+	t.Run("Test notification fan-out skips missing users", func(t *testing.T) {
+		created, err := repo.notification.CreateMany(ctx, []domain.Notification{
+			{UserIDReceiver: receiver.ID, Type: domain.NotificationReportResolved},
+			{UserIDReceiver: receiver.ID, UserIDSender: &sender.ID, Type: domain.NotificationReviewComment,
+				Message: map[string]interface{}{"comment_id": uuid.New().String()}},
+			{UserIDReceiver: uuid.New(), Type: domain.NotificationReportResolved},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, created)
+
+		unread, err := repo.notification.CountUnread(ctx, receiver.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, unread)
+	})
+
+	t.Run("Test notification list pagination", func(t *testing.T) {
+		page, pag, err := repo.notification.ListByUser(ctx, receiver.ID, false, domain.UUIDPagination{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+
+		rest, _, err := repo.notification.ListByUser(ctx, receiver.ID, false, pag)
+		require.NoError(t, err)
+		require.Len(t, rest, 1)
+		assert.Equal(t, first.ID, rest[0].ID)
+	})
+
+	t.Run("Test notification mark as read", func(t *testing.T) {
+		err := repo.notification.MarkAsRead(ctx, sender.ID, first.ID)
+		assert.Error(t, err)
+
+		require.NoError(t, repo.notification.MarkAsRead(ctx, receiver.ID, first.ID))
+		unread, _, err := repo.notification.ListByUser(ctx, receiver.ID, true, domain.UUIDPagination{Limit: 10})
+		require.NoError(t, err)
+		assert.Len(t, unread, 2)
+
+		marked, err := repo.notification.MarkAllAsRead(ctx, receiver.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, marked)
+
+		count, err := repo.notification.CountUnread(ctx, receiver.ID)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("Test notification preferences", func(t *testing.T) {
+		err := repo.notification.SetPreferences(ctx, receiver.ID, domain.NotificationPreferences{
+			domain.NotificationMention:        {domain.InAppChannel: false, domain.EmailChannel: false, domain.PushChannel: false},
+			domain.NotificationReportResolved: {domain.InAppChannel: false, domain.EmailChannel: true},
+		})
+		require.NoError(t, err)
+
+		prefs, err := repo.notification.GetPreferences(ctx, receiver.ID)
+		require.NoError(t, err)
+		assert.False(t, prefs.Enabled(domain.NotificationMention, domain.InAppChannel))
+		assert.True(t, prefs.Enabled(domain.NotificationReportResolved, domain.EmailChannel))
+
+		muted, err := repo.notification.Create(ctx, domain.Notification{
+			UserIDReceiver: receiver.ID,
+			UserIDSender:   &sender.ID,
+			Type:           domain.NotificationMention,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uuid.Nil, muted.ID)
+
+		emailOnly, err := repo.notification.Create(ctx, domain.Notification{
+			UserIDReceiver: receiver.ID,
+			Type:           domain.NotificationReportResolved,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{domain.EmailChannel}, emailOnly.Channels)
+
+		count, err := repo.notification.CountUnread(ctx, receiver.ID)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("Test subscription alerts", func(t *testing.T) {
+		_, err := repo.user.CreateSub(ctx, domain.Subscription{
+			SubscriberID:      receiver.ID,
+			FollowedID:        sender.ID,
+			NotificationFlags: domain.SubscriptionFlags{NewReview: true},
+			CreatedAt:         time.Now(),
+			UpdatedAt:         time.Now(),
+		})
+		require.NoError(t, err)
+
+		alert := domain.Notification{
+			UserIDSender: &sender.ID,
+			Type:         domain.NotificationNewReview,
+			Message:      map[string]interface{}{"review_id": 1},
+			ReviewID:     1,
+		}
+		created, err := repo.notification.NotifySubscribers(ctx, alert)
+		require.NoError(t, err)
+		assert.Equal(t, 1, created)
+
+		// повтор обработки публикации не дублирует оповещение
+		created, err = repo.notification.NotifySubscribers(ctx, alert)
+		require.NoError(t, err)
+		assert.Zero(t, created)
+
+		created, err = repo.notification.NotifySubscribers(ctx, domain.Notification{
+			UserIDSender: &sender.ID,
+			Type:         domain.NotificationEvent,
+		})
+		require.NoError(t, err)
+		assert.Zero(t, created)
+	})
+
+	t.Run("Test notification aggregation", func(t *testing.T) {
+		reaction := domain.Notification{
+			UserIDReceiver: receiver.ID,
+			UserIDSender:   &sender.ID,
+			Type:           domain.NotificationReviewReaction,
+			GroupKey:       domain.ReactionGroupKey(1, domain.LikeReaction),
+			Message:        map[string]interface{}{"review_id": 1},
+		}
+		since := time.Now().UTC().Add(-time.Hour)
+
+		first, err := repo.notification.Aggregate(ctx, reaction, since)
+		require.NoError(t, err)
+		assert.Equal(t, 1, first.Count)
+
+		second, err := repo.notification.Aggregate(ctx, reaction, since)
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, second.ID)
+		assert.Equal(t, 2, second.Count)
+
+		other := reaction
+		other.GroupKey = domain.ReactionGroupKey(2, domain.LikeReaction)
+		separate, err := repo.notification.Aggregate(ctx, other, since)
+		require.NoError(t, err)
+		assert.NotEqual(t, first.ID, separate.ID)
+
+		outOfWindow, err := repo.notification.Aggregate(ctx, reaction, time.Now().UTC().Add(time.Hour))
+		require.NoError(t, err)
+		assert.NotEqual(t, first.ID, outOfWindow.ID)
+		assert.Equal(t, 1, outOfWindow.Count)
+	})
+
+	t.Run("Test notification digests", func(t *testing.T) {
+		settings, err := repo.notification.GetDigestSettings(ctx, receiver.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.DigestOff, settings.Frequency)
+
+		settings, err = repo.notification.SetDigestSettings(ctx, domain.DigestSettings{
+			UserID:    receiver.ID,
+			Frequency: domain.DigestDaily,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.DigestDaily, settings.Frequency)
+		assert.Nil(t, settings.LastSentAt)
+
+		now := time.Now().UTC().Add(time.Minute)
+		digests, err := repo.notification.ClaimDueDigests(ctx, now, time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, digests, 1)
+		assert.Equal(t, receiver.Email, digests[0].Email)
+		assert.Equal(t, domain.DigestDaily, digests[0].Frequency)
+		assert.Equal(t, 1, digests[0].Attempts)
+		assert.Equal(t, 4, digests[0].Counts[domain.NotificationReviewReaction])
+
+		claimed, err := repo.notification.ClaimDueDigests(ctx, now, time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed)
+
+		err = repo.notification.RescheduleDigest(ctx, receiver.ID, now.Add(-time.Second))
+		require.NoError(t, err)
+		digests, err = repo.notification.ClaimDueDigests(ctx, now, time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, digests, 1)
+		assert.Equal(t, 2, digests[0].Attempts)
+
+		err = repo.notification.MarkDigestSent(ctx, receiver.ID, now)
+		require.NoError(t, err)
+
+		digests, err = repo.notification.ClaimDueDigests(ctx, now, time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, digests)
+	})
+
+	t.Run("Test notification retention", func(t *testing.T) {
+		_, err := repo.notification.MarkAllAsRead(ctx, receiver.ID)
+		require.NoError(t, err)
+		_, err = repo.notification.Create(ctx, domain.Notification{
+			UserIDReceiver: receiver.ID,
+			Type:           domain.NotificationReportResolved,
+		})
+		require.NoError(t, err)
+
+		now := time.Now().UTC()
+		read, unread, err := repo.notification.DeleteOutdated(ctx, now.Add(time.Hour), now.Add(-time.Hour), 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, read)
+		assert.Zero(t, unread)
+
+		_, unread, err = repo.notification.DeleteOutdated(ctx, now.Add(time.Hour), now.Add(-time.Hour), 1000)
+		require.NoError(t, err)
+		assert.Zero(t, unread)
+
+		left, _, err := repo.notification.ListByUser(ctx, receiver.ID, false, domain.UUIDPagination{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, left, 1)
+		assert.False(t, left[0].Read)
+	})
+}
@@ -0,0 +1,165 @@
+package postgre
+
+import (
+	c "context"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/repository/postgre/models"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"sort"
+	"time"
+)
+
+var _ ports.OutboxRepository = &outboxRepository{}
+
+func NewOutboxRepository(db *sqlx.DB) ports.OutboxRepository {
+	return &outboxRepository{db: db,
+		spanName: spanBaseName + "outboxRepository."}
+}
+
+func newOutboxRepository(db *sqlx.DB) outboxRepository {
+	return outboxRepository{db: db,
+		spanName: spanBaseName + "outboxRepository."}
+}
+
+type outboxRepository struct {
+	db       *sqlx.DB
+	spanName string
+}
+
+func (r outboxRepository) Add(ctx c.Context, event domain.DomainEvent) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Add")
+	defer span.End()
+
+	q := `
+	INSERT INTO outbox_events (id, type, payload)
+	VALUES ($1, $2, $3);
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	_, err := executorFrom(ctx, r.db).ExecContext(ctx, q, event.ID, event.Type, string(event.Payload))
+	if err != nil {
+		if pqErrorCode(err) == uniqueViolationCode {
+			return app.NewError(http.StatusConflict, "event already exists",
+				fmt.Sprintf("outbox event %s already exists", event.ID), err)
+		}
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return nil
+}
+
+func (r outboxRepository) ClaimDue(ctx c.Context, now time.Time, lease time.Duration, limit int) ([]domain.DomainEvent, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"ClaimDue")
+	defer span.End()
+
+	q := `
+	WITH due AS (
+		SELECT seq FROM outbox_events
+		WHERE published_at IS NULL AND available_at <= $1
+		ORDER BY seq
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED
+	)
+	UPDATE outbox_events o
+	SET available_at = $1::timestamp + make_interval(secs => $2::float8), attempts = o.attempts + 1
+	FROM due
+	WHERE o.seq = due.seq
+	RETURNING o.*;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var rows []models.OutboxEventModel
+	err := r.db.SelectContext(ctx, &rows, q, now, lease.Seconds(), limit)
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	events := make([]domain.DomainEvent, len(rows))
+	for i, row := range rows {
+		events[i] = row.ToDomain()
+	}
+	// UPDATE ... RETURNING не сохраняет порядок подзапроса
+	sort.Slice(events, func(i, j int) bool { return events[i].Seq < events[j].Seq })
+	return events, nil
+}
+
+func (r outboxRepository) MarkPublished(ctx c.Context, seq int64, publishedAt time.Time) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"MarkPublished")
+	defer span.End()
+
+	q := `
+	UPDATE outbox_events
+	SET published_at = $2, last_error = ''
+	WHERE seq = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	res, err := r.db.ExecContext(ctx, q, seq, publishedAt)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return app.NewError(http.StatusNotFound, "event not found", fmt.Sprintf("outbox event %d does not exist", seq), nil)
+	}
+	return nil
+}
+
+func (r outboxRepository) MarkHandled(ctx c.Context, seq int64, handledAt time.Time) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"MarkHandled")
+	defer span.End()
+
+	q := `
+	UPDATE outbox_events
+	SET handled_at = $2
+	WHERE seq = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	res, err := r.db.ExecContext(ctx, q, seq, handledAt)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return app.NewError(http.StatusNotFound, "event not found", fmt.Sprintf("outbox event %d does not exist", seq), nil)
+	}
+	return nil
+}
+
+func (r outboxRepository) Reschedule(ctx c.Context, seq int64, retryAt time.Time, reason string) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Reschedule")
+	defer span.End()
+
+	q := `
+	UPDATE outbox_events
+	SET available_at = $2, last_error = $3
+	WHERE seq = $1 AND published_at IS NULL;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	_, err := r.db.ExecContext(ctx, q, seq, retryAt, reason)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package postgre
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"testing"
+	"time"
+)
+
+func TestOutboxRepository(t *testing.T) {
+	repo, closeDB, cleanDB, err := initializeRepository()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer closeDB()
+	defer cleanDB()
+
+	ctx := context.Background()
+
+	newUser := func(nickname string) domain.User {
+		user, err := repo.user.Create(ctx, domain.User{
+			Profile:      domain.Profile{ID: uuid.New(), Nickname: nickname},
+			Email:        nickname + "@example.com",
+			PasswordHash: "hashedpassword",
+			Roles:        domain.NewRoles([]string{domain.UserRole}),
+		})
+		require.NoError(t, err)
+		return user
+	}
+	subscriber, followed := newUser("subscriber"), newUser("followed")
+
+	subscribe := func(commit bool) error {
+		tx := repo.transactions.NewTransaction()
+		require.NoError(t, tx.Start(ctx))
+		defer tx.End(ctx)
+		txCtx := ports.WithTransaction(ctx, tx)
+
+		sub, err := repo.user.CreateSub(txCtx, domain.Subscription{SubscriberID: subscriber.ID, FollowedID: followed.ID})
+		if err != nil {
+			return err
+		}
+		event, err := domain.NewDomainEvent(domain.EventSubscribed,
+			domain.SubscribedEvent{SubscriberID: sub.SubscriberID, FollowedID: sub.FollowedID})
+		require.NoError(t, err)
+		if err = repo.outbox.Add(txCtx, event); err != nil {
+			return err
+		}
+		if !commit {
+			return errors.New("aborted")
+		}
+		return tx.Commit(ctx)
+	}
+
+	t.Run("Test aborted unit of work leaves neither change nor event", func(t *testing.T) {
+		require.Error(t, subscribe(false))
+
+		_, err := repo.user.GetSub(ctx, subscriber.ID, followed.ID)
+		assert.Error(t, err)
+		events, err := repo.outbox.ClaimDue(ctx, time.Now().UTC(), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	var claimed domain.DomainEvent
+	t.Run("Test committed unit of work writes change and event", func(t *testing.T) {
+		require.NoError(t, subscribe(true))
+
+		_, err := repo.user.GetSub(ctx, subscriber.ID, followed.ID)
+		require.NoError(t, err)
+		events, err := repo.outbox.ClaimDue(ctx, time.Now().UTC(), time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		claimed = events[0]
+		assert.Equal(t, domain.EventSubscribed, claimed.Type)
+		assert.Equal(t, 1, claimed.Attempts)
+
+		var payload domain.SubscribedEvent
+		require.NoError(t, claimed.Decode(&payload))
+		assert.Equal(t, followed.ID, payload.FollowedID)
+	})
+
+	t.Run("Test claimed event is leased", func(t *testing.T) {
+		events, err := repo.outbox.ClaimDue(ctx, time.Now().UTC(), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+
+		events, err = repo.outbox.ClaimDue(ctx, time.Now().UTC().Add(2*time.Minute), time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, 2, events[0].Attempts)
+	})
+
+	t.Run("Test rescheduled and published event", func(t *testing.T) {
+		retryAt := time.Now().UTC().Add(time.Hour)
+		require.NoError(t, repo.outbox.Reschedule(ctx, claimed.Seq, retryAt, "handler failed"))
+
+		events, err := repo.outbox.ClaimDue(ctx, retryAt.Add(time.Second), time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Nil(t, events[0].HandledAt)
+
+		require.NoError(t, repo.outbox.MarkHandled(ctx, claimed.Seq, time.Now().UTC()))
+		require.NoError(t, repo.outbox.Reschedule(ctx, claimed.Seq, retryAt, "bus unavailable"))
+		events, err = repo.outbox.ClaimDue(ctx, retryAt.Add(time.Minute), time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.NotNil(t, events[0].HandledAt)
+
+		require.NoError(t, repo.outbox.MarkPublished(ctx, claimed.Seq, time.Now().UTC()))
+		events, err = repo.outbox.ClaimDue(ctx, retryAt.Add(time.Hour), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+}
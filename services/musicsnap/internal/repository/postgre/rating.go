@@ -0,0 +1,154 @@
+package postgre
+
+import (
+	c "context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/repository/postgre/models"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+)
+
+var _ ports.RatingRepository = &ratingRepository{}
+
+func NewRatingRepository(db *sqlx.DB) ports.RatingRepository {
+	return &ratingRepository{db: db,
+		spanName: spanBaseName + "ratingRepository."}
+}
+
+func newRatingRepository(db *sqlx.DB) ratingRepository {
+	return ratingRepository{db: db,
+		spanName: spanBaseName + "ratingRepository."}
+}
+
+type ratingRepository struct {
+	db       *sqlx.DB
+	spanName string
+}
+
+// Upsert creates rating or replaces value of existing rating of the same user and piece
+func (r ratingRepository) Upsert(ctx c.Context, rating domain.Rating) (domain.Rating, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Upsert")
+	defer span.End()
+
+	q := `
+	INSERT INTO ratings (id, user_id, piece_id, rating)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (user_id, piece_id) DO UPDATE SET rating = EXCLUDED.rating
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	ratingToWrite := models.ToRatingModel(rating)
+	if ratingToWrite.ID == uuid.Nil {
+		ratingToWrite.ID = uuid.New()
+	}
+
+	var upsertedRating models.RatingModel
+	err := r.db.GetContext(ctx, &upsertedRating, q,
+		ratingToWrite.ID, ratingToWrite.UserID, ratingToWrite.PieceID, ratingToWrite.Rating)
+	if err != nil {
+		if pqErrorCode(err) == foreignKeyViolationCode {
+			return domain.Rating{}, app.NewError(http.StatusNotFound, "user or piece not found", "rating references unknown user or piece", err)
+		}
+		return domain.Rating{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	return upsertedRating.ToDomain(), nil
+}
+
+func (r ratingRepository) Get(ctx c.Context, userID uuid.UUID, pieceID string) (domain.Rating, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Get")
+	defer span.End()
+
+	q := `
+	SELECT * FROM ratings
+	WHERE user_id = $1 AND piece_id = $2;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var rating models.RatingModel
+	err := r.db.GetContext(ctx, &rating, q, userID, pieceID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Rating{}, app.NewError(http.StatusNotFound, "rating not found", "rating not found", err)
+		}
+		return domain.Rating{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	return rating.ToDomain(), nil
+}
+
+func (r ratingRepository) Delete(ctx c.Context, userID uuid.UUID, pieceID string) (domain.Rating, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Delete")
+	defer span.End()
+
+	q := `
+	DELETE FROM ratings
+	WHERE user_id = $1 AND piece_id = $2
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var deletedRating models.RatingModel
+	err := r.db.GetContext(ctx, &deletedRating, q, userID, pieceID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Rating{}, app.NewError(http.StatusNotFound, "rating not found", "rating not found", err)
+		}
+		return domain.Rating{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	return deletedRating.ToDomain(), nil
+}
+
+func (r ratingRepository) ListByUser(ctx c.Context, userID uuid.UUID, pag domain.UUIDPagination) ([]domain.Rating, domain.UUIDPagination, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"ListByUser")
+	defer span.End()
+
+	q := `
+	SELECT * FROM ratings
+	WHERE user_id = $1 AND id > $2
+	ORDER BY id ASC
+	LIMIT $3;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var ratingRows []models.RatingModel
+	err := r.db.SelectContext(ctx, &ratingRows, q, userID, pag.LastUUID, pag.Limit)
+	if err != nil {
+		return nil, pag, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	if len(ratingRows) == 0 {
+		pag.LastUUID = uuid.Nil
+		return []domain.Rating{}, pag, nil
+	}
+
+	ratings := make([]domain.Rating, len(ratingRows))
+	for i, row := range ratingRows {
+		ratings[i] = row.ToDomain()
+	}
+
+	pag.LastUUID = ratings[len(ratings)-1].ID
+	return ratings, pag, nil
+}
@@ -0,0 +1,86 @@
+package postgre
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"net/http"
+	"testing"
+)
+
+func TestRatingRepository(t *testing.T) {
+	repo, closeDB, cleanDB, err := initializeRepository()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer closeDB()
+	defer cleanDB()
+
+	ctx := context.Background()
+
+	createdUser, err := repo.user.Create(ctx, domain.User{
+		Profile:      domain.Profile{ID: uuid.New(), Nickname: "rater"},
+		Email:        "rater@example.com",
+		PasswordHash: "hashedpassword",
+		Roles:        domain.NewRoles([]string{domain.UserRole}),
+	})
+	require.NoError(t, err)
+
+	pieceID := createPiece(t, repo)
+
+	t.Run("Test rating upsert", func(t *testing.T) {
+		created, err := repo.rating.Upsert(ctx, domain.Rating{UserID: createdUser.ID, PieceID: pieceID, Rating: 4})
+		require.NoError(t, err)
+
+		updated, err := repo.rating.Upsert(ctx, domain.Rating{UserID: createdUser.ID, PieceID: pieceID, Rating: 8})
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, updated.ID)
+		assert.Equal(t, 8, updated.Rating)
+
+		got, err := repo.rating.Get(ctx, createdUser.ID, pieceID)
+		require.NoError(t, err)
+		assert.Equal(t, 8, got.Rating)
+	})
+
+	t.Run("Test stats include quick ratings and reviews", func(t *testing.T) {
+		_, err := repo.review.Create(ctx, domain.Review{
+			UserID:    createdUser.ID,
+			PieceID:   pieceID,
+			Rating:    6,
+			Published: true,
+		})
+		require.NoError(t, err)
+
+		stats, err := repo.stats.GetPieceStats(ctx, pieceID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, stats.TotalRatingsCount)
+		assert.Equal(t, 1, stats.TotalReviewsCount)
+		assert.Equal(t, 7.0, stats.AverageRating)
+		assert.Equal(t, 1, stats.Histogram[5])
+		assert.Equal(t, 1, stats.Histogram[7])
+	})
+
+	t.Run("Test rating list by user", func(t *testing.T) {
+		ratings, pag, err := repo.rating.ListByUser(ctx, createdUser.ID, domain.UUIDPagination{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, ratings, 1)
+		assert.Equal(t, ratings[0].ID, pag.LastUUID)
+	})
+
+	t.Run("Test rating delete", func(t *testing.T) {
+		_, err := repo.rating.Delete(ctx, createdUser.ID, pieceID)
+		require.NoError(t, err)
+
+		_, err = repo.rating.Get(ctx, createdUser.ID, pieceID)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+
+		stats, err := repo.stats.GetPieceStats(ctx, pieceID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, stats.TotalRatingsCount)
+		assert.Equal(t, 6.0, stats.AverageRating)
+	})
+}
@@ -4,8 +4,11 @@ import (
 	c "context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"music-snap/pkg/app"
+	qb "music-snap/pkg/querybuilder"
 
 	//"database/sql"
 	//"github.com/google/uuid"
@@ -201,3 +204,167 @@ func (r reactionRepository) Delete(ctx c.Context, id int) error {
 
 	return nil
 }
+
+func (r reactionRepository) Toggle(ctx c.Context, reaction domain.Reaction) (domain.Reaction, bool, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Toggle")
+	defer span.End()
+
+	tx, commit, rollback, err := beginTx(ctx, r.db)
+	if err != nil {
+		return domain.Reaction{}, false, app.NewError(http.StatusInternalServerError, "unknown error", "failed to start transaction", err)
+	}
+	defer rollback()
+
+	qExisting := `
+	SELECT * FROM reactions
+	WHERE user_id = $1 AND review_id = $2
+	FOR UPDATE;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qExisting)))
+
+	var existing models.ReactionModel
+	err = tx.GetContext(ctx, &existing, qExisting, reaction.UserID, reaction.ReviewID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return domain.Reaction{}, false, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	found := err == nil
+
+	var q string
+	var args []any
+	removed := false
+	switch {
+	case !found:
+		q = `
+		INSERT INTO reactions (user_id, review_id, type, created_at)
+		VALUES ($1, $2, $3, NOW())
+		RETURNING *;
+		`
+		args = []any{reaction.UserID, reaction.ReviewID, reaction.Type}
+	case existing.Type == reaction.Type:
+		// повторная реакция того же типа снимает её
+		q = `
+		DELETE FROM reactions
+		WHERE id = $1
+		RETURNING *;
+		`
+		args = []any{existing.ID}
+		removed = true
+	default:
+		q = `
+		UPDATE reactions
+		SET type = $2
+		WHERE id = $1
+		RETURNING *;
+		`
+		args = []any{existing.ID, reaction.Type}
+	}
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var toggled models.ReactionModel
+	err = tx.GetContext(ctx, &toggled, q, args...)
+	if err != nil {
+		switch pqErrorCode(err) {
+		case foreignKeyViolationCode:
+			return domain.Reaction{}, false, app.NewError(http.StatusNotFound, "review not found",
+				fmt.Sprintf("review %d or user %s not found", reaction.ReviewID, reaction.UserID), err)
+		case uniqueViolationCode:
+			return domain.Reaction{}, false, app.NewError(http.StatusConflict, "reaction already exists",
+				fmt.Sprintf("reaction of user %s to review %d was created concurrently", reaction.UserID, reaction.ReviewID), err)
+		}
+		return domain.Reaction{}, false, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	err = commit()
+	if err != nil {
+		return domain.Reaction{}, false, app.NewError(http.StatusInternalServerError, "unknown error", "failed to commit transaction", err)
+	}
+
+	return toggled.ToDomain(), removed, nil
+}
+
+func (r reactionRepository) List(ctx c.Context, filter domain.ReactionFilter, pag domain.IDPagination) ([]domain.Reaction, domain.IDPagination, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"List")
+	defer span.End()
+
+	qBuild := qb.NewNamed().
+		Q("SELECT * FROM reactions").
+		WhereOptPart().
+		CompConnectorOpt("review_id", qb.EQ(), "review_id", filter.ReviewID, qb.AND()).
+		CompConnectorOpt("type", qb.EQ(), "type", filter.Type, qb.AND()).
+		CompConnectorOpt("id", qb.GT(), "last_id", pag.LastID, qb.AND()).
+		EndWhereOpt().
+		OrderBy("id", true).
+		Limit("", pag.Limit)
+	q, args := qBuild.Build()
+
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	preparedQ, err := r.db.PrepareNamedContext(ctx, q)
+	if err != nil {
+		return nil, pag, app.NewError(http.StatusInternalServerError, "unknown error", "internal error preparing named query", err)
+	}
+	defer preparedQ.Close()
+
+	var rows []models.ReactionModel
+	err = preparedQ.SelectContext(ctx, &rows, args)
+	if err != nil {
+		return nil, pag, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	if len(rows) == 0 {
+		pag.LastID = 0
+		return []domain.Reaction{}, pag, nil
+	}
+
+	reactions := make([]domain.Reaction, len(rows))
+	for i, row := range rows {
+		reactions[i] = row.ToDomain()
+	}
+
+	pag.LastID = reactions[len(reactions)-1].ID
+	return reactions, pag, nil
+}
+
+func (r reactionRepository) CountByReviews(ctx c.Context, reviewIDs []int) (map[int]domain.ReactionCounts, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"CountByReviews")
+	defer span.End()
+
+	counts := make(map[int]domain.ReactionCounts)
+	if len(reviewIDs) == 0 {
+		return counts, nil
+	}
+
+	q := `
+	SELECT review_id, type, COUNT(*) AS count FROM reactions
+	WHERE review_id = ANY ($1)
+	GROUP BY review_id, type;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var rows []struct {
+		ReviewID int    `db:"review_id"`
+		Type     string `db:"type"`
+		Count    int    `db:"count"`
+	}
+	err := r.db.SelectContext(ctx, &rows, q, pq.Array(reviewIDs))
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	for _, row := range rows {
+		if counts[row.ReviewID] == nil {
+			counts[row.ReviewID] = make(domain.ReactionCounts)
+		}
+		counts[row.ReviewID][row.Type] = row.Count
+	}
+	return counts, nil
+}
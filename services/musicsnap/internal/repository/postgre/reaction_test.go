@@ -2,6 +2,7 @@ package postgre
 
 import (
 	"context"
+	"fmt"
 	"github.com/google/uuid"
 	_ "github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
@@ -43,7 +44,7 @@ func TestReactionRepository(t *testing.T) {
 
 	testReview := domain.Review{
 		UserID:    createdUser.ID,
-		PieceID:   uuid.New().String(),
+		PieceID:   createPiece(t, repo),
 		Rating:    9,
 		Content:   "Great piece of music!",
 		Moderated: false,
@@ -224,4 +225,72 @@ func TestReactionRepository(t *testing.T) {
 			require.NoError(t, err)
 		}
 	})
+
+	t.Run("Test reaction toggle", func(t *testing.T) {
+		ctx := context.Background()
+
+		toggled := domain.Reaction{UserID: createdUser.ID, ReviewID: createdReview.ID, Type: "fire"}
+
+		added, removed, err := repo.reaction.Toggle(ctx, toggled)
+		require.NoError(t, err)
+		assert.False(t, removed)
+		assert.Equal(t, "fire", added.Type)
+
+		toggled.Type = domain.LikeReaction
+		changed, removed, err := repo.reaction.Toggle(ctx, toggled)
+		require.NoError(t, err)
+		assert.False(t, removed)
+		assert.Equal(t, added.ID, changed.ID)
+		assert.Equal(t, domain.LikeReaction, changed.Type)
+
+		_, removed, err = repo.reaction.Toggle(ctx, toggled)
+		require.NoError(t, err)
+		assert.True(t, removed)
+
+		_, err = repo.reaction.GetFromActor(ctx, createdUser.ID, createdReview.ID)
+		assert.Error(t, err)
+
+		_, _, err = repo.reaction.Toggle(ctx, domain.Reaction{UserID: createdUser.ID, ReviewID: 999999, Type: "fire"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Test reaction list and counts", func(t *testing.T) {
+		ctx := context.Background()
+
+		users := make([]domain.User, 3)
+		for i := range users {
+			users[i], err = repo.user.Create(ctx, domain.User{
+				Profile:      domain.Profile{ID: uuid.New(), Nickname: fmt.Sprintf("reactor%d", i)},
+				Email:        fmt.Sprintf("reactor%d@example.com", i),
+				PasswordHash: "hashedpassword",
+				Roles:        domain.NewRoles([]string{domain.UserRole}),
+			})
+			require.NoError(t, err)
+		}
+		for i, typ := range []string{"fire", "heart", "fire"} {
+			_, _, err = repo.reaction.Toggle(ctx, domain.Reaction{UserID: users[i].ID, ReviewID: createdReview.ID, Type: typ})
+			require.NoError(t, err)
+		}
+
+		counts, err := repo.reaction.CountByReviews(ctx, []int{createdReview.ID, 999999})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReactionCounts{"fire": 2, "heart": 1}, counts[createdReview.ID])
+		assert.Nil(t, counts[999999])
+
+		fire := "fire"
+		page, pag, err := repo.reaction.List(ctx, domain.ReactionFilter{ReviewID: createdReview.ID, Type: &fire},
+			domain.IDPagination{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, users[0].ID, page[0].UserID)
+
+		page, pag, err = repo.reaction.List(ctx, domain.ReactionFilter{ReviewID: createdReview.ID, Type: &fire}, pag)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, users[2].ID, page[0].UserID)
+
+		page, _, err = repo.reaction.List(ctx, domain.ReactionFilter{ReviewID: createdReview.ID, Type: &fire}, pag)
+		require.NoError(t, err)
+		assert.Empty(t, page)
+	})
 }
@@ -0,0 +1,115 @@
+package postgre
+
+import (
+	c "context"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/juju/zaputil/zapctx"
+	"github.com/lib/pq"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/repository/postgre/models"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"sort"
+	"time"
+)
+
+var _ ports.EventReminderRepository = &eventReminderRepository{}
+
+func NewEventReminderRepository(db *sqlx.DB) ports.EventReminderRepository {
+	return &eventReminderRepository{db: db,
+		spanName: spanBaseName + "eventReminderRepository."}
+}
+
+func newEventReminderRepository(db *sqlx.DB) eventReminderRepository {
+	return eventReminderRepository{db: db,
+		spanName: spanBaseName + "eventReminderRepository."}
+}
+
+type eventReminderRepository struct {
+	db       *sqlx.DB
+	spanName string
+}
+
+// Schedule creates reminders of participants of event, reminders of moved event are recomputed
+func (r eventReminderRepository) Schedule(ctx c.Context, eventID uuid.UUID, offsets []time.Duration, now time.Time) (int, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Schedule")
+	defer span.End()
+
+	seconds := make([]int64, len(offsets))
+	for i, offset := range offsets {
+		seconds[i] = int64(offset / time.Second)
+	}
+
+	// перенесённое событие напоминается снова, если новое время напоминания ещё не прошло
+	q := `
+	INSERT INTO event_reminders (event_id, user_id, offset_seconds, remind_at)
+	SELECT p.event_id, p.user_id, o.offset_seconds, e.date - make_interval(secs => o.offset_seconds)
+	FROM event_participants p
+	JOIN events e ON e.id = p.event_id
+	CROSS JOIN UNNEST($2::INT[]) AS o(offset_seconds)
+	WHERE e.id = $1 AND e.date > $3 AND e.cancelled_at IS NULL
+	ON CONFLICT (event_id, user_id, offset_seconds) DO UPDATE
+	SET remind_at = EXCLUDED.remind_at,
+	    sent_at   = CASE WHEN EXCLUDED.remind_at > $3 THEN NULL ELSE event_reminders.sent_at END
+	WHERE event_reminders.remind_at <> EXCLUDED.remind_at;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	res, err := executorFrom(ctx, r.db).ExecContext(ctx, q, eventID, pq.Array(seconds), now)
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	scheduled, err := res.RowsAffected()
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return int(scheduled), nil
+}
+
+func (r eventReminderRepository) ClaimDue(ctx c.Context, now time.Time, notBefore time.Time, limit int) ([]domain.EventReminder, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"ClaimDue")
+	defer span.End()
+
+	q := `
+	WITH due AS (
+		SELECT r.id FROM event_reminders r
+		JOIN events e ON e.id = r.event_id
+		WHERE r.sent_at IS NULL AND r.remind_at <= $1 AND r.remind_at > $2
+		  AND e.date > $1 AND e.cancelled_at IS NULL
+		ORDER BY r.remind_at, r.id
+		LIMIT $3
+		FOR UPDATE OF r SKIP LOCKED
+	)
+	UPDATE event_reminders
+	SET sent_at = $1
+	FROM due, events
+	WHERE event_reminders.id = due.id AND events.id = event_reminders.event_id
+	RETURNING event_reminders.*, events.name AS event_name, events.date AS event_date,
+	          events.location AS event_location;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var rows []models.EventReminderModel
+	err := executorFrom(ctx, r.db).SelectContext(ctx, &rows, q, now, notBefore, limit)
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	reminders := make([]domain.EventReminder, len(rows))
+	for i, row := range rows {
+		reminders[i] = row.ToDomain()
+	}
+	// UPDATE ... RETURNING не сохраняет порядок подзапроса
+	sort.Slice(reminders, func(i, j int) bool { return reminders[i].RemindAt.Before(reminders[j].RemindAt) })
+	return reminders, nil
+}
@@ -0,0 +1,102 @@
+package postgre
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"music-snap/services/musicsnap/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestEventReminderRepository(t *testing.T) {
+	repo, closeDB, cleanDB, err := initializeRepository()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer closeDB()
+	defer cleanDB()
+
+	ctx := context.Background()
+
+	newUser := func(nickname string) domain.User {
+		user, err := repo.user.Create(ctx, domain.User{
+			Profile:      domain.Profile{ID: uuid.New(), Nickname: nickname},
+			Email:        nickname + "@example.com",
+			PasswordHash: "hashedpassword",
+			Roles:        domain.NewRoles([]string{domain.UserRole}),
+		})
+		require.NoError(t, err)
+		return user
+	}
+	author, fan := newUser("author"), newUser("fan")
+
+	offsets := []time.Duration{24 * time.Hour, 2 * time.Hour}
+	now := time.Now().UTC().Truncate(time.Second)
+	concert, err := repo.event.Create(ctx, domain.Event{Name: "Concert", Date: now.Add(30 * time.Hour),
+		Location: "Main hall", Authors: []uuid.UUID{author.ID}})
+	require.NoError(t, err)
+	require.NoError(t, repo.event.AddParticipant(ctx, concert.ID, fan.ID))
+
+	t.Run("Test schedule", func(t *testing.T) {
+		scheduled, err := repo.reminder.Schedule(ctx, concert.ID, offsets, now)
+		require.NoError(t, err)
+		assert.Equal(t, 2, scheduled)
+
+		scheduled, err = repo.reminder.Schedule(ctx, concert.ID, offsets, now)
+		require.NoError(t, err)
+		assert.Zero(t, scheduled, "unchanged reminders are not rescheduled")
+
+		due, err := repo.reminder.ClaimDue(ctx, now, now.Add(-15*time.Minute), 10)
+		require.NoError(t, err)
+		assert.Empty(t, due)
+	})
+
+	t.Run("Test due reminder is claimed once", func(t *testing.T) {
+		at := now.Add(6 * time.Hour)
+		due, err := repo.reminder.ClaimDue(ctx, at, at.Add(-15*time.Minute), 10)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		assert.Equal(t, fan.ID, due[0].UserID)
+		assert.Equal(t, 24*time.Hour, due[0].Before)
+		assert.Equal(t, "Concert", due[0].Event.Name)
+		assert.Equal(t, "Main hall", due[0].Event.Location)
+
+		again, err := repo.reminder.ClaimDue(ctx, at, at.Add(-15*time.Minute), 10)
+		require.NoError(t, err)
+		assert.Empty(t, again)
+	})
+
+	t.Run("Test moved event is reminded again", func(t *testing.T) {
+		concert.Date = now.Add(48 * time.Hour)
+		_, err := repo.event.Update(ctx, concert)
+		require.NoError(t, err)
+
+		scheduled, err := repo.reminder.Schedule(ctx, concert.ID, offsets, now)
+		require.NoError(t, err)
+		assert.Equal(t, 2, scheduled)
+
+		at := now.Add(24 * time.Hour)
+		due, err := repo.reminder.ClaimDue(ctx, at, at.Add(-15*time.Minute), 10)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		assert.Equal(t, now.Add(48*time.Hour), due[0].Event.Date)
+	})
+
+	t.Run("Test late reminder is skipped", func(t *testing.T) {
+		at := now.Add(47 * time.Hour)
+		due, err := repo.reminder.ClaimDue(ctx, at, at.Add(-15*time.Minute), 10)
+		require.NoError(t, err)
+		assert.Empty(t, due, "2h reminder is late by an hour")
+	})
+
+	t.Run("Test cancelled participation drops reminders", func(t *testing.T) {
+		require.NoError(t, repo.event.RemoveParticipant(ctx, concert.ID, fan.ID))
+
+		at := now.Add(46 * time.Hour)
+		due, err := repo.reminder.ClaimDue(ctx, at, at.Add(-15*time.Minute), 10)
+		require.NoError(t, err)
+		assert.Empty(t, due)
+	})
+}
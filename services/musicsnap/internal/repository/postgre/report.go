@@ -0,0 +1,366 @@
+package postgre
+
+import (
+	c "context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/juju/zaputil/zapctx"
+	"github.com/lib/pq"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	qb "music-snap/pkg/querybuilder"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/repository/postgre/models"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"strconv"
+)
+
+var _ ports.ReportRepository = &reportRepository{}
+
+func NewReportRepository(db *sqlx.DB) ports.ReportRepository {
+	return &reportRepository{db: db,
+		spanName: spanBaseName + "reportRepository."}
+}
+
+func newReportRepository(db *sqlx.DB) reportRepository {
+	return reportRepository{db: db,
+		spanName: spanBaseName + "reportRepository."}
+}
+
+type reportRepository struct {
+	db       *sqlx.DB
+	spanName string
+}
+
+// reportTargetQueries checks that reported object exists.
+// Notes are not stored in database yet, so their reports are accepted without the check
+var reportTargetQueries = map[domain.ReportTargetType]string{
+	domain.ReportTargetReview:     `SELECT EXISTS(SELECT 1 FROM reviews WHERE id = $1::int);`,
+	domain.ReportTargetProfile:    `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1::uuid);`,
+	domain.ReportTargetEventPhoto: `SELECT EXISTS(SELECT 1 FROM event_photos WHERE id = $1::uuid);`,
+}
+
+func (r reportRepository) Create(ctx c.Context, report domain.Report, hideThreshold int) (domain.Report, domain.ReportTarget, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Create")
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return domain.Report{}, domain.ReportTarget{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to start transaction", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	if qExists, ok := reportTargetQueries[report.TargetType]; ok {
+		logger.With(zap.String("PSQL query", formatQuery(qExists)))
+
+		var exists bool
+		err = tx.GetContext(ctx, &exists, qExists, report.TargetID)
+		if err != nil {
+			return domain.Report{}, domain.ReportTarget{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+		}
+		if !exists {
+			return domain.Report{}, domain.ReportTarget{}, app.NewError(http.StatusNotFound, "reported object not found",
+				fmt.Sprintf("%s %s not found", report.TargetType, report.TargetID), nil)
+		}
+	}
+
+	// повторная жалоба того же пользователя обновляет открытую, а не добавляет новую
+	q := `
+	INSERT INTO reports (reporter_id, target_type, target_id, reason, comment)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (reporter_id, target_type, target_id) WHERE status = 'open'
+	DO UPDATE SET reason = EXCLUDED.reason, comment = EXCLUDED.comment
+	RETURNING *, (xmax = 0) AS inserted;
+	`
+	reporterID := &report.ReporterID
+	if report.ByFilter() {
+		q = `
+		INSERT INTO reports (reporter_id, target_type, target_id, reason, comment)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (target_type, target_id) WHERE status = 'open' AND reporter_id IS NULL
+		DO UPDATE SET reason = EXCLUDED.reason, comment = EXCLUDED.comment
+		RETURNING *, (xmax = 0) AS inserted;
+		`
+		reporterID = nil
+	}
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var created struct {
+		models.ReportModel
+		Inserted bool `db:"inserted"`
+	}
+	err = tx.GetContext(ctx, &created, q, reporterID, string(report.TargetType), report.TargetID,
+		string(report.Reason), report.Comment)
+	if err != nil {
+		if pqErrorCode(err) == foreignKeyViolationCode {
+			return domain.Report{}, domain.ReportTarget{}, app.NewError(http.StatusNotFound, "user not found",
+				fmt.Sprintf("reporter %s not found", report.ReporterID), err)
+		}
+		return domain.Report{}, domain.ReportTarget{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	var target models.ReportTargetModel
+	if created.Inserted {
+		qTarget := `
+		INSERT INTO report_targets (target_type, target_id, open_reports, hidden_at)
+		VALUES ($1, $2, 1, CASE WHEN 1 >= $3 THEN NOW() END)
+		ON CONFLICT (target_type, target_id) DO UPDATE
+		SET open_reports = report_targets.open_reports + 1,
+		    hidden_at = COALESCE(report_targets.hidden_at,
+		                         CASE WHEN report_targets.open_reports + 1 >= $3 THEN NOW() END)
+		RETURNING *;
+		`
+		logger.With(zap.String("PSQL query", formatQuery(qTarget)))
+
+		err = tx.GetContext(ctx, &target, qTarget, string(report.TargetType), report.TargetID, hideThreshold)
+	} else {
+		qTarget := `
+		SELECT * FROM report_targets
+		WHERE target_type = $1 AND target_id = $2;
+		`
+		logger.With(zap.String("PSQL query", formatQuery(qTarget)))
+
+		err = tx.GetContext(ctx, &target, qTarget, string(report.TargetType), report.TargetID)
+	}
+	if err != nil {
+		return domain.Report{}, domain.ReportTarget{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	if report.TargetType == domain.ReportTargetReview {
+		err = syncReviewHidden(ctx, tx, report.TargetID)
+		if err != nil {
+			return domain.Report{}, domain.ReportTarget{}, err
+		}
+		if created.Inserted {
+			reviewID, _ := strconv.Atoi(report.TargetID)
+			err = enqueueModeration(ctx, tx, reviewID, domain.QueueReasonReported)
+			if err != nil {
+				return domain.Report{}, domain.ReportTarget{}, err
+			}
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return domain.Report{}, domain.ReportTarget{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to commit transaction", err)
+	}
+
+	return created.ReportModel.ToDomain(), target.ToDomain(), nil
+}
+
+func (r reportRepository) List(ctx c.Context, filter domain.ReportFilter, pag domain.IDPagination) ([]domain.Report, domain.IDPagination, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"List")
+	defer span.End()
+
+	var targetType, status *string
+	if filter.TargetType != nil {
+		targetTypeStr := string(*filter.TargetType)
+		targetType = &targetTypeStr
+	}
+	if filter.Status != nil {
+		statusStr := string(*filter.Status)
+		status = &statusStr
+	}
+
+	qBuild := qb.NewNamed().
+		Q("SELECT * FROM reports").
+		WhereOptPart().
+		CompConnectorOpt("reporter_id", qb.EQ(), "reporter_id", filter.ReporterID, qb.AND()).
+		CompConnectorOpt("target_type", qb.EQ(), "target_type", targetType, qb.AND()).
+		CompConnectorOpt("target_id", qb.EQ(), "target_id", filter.TargetID, qb.AND()).
+		CompConnectorOpt("status", qb.EQ(), "status", status, qb.AND()).
+		CompConnectorOpt("id", qb.GT(), "last_id", pag.LastID, qb.AND()).
+		EndWhereOpt().
+		OrderBy("id", true).
+		Limit("", pag.Limit)
+	q, args := qBuild.Build()
+
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	preparedQ, err := r.db.PrepareNamedContext(ctx, q)
+	if err != nil {
+		return nil, pag, app.NewError(http.StatusInternalServerError, "unknown error", "internal error preparing named query", err)
+	}
+	defer preparedQ.Close()
+
+	var rows []models.ReportModel
+	err = preparedQ.SelectContext(ctx, &rows, args)
+	if err != nil {
+		return nil, pag, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	if len(rows) == 0 {
+		pag.LastID = 0
+		return []domain.Report{}, pag, nil
+	}
+
+	reports := make([]domain.Report, len(rows))
+	for i, row := range rows {
+		reports[i] = row.ToDomain()
+	}
+
+	pag.LastID = reports[len(reports)-1].ID
+	return reports, pag, nil
+}
+
+// GetTarget returns reports summary of the object, object without reports has empty summary
+func (r reportRepository) GetTarget(ctx c.Context, targetType domain.ReportTargetType, targetID string) (domain.ReportTarget, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"GetTarget")
+	defer span.End()
+
+	q := `
+	SELECT * FROM report_targets
+	WHERE target_type = $1 AND target_id = $2;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var target models.ReportTargetModel
+	err := r.db.GetContext(ctx, &target, q, string(targetType), targetID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ReportTarget{TargetType: targetType, TargetID: targetID}, nil
+		}
+		return domain.ReportTarget{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return target.ToDomain(), nil
+}
+
+func (r reportRepository) HiddenTargets(ctx c.Context, targetType domain.ReportTargetType, targetIDs []string) ([]string, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"HiddenTargets")
+	defer span.End()
+
+	if len(targetIDs) == 0 {
+		return []string{}, nil
+	}
+
+	q := `
+	SELECT target_id FROM report_targets
+	WHERE target_type = $1 AND target_id = ANY($2) AND hidden_at IS NOT NULL;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	hidden := make([]string, 0)
+	err := r.db.SelectContext(ctx, &hidden, q, string(targetType), pq.Array(targetIDs))
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return hidden, nil
+}
+
+func (r reportRepository) Resolve(ctx c.Context, targetType domain.ReportTargetType, targetID string, status domain.ReportStatus, moderatorID uuid.UUID) (int, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Resolve")
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "failed to start transaction", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	resolved, err := resolveReports(ctx, tx, targetType, targetID, status, moderatorID)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "failed to commit transaction", err)
+	}
+	return resolved, nil
+}
+
+// resolveReports closes open reports of the target, writes notifications for reporters
+// and updates target visibility in the caller transaction
+func resolveReports(ctx c.Context, tx *sqlx.Tx, targetType domain.ReportTargetType, targetID string, status domain.ReportStatus, moderatorID uuid.UUID) (int, error) {
+	logger := zapctx.Logger(ctx)
+
+	q := `
+	WITH resolved AS (
+	    UPDATE reports
+	    SET status = $3, resolved_by = $4, resolved_at = NOW()
+	    WHERE target_type = $1 AND target_id = $2 AND status = 'open'
+	    RETURNING id, reporter_id, target_type, target_id, status
+	), notified AS (
+	    INSERT INTO notifications (id, user_id, type, message, created_at)
+	    SELECT gen_random_uuid(), reporter_id, $5,
+	           jsonb_build_object('report_id', id, 'target_type', target_type,
+	                              'target_id', target_id, 'status', status),
+	           NOW()
+	    FROM resolved
+	    WHERE reporter_id IS NOT NULL
+	)
+	SELECT COUNT(*) FROM resolved;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var resolved int
+	err := tx.GetContext(ctx, &resolved, q, string(targetType), targetID, string(status), moderatorID,
+		domain.NotificationReportResolved)
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	// подтверждённая жалоба оставляет объект скрытым, отклонённая возвращает его
+	qTarget := `
+	UPDATE report_targets
+	SET open_reports = 0,
+	    hidden_at = CASE WHEN $3 = 'dismissed' THEN NULL ELSE COALESCE(hidden_at, NOW()) END
+	WHERE target_type = $1 AND target_id = $2;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qTarget)))
+
+	_, err = tx.ExecContext(ctx, qTarget, string(targetType), targetID, string(status))
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	if targetType == domain.ReportTargetReview {
+		err = syncReviewHidden(ctx, tx, targetID)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return resolved, nil
+}
+
+// syncReviewHidden copies hidden state of reported review to reviews table used by review lists
+func syncReviewHidden(ctx c.Context, tx *sqlx.Tx, reviewID string) error {
+	logger := zapctx.Logger(ctx)
+
+	q := `
+	UPDATE reviews
+	SET hidden = h.hidden
+	FROM (SELECT EXISTS(SELECT 1 FROM report_targets
+	                    WHERE target_type = 'review' AND target_id = $1 AND hidden_at IS NOT NULL) AS hidden) h
+	WHERE reviews.id = $1::int AND reviews.hidden <> h.hidden;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	_, err := tx.ExecContext(ctx, q, reviewID)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return nil
+}
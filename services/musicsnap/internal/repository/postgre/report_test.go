@@ -0,0 +1,174 @@
+package postgre
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestReportRepository(t *testing.T) {
+	repo, closeDB, cleanDB, err := initializeRepository()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer closeDB()
+	defer cleanDB()
+
+	ctx := context.Background()
+
+	users := make([]domain.User, 3)
+	for i := range users {
+		users[i], err = repo.user.Create(ctx, domain.User{
+			Profile:      domain.Profile{ID: uuid.New(), Nickname: "reporter" + strconv.Itoa(i)},
+			Email:        "reporter" + strconv.Itoa(i) + "@example.com",
+			PasswordHash: "hashedpassword",
+			Roles:        domain.NewRoles([]string{domain.UserRole}),
+		})
+		require.NoError(t, err)
+	}
+	author, moderator := users[0], users[2]
+
+	review, err := repo.review.Create(ctx, domain.Review{
+		UserID:    author.ID,
+		PieceID:   createPiece(t, repo),
+		Rating:    1,
+		Content:   "you are all idiots",
+		Published: true,
+	})
+	require.NoError(t, err)
+
+	reviewID := strconv.Itoa(review.ID)
+	const hideThreshold = 2
+
+	t.Run("Test report of missing target", func(t *testing.T) {
+		_, _, err := repo.report.Create(ctx, domain.Report{
+			ReporterID: users[1].ID,
+			TargetType: domain.ReportTargetReview,
+			TargetID:   "0",
+			Reason:     domain.ReasonSpam,
+		}, hideThreshold)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, app.GetCode(err))
+	})
+
+	t.Run("Test repeated report is deduplicated", func(t *testing.T) {
+		first, target, err := repo.report.Create(ctx, domain.Report{
+			ReporterID: users[1].ID,
+			TargetType: domain.ReportTargetReview,
+			TargetID:   reviewID,
+			Reason:     domain.ReasonSpam,
+		}, hideThreshold)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReportOpen, first.Status)
+		assert.Equal(t, 1, target.OpenReports)
+		assert.False(t, target.Hidden())
+
+		second, target, err := repo.report.Create(ctx, domain.Report{
+			ReporterID: users[1].ID,
+			TargetType: domain.ReportTargetReview,
+			TargetID:   reviewID,
+			Reason:     domain.ReasonHarassment,
+			Comment:    "insults",
+		}, hideThreshold)
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, second.ID)
+		assert.Equal(t, domain.ReasonHarassment, second.Reason)
+		assert.Equal(t, 1, target.OpenReports)
+
+		item, err := repo.moderation.GetQueueItem(ctx, review.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.QueueReasonReported, item.Reason)
+	})
+
+	t.Run("Test threshold hides review", func(t *testing.T) {
+		_, target, err := repo.report.Create(ctx, domain.Report{
+			ReporterID: moderator.ID,
+			TargetType: domain.ReportTargetReview,
+			TargetID:   reviewID,
+			Reason:     domain.ReasonOffensive,
+		}, hideThreshold)
+		require.NoError(t, err)
+		assert.Equal(t, 2, target.OpenReports)
+		assert.True(t, target.Hidden())
+
+		hidden, err := repo.report.HiddenTargets(ctx, domain.ReportTargetReview, []string{reviewID, "100500"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{reviewID}, hidden)
+
+		hiddenFilter := false
+		reviews, _, err := repo.review.GetList(ctx, domain.ReviewFilter{UserID: &author.ID, Hidden: &hiddenFilter},
+			domain.IDPagination{})
+		require.NoError(t, err)
+		assert.Empty(t, reviews)
+	})
+
+	t.Run("Test approval dismisses reports", func(t *testing.T) {
+		approved, err := repo.moderation.Decide(ctx, domain.ModerationAction{
+			ReviewID:    review.ID,
+			ModeratorID: moderator.ID,
+			Action:      domain.ModerationApprove,
+		}, time.Now().Add(-15*time.Minute), false)
+		require.NoError(t, err)
+		assert.False(t, approved.Hidden)
+
+		target, err := repo.report.GetTarget(ctx, domain.ReportTargetReview, reviewID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, target.OpenReports)
+		assert.False(t, target.Hidden())
+
+		status := domain.ReportDismissed
+		reports, _, err := repo.report.List(ctx, domain.ReportFilter{ReporterID: &users[1].ID, Status: &status},
+			domain.IDPagination{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		require.NotNil(t, reports[0].ResolvedBy)
+		assert.Equal(t, moderator.ID, *reports[0].ResolvedBy)
+	})
+
+	t.Run("Test content filter report hides target at once", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			report, target, err := repo.report.Create(ctx, domain.Report{
+				TargetType: domain.ReportTargetProfile,
+				TargetID:   users[1].ID.String(),
+				Reason:     domain.ReasonOther,
+				Comment:    "nickname is not allowed",
+			}, 1)
+			require.NoError(t, err)
+			assert.True(t, report.ByFilter())
+			assert.Equal(t, 1, target.OpenReports)
+			assert.True(t, target.Hidden())
+		}
+
+		resolved, err := repo.report.Resolve(ctx, domain.ReportTargetProfile, users[1].ID.String(), domain.ReportDismissed, moderator.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, resolved)
+	})
+
+	t.Run("Test upheld profile reports keep profile hidden", func(t *testing.T) {
+		profileID := author.ID.String()
+		for _, reporter := range users[1:] {
+			_, _, err := repo.report.Create(ctx, domain.Report{
+				ReporterID: reporter.ID,
+				TargetType: domain.ReportTargetProfile,
+				TargetID:   profileID,
+				Reason:     domain.ReasonHarassment,
+			}, hideThreshold)
+			require.NoError(t, err)
+		}
+
+		resolved, err := repo.report.Resolve(ctx, domain.ReportTargetProfile, profileID, domain.ReportUpheld, moderator.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, resolved)
+
+		hidden, err := repo.report.HiddenTargets(ctx, domain.ReportTargetProfile, []string{profileID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{profileID}, hidden)
+	})
+}
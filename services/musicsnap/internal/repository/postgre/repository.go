@@ -1,8 +1,10 @@
 package postgre
 
 import (
+	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"music-snap/services/musicsnap/internal/service/ports"
 	"strings"
 )
@@ -12,30 +14,92 @@ const (
 )
 
 type Repository struct {
-	User     ports.UserRepository
-	Review   ports.ReviewRepository
-	Reaction ports.ReactionRepository
+	User         ports.UserRepository
+	Review       ports.ReviewRepository
+	Reaction     ports.ReactionRepository
+	Catalog      ports.CatalogRepository
+	Stats        ports.StatsRepository
+	Rating       ports.RatingRepository
+	Moderation   ports.ModerationRepository
+	Report       ports.ReportRepository
+	Comment      ports.CommentRepository
+	Tag          ports.TagRepository
+	Notification ports.NotificationRepository
+	Stream       ports.StreamRepository
+	Webhook      ports.WebhookRepository
+	Outbox       ports.OutboxRepository
+	Event        ports.EventRepository
+	CalendarFeed ports.CalendarFeedRepository
+	Reminder     ports.EventReminderRepository
+	Transactions ports.TransactionFactory
+	Loader       ports.BatchLoaderFactory
 }
 
 func NewRepository(db *sqlx.DB) Repository {
 	return Repository{
-		User:     NewUserRepository(db),
-		Review:   NewReviewRepository(db),
-		Reaction: NewReactionRepository(db),
+		User:         NewUserRepository(db),
+		Review:       NewReviewRepository(db),
+		Reaction:     NewReactionRepository(db),
+		Catalog:      NewCatalogRepository(db),
+		Stats:        NewStatsRepository(db),
+		Rating:       NewRatingRepository(db),
+		Moderation:   NewModerationRepository(db),
+		Report:       NewReportRepository(db),
+		Comment:      NewCommentRepository(db),
+		Tag:          NewTagRepository(db),
+		Notification: NewNotificationRepository(db),
+		Stream:       NewStreamRepository(db),
+		Webhook:      NewWebhookRepository(db),
+		Outbox:       NewOutboxRepository(db),
+		Event:        NewEventRepository(db),
+		CalendarFeed: NewCalendarFeedRepository(db),
+		Reminder:     NewEventReminderRepository(db),
+		Transactions: NewTransactionFactory(db),
+		Loader:       NewBatchLoaderFactory(db),
 	}
 }
 
 type repository struct {
-	user     userRepository
-	review   reviewRepository
-	reaction reactionRepository
+	user         userRepository
+	review       reviewRepository
+	reaction     reactionRepository
+	catalog      catalogRepository
+	stats        statsRepository
+	rating       ratingRepository
+	moderation   moderationRepository
+	report       reportRepository
+	comment      commentRepository
+	tag          tagRepository
+	notification notificationRepository
+	stream       streamRepository
+	webhook      webhookRepository
+	outbox       outboxRepository
+	event        eventRepository
+	calendarFeed calendarFeedRepository
+	reminder     eventReminderRepository
+	transactions transactionFactory
 }
 
 func newRepository(db *sqlx.DB) repository {
 	return repository{
-		user:     newUserRepository(db),
-		review:   newReviewRepository(db),
-		reaction: newReactionRepository(db),
+		user:         newUserRepository(db),
+		review:       newReviewRepository(db),
+		reaction:     newReactionRepository(db),
+		catalog:      newCatalogRepository(db),
+		stats:        newStatsRepository(db),
+		rating:       newRatingRepository(db),
+		moderation:   newModerationRepository(db),
+		report:       newReportRepository(db),
+		comment:      newCommentRepository(db),
+		tag:          newTagRepository(db),
+		notification: newNotificationRepository(db),
+		stream:       newStreamRepository(db),
+		webhook:      newWebhookRepository(db),
+		outbox:       newOutboxRepository(db),
+		event:        newEventRepository(db),
+		calendarFeed: newCalendarFeedRepository(db),
+		reminder:     newEventReminderRepository(db),
+		transactions: transactionFactory{db: db},
 	}
 }
 
@@ -44,6 +108,20 @@ const (
 	ReviewTable = "reviews"
 )
 
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	uniqueViolationCode     = "23505"
+	foreignKeyViolationCode = "23503"
+)
+
+func pqErrorCode(err error) string {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return string(pqErr.Code)
+	}
+	return ""
+}
+
 func formatQuery(q string) string {
 	return fmt.Sprintf("SQL Query: %s", strings.ReplaceAll(strings.ReplaceAll(q, "\t", ""), "\n", " "))
 }
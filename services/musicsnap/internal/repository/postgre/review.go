@@ -4,6 +4,8 @@ import (
 	c "context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"github.com/google/uuid"
 	"github.com/juju/zaputil/zapctx"
 	global "go.opentelemetry.io/otel"
 	"go.uber.org/zap"
@@ -11,6 +13,7 @@ import (
 	qb "music-snap/pkg/querybuilder"
 	"music-snap/services/musicsnap/internal/repository/postgre/models"
 	"net/http"
+	"time"
 
 	//"database/sql"
 	//"github.com/google/uuid"
@@ -53,8 +56,14 @@ func (r reviewRepository) Create(ctx c.Context, review domain.Review) (domain.Re
 	ctx, span := tr.Start(ctx, r.spanName+"Create")
 	defer span.End()
 
+	tx, commit, rollback, err := beginTx(ctx, r.db)
+	if err != nil {
+		return domain.Review{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to start transaction", err)
+	}
+	defer rollback()
+
 	q := `
-	INSERT INTO reviews (user_id, piece_id, rating, photo_url, content, moderated, published)
+	INSERT INTO reviews (user_id, piece_id, rating, photo_url, content, published, publish_at)
 	VALUES ($1, $2, $3, $4, $5, $6, $7)
 	
 	RETURNING *;
@@ -64,25 +73,61 @@ func (r reviewRepository) Create(ctx c.Context, review domain.Review) (domain.Re
 	reviewToWrite := models.ToReviewModel(review)
 
 	var createdReview models.ReviewModel
-	err := r.db.GetContext(ctx, &createdReview, q, reviewToWrite.UserID, reviewToWrite.PieceID, reviewToWrite.Rating, reviewToWrite.PhotoURL, reviewToWrite.Content, reviewToWrite.Moderated, reviewToWrite.Published)
+	err = tx.GetContext(ctx, &createdReview, q, reviewToWrite.UserID, reviewToWrite.PieceID, reviewToWrite.Rating, reviewToWrite.PhotoURL, reviewToWrite.Content, reviewToWrite.Published, reviewToWrite.PublishAt)
 	if err != nil {
 		return domain.Review{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
 	}
 
+	err = r.appendRevision(ctx, tx, createdReview, createdReview.UserID)
+	if err != nil {
+		return domain.Review{}, err
+	}
+
+	err = commit()
+	if err != nil {
+		return domain.Review{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to commit transaction", err)
+	}
+
 	return createdReview.ToDomain(), nil
 }
 
-func (r reviewRepository) Update(ctx c.Context, review domain.Review) (domain.Review, error) {
+// Update overwrites review and appends revision in the same transaction if review content changed.
+// Moderation status is changed only by moderation repository
+func (r reviewRepository) Update(ctx c.Context, review domain.Review, editorID uuid.UUID) (domain.Review, error) {
 	logger := zapctx.Logger(ctx)
 
 	tr := global.Tracer(domain.ServiceName)
 	ctx, span := tr.Start(ctx, r.spanName+"Update")
 	defer span.End()
 
+	tx, commit, rollback, err := beginTx(ctx, r.db)
+	if err != nil {
+		return domain.Review{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to start transaction", err)
+	}
+	defer rollback()
+
+	qLock := `
+	SELECT * FROM reviews
+	WHERE id = $1
+	FOR UPDATE;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qLock)))
+
+	var oldReview models.ReviewModel
+	err = tx.GetContext(ctx, &oldReview, qLock, review.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Review{}, app.NewError(http.StatusNotFound, "review not found", "review not found", err)
+		}
+		return domain.Review{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	contentChanged := oldReview.ToDomain().ContentChanged(review)
+
 	q := `
 	UPDATE reviews
-	SET piece_id = $1, rating = $2, photo_url = $3, content = $4, moderated = $5, published = $6
-	WHERE id = $7
+	SET piece_id = $1, rating = $2, photo_url = $3, content = $4, published = $5, publish_at = $6,
+	    edited_at = CASE WHEN $7 THEN NOW() ELSE edited_at END
+	WHERE id = $8
 	RETURNING *;
 	`
 	logger.With(zap.String("PSQL query", formatQuery(q)))
@@ -90,14 +135,98 @@ func (r reviewRepository) Update(ctx c.Context, review domain.Review) (domain.Re
 	reviewToWrite := models.ToReviewModel(review)
 
 	var updatedReview models.ReviewModel
-	err := r.db.GetContext(ctx, &updatedReview, q, reviewToWrite.PieceID, reviewToWrite.Rating, reviewToWrite.PhotoURL, reviewToWrite.Content, reviewToWrite.Moderated, reviewToWrite.Published, review.ID)
+	err = tx.GetContext(ctx, &updatedReview, q, reviewToWrite.PieceID, reviewToWrite.Rating, reviewToWrite.PhotoURL, reviewToWrite.Content, reviewToWrite.Published, reviewToWrite.PublishAt, contentChanged, review.ID)
 	if err != nil {
 		return domain.Review{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
 	}
 
+	if contentChanged {
+		err = r.appendRevision(ctx, tx, updatedReview, editorID)
+		if err != nil {
+			return domain.Review{}, err
+		}
+	}
+
+	err = commit()
+	if err != nil {
+		return domain.Review{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to commit transaction", err)
+	}
+
 	return updatedReview.ToDomain(), nil
 }
 
+// appendRevision writes review snapshot as the next revision, review row must be locked by the transaction
+func (r reviewRepository) appendRevision(ctx c.Context, tx *sqlx.Tx, review models.ReviewModel, editorID uuid.UUID) error {
+	logger := zapctx.Logger(ctx)
+
+	q := `
+	INSERT INTO review_revisions (review_id, revision, editor_id, piece_id, rating, content, photo_url)
+	SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3, $4, $5, $6
+	FROM review_revisions
+	WHERE review_id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	_, err := tx.ExecContext(ctx, q, review.ID, editorID, review.PieceID, review.Rating, review.Content, review.PhotoURL)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return nil
+}
+
+func (r reviewRepository) ListRevisions(ctx c.Context, reviewID int) ([]domain.ReviewRevision, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"ListRevisions")
+	defer span.End()
+
+	q := `
+	SELECT * FROM review_revisions
+	WHERE review_id = $1
+	ORDER BY revision ASC;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var revisionRows []models.ReviewRevisionModel
+	err := r.db.SelectContext(ctx, &revisionRows, q, reviewID)
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	revisions := make([]domain.ReviewRevision, len(revisionRows))
+	for i, row := range revisionRows {
+		revisions[i] = row.ToDomain()
+	}
+	return revisions, nil
+}
+
+func (r reviewRepository) GetRevision(ctx c.Context, reviewID, revision int) (domain.ReviewRevision, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"GetRevision")
+	defer span.End()
+
+	q := `
+	SELECT * FROM review_revisions
+	WHERE review_id = $1 AND revision = $2;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var rev models.ReviewRevisionModel
+	err := r.db.GetContext(ctx, &rev, q, reviewID, revision)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ReviewRevision{}, app.NewError(http.StatusNotFound, "review revision not found",
+				fmt.Sprintf("revision %d of review %d not found", revision, reviewID), err)
+		}
+		return domain.ReviewRevision{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	return rev.ToDomain(), nil
+}
+
 func (r reviewRepository) GetByID(ctx c.Context, id int) (domain.Review, error) {
 	logger := zapctx.Logger(ctx)
 
@@ -106,14 +235,19 @@ func (r reviewRepository) GetByID(ctx c.Context, id int) (domain.Review, error)
 	defer span.End()
 
 	q := `
-	SELECT * FROM reviews
-	WHERE id = $1;
+	SELECT reviews.*, COALESCE(threads.comments_count, 0) AS comments_count FROM reviews
+	LEFT JOIN threads ON threads.review_id = reviews.id
+	WHERE reviews.id = $1;
 	`
 	logger.With(zap.String("PSQL query", formatQuery(q)))
 
 	var review models.ReviewModel
 	err := r.db.GetContext(ctx, &review, q, id)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Review{}, app.NewError(http.StatusNotFound, "review not found",
+				fmt.Sprintf("review %d not found", id), err)
+		}
 		return domain.Review{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
 	}
 
@@ -136,11 +270,12 @@ func (r reviewRepository) GetList(ctx c.Context, filter domain.ReviewFilter, pag
 
 	// TODO Add OPT join
 	qBuild := qb.NewNamed().
-		Q("SELECT reviews.*, users.id AS user_id, users.nickname,users.avatar_url, users.background_url, users.bio  FROM reviews").
+		Q("SELECT reviews.*, COALESCE(threads.comments_count, 0) AS comments_count FROM reviews").
+		Q("LEFT JOIN threads ON threads.review_id = reviews.id").
 		StartOpt().
-		Q("JOIN").Table("users").ON().Q("reviews.user_id = users.id").
+		Q("JOIN").Table("hashtags").ON().Q("hashtags.review_id = reviews.id AND hashtags.source_type = 'review'").
 		EndOptIf(func() bool {
-			return true
+			return filter.Tag != nil
 		}).
 		WhereOptPart().
 		CompConnectorOpt("user_id", qb.EQ(), "user_id", filter.UserID, qb.AND()).
@@ -148,6 +283,10 @@ func (r reviewRepository) GetList(ctx c.Context, filter domain.ReviewFilter, pag
 		CompConnectorOpt("rating", qb.GET(), "rating", filter.Rating, qb.AND()).
 		CompConnectorOpt("moderated", qb.EQ(), "moderated", filter.Moderated, qb.AND()).
 		CompConnectorOpt("published", qb.EQ(), "published", filter.Published, qb.AND()).
+		CompConnectorOpt("moderation_status", qb.EQ(), "moderation_status", filter.ModerationStatus, qb.AND()).
+		CompConnectorOpt("moderation_status", qb.NEQ(), "excluded_moderation_status", filter.ExcludeModerationStatus, qb.AND()).
+		CompConnectorOpt("hidden", qb.EQ(), "hidden", filter.Hidden, qb.AND()).
+		CompConnectorOpt("hashtags.tag", qb.EQ(), "tag", filter.Tag, qb.AND()).
 		CompConnectorOpt("reviews.id", qb.GT(), "last_id", pag.LastID, qb.AND()).
 		EndWhereOpt().
 		OrderBy(orderByField, filter.OrderAsc).
@@ -158,12 +297,7 @@ func (r reviewRepository) GetList(ctx c.Context, filter domain.ReviewFilter, pag
 
 	logger.Warn("Executing query", zap.String("query", q), zap.Any("args", args))
 
-	type ReviewAndProfileRow struct {
-		models.ReviewModel
-		models.UserModel
-	}
-
-	var reviewRows []ReviewAndProfileRow
+	var reviewRows []models.ReviewModel
 	preparedQ, err := r.db.PrepareNamedContext(ctx, q)
 	if err != nil {
 		return nil, pag, app.NewError(http.StatusInternalServerError, "unknown error", "internal error preparing named query", err)
@@ -178,13 +312,24 @@ func (r reviewRepository) GetList(ctx c.Context, filter domain.ReviewFilter, pag
 	}
 
 	reviewsRes := make([]domain.Review, 0, len(reviewRows))
+	for _, rRow := range reviewRows {
+		reviewsRes = append(reviewsRes, rRow.ToDomain())
+	}
+
+	// профили авторов загружаются одним запросом, а не join на каждую строку
 	if filter.IncludeProfiles {
-		for _, rRow := range reviewRows {
-			reviewsRes = append(reviewsRes, rRow.ReviewModel.ToDomainProfile(rRow.UserModel.ToProfileDomain()))
+		loader := newBatchLoader(r.db, uuid.Nil)
+		for _, review := range reviewsRes {
+			loader.WantProfiles(review.UserID)
 		}
-	} else {
-		for _, rRow := range reviewRows {
-			reviewsRes = append(reviewsRes, rRow.ReviewModel.ToDomain())
+		err = loader.Load(ctx)
+		if err != nil {
+			return nil, pag, err
+		}
+		for i := range reviewsRes {
+			if profile, ok := loader.Profile(reviewsRes[i].UserID); ok {
+				reviewsRes[i].Profile = &profile
+			}
 		}
 	}
 
@@ -221,3 +366,150 @@ func (r reviewRepository) Delete(ctx c.Context, id int) (domain.Review, error) {
 
 	return review.ToDomain(), nil
 }
+
+// PublishScheduled publishes reviews scheduled not later than now, publication triggers enqueue their side effects
+func (r reviewRepository) PublishScheduled(ctx c.Context, now time.Time, limit int) ([]domain.Review, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"PublishScheduled")
+	defer span.End()
+
+	q := `
+	UPDATE reviews
+	SET published = true
+	WHERE id IN (SELECT id FROM reviews
+	             WHERE NOT published AND publish_at <= $1
+	             ORDER BY publish_at
+	             LIMIT $2
+	             FOR UPDATE SKIP LOCKED)
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var reviewRows []models.ReviewModel
+	err := r.db.SelectContext(ctx, &reviewRows, q, now, limit)
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	reviews := make([]domain.Review, len(reviewRows))
+	for i, row := range reviewRows {
+		reviews[i] = row.ToDomain()
+	}
+	return reviews, nil
+}
+
+// ClaimPublications claims due publications in order of their retry time, claimed one is retried
+// after lease if the server stops before marking it
+func (r reviewRepository) ClaimPublications(ctx c.Context, now time.Time, lease time.Duration, limit int, approvedOnly bool) ([]domain.ReviewPublication, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"ClaimPublications")
+	defer span.End()
+
+	q := `
+	WITH due AS (
+	    SELECT p.review_id FROM review_publications p
+	    JOIN reviews rv ON rv.id = p.review_id
+	    WHERE p.processed_at IS NULL AND p.next_attempt_at <= $1
+	      AND (NOT $4 OR rv.moderation_status = 'approved')
+	    ORDER BY p.next_attempt_at, p.published_at
+	    LIMIT $3
+	    FOR UPDATE OF p SKIP LOCKED
+	), claimed AS (
+	    UPDATE review_publications p
+	    SET next_attempt_at = $1::timestamp + make_interval(secs => $2::float8), attempts = p.attempts + 1
+	    FROM due
+	    WHERE p.review_id = due.review_id
+	    RETURNING p.review_id, p.attempts, p.published_at
+	)
+	SELECT rv.*, claimed.attempts AS publication_attempts FROM claimed
+	JOIN reviews rv ON rv.id = claimed.review_id
+	ORDER BY claimed.published_at;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var rows []struct {
+		models.ReviewModel
+		Attempts int `db:"publication_attempts"`
+	}
+	err := r.db.SelectContext(ctx, &rows, q, now, lease.Seconds(), limit, approvedOnly)
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	publications := make([]domain.ReviewPublication, len(rows))
+	for i, row := range rows {
+		publications[i] = domain.ReviewPublication{Review: row.ReviewModel.ToDomain(), Attempts: row.Attempts}
+	}
+	return publications, nil
+}
+
+func (r reviewRepository) MarkPublicationProcessed(ctx c.Context, reviewID int, processedAt time.Time) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"MarkPublicationProcessed")
+	defer span.End()
+
+	q := `
+	UPDATE review_publications
+	SET processed_at = $2, last_error = NULL
+	WHERE review_id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	_, err := r.db.ExecContext(ctx, q, reviewID, processedAt)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return nil
+}
+
+func (r reviewRepository) ReschedulePublication(ctx c.Context, reviewID int, retryAt time.Time, lastError string) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"ReschedulePublication")
+	defer span.End()
+
+	q := `
+	UPDATE review_publications
+	SET next_attempt_at = $2, last_error = $3
+	WHERE review_id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	_, err := r.db.ExecContext(ctx, q, reviewID, retryAt, lastError)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return nil
+}
+
+func (r reviewRepository) MarkPublicationHandled(ctx c.Context, reviewID int, handler string) (bool, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"MarkPublicationHandled")
+	defer span.End()
+
+	q := `
+	INSERT INTO review_publication_handlers (review_id, handler)
+	VALUES ($1, $2)
+	ON CONFLICT (review_id, handler) DO NOTHING;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	res, err := executorFrom(ctx, r.db).ExecContext(ctx, q, reviewID, handler)
+	if err != nil {
+		return false, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	marked, err := res.RowsAffected()
+	if err != nil {
+		return false, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return marked > 0, nil
+}
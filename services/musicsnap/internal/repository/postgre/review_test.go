@@ -9,7 +9,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"music-snap/pkg/app"
 	"music-snap/services/musicsnap/internal/domain"
+	"net/http"
 	"testing"
+	"time"
 )
 
 func initializeRepository() (repository, func() error, func() error, error) {
@@ -23,6 +25,19 @@ func initializeRepository() (repository, func() error, func() error, error) {
 	return repo, closeDB, cleanDB, nil
 }
 
+// createPiece adds track to catalog, reviews and ratings reference only existing pieces
+func createPiece(t *testing.T, repo repository) string {
+	ctx := context.Background()
+	artist, err := repo.catalog.CreateArtist(ctx, domain.Artist{Name: "Artist"})
+	require.NoError(t, err)
+	piece, err := repo.catalog.CreatePiece(ctx, domain.Piece{
+		Type:  domain.TrackPiece,
+		Track: &domain.Track{Title: "Track", ArtistID: artist.ID},
+	})
+	require.NoError(t, err)
+	return piece.ID.String()
+}
+
 func TestReviewRepository(t *testing.T) {
 	repo, closeDB, cleanDB, err := initializeRepository()
 	if err != nil {
@@ -54,7 +69,7 @@ func TestReviewRepository(t *testing.T) {
 
 	testReview := domain.Review{
 		UserID:    createdUser.ID,
-		PieceID:   uuid.New().String(),
+		PieceID:   createPiece(t, repo),
 		Rating:    9,
 		Content:   "Great piece of music!",
 		Moderated: false,
@@ -146,7 +161,7 @@ func TestReviewRepository(t *testing.T) {
 			updatedReview.Content = "Updated review content"
 			updatedReview.Rating = 8
 
-			result, err := repo.review.Update(ctx, updatedReview)
+			result, err := repo.review.Update(ctx, updatedReview, updatedReview.UserID)
 			require.NoError(t, err)
 			assert.Equal(t, updatedReview.ID, result.ID)
 			assert.Equal(t, updatedReview.UserID, result.UserID)
@@ -158,6 +173,35 @@ func TestReviewRepository(t *testing.T) {
 			assert.Equal(t, updatedReview.PhotoURL, result.PhotoURL)
 			assert.Equal(t, updatedReview.CreatedAt, result.CreatedAt)
 			assert.True(t, result.UpdatedAt.After(testReview.UpdatedAt))
+			assert.True(t, result.Edited())
+		})
+
+		t.Run("Test update review appends revision", func(t *testing.T) {
+			ctx := context.Background()
+
+			revisions, err := repo.review.ListRevisions(ctx, testReview.ID)
+			require.NoError(t, err)
+			require.Len(t, revisions, 2)
+			assert.Equal(t, 1, revisions[0].Revision)
+			assert.Equal(t, testReview.Content, revisions[0].Content)
+			assert.Equal(t, 2, revisions[1].Revision)
+			assert.Equal(t, "Updated review content", revisions[1].Content)
+			assert.Equal(t, testReview.UserID, revisions[1].EditorID)
+
+			latest, err := repo.review.GetByID(ctx, testReview.ID)
+			require.NoError(t, err)
+			latest.Published = !latest.Published
+
+			_, err = repo.review.Update(ctx, latest, latest.UserID)
+			require.NoError(t, err)
+
+			revisions, err = repo.review.ListRevisions(ctx, testReview.ID)
+			require.NoError(t, err)
+			assert.Len(t, revisions, 2, "publication change is not a content revision")
+
+			_, err = repo.review.GetRevision(ctx, testReview.ID, 3)
+			require.Error(t, err)
+			assert.Equal(t, http.StatusNotFound, app.GetCode(err))
 		})
 	})
 
@@ -190,7 +234,7 @@ func TestReviewRepository(t *testing.T) {
 			reviewForSearch := domain.Review{
 				ID:        i,
 				UserID:    testUser.ID,
-				PieceID:   uuid.New().String(),
+				PieceID:   createPiece(t, repo),
 				Rating:    5 + i,
 				Content:   "Test review content " + fmt.Sprintf("%d", i),
 				Moderated: i%2 == 0, // Every second review is moderated
@@ -299,3 +343,92 @@ func TestReviewRepository(t *testing.T) {
 		})
 	})
 }
+
+func TestReviewScheduledPublishing(t *testing.T) {
+	repo, closeDB, cleanDB, err := initializeRepository()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer closeDB()
+	defer cleanDB()
+
+	ctx := context.Background()
+
+	createdUser, err := repo.user.Create(ctx, domain.User{
+		Profile:      domain.Profile{ID: uuid.New(), Nickname: "scheduler"},
+		Email:        "scheduler@example.com",
+		PasswordHash: "hashedpassword",
+		Roles:        domain.NewRoles([]string{domain.UserRole}),
+	})
+	require.NoError(t, err)
+
+	publishAt := time.Now().Add(time.Hour)
+	scheduled, err := repo.review.Create(ctx, domain.Review{
+		UserID:    createdUser.ID,
+		PieceID:   createPiece(t, repo),
+		Rating:    7,
+		Content:   "Scheduled review",
+		PublishAt: &publishAt,
+	})
+	require.NoError(t, err)
+	assert.True(t, scheduled.Scheduled())
+	assert.Nil(t, scheduled.PublishedAt)
+
+	published, err := repo.review.PublishScheduled(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, published, "review is published before its time")
+
+	published, err = repo.review.PublishScheduled(ctx, publishAt.Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, published, 1)
+	assert.Equal(t, scheduled.ID, published[0].ID)
+	assert.True(t, published[0].Published)
+	assert.NotNil(t, published[0].PublishedAt)
+	assert.Nil(t, published[0].PublishAt)
+
+	now := time.Now().UTC()
+	claimed, err := repo.review.ClaimPublications(ctx, now, time.Minute, 10, false)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, scheduled.ID, claimed[0].Review.ID)
+	assert.Equal(t, 1, claimed[0].Attempts)
+
+	// до конца аренды публикацию не берёт другой сервер, после сбоя она ждёт повтора
+	claimed, err = repo.review.ClaimPublications(ctx, now, time.Minute, 10, false)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	err = repo.review.ReschedulePublication(ctx, scheduled.ID, now.Add(time.Hour), "connection lost")
+	require.NoError(t, err)
+	claimed, err = repo.review.ClaimPublications(ctx, now.Add(2*time.Minute), time.Minute, 10, false)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	claimed, err = repo.review.ClaimPublications(ctx, now.Add(time.Hour), time.Minute, 10, false)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, 2, claimed[0].Attempts)
+
+	err = repo.review.MarkPublicationProcessed(ctx, scheduled.ID, now)
+	require.NoError(t, err)
+
+	first, err := repo.review.MarkPublicationHandled(ctx, scheduled.ID, "stream")
+	require.NoError(t, err)
+	assert.True(t, first)
+	first, err = repo.review.MarkPublicationHandled(ctx, scheduled.ID, "stream")
+	require.NoError(t, err)
+	assert.False(t, first, "handler runs once")
+
+	// повторная публикация после снятия не повторяет побочные эффекты
+	unpublished := published[0]
+	unpublished.Published = false
+	_, err = repo.review.Update(ctx, unpublished, createdUser.ID)
+	require.NoError(t, err)
+	unpublished.Published = true
+	_, err = repo.review.Update(ctx, unpublished, createdUser.ID)
+	require.NoError(t, err)
+
+	claimed, err = repo.review.ClaimPublications(ctx, now.Add(2*time.Hour), time.Minute, 10, false)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+}
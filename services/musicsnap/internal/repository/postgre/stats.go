@@ -0,0 +1,80 @@
+package postgre
+
+import (
+	c "context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/repository/postgre/models"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+)
+
+var _ ports.StatsRepository = &statsRepository{}
+
+func NewStatsRepository(db *sqlx.DB) ports.StatsRepository {
+	return &statsRepository{db: db,
+		spanName: spanBaseName + "statsRepository."}
+}
+
+func newStatsRepository(db *sqlx.DB) statsRepository {
+	return statsRepository{db: db,
+		spanName: spanBaseName + "statsRepository."}
+}
+
+type statsRepository struct {
+	db       *sqlx.DB
+	spanName string
+}
+
+func (r statsRepository) GetPieceStats(ctx c.Context, pieceID string) (domain.TrackStats, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"GetPieceStats")
+	defer span.End()
+
+	q := `
+	SELECT * FROM piece_stats
+	WHERE piece_id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var stats models.PieceStatsModel
+	err := r.db.GetContext(ctx, &stats, q, pieceID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.TrackStats{}, app.NewError(http.StatusNotFound, "piece stats not found", "piece stats not found", err)
+		}
+		return domain.TrackStats{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	return stats.ToDomain(), nil
+}
+
+// RebuildPieceStats recomputes all piece aggregates from reviews and reactions, returns amount of rebuilt pieces
+func (r statsRepository) RebuildPieceStats(ctx c.Context) (int, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"RebuildPieceStats")
+	defer span.End()
+
+	q := `
+	SELECT rebuild_piece_stats();
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var rebuilt int
+	err := r.db.GetContext(ctx, &rebuilt, q)
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	return rebuilt, nil
+}
@@ -0,0 +1,235 @@
+package postgre
+
+import (
+	c "context"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/juju/zaputil/zapctx"
+	"github.com/lib/pq"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/repository/postgre/models"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"time"
+)
+
+var _ ports.StreamRepository = &streamRepository{}
+
+func NewStreamRepository(db *sqlx.DB) ports.StreamRepository {
+	return &streamRepository{db: db,
+		spanName: spanBaseName + "streamRepository."}
+}
+
+func newStreamRepository(db *sqlx.DB) streamRepository {
+	return streamRepository{db: db,
+		spanName: spanBaseName + "streamRepository."}
+}
+
+// streamRepository stores events of real-time stream, events of notifications are added by trigger
+type streamRepository struct {
+	db       *sqlx.DB
+	spanName string
+}
+
+func (r streamRepository) AddFeedEvents(ctx c.Context, review domain.Review) (int, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"AddFeedEvents")
+	defer span.End()
+
+	// рецензия попадает в ленту подписчика один раз
+	q := `
+	INSERT INTO stream_events (user_id, kind, review_id, payload)
+	SELECT s.subscriber_id, $1, rv.id,
+	       jsonb_build_object('review_id', rv.id, 'user_id', rv.user_id, 'piece_id', rv.piece_id,
+	                          'rating', rv.rating, 'created_at', rv.created_at)
+	FROM subscriptions s
+	JOIN reviews rv ON rv.user_id = s.followed_id
+	WHERE rv.id = $2 AND s.subscriber_id IS NOT NULL
+	ON CONFLICT (user_id, kind, review_id) WHERE review_id IS NOT NULL DO NOTHING;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	res, err := executorFrom(ctx, r.db).ExecContext(ctx, q, domain.FeedStreamEvent, review.ID)
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	added, err := res.RowsAffected()
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return int(added), nil
+}
+
+// ListAfter lists events of user after position afterID, events of transactions which are not committed yet
+// get their positions later, so stream is resumed after them in order of commits
+func (r streamRepository) ListAfter(ctx c.Context, userID uuid.UUID, afterID int64, limit int) ([]domain.StreamEvent, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"ListAfter")
+	defer span.End()
+
+	err := r.assignPositions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := `
+	SELECT id, user_id, kind, payload, created_at, position FROM stream_events
+	WHERE user_id = $1 AND position > $2
+	ORDER BY position ASC
+	LIMIT $3;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var rows []models.StreamEventModel
+	err = r.db.SelectContext(ctx, &rows, q, userID, afterID, limit)
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	events := make([]domain.StreamEvent, len(rows))
+	for i, row := range rows {
+		events[i] = row.ToDomain()
+	}
+	return events, nil
+}
+
+// assignPositions numbers events of transactions older than the oldest running one, in order of transactions.
+// Later transaction can't get smaller position, so position never falls behind cursor of client.
+// Streams of users whose events got positions are woken, events may wait there for an older transaction
+func (r streamRepository) assignPositions(ctx c.Context) error {
+	logger := zapctx.Logger(ctx)
+
+	qPending := `
+	SELECT EXISTS (SELECT 1 FROM stream_events
+	               WHERE position IS NULL AND tx_id < pg_snapshot_xmin(pg_current_snapshot()));
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qPending)))
+
+	var pending bool
+	err := r.db.GetContext(ctx, &pending, qPending)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	if !pending {
+		return nil
+	}
+
+	tx, commit, rollback, err := beginTx(ctx, r.db)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "can't begin transaction", err)
+	}
+	defer rollback()
+
+	// позиции назначаются по одной транзакции за раз
+	qLock := `SELECT pg_advisory_xact_lock(hashtext('stream_events_position'));`
+	logger.With(zap.String("PSQL query", formatQuery(qLock)))
+
+	_, err = tx.ExecContext(ctx, qLock)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	q := `
+	WITH ready AS (
+	    SELECT id, row_number() OVER (ORDER BY tx_id, id) AS n
+	    FROM stream_events
+	    WHERE position IS NULL AND tx_id < pg_snapshot_xmin(pg_current_snapshot())
+	)
+	UPDATE stream_events s
+	SET position = (SELECT last_value FROM stream_events_position_seq) + ready.n
+	FROM ready
+	WHERE s.id = ready.id
+	RETURNING s.user_id, s.position;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var assigned []struct {
+		UserID   uuid.UUID `db:"user_id"`
+		Position int64     `db:"position"`
+	}
+	err = tx.SelectContext(ctx, &assigned, q)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	if len(assigned) == 0 {
+		return nil
+	}
+
+	last := int64(0)
+	woken := make(map[uuid.UUID]bool, len(assigned))
+	userIDs := make([]uuid.UUID, 0, len(assigned))
+	for _, row := range assigned {
+		if row.Position > last {
+			last = row.Position
+		}
+		if !woken[row.UserID] {
+			woken[row.UserID] = true
+			userIDs = append(userIDs, row.UserID)
+		}
+	}
+
+	qSequence := `SELECT setval('stream_events_position_seq', $1);`
+	logger.With(zap.String("PSQL query", formatQuery(qSequence)))
+
+	_, err = tx.ExecContext(ctx, qSequence, last)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	// pg_notify доставляется после фиксации, когда позиции уже видны
+	qNotify := `
+	SELECT pg_notify('musicsnap_stream', json_build_object('user_id', u)::text)
+	FROM unnest($1::uuid[]) AS u;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qNotify)))
+
+	_, err = tx.ExecContext(ctx, qNotify, pq.Array(userIDs))
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	if err = commit(); err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "can't commit transaction", err)
+	}
+	return nil
+}
+
+// DeleteOutdated deletes one batch of stream events created before, events are already delivered
+// or client resumes stream from the oldest kept one
+func (r streamRepository) DeleteOutdated(ctx c.Context, before time.Time, limit int) (int, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"DeleteOutdated")
+	defer span.End()
+
+	q := `
+	WITH outdated AS (
+	    SELECT id FROM stream_events
+	    WHERE created_at < $1 AND position IS NOT NULL
+	    LIMIT $2
+	    FOR UPDATE SKIP LOCKED
+	)
+	DELETE FROM stream_events s
+	USING outdated
+	WHERE s.id = outdated.id;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	res, err := r.db.ExecContext(ctx, q, before, limit)
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	return int(deleted), nil
+}
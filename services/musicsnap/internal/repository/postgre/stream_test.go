@@ -0,0 +1,112 @@
+package postgre
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"music-snap/services/musicsnap/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestStreamRepository(t *testing.T) {
+	repo, closeDB, cleanDB, err := initializeRepository()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer closeDB()
+	defer cleanDB()
+
+	ctx := context.Background()
+
+	users := make([]domain.User, 2)
+	for i := range users {
+		users[i], err = repo.user.Create(ctx, domain.User{
+			Profile:      domain.Profile{ID: uuid.New(), Nickname: fmt.Sprintf("streamed%d", i)},
+			Email:        fmt.Sprintf("streamed%d@example.com", i),
+			PasswordHash: "hashedpassword",
+			Roles:        domain.NewRoles([]string{domain.UserRole}),
+		})
+		require.NoError(t, err)
+	}
+	follower, author := users[0], users[1]
+
+	_, err = repo.user.CreateSub(ctx, domain.Subscription{
+		SubscriberID: follower.ID,
+		FollowedID:   author.ID,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	})
+	require.NoError(t, err)
+
+	t.Run("Test notification is added to stream", func(t *testing.T) {
+		notification, err := repo.notification.Create(ctx, domain.Notification{
+			UserIDReceiver: follower.ID,
+			UserIDSender:   &author.ID,
+			Type:           domain.NotificationMention,
+		})
+		require.NoError(t, err)
+
+		events, err := repo.stream.ListAfter(ctx, follower.ID, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, domain.NotificationStreamEvent, events[0].Kind)
+
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(events[0].Payload, &payload))
+		assert.Equal(t, notification.ID.String(), payload["id"])
+	})
+
+	t.Run("Test feed events of followers", func(t *testing.T) {
+		review, err := repo.review.Create(ctx, domain.Review{
+			UserID:    author.ID,
+			PieceID:   createPiece(t, repo),
+			Rating:    9,
+			Content:   "Streamed review",
+			Published: true,
+		})
+		require.NoError(t, err)
+
+		added, err := repo.stream.AddFeedEvents(ctx, review)
+		require.NoError(t, err)
+		assert.Equal(t, 1, added)
+
+		added, err = repo.stream.AddFeedEvents(ctx, review)
+		require.NoError(t, err)
+		assert.Zero(t, added, "review is added to feed once")
+
+		authorEvents, err := repo.stream.ListAfter(ctx, author.ID, 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, authorEvents)
+	})
+
+	t.Run("Test events after resume id", func(t *testing.T) {
+		events, err := repo.stream.ListAfter(ctx, follower.ID, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Less(t, events[0].ID, events[1].ID)
+		assert.Equal(t, domain.FeedStreamEvent, events[1].Kind)
+
+		resumed, err := repo.stream.ListAfter(ctx, follower.ID, events[0].ID, 10)
+		require.NoError(t, err)
+		require.Len(t, resumed, 1)
+		assert.Equal(t, events[1].ID, resumed[0].ID)
+	})
+
+	t.Run("Test outdated events are deleted", func(t *testing.T) {
+		deleted, err := repo.stream.DeleteOutdated(ctx, time.Now().UTC().Add(-time.Hour), 10)
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+
+		deleted, err = repo.stream.DeleteOutdated(ctx, time.Now().UTC().Add(time.Hour), 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		events, err := repo.stream.ListAfter(ctx, follower.ID, 0, 10)
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+	})
+}
@@ -0,0 +1,162 @@
+package postgre
+
+import (
+	c "context"
+	"github.com/jmoiron/sqlx"
+	"github.com/juju/zaputil/zapctx"
+	"github.com/lib/pq"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var _ ports.TagRepository = &tagRepository{}
+
+func NewTagRepository(db *sqlx.DB) ports.TagRepository {
+	return &tagRepository{db: db,
+		spanName: spanBaseName + "tagRepository."}
+}
+
+func newTagRepository(db *sqlx.DB) tagRepository {
+	return tagRepository{db: db,
+		spanName: spanBaseName + "tagRepository."}
+}
+
+type tagRepository struct {
+	db       *sqlx.DB
+	spanName string
+}
+
+func (r tagRepository) Index(ctx c.Context, source domain.TextSource, nicknames []string, tags []string, notify bool) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Index")
+	defer span.End()
+
+	// nil массив передаётся как NULL, а не пустой массив
+	if tags == nil {
+		tags = []string{}
+	}
+	lowerNicknames := make([]string, len(nicknames))
+	for i, nickname := range nicknames {
+		lowerNicknames[i] = strings.ToLower(nickname)
+	}
+
+	tx, commit, rollback, err := beginTx(ctx, r.db)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "failed to start transaction", err)
+	}
+	defer rollback()
+
+	// упоминания, удалённые из текста при редактировании, удаляются из индекса
+	qMentions := `
+	WITH mentioned AS (
+	    SELECT id FROM users
+	    WHERE lower(nickname) = ANY ($5) AND id <> $4
+	), removed AS (
+	    DELETE FROM mentions
+	    WHERE source_type = $1 AND source_id = $2 AND user_id NOT IN (SELECT id FROM mentioned)
+	)
+	INSERT INTO mentions (source_type, source_id, review_id, user_id, author_id)
+	SELECT $1, $2, $3, id, $4 FROM mentioned
+	ON CONFLICT DO NOTHING;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qMentions)))
+
+	_, err = tx.ExecContext(ctx, qMentions, string(source.Type), source.ID, source.ReviewID, source.AuthorID,
+		pq.Array(lowerNicknames))
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	if notify {
+		// упоминание считается обработанным и при блокировке, чтобы уведомление не пришло после разблокировки
+		qNotify := `
+		WITH notified AS (
+		    UPDATE mentions
+		    SET notified_at = NOW()
+		    WHERE source_type = $1 AND source_id = $2 AND notified_at IS NULL
+		    RETURNING user_id, author_id, review_id
+		)
+		INSERT INTO notifications (id, user_id, sender_id, type, message, created_at)
+		SELECT gen_random_uuid(), n.user_id, n.author_id, $3,
+		       jsonb_build_object('source_type', $1::text, 'source_id', $2::text,
+		                          'review_id', n.review_id, 'user_id', n.author_id),
+		       NOW()
+		FROM notified n
+		WHERE NOT EXISTS (SELECT 1 FROM user_blocks b
+		                  WHERE (b.blocker_id = n.user_id AND b.blocked_id = n.author_id)
+		                     OR (b.blocker_id = n.author_id AND b.blocked_id = n.user_id));
+		`
+		logger.With(zap.String("PSQL query", formatQuery(qNotify)))
+
+		_, err = tx.ExecContext(ctx, qNotify, string(source.Type), source.ID, domain.NotificationMention)
+		if err != nil {
+			return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+		}
+	}
+
+	// оставшиеся теги сохраняют время первого появления
+	qTags := `
+	WITH removed AS (
+	    DELETE FROM hashtags
+	    WHERE source_type = $1 AND source_id = $2 AND tag <> ALL ($4)
+	)
+	INSERT INTO hashtags (source_type, source_id, review_id, tag)
+	SELECT $1, $2, $3, unnest($4::text[])
+	ON CONFLICT DO NOTHING;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qTags)))
+
+	_, err = tx.ExecContext(ctx, qTags, string(source.Type), source.ID, source.ReviewID, pq.Array(tags))
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	err = commit()
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "failed to commit transaction", err)
+	}
+	return nil
+}
+
+func (r tagRepository) Trending(ctx c.Context, since time.Time, approvedOnly bool, limit int) ([]domain.TagTrend, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Trending")
+	defer span.End()
+
+	q := `
+	SELECT hashtags.tag, COUNT(*) AS uses FROM hashtags
+	JOIN reviews ON reviews.id = hashtags.review_id
+	WHERE hashtags.created_at >= $1
+	  AND reviews.published AND NOT reviews.hidden AND reviews.moderation_status <> 'rejected'
+	  AND (NOT $2 OR reviews.moderation_status = 'approved')
+	GROUP BY hashtags.tag
+	ORDER BY uses DESC, hashtags.tag
+	LIMIT $3;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var rows []struct {
+		Tag  string `db:"tag"`
+		Uses int    `db:"uses"`
+	}
+	err := r.db.SelectContext(ctx, &rows, q, since, approvedOnly, limit)
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	trends := make([]domain.TagTrend, len(rows))
+	for i, row := range rows {
+		trends[i] = domain.TagTrend{Tag: row.Tag, Uses: row.Uses}
+	}
+	return trends, nil
+}
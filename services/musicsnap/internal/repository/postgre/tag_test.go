@@ -0,0 +1,97 @@
+package postgre
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"music-snap/services/musicsnap/internal/domain"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestTagRepository(t *testing.T) {
+	repo, closeDB, cleanDB, err := initializeRepository()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer closeDB()
+	defer cleanDB()
+
+	ctx := context.Background()
+
+	users := make([]domain.User, 3)
+	for i := range users {
+		users[i], err = repo.user.Create(ctx, domain.User{
+			Profile:      domain.Profile{ID: uuid.New(), Nickname: "Tagger" + strconv.Itoa(i)},
+			Email:        "tagger" + strconv.Itoa(i) + "@example.com",
+			PasswordHash: "hashedpassword",
+			Roles:        domain.NewRoles([]string{domain.UserRole}),
+		})
+		require.NoError(t, err)
+	}
+	author, fan, blocker := users[0], users[1], users[2]
+
+	require.NoError(t, repo.user.Block(ctx, blocker.ID, author.ID))
+
+	review, err := repo.review.Create(ctx, domain.Review{
+		UserID:    author.ID,
+		PieceID:   createPiece(t, repo),
+		Rating:    9,
+		Content:   "@tagger1 @tagger2 #shoegaze #dreampop",
+		Published: true,
+	})
+	require.NoError(t, err)
+
+	source := domain.TextSource{
+		Type:     domain.TextSourceReview,
+		ID:       strconv.Itoa(review.ID),
+		ReviewID: review.ID,
+		AuthorID: author.ID,
+		Text:     review.Content,
+	}
+
+	mentionNotifications := func(userID uuid.UUID) int {
+		var count int
+		err := repo.tag.db.GetContext(ctx, &count,
+			`SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND type = $2`, userID, domain.NotificationMention)
+		require.NoError(t, err)
+		return count
+	}
+
+	t.Run("Test mentions notify once and respect blocks", func(t *testing.T) {
+		nicknames, tags := domain.ParseMentions(source.Text), domain.ParseHashtags(source.Text)
+		require.NoError(t, repo.tag.Index(ctx, source, nicknames, tags, true))
+		require.NoError(t, repo.tag.Index(ctx, source, nicknames, tags, true))
+
+		assert.Equal(t, 1, mentionNotifications(fan.ID))
+		assert.Equal(t, 0, mentionNotifications(blocker.ID))
+	})
+
+	t.Run("Test reviews of tag", func(t *testing.T) {
+		tag := "shoegaze"
+		reviews, _, err := repo.review.GetList(ctx, domain.ReviewFilter{Tag: &tag}, domain.IDPagination{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, reviews, 1)
+		assert.Equal(t, review.ID, reviews[0].ID)
+	})
+
+	t.Run("Test edit updates trending tags", func(t *testing.T) {
+		trends, err := repo.tag.Trending(ctx, time.Now().Add(-time.Hour), false, 10)
+		require.NoError(t, err)
+		assert.Len(t, trends, 2)
+
+		source.Text = "#dreampop only"
+		require.NoError(t, repo.tag.Index(ctx, source, nil, domain.ParseHashtags(source.Text), true))
+
+		trends, err = repo.tag.Trending(ctx, time.Now().Add(-time.Hour), false, 10)
+		require.NoError(t, err)
+		require.Len(t, trends, 1)
+		assert.Equal(t, domain.TagTrend{Tag: "dreampop", Uses: 1}, trends[0])
+
+		trends, err = repo.tag.Trending(ctx, time.Now().Add(time.Hour), false, 10)
+		require.NoError(t, err)
+		assert.Empty(t, trends)
+	})
+}
@@ -0,0 +1,116 @@
+package postgre
+
+import (
+	c "context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+)
+
+var _ ports.Transaction = &transaction{}
+
+func NewTransactionFactory(db *sqlx.DB) ports.TransactionFactory {
+	return transactionFactory{db: db}
+}
+
+type transactionFactory struct {
+	db *sqlx.DB
+}
+
+func (f transactionFactory) NewTransaction() ports.Transaction {
+	return &transaction{db: f.db}
+}
+
+// transaction: Единица работы над sqlx.Tx
+type transaction struct {
+	db   *sqlx.DB
+	tx   *sqlx.Tx
+	done bool
+}
+
+func (t *transaction) Start(ctx c.Context) error {
+	if t.tx != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "transaction is already started", nil)
+	}
+	tx, err := t.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "failed to start transaction", err)
+	}
+	t.tx = tx
+	return nil
+}
+
+func (t *transaction) Commit(_ c.Context) error {
+	if t.tx == nil || t.done {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "transaction is not started or already finished", nil)
+	}
+	t.done = true
+	err := t.tx.Commit()
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "failed to commit transaction", err)
+	}
+	return nil
+}
+
+func (t *transaction) Abort(_ c.Context) error {
+	if t.tx == nil || t.done {
+		return nil
+	}
+	t.done = true
+	err := t.tx.Rollback()
+	if err != nil && !errors.Is(err, sql.ErrTxDone) {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "failed to abort transaction", err)
+	}
+	return nil
+}
+
+func (t *transaction) End(ctx c.Context) {
+	if err := t.Abort(ctx); err != nil {
+		zapctx.Logger(ctx).Error("can't abort unfinished transaction", zap.Error(err))
+	}
+}
+
+// executor runs queries of repository method
+type executor interface {
+	sqlx.ExtContext
+	GetContext(ctx c.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx c.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// executorFrom returns transaction of unit of work from ctx or the database
+func executorFrom(ctx c.Context, db *sqlx.DB) executor {
+	if tx := joinedTx(ctx); tx != nil {
+		return tx
+	}
+	return db
+}
+
+// beginTx starts own transaction of repository method or joins transaction of unit of work from ctx.
+// Joined transaction is committed and rolled back only by its unit of work, so commit and rollback do nothing for it
+func beginTx(ctx c.Context, db *sqlx.DB) (*sqlx.Tx, func() error, func(), error) {
+	if tx := joinedTx(ctx); tx != nil {
+		return tx, func() error { return nil }, func() {}, nil
+	}
+	tx, err := db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return tx, tx.Commit, func() { _ = tx.Rollback() }, nil
+}
+
+func joinedTx(ctx c.Context) *sqlx.Tx {
+	unit, ok := ports.TransactionFrom(ctx)
+	if !ok {
+		return nil
+	}
+	t, ok := unit.(*transaction)
+	if !ok || t.tx == nil || t.done {
+		return nil
+	}
+	return t.tx
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/juju/zaputil/zapctx"
@@ -58,20 +59,19 @@ func (r userRepository) GetList(ctx context.Context, nickQuery string, pag domai
 		return []domain.User{}, uuid.Nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
 	}
 
+	// роли всех пользователей страницы загружаются одним запросом
+	loader := newBatchLoader(r.db, uuid.Nil)
+	for _, user := range resUsers {
+		loader.WantRoles(user.ID)
+	}
+	err = loader.Load(ctx)
+	if err != nil {
+		return []domain.User{}, uuid.Nil, err
+	}
+
 	var users []domain.User
 	for _, user := range resUsers {
-		q = `
-		SELECT * FROM user_roles WHERE user_id = $1 ORDER BY role ASC;
-		`
-		var roles []models.RoleModel
-		err = r.db.SelectContext(ctx, &roles, q, user.ID)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return []domain.User{}, uuid.Nil, app.NewError(http.StatusNotFound, "user roles not found", "user roles not found", err)
-			}
-			return []domain.User{}, uuid.Nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
-		}
-		users = append(users, user.ToDomain(roles))
+		users = append(users, user.ToDomain(loader.roles[user.ID]))
 	}
 
 	if len(users) == 0 {
@@ -102,8 +102,8 @@ func (r userRepository) Create(ctx context.Context, user domain.User) (domain.Us
 	}(tx)
 
 	q := `
-	INSERT INTO users (id, nickname, avatar_url, background_url, bio, email, password_hash)
-	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	INSERT INTO users (id, nickname, avatar_url, background_url, bio, email, password_hash, locale)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE(NULLIF($8, ''), 'en'))
 	RETURNING *;
 	`
 	logger.With(zap.String("PSQL query", formatQuery(q)))
@@ -116,13 +116,13 @@ func (r userRepository) Create(ctx context.Context, user domain.User) (domain.Us
 
 	var resUser models.UserModel
 	//res, err := r.db.NamedExec(q, resUser)
-	row := tx.QueryRow(q, writeUser.ID, writeUser.Nickname, writeUser.AvatarURL, writeUser.BackgroundURL, writeUser.Bio, writeUser.Email, writeUser.PasswordHash)
+	row := tx.QueryRow(q, writeUser.ID, writeUser.Nickname, writeUser.AvatarURL, writeUser.BackgroundURL, writeUser.Bio, writeUser.Email, writeUser.PasswordHash, writeUser.Locale)
 	if err != nil {
 		_ = tx.Rollback()
 		return domain.User{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
 	}
 
-	err = row.Scan(&resUser.ID, &resUser.Nickname, &resUser.AvatarURL, &resUser.BackgroundURL, &resUser.Bio, &resUser.Email, &resUser.PasswordHash, &resUser.CreatedAt, &resUser.UpdatedAt)
+	err = row.Scan(&resUser.ID, &resUser.Nickname, &resUser.AvatarURL, &resUser.BackgroundURL, &resUser.Bio, &resUser.Email, &resUser.PasswordHash, &resUser.CreatedAt, &resUser.UpdatedAt, &resUser.Locale)
 	if err != nil {
 		_ = tx.Rollback()
 		if errors.Is(err, sql.ErrNoRows) {
@@ -361,7 +361,8 @@ func (r userRepository) UpdateUser(ctx context.Context, user domain.User) (domai
 	defer span.End()
 
 	q := `
-	UPDATE users SET (nickname, avatar_url, background_url, bio, email, password_hash) = ($1, $2, $3, $4, $5, $6)
+	UPDATE users SET (nickname, avatar_url, background_url, bio, email, password_hash, locale) =
+	    ($1, $2, $3, $4, $5, $6, COALESCE(NULLIF($8, ''), locale))
 	WHERE id = $7
 	RETURNING *;
 	`
@@ -369,7 +370,7 @@ func (r userRepository) UpdateUser(ctx context.Context, user domain.User) (domai
 
 	writeUser := models.ToUserModel(user)
 	resUser := models.UserModel{}
-	err := r.db.GetContext(ctx, &resUser, q, writeUser.Nickname, writeUser.AvatarURL, writeUser.BackgroundURL, writeUser.Bio, writeUser.Email, writeUser.PasswordHash, writeUser.ID)
+	err := r.db.GetContext(ctx, &resUser, q, writeUser.Nickname, writeUser.AvatarURL, writeUser.BackgroundURL, writeUser.Bio, writeUser.Email, writeUser.PasswordHash, writeUser.ID, writeUser.Locale)
 	if err != nil {
 		return domain.User{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
 	}
@@ -462,8 +463,8 @@ func (r userRepository) CreateSub(ctx context.Context, sub domain.Subscription)
 	defer span.End()
 
 	q := `
-	INSERT INTO subscriptions (subscriber_id, followed_id, notification_flag)
-	VALUES ($1, $2, $3)
+	INSERT INTO subscriptions (subscriber_id, followed_id, notification_flags, created_at)
+	VALUES ($1, $2, $3, NOW())
 	RETURNING *;
 	`
 	logger.With(zap.String("PSQL query", formatQuery(q)))
@@ -471,7 +472,7 @@ func (r userRepository) CreateSub(ctx context.Context, sub domain.Subscription)
 	writeSub := models.ToSubscriptionModel(sub)
 
 	var resSub models.SubscriptionModel
-	err := r.db.GetContext(ctx, &resSub, q, writeSub.SubscriberID, writeSub.FollowedID, writeSub.NotificationFlag)
+	err := executorFrom(ctx, r.db).GetContext(ctx, &resSub, q, writeSub.SubscriberID, writeSub.FollowedID, writeSub.NotificationFlags)
 	if err != nil {
 		return domain.Subscription{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
 	}
@@ -511,8 +512,8 @@ func (r userRepository) UpdateSub(ctx context.Context, sub domain.Subscription)
 	defer span.End()
 
 	q := `
-	UPDATE subscriptions SET (notification_flag) = ($1)
-	WHERE id = $2
+	UPDATE subscriptions SET notification_flags = $1, updated_at = NOW()
+	WHERE sub_id = $2
 	RETURNING *;
 	`
 	logger.With(zap.String("PSQL query", formatQuery(q)))
@@ -520,7 +521,7 @@ func (r userRepository) UpdateSub(ctx context.Context, sub domain.Subscription)
 	writeSub := models.ToSubscriptionModel(sub)
 
 	var resSub models.SubscriptionModel
-	err := r.db.GetContext(ctx, &resSub, q, writeSub.NotificationFlag, writeSub.ID)
+	err := r.db.GetContext(ctx, &resSub, q, writeSub.NotificationFlags, writeSub.ID)
 	if err != nil {
 		return domain.Subscription{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
 	}
@@ -548,6 +549,56 @@ func (r userRepository) DeleteSub(ctx context.Context, sub domain.Subscription)
 	return domain.Subscription{}, nil
 }
 
+// Block records block and removes subscriptions between users in both directions
+func (r userRepository) Block(ctx context.Context, blockerID uuid.UUID, blockedID uuid.UUID) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Block")
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "failed to start transaction", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	q := `
+	INSERT INTO user_blocks (blocker_id, blocked_id)
+	VALUES ($1, $2)
+	ON CONFLICT DO NOTHING;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	_, err = tx.ExecContext(ctx, q, blockerID, blockedID)
+	if err != nil {
+		if pqErrorCode(err) == foreignKeyViolationCode {
+			return app.NewError(http.StatusNotFound, "user not found",
+				fmt.Sprintf("user %s to block not found", blockedID), err)
+		}
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	qSubs := `
+	DELETE FROM subscriptions
+	WHERE (subscriber_id = $1 AND followed_id = $2) OR (subscriber_id = $2 AND followed_id = $1);
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qSubs)))
+
+	_, err = tx.ExecContext(ctx, qSubs, blockerID, blockedID)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "failed to commit transaction", err)
+	}
+	return nil
+}
+
 func (r userRepository) ListSubscriptions(ctx context.Context, subscriberID uuid.UUID, followedID uuid.UUID, pag domain.IDPagination) ([]domain.Subscription, domain.IDPagination, error) {
 	logger := zapctx.Logger(ctx)
 
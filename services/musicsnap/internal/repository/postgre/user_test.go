@@ -71,6 +71,7 @@ func TestUserRepository(t *testing.T) {
 			assert.Equal(t, testUser.AvatarURL, createdUser.AvatarURL)
 			assert.Equal(t, testUser.BackgroundURL, createdUser.BackgroundURL)
 			assert.Equal(t, testUser.Bio, createdUser.Bio)
+			assert.Equal(t, domain.DefaultLocale, createdUser.Locale)
 			assert.True(t, createdUser.CreatedAt != time.Time{})
 			assert.True(t, createdUser.UpdatedAt != time.Time{})
 
@@ -266,6 +267,7 @@ func TestUserRepository(t *testing.T) {
 			newUser.Email = "updatedtest@example.com"
 			newUser.Nickname = "updateduser"
 			newUser.Bio = "Updated bio"
+			newUser.Locale = domain.LocaleRU
 
 			updatedUser, err := repo.UpdateUser(ctx, newUser)
 
@@ -276,6 +278,7 @@ func TestUserRepository(t *testing.T) {
 			assert.Equal(t, newUser.AvatarURL, updatedUser.AvatarURL)
 			assert.Equal(t, newUser.BackgroundURL, updatedUser.BackgroundURL)
 			assert.Equal(t, newUser.Bio, updatedUser.Bio)
+			assert.Equal(t, domain.LocaleRU, updatedUser.Locale)
 			assert.Equal(t, newUser.CreatedAt, updatedUser.CreatedAt)
 			assert.True(t, newUser.UpdatedAt != time.Time{})
 			assert.True(t, updatedUser.UpdatedAt != testUser.UpdatedAt)
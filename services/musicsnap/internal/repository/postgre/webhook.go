@@ -0,0 +1,424 @@
+package postgre
+
+import (
+	c "context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	qb "music-snap/pkg/querybuilder"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/repository/postgre/models"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"time"
+)
+
+var _ ports.WebhookRepository = &webhookRepository{}
+
+func NewWebhookRepository(db *sqlx.DB) ports.WebhookRepository {
+	return &webhookRepository{db: db,
+		spanName: spanBaseName + "webhookRepository."}
+}
+
+func newWebhookRepository(db *sqlx.DB) webhookRepository {
+	return webhookRepository{db: db,
+		spanName: spanBaseName + "webhookRepository."}
+}
+
+type webhookRepository struct {
+	db       *sqlx.DB
+	spanName string
+}
+
+func (r webhookRepository) Create(ctx c.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Create")
+	defer span.End()
+
+	q := `
+	INSERT INTO webhooks (user_id, url, secret, event_types, active)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	toWrite := models.ToWebhookModel(webhook)
+
+	var created models.WebhookModel
+	err := r.db.GetContext(ctx, &created, q, toWrite.UserID, toWrite.URL, toWrite.Secret, toWrite.EventTypes, toWrite.Active)
+	if err != nil {
+		if pqErrorCode(err) == foreignKeyViolationCode {
+			return domain.Webhook{}, app.NewError(http.StatusNotFound, "user not found",
+				fmt.Sprintf("owner %v of webhook not found", webhook.UserID), err)
+		}
+		return domain.Webhook{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	return created.ToDomain(), nil
+}
+
+func (r webhookRepository) GetByID(ctx c.Context, id int) (domain.Webhook, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"GetByID")
+	defer span.End()
+
+	q := `
+	SELECT * FROM webhooks
+	WHERE id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var webhook models.WebhookModel
+	err := r.db.GetContext(ctx, &webhook, q, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Webhook{}, app.NewError(http.StatusNotFound, "webhook not found", "webhook with given id does not exist", err)
+		}
+		return domain.Webhook{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	return webhook.ToDomain(), nil
+}
+
+func (r webhookRepository) List(ctx c.Context, userID *uuid.UUID) ([]domain.Webhook, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"List")
+	defer span.End()
+
+	q := `
+	SELECT * FROM webhooks
+	WHERE user_id IS NOT DISTINCT FROM $1
+	ORDER BY id;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var rows []models.WebhookModel
+	err := r.db.SelectContext(ctx, &rows, q, userID)
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	webhooks := make([]domain.Webhook, len(rows))
+	for i, row := range rows {
+		webhooks[i] = row.ToDomain()
+	}
+	return webhooks, nil
+}
+
+func (r webhookRepository) Update(ctx c.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Update")
+	defer span.End()
+
+	q := `
+	UPDATE webhooks
+	SET url = $2, event_types = $3, active = $4, updated_at = NOW()
+	WHERE id = $1
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	toWrite := models.ToWebhookModel(webhook)
+
+	var updated models.WebhookModel
+	err := r.db.GetContext(ctx, &updated, q, toWrite.ID, toWrite.URL, toWrite.EventTypes, toWrite.Active)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Webhook{}, app.NewError(http.StatusNotFound, "webhook not found", "webhook with given id does not exist", err)
+		}
+		return domain.Webhook{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	return updated.ToDomain(), nil
+}
+
+func (r webhookRepository) Delete(ctx c.Context, id int) error {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Delete")
+	defer span.End()
+
+	q := `
+	DELETE FROM webhooks
+	WHERE id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	res, err := r.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return app.NewError(http.StatusNotFound, "webhook not found", "webhook with given id does not exist", nil)
+	}
+	return nil
+}
+
+func (r webhookRepository) Enqueue(ctx c.Context, event domain.WebhookEvent) (int, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Enqueue")
+	defer span.End()
+
+	payload, err := event.Body()
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "failed to marshal webhook event", err)
+	}
+
+	// повторная постановка того же события не создаёт вторую доставку
+	q := `
+	INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, next_attempt_at)
+	SELECT w.id, $1::uuid, $2::varchar, $3::jsonb, NOW() FROM webhooks w
+	WHERE w.active AND $2::varchar = ANY (w.event_types) AND (w.user_id IS NULL OR w.user_id = $4)
+	ON CONFLICT (webhook_id, event_id) DO NOTHING;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	res, err := executorFrom(ctx, r.db).ExecContext(ctx, q, event.ID, event.Type, string(payload), event.SubjectID)
+	if err != nil {
+		return 0, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+	n, _ := res.RowsAffected()
+	return int(n), nil
+}
+
+func (r webhookRepository) ClaimDue(ctx c.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"ClaimDue")
+	defer span.End()
+
+	q := `
+	WITH due AS (
+		SELECT d.id FROM webhook_deliveries d
+		JOIN webhooks w ON w.id = d.webhook_id
+		WHERE d.status = 'pending' AND d.next_attempt_at <= $1 AND w.active
+		ORDER BY d.next_attempt_at
+		LIMIT $3
+		FOR UPDATE OF d SKIP LOCKED
+	)
+	UPDATE webhook_deliveries d
+	SET next_attempt_at = $1::timestamp + make_interval(secs => $2::float8), updated_at = NOW()
+	FROM due, webhooks w
+	WHERE d.id = due.id AND w.id = d.webhook_id
+	RETURNING d.*, w.url, w.secret;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var rows []struct {
+		models.WebhookDeliveryModel
+		URL    string `db:"url"`
+		Secret string `db:"secret"`
+	}
+	err := r.db.SelectContext(ctx, &rows, q, now, lease.Seconds(), limit)
+	if err != nil {
+		return nil, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	deliveries := make([]domain.WebhookDelivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = row.ToDomain()
+		deliveries[i].URL = row.URL
+		deliveries[i].Secret = row.Secret
+	}
+	return deliveries, nil
+}
+
+func (r webhookRepository) RecordAttempt(ctx c.Context, attempt domain.WebhookAttempt, retryAt *time.Time) (domain.WebhookDelivery, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"RecordAttempt")
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return domain.WebhookDelivery{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to start transaction", err)
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+
+	qAttempt := `
+	INSERT INTO webhook_delivery_attempts (delivery_id, status_code, error, duration_ms, attempted_at)
+	VALUES ($1, $2, $3, $4, $5);
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qAttempt)))
+
+	toWrite := models.ToWebhookAttemptModel(attempt)
+	_, err = tx.ExecContext(ctx, qAttempt, toWrite.DeliveryID, toWrite.StatusCode, toWrite.Error, toWrite.DurationMs, toWrite.AttemptedAt)
+	if err != nil {
+		if pqErrorCode(err) == foreignKeyViolationCode {
+			return domain.WebhookDelivery{}, app.NewError(http.StatusNotFound, "delivery not found",
+				fmt.Sprintf("delivery %d not found", attempt.DeliveryID), err)
+		}
+		return domain.WebhookDelivery{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	status := domain.DeliveryDead
+	switch {
+	case attempt.Succeeded():
+		status = domain.DeliveryDelivered
+		retryAt = nil
+	case retryAt != nil:
+		status = domain.DeliveryPending
+	}
+
+	q := `
+	UPDATE webhook_deliveries
+	SET attempts = attempts + 1, status = $2, next_attempt_at = $3, updated_at = NOW(),
+		delivered_at = CASE WHEN $2 = 'delivered' THEN $4::timestamp END
+	WHERE id = $1
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var delivery models.WebhookDeliveryModel
+	err = tx.GetContext(ctx, &delivery, q, attempt.DeliveryID, status, retryAt, attempt.AttemptedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.WebhookDelivery{}, app.NewError(http.StatusNotFound, "delivery not found", "delivery with given id does not exist", err)
+		}
+		return domain.WebhookDelivery{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return domain.WebhookDelivery{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to commit transaction", err)
+	}
+
+	return delivery.ToDomain(), nil
+}
+
+func (r webhookRepository) ListDeliveries(ctx c.Context, filter domain.WebhookDeliveryFilter, pag domain.IDPagination) ([]domain.WebhookDelivery, domain.IDPagination, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"ListDeliveries")
+	defer span.End()
+
+	qBuild := qb.NewNamed().
+		Q("SELECT * FROM webhook_deliveries").
+		WhereOptPart().
+		CompConnectorOpt("webhook_id", qb.EQ(), "webhook_id", filter.WebhookID, qb.AND()).
+		CompConnectorOpt("status", qb.EQ(), "status", filter.Status, qb.AND()).
+		CompConnectorOpt("id", qb.GT(), "last_id", pag.LastID, qb.AND()).
+		EndWhereOpt().
+		OrderBy("id", true).
+		Limit("", pag.Limit)
+	q, args := qBuild.Build()
+
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	preparedQ, err := r.db.PrepareNamedContext(ctx, q)
+	if err != nil {
+		return nil, pag, app.NewError(http.StatusInternalServerError, "unknown error", "internal error preparing named query", err)
+	}
+	defer preparedQ.Close()
+
+	var rows []models.WebhookDeliveryModel
+	err = preparedQ.SelectContext(ctx, &rows, args)
+	if err != nil {
+		return nil, pag, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	if len(rows) == 0 {
+		pag.LastID = 0
+		return []domain.WebhookDelivery{}, pag, nil
+	}
+
+	deliveries := make([]domain.WebhookDelivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = row.ToDomain()
+	}
+
+	pag.LastID = int(deliveries[len(deliveries)-1].ID)
+	return deliveries, pag, nil
+}
+
+func (r webhookRepository) GetDelivery(ctx c.Context, id int64) (domain.WebhookDelivery, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"GetDelivery")
+	defer span.End()
+
+	q := `
+	SELECT * FROM webhook_deliveries
+	WHERE id = $1;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var row models.WebhookDeliveryModel
+	err := r.db.GetContext(ctx, &row, q, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.WebhookDelivery{}, app.NewError(http.StatusNotFound, "delivery not found", "delivery with given id does not exist", err)
+		}
+		return domain.WebhookDelivery{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	qLog := `
+	SELECT * FROM webhook_delivery_attempts
+	WHERE delivery_id = $1
+	ORDER BY id;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(qLog)))
+
+	var attempts []models.WebhookAttemptModel
+	err = r.db.SelectContext(ctx, &attempts, qLog, id)
+	if err != nil {
+		return domain.WebhookDelivery{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	delivery := row.ToDomain()
+	delivery.Log = make([]domain.WebhookAttempt, len(attempts))
+	for i, a := range attempts {
+		delivery.Log[i] = a.ToDomain()
+	}
+	return delivery, nil
+}
+
+func (r webhookRepository) Redeliver(ctx c.Context, id int64) (domain.WebhookDelivery, error) {
+	logger := zapctx.Logger(ctx)
+
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, r.spanName+"Redeliver")
+	defer span.End()
+
+	q := `
+	UPDATE webhook_deliveries
+	SET status = 'pending', attempts = 0, next_attempt_at = NOW(), delivered_at = NULL, updated_at = NOW()
+	WHERE id = $1
+	RETURNING *;
+	`
+	logger.With(zap.String("PSQL query", formatQuery(q)))
+
+	var delivery models.WebhookDeliveryModel
+	err := r.db.GetContext(ctx, &delivery, q, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.WebhookDelivery{}, app.NewError(http.StatusNotFound, "delivery not found", "delivery with given id does not exist", err)
+		}
+		return domain.WebhookDelivery{}, app.NewError(http.StatusInternalServerError, "unknown error", "postgres internal error", err)
+	}
+
+	return delivery.ToDomain(), nil
+}
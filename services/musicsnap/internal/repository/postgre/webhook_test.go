@@ -0,0 +1,161 @@
+package postgre
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"music-snap/services/musicsnap/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestWebhookRepository(t *testing.T) {
+	repo, closeDB, cleanDB, err := initializeRepository()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer closeDB()
+	defer cleanDB()
+
+	ctx := context.Background()
+
+	owner, err := repo.user.Create(ctx, domain.User{
+		Profile:      domain.Profile{ID: uuid.New(), Nickname: "partner"},
+		Email:        "partner@example.com",
+		PasswordHash: "hashedpassword",
+		Roles:        domain.NewRoles([]string{domain.UserRole}),
+	})
+	require.NoError(t, err)
+
+	var userHook, appHook domain.Webhook
+	t.Run("Test webhook create", func(t *testing.T) {
+		userHook, err = repo.webhook.Create(ctx, domain.Webhook{
+			UserID:     &owner.ID,
+			URL:        "https://partner.example/hook",
+			Secret:     "secret",
+			EventTypes: []string{domain.WebhookReviewPublished, domain.WebhookUserFollowed},
+			Active:     true,
+		})
+		require.NoError(t, err)
+		assert.NotZero(t, userHook.ID)
+		assert.Equal(t, owner.ID, *userHook.UserID)
+
+		appHook, err = repo.webhook.Create(ctx, domain.Webhook{
+			URL:        "https://app.example/hook",
+			Secret:     "app-secret",
+			EventTypes: []string{domain.WebhookUserFollowed},
+			Active:     true,
+		})
+		require.NoError(t, err)
+		assert.Nil(t, appHook.UserID)
+
+		own, err := repo.webhook.List(ctx, &owner.ID)
+		require.NoError(t, err)
+		require.Len(t, own, 1)
+		assert.Equal(t, userHook.ID, own[0].ID)
+
+		app, err := repo.webhook.List(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, app, 1)
+		assert.Equal(t, appHook.ID, app[0].ID)
+	})
+
+	t.Run("Test webhook enqueue by type and subject", func(t *testing.T) {
+		event := domain.WebhookEvent{ID: uuid.New(), Type: domain.WebhookUserFollowed, SubjectID: owner.ID,
+			Data: map[string]interface{}{"followed_id": owner.ID}, CreatedAt: time.Now().UTC()}
+		enqueued, err := repo.webhook.Enqueue(ctx, event)
+		require.NoError(t, err)
+		assert.Equal(t, 2, enqueued)
+
+		// повтор того же события не дублирует доставки
+		enqueued, err = repo.webhook.Enqueue(ctx, event)
+		require.NoError(t, err)
+		assert.Zero(t, enqueued)
+
+		enqueued, err = repo.webhook.Enqueue(ctx, domain.WebhookEvent{ID: uuid.New(), Type: domain.WebhookReviewPublished,
+			SubjectID: uuid.New(), CreatedAt: time.Now().UTC()})
+		require.NoError(t, err)
+		assert.Zero(t, enqueued, "review of other user reaches neither webhook")
+	})
+
+	var delivery domain.WebhookDelivery
+	t.Run("Test webhook claim and retry", func(t *testing.T) {
+		now := time.Now().UTC().Add(time.Minute)
+		claimed, err := repo.webhook.ClaimDue(ctx, now, time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 2)
+		for _, d := range claimed {
+			if d.WebhookID == userHook.ID {
+				delivery = d
+			}
+		}
+		require.NotZero(t, delivery.ID)
+		assert.Equal(t, userHook.URL, delivery.URL)
+		assert.Equal(t, "secret", delivery.Secret)
+
+		again, err := repo.webhook.ClaimDue(ctx, now, time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, again, "claimed deliveries are leased")
+
+		retryAt := now.Add(time.Hour)
+		recorded, err := repo.webhook.RecordAttempt(ctx, domain.WebhookAttempt{DeliveryID: delivery.ID, StatusCode: 500,
+			Error: "boom", Duration: 120 * time.Millisecond, AttemptedAt: now}, &retryAt)
+		require.NoError(t, err)
+		assert.Equal(t, domain.DeliveryPending, recorded.Status)
+		assert.Equal(t, 1, recorded.Attempts)
+	})
+
+	t.Run("Test webhook dead letter and redelivery", func(t *testing.T) {
+		now := time.Now().UTC()
+		recorded, err := repo.webhook.RecordAttempt(ctx, domain.WebhookAttempt{DeliveryID: delivery.ID,
+			Error: "connection refused", AttemptedAt: now}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.DeliveryDead, recorded.Status)
+		assert.Nil(t, recorded.NextAttemptAt)
+
+		dead := domain.DeliveryDead
+		deadList, _, err := repo.webhook.ListDeliveries(ctx, domain.WebhookDeliveryFilter{WebhookID: userHook.ID, Status: &dead},
+			domain.IDPagination{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, deadList, 1)
+		assert.Equal(t, delivery.ID, deadList[0].ID)
+
+		withLog, err := repo.webhook.GetDelivery(ctx, delivery.ID)
+		require.NoError(t, err)
+		require.Len(t, withLog.Log, 2)
+		assert.Equal(t, 500, withLog.Log[0].StatusCode)
+		assert.Equal(t, 120*time.Millisecond, withLog.Log[0].Duration)
+		assert.Equal(t, "connection refused", withLog.Log[1].Error)
+
+		redelivered, err := repo.webhook.Redeliver(ctx, delivery.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.DeliveryPending, redelivered.Status)
+		assert.Zero(t, redelivered.Attempts)
+
+		recorded, err = repo.webhook.RecordAttempt(ctx, domain.WebhookAttempt{DeliveryID: delivery.ID, StatusCode: 204,
+			AttemptedAt: now}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.DeliveryDelivered, recorded.Status)
+		assert.NotNil(t, recorded.DeliveredAt)
+	})
+
+	t.Run("Test webhook update and delete", func(t *testing.T) {
+		userHook.Active = false
+		userHook.EventTypes = []string{domain.WebhookEventJoined}
+		updated, err := repo.webhook.Update(ctx, userHook)
+		require.NoError(t, err)
+		assert.False(t, updated.Active)
+		assert.Equal(t, []string{domain.WebhookEventJoined}, updated.EventTypes)
+
+		enqueued, err := repo.webhook.Enqueue(ctx, domain.WebhookEvent{ID: uuid.New(), Type: domain.WebhookEventJoined,
+			SubjectID: owner.ID, CreatedAt: time.Now().UTC()})
+		require.NoError(t, err)
+		assert.Zero(t, enqueued, "inactive webhook gets no deliveries")
+
+		require.NoError(t, repo.webhook.Delete(ctx, userHook.ID))
+		_, err = repo.webhook.GetDelivery(ctx, delivery.ID)
+		assert.Error(t, err)
+		assert.Error(t, repo.webhook.Delete(ctx, userHook.ID))
+	})
+}
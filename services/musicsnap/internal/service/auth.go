@@ -17,13 +17,18 @@ import (
 var _ ports.AuthSvc = &AuthSvc{}
 
 type AuthSvc struct {
-	r   ports.UserRepository
-	jwt ports.JwtSvc
+	r       ports.UserRepository
+	reports ports.ReportRepository
+	jwt     ports.JwtSvc
+	filter  ports.ContentFilter
 }
 
-func NewAuthSvc(jwt ports.JwtSvc, userRepository ports.UserRepository) *AuthSvc {
+func NewAuthSvc(jwt ports.JwtSvc, userRepository ports.UserRepository, reportRepository ports.ReportRepository,
+	filter ports.ContentFilter) *AuthSvc {
 	return &AuthSvc{jwt: jwt,
-		r: userRepository}
+		r:       userRepository,
+		reports: reportRepository,
+		filter:  filter}
 }
 
 func (s AuthSvc) spanName(funcName string) string {
@@ -90,12 +95,18 @@ func (s AuthSvc) Register(ctx context.Context, actor d.Actor, user d.User, passw
 		return "", d.User{}, err
 	}
 
+	filtered := s.filter.CheckNickname(user.Nickname)
+	if err = filterError(filtered, "nickname"); err != nil {
+		return "", d.User{}, err
+	}
+
 	user.Roles.Add(d.UserRole)
 
 	userCreated, err := s.r.Create(ctx, user)
 	if err != nil {
 		return "", d.User{}, err
 	}
+	holdFlagged(ctx, s.reports, filtered, d.ReportTargetProfile, userCreated.ID.String())
 
 	// make JWT
 	jwt, err = s.jwt.Generate(userCreated)
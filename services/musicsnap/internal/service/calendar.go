@@ -0,0 +1,144 @@
+package service
+
+import (
+	c "context"
+	"fmt"
+	"github.com/google/uuid"
+	global "go.opentelemetry.io/otel"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/config"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+func (s calendarSvc) spanName(funcName string) string {
+	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
+}
+
+const (
+	// calendarTokenSize is the number of random bytes in token of feed
+	calendarTokenSize = 32
+	// feed name in calendar apps
+	calendarFeedName = "MusicSnap"
+
+	defaultFeedPast  = 30 * 24 * time.Hour
+	defaultFeedLimit = 500
+)
+
+func NewCalendarSvc(events ports.EventRepository, feeds ports.CalendarFeedRepository, encoder ports.CalendarEncoder,
+	cfg config.CalendarConfig) ports.CalendarService {
+	if cfg.FeedPast <= 0 {
+		cfg.FeedPast = defaultFeedPast
+	}
+	if cfg.FeedLimit <= 0 {
+		cfg.FeedLimit = defaultFeedLimit
+	}
+	return calendarSvc{events: events, feeds: feeds, encoder: encoder, cfg: cfg}
+}
+
+var _ ports.CalendarService = &calendarSvc{}
+
+type calendarSvc struct {
+	events  ports.EventRepository
+	feeds   ports.CalendarFeedRepository
+	encoder ports.CalendarEncoder
+	cfg     config.CalendarConfig
+}
+
+func (s calendarSvc) ExportEvent(ctx c.Context, eventID uuid.UUID) ([]byte, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("ExportEvent"))
+	defer span.End()
+
+	event, err := s.events.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return s.encoder.Encode(domain.Calendar{
+		Events:      []domain.Event{event},
+		GeneratedAt: time.Now().UTC(),
+	}), nil
+}
+
+func (s calendarSvc) GetFeed(ctx c.Context, actor domain.Actor) (domain.CalendarFeed, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("GetFeed"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if actor.ID == uuid.Nil {
+		return domain.CalendarFeed{}, app.NewError(http.StatusUnauthorized, "unauthorized", "guest has no calendar feed", nil)
+	}
+	feed, err := s.feeds.Get(ctx, actor.ID)
+	if err != nil {
+		return domain.CalendarFeed{}, err
+	}
+	return s.withURL(feed), nil
+}
+
+func (s calendarSvc) RotateFeed(ctx c.Context, actor domain.Actor) (domain.CalendarFeed, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("RotateFeed"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if actor.ID == uuid.Nil {
+		return domain.CalendarFeed{}, app.NewError(http.StatusUnauthorized, "unauthorized", "guest can't create calendar feed", nil)
+	}
+	token, err := randomHex(calendarTokenSize)
+	if err != nil {
+		return domain.CalendarFeed{}, app.NewError(http.StatusInternalServerError, "unknown error", "can't generate token of feed", err)
+	}
+	feed, err := s.feeds.Save(ctx, domain.CalendarFeed{UserID: actor.ID, Token: token})
+	if err != nil {
+		return domain.CalendarFeed{}, err
+	}
+	return s.withURL(feed), nil
+}
+
+func (s calendarSvc) DeleteFeed(ctx c.Context, actor domain.Actor) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("DeleteFeed"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if actor.ID == uuid.Nil {
+		return app.NewError(http.StatusUnauthorized, "unauthorized", "guest has no calendar feed", nil)
+	}
+	return s.feeds.Delete(ctx, actor.ID)
+}
+
+// RenderFeed lists upcoming and recent events, cancelled ones stay in feed so calendar apps mark them
+func (s calendarSvc) RenderFeed(ctx c.Context, token string) ([]byte, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("RenderFeed"))
+	defer span.End()
+
+	if token == "" {
+		return nil, app.NewError(http.StatusNotFound, "calendar feed not found", "token of feed is empty", nil)
+	}
+	feed, err := s.feeds.GetByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	events, err := s.events.ListFeed(ctx, feed.UserID, now.Add(-s.cfg.FeedPast), s.cfg.FeedLimit)
+	if err != nil {
+		return nil, err
+	}
+	return s.encoder.Encode(domain.Calendar{
+		Name:        calendarFeedName,
+		Events:      events,
+		GeneratedAt: now,
+	}), nil
+}
+
+func (s calendarSvc) withURL(feed domain.CalendarFeed) domain.CalendarFeed {
+	feed.URL = strings.TrimSuffix(s.cfg.FeedURL, "/") + "/" + feed.Token + ".ics"
+	return feed
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	c "context"
+	"fmt"
+	"github.com/google/uuid"
+	global "go.opentelemetry.io/otel"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"reflect"
+)
+
+func (s catalogSvc) spanName(funcName string) string {
+	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
+}
+
+func NewCatalogSvc(catalogRepository ports.CatalogRepository) ports.CatalogService {
+	return catalogSvc{r: catalogRepository}
+}
+
+var _ ports.CatalogService = &catalogSvc{}
+
+type catalogSvc struct {
+	r ports.CatalogRepository
+}
+
+// canEditCatalog reports whether actor can change artists, pieces and aliases
+func canEditCatalog(actor domain.Actor) bool {
+	return actor.HasRole(domain.AdminRole) || actor.HasRole(domain.ModeratorRole)
+}
+
+// resolvePiece finds catalog piece by canonical ID or by alias of form "source:external_id"
+func resolvePiece(ctx c.Context, catalog ports.CatalogRepository, pieceRef string) (domain.Piece, error) {
+	ref, err := domain.ParsePieceRef(pieceRef)
+	if err != nil {
+		return domain.Piece{}, app.NewError(http.StatusBadRequest, "invalid piece id",
+			"piece reference is neither canonical id nor alias", err)
+	}
+	if ref.IsCanonical() {
+		return catalog.GetPiece(ctx, ref.ID)
+	}
+	return catalog.GetPieceByAlias(ctx, ref.Source, ref.ExternalID)
+}
+
+// canonicalPieceID checks that referenced piece exists in catalog and returns its canonical id,
+// unknown piece is a client error for content that references it
+func canonicalPieceID(ctx c.Context, catalog ports.CatalogRepository, pieceRef string) (string, error) {
+	piece, err := resolvePiece(ctx, catalog, pieceRef)
+	if err != nil {
+		if app.GetCode(err) == http.StatusNotFound {
+			return "", app.NewError(http.StatusBadRequest, "piece not found in catalog",
+				fmt.Sprintf("reference to unknown piece %q", pieceRef), err)
+		}
+		return "", err
+	}
+	return piece.ID.String(), nil
+}
+
+func (s catalogSvc) CreateArtist(ctx c.Context, actor domain.Actor, artist domain.Artist) (domain.Artist, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("CreateArtist"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if !canEditCatalog(actor) {
+		return domain.Artist{},
+			app.NewError(http.StatusForbidden, "user can't edit catalog",
+				"actor do not have admin or moderator role to create artist", nil)
+	}
+
+	err := artist.Validate()
+	if err != nil {
+		return domain.Artist{},
+			app.NewError(http.StatusBadRequest, "invalid artist", "invalid fields for artist validation", err)
+	}
+
+	return s.r.CreateArtist(ctx, artist)
+}
+
+func (s catalogSvc) GetArtist(ctx c.Context, actor domain.Actor, artistID uuid.UUID) (domain.Artist, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("GetArtist"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	return s.r.GetArtist(ctx, artistID)
+}
+
+func (s catalogSvc) CreatePiece(ctx c.Context, actor domain.Actor, piece domain.Piece) (domain.Piece, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("CreatePiece"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if !canEditCatalog(actor) {
+		return domain.Piece{},
+			app.NewError(http.StatusForbidden, "user can't edit catalog",
+				"actor do not have admin or moderator role to create piece", nil)
+	}
+
+	err := piece.Validate()
+	if err != nil {
+		return domain.Piece{},
+			app.NewError(http.StatusBadRequest, "invalid piece", "invalid fields for piece validation", err)
+	}
+	for _, alias := range piece.Aliases {
+		// piece id is not known yet, it is assigned by repository
+		alias.PieceID = uuid.New()
+		err = alias.Validate()
+		if err != nil {
+			return domain.Piece{},
+				app.NewError(http.StatusBadRequest, "invalid piece alias", "invalid fields for alias validation", err)
+		}
+	}
+
+	return s.r.CreatePiece(ctx, piece)
+}
+
+func (s catalogSvc) UpdatePiece(ctx c.Context, actor domain.Actor, piece domain.Piece) (domain.Piece, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("UpdatePiece"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if !canEditCatalog(actor) {
+		return domain.Piece{},
+			app.NewError(http.StatusForbidden, "user can't edit catalog",
+				"actor do not have admin or moderator role to update piece", nil)
+	}
+
+	err := piece.Validate()
+	if err != nil {
+		return domain.Piece{},
+			app.NewError(http.StatusBadRequest, "invalid piece", "invalid fields for piece validation", err)
+	}
+
+	return s.r.UpdatePiece(ctx, piece)
+}
+
+func (s catalogSvc) GetPiece(ctx c.Context, actor domain.Actor, pieceRef string) (domain.Piece, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("GetPiece"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	return resolvePiece(ctx, s.r, pieceRef)
+}
+
+func (s catalogSvc) ListPieces(ctx c.Context, actor domain.Actor, filter domain.PieceFilter, pagination domain.UUIDPagination) ([]domain.Piece, domain.UUIDPagination, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("ListPieces"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if filter.Type != nil && *filter.Type != domain.TrackPiece && *filter.Type != domain.AlbumPiece {
+		return nil, pagination, app.NewError(http.StatusBadRequest, "invalid piece type",
+			"piece type filter must be track or album", nil)
+	}
+
+	return s.r.ListPieces(ctx, filter, pagination)
+}
+
+func (s catalogSvc) AddAlias(ctx c.Context, actor domain.Actor, alias domain.PieceAlias) (domain.PieceAlias, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("AddAlias"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if !canEditCatalog(actor) {
+		return domain.PieceAlias{},
+			app.NewError(http.StatusForbidden, "user can't edit catalog",
+				"actor do not have admin or moderator role to add alias", nil)
+	}
+
+	err := alias.Validate()
+	if err != nil {
+		return domain.PieceAlias{},
+			app.NewError(http.StatusBadRequest, "invalid piece alias", "invalid fields for alias validation", err)
+	}
+
+	return s.r.AddAlias(ctx, alias)
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	c "context"
+	"fmt"
+	"github.com/google/uuid"
+	global "go.opentelemetry.io/otel"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/config"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"reflect"
+)
+
+const defaultCommentMaxDepth = 5
+
+func (s commentSvc) spanName(funcName string) string {
+	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
+}
+
+// NewCommentSvc creates comment service, comments of review are visible to those who can see the review
+func NewCommentSvc(commentRepository ports.CommentRepository, reviewRepository ports.ReviewRepository,
+	filter ports.ContentFilter, tags ports.TagService, cfg config.CommentsConfig, preModeration bool) ports.CommentService {
+	maxDepth := cfg.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultCommentMaxDepth
+	}
+	return commentSvc{r: commentRepository, reviews: reviewRepository, filter: filter, tags: tags,
+		maxDepth: maxDepth, preModeration: preModeration}
+}
+
+var _ ports.CommentService = &commentSvc{}
+
+type commentSvc struct {
+	r       ports.CommentRepository
+	reviews ports.ReviewRepository
+	filter  ports.ContentFilter
+	tags    ports.TagService
+
+	maxDepth      int
+	preModeration bool
+}
+
+func (s commentSvc) checkReview(ctx c.Context, actor domain.Actor, reviewID int) error {
+	_, err := visibleReview(ctx, s.reviews, actor, reviewID, s.preModeration)
+	return err
+}
+
+func (s commentSvc) checkText(comment domain.Comment) error {
+	err := comment.Validate()
+	if err != nil {
+		return app.NewError(http.StatusBadRequest, "invalid comment", "comment validation error", err)
+	}
+	return filterError(s.filter.CheckReview(comment.Text), "comment")
+}
+
+func (s commentSvc) CreateComment(ctx c.Context, actor domain.Actor, comment domain.Comment) (domain.Comment, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("CreateComment"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	comment.UserID = actor.ID
+
+	err := s.checkText(comment)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	err = s.checkReview(ctx, actor, comment.ReviewID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	if comment.ParentID != nil {
+		parent, err := s.r.GetByID(ctx, *comment.ParentID)
+		if err != nil {
+			return domain.Comment{}, err
+		}
+		if parent.ReviewID != comment.ReviewID {
+			return domain.Comment{}, app.NewError(http.StatusNotFound, "parent comment not found",
+				fmt.Sprintf("comment %s belongs to review %d", parent.ID, parent.ReviewID), nil)
+		}
+		if parent.Deleted() {
+			return domain.Comment{}, app.NewError(http.StatusConflict, "can't reply to deleted comment",
+				fmt.Sprintf("comment %s is deleted", parent.ID), nil)
+		}
+		if parent.Depth+1 > s.maxDepth {
+			return domain.Comment{}, app.NewError(http.StatusBadRequest, "replies are too deep",
+				fmt.Sprintf("reply depth %d exceeds max depth %d", parent.Depth+1, s.maxDepth), nil)
+		}
+	}
+
+	created, err := s.r.Create(ctx, comment)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	indexComment(ctx, s.tags, created)
+	return created, nil
+}
+
+func (s commentSvc) GetComment(ctx c.Context, actor domain.Actor, commentID uuid.UUID) (domain.Comment, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("GetComment"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	comment, err := s.r.GetByID(ctx, commentID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	err = s.checkReview(ctx, actor, comment.ReviewID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return comment, nil
+}
+
+func (s commentSvc) UpdateComment(ctx c.Context, actor domain.Actor, commentID uuid.UUID, text string) (domain.Comment, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("UpdateComment"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	comment, err := s.GetComment(ctx, actor, commentID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if comment.UserID != actor.ID {
+		return domain.Comment{}, app.NewError(http.StatusForbidden, "user can't edit other persons comment",
+			"actor is not the author of comment", nil)
+	}
+	if comment.Deleted() {
+		return domain.Comment{}, app.NewError(http.StatusConflict, "comment is deleted",
+			fmt.Sprintf("comment %s is deleted", commentID), nil)
+	}
+
+	comment.Text = text
+	err = s.checkText(comment)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	updated, err := s.r.UpdateText(ctx, commentID, text)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	indexComment(ctx, s.tags, updated)
+	return updated, nil
+}
+
+func (s commentSvc) DeleteComment(ctx c.Context, actor domain.Actor, commentID uuid.UUID) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("DeleteComment"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	comment, err := s.GetComment(ctx, actor, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.UserID != actor.ID && !canModerate(actor) {
+		return app.NewError(http.StatusForbidden, "user can't delete other persons comment",
+			"actor is neither the author of comment nor moderator", nil)
+	}
+	if comment.Deleted() {
+		return app.NewError(http.StatusConflict, "comment is already deleted",
+			fmt.Sprintf("comment %s is deleted", commentID), nil)
+	}
+
+	deleted, err := s.r.Delete(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	indexComment(ctx, s.tags, deleted)
+	return nil
+}
+
+func (s commentSvc) ListComments(ctx c.Context, actor domain.Actor, reviewID int, parentID *uuid.UUID, pag domain.UUIDPagination) ([]domain.Comment, domain.UUIDPagination, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("ListComments"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	err := s.checkReview(ctx, actor, reviewID)
+	if err != nil {
+		return nil, pag, err
+	}
+
+	if parentID != nil {
+		parent, err := s.r.GetByID(ctx, *parentID)
+		if err != nil {
+			return nil, pag, err
+		}
+		if parent.ReviewID != reviewID {
+			return nil, pag, app.NewError(http.StatusNotFound, "parent comment not found",
+				fmt.Sprintf("comment %s belongs to review %d", parent.ID, parent.ReviewID), nil)
+		}
+	}
+
+	return s.r.List(ctx, reviewID, parentID, pag)
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	c "context"
+	"fmt"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+)
+
+// flaggedHideThreshold hides flagged content with the first content filter report
+const flaggedHideThreshold = 1
+
+// filterError returns error with reasons of rejected text, flagged and allowed text passes
+func filterError(result domain.FilterResult, field string) error {
+	if result.Verdict() != domain.FilterReject {
+		return nil
+	}
+	summary := result.Summary(domain.FilterReject)
+	return app.NewError(http.StatusBadRequest, fmt.Sprintf("%s rejected: %s", field, summary),
+		fmt.Sprintf("content filter rejected %s: %s", field, summary), nil)
+}
+
+// holdFlagged files content filter report on flagged content, so it is hidden until moderator decision.
+// Content is already saved, so failure is only logged
+func holdFlagged(ctx c.Context, reports ports.ReportRepository, result domain.FilterResult,
+	targetType domain.ReportTargetType, targetID string) {
+	if result.Verdict() != domain.FilterFlag {
+		return
+	}
+
+	_, _, err := reports.Create(ctx, domain.Report{
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     result.Code(domain.FilterFlag),
+		Comment:    result.Summary(domain.FilterFlag),
+	}, flaggedHideThreshold)
+	if err != nil {
+		zapctx.Logger(ctx).Error("can't hold content flagged by filter",
+			zap.String("target_type", string(targetType)), zap.String("target_id", targetID), zap.Error(err))
+	}
+}
@@ -0,0 +1,167 @@
+package contentfilter
+
+import (
+	"fmt"
+	"music-snap/pkg/app"
+	d "music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"regexp"
+)
+
+const (
+	ActionFlag   = "flag"
+	ActionReject = "reject"
+)
+
+// StageConfig: Общие настройки этапа фильтра
+type StageConfig struct {
+	Enable bool `mapstructure:"enable"`
+	// Action is verdict of the stage for matched text: flag or reject
+	Action string `mapstructure:"action"`
+}
+
+type ProfanityConfig struct {
+	StageConfig `mapstructure:",squash"`
+	// Languages are built-in wordlists: en, ru
+	Languages []string `mapstructure:"languages"`
+	// Words are added to built-in wordlists, '*' at the start or end marks word root
+	Words []string `mapstructure:"words"`
+}
+
+type LinksConfig struct {
+	StageConfig `mapstructure:",squash"`
+	MaxLinks    int `mapstructure:"max_links"`
+}
+
+type RepeatsConfig struct {
+	StageConfig `mapstructure:",squash"`
+	// MaxRun is the longest allowed run of the same character
+	MaxRun int `mapstructure:"max_run"`
+}
+
+type NicknamesConfig struct {
+	StageConfig `mapstructure:",squash"`
+	// Patterns are case-insensitive regular expressions of banned nicknames
+	Patterns []string `mapstructure:"patterns"`
+}
+
+type Config struct {
+	Profanity ProfanityConfig `mapstructure:"profanity"`
+	Links     LinksConfig     `mapstructure:"links"`
+	Repeats   RepeatsConfig   `mapstructure:"repeats"`
+	Nicknames NicknamesConfig `mapstructure:"nicknames"`
+}
+
+func (c StageConfig) verdict() (d.FilterVerdict, error) {
+	switch c.Action {
+	case ActionFlag:
+		return d.FilterFlag, nil
+	case ActionReject:
+		return d.FilterReject, nil
+	}
+	return d.FilterAllow, app.NewError(http.StatusInternalServerError, "invalid config",
+		fmt.Sprintf("unknown content filter action %q", c.Action), nil)
+}
+
+func (c *Config) Validate() error {
+	if c == nil {
+		return app.NewError(http.StatusInternalServerError, "invalid config",
+			"config is nil", nil)
+	}
+	if c.Links.Enable && c.Links.MaxLinks < 0 {
+		return app.NewError(http.StatusInternalServerError, "invalid max links",
+			"max links is negative", nil)
+	}
+	if c.Repeats.Enable && c.Repeats.MaxRun < 2 {
+		return app.NewError(http.StatusInternalServerError, "invalid max run",
+			"max run of repeated characters is too short", nil)
+	}
+	return nil
+}
+
+// Pipeline: Этапы фильтра, которые проходит текст
+type Pipeline []Stage
+
+// Check runs all stages, so that moderators see every reason of flagged text
+func (p Pipeline) Check(text string) d.FilterResult {
+	var result d.FilterResult
+	for _, stage := range p {
+		if reason := stage.Check(text); reason != nil {
+			result.Reasons = append(result.Reasons, *reason)
+		}
+	}
+	return result
+}
+
+var _ ports.ContentFilter = &Filter{}
+
+// Filter: Фильтр текста рецензий и никнеймов
+type Filter struct {
+	review   Pipeline
+	nickname Pipeline
+}
+
+func New(config *Config) (ports.ContentFilter, error) {
+	err := config.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var review, nickname Pipeline
+
+	if config.Profanity.Enable {
+		verdict, err := config.Profanity.verdict()
+		if err != nil {
+			return nil, err
+		}
+		profanity, err := newProfanityStage(verdict, config.Profanity.Languages, config.Profanity.Words)
+		if err != nil {
+			return nil, err
+		}
+		review = append(review, profanity)
+		nickname = append(nickname, profanity)
+	}
+
+	if config.Links.Enable {
+		verdict, err := config.Links.verdict()
+		if err != nil {
+			return nil, err
+		}
+		review = append(review, linksStage{verdict: verdict, maxLinks: config.Links.MaxLinks})
+	}
+
+	if config.Repeats.Enable {
+		verdict, err := config.Repeats.verdict()
+		if err != nil {
+			return nil, err
+		}
+		review = append(review, repeatsStage{verdict: verdict, maxRun: config.Repeats.MaxRun})
+	}
+
+	if config.Nicknames.Enable {
+		verdict, err := config.Nicknames.verdict()
+		if err != nil {
+			return nil, err
+		}
+		patterns := make([]*regexp.Regexp, len(config.Nicknames.Patterns))
+		for i, pattern := range config.Nicknames.Patterns {
+			patterns[i], err = regexp.Compile("(?i)" + pattern)
+			if err != nil {
+				return nil, app.NewError(http.StatusInternalServerError, "invalid nickname pattern",
+					fmt.Sprintf("can't compile nickname pattern %q", pattern), err)
+			}
+		}
+		nickname = append(nickname, nicknameStage{verdict: verdict, patterns: patterns})
+	}
+
+	return &Filter{review: review, nickname: nickname}, nil
+}
+
+func (f *Filter) CheckReview(content string) d.FilterResult {
+	return f.review.Check(content)
+}
+
+func (f *Filter) CheckNickname(nickname string) d.FilterResult {
+	return f.nickname.Check(nickname)
+}
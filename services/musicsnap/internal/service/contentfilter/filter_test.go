@@ -0,0 +1,106 @@
+package contentfilter
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	d "music-snap/services/musicsnap/internal/domain"
+	"testing"
+)
+
+func testConfig() *Config {
+	return &Config{
+		Profanity: ProfanityConfig{
+			StageConfig: StageConfig{Enable: true, Action: ActionReject},
+			Languages:   []string{"en", "ru"},
+			Words:       []string{"scam*"},
+		},
+		Links: LinksConfig{
+			StageConfig: StageConfig{Enable: true, Action: ActionFlag},
+			MaxLinks:    1,
+		},
+		Repeats: RepeatsConfig{
+			StageConfig: StageConfig{Enable: true, Action: ActionFlag},
+			MaxRun:      5,
+		},
+		Nicknames: NicknamesConfig{
+			StageConfig: StageConfig{Enable: true, Action: ActionReject},
+			Patterns:    []string{`admin`, `^[0-9_.-]+$`},
+		},
+	}
+}
+
+func TestFilterCheckReview(t *testing.T) {
+	t.Parallel()
+
+	filter, err := New(testConfig())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		content string
+		verdict d.FilterVerdict
+	}{
+		{name: "Clean", content: "Great album, the bass line is amazing", verdict: d.FilterAllow},
+		{name: "CleanRussian", content: "Небанальный альбом, хлеба и зрелищ", verdict: d.FilterAllow},
+		{name: "Profanity", content: "this is shit!", verdict: d.FilterReject},
+		{name: "RepeatedLetters", content: "fuuuuck this", verdict: d.FilterReject},
+		{name: "Leetspeak", content: "total sh1t", verdict: d.FilterReject},
+		{name: "RootInsideWord", content: "motherfucker", verdict: d.FilterReject},
+		{name: "Russian", content: "полная хуйня", verdict: d.FilterReject},
+		{name: "MixedScripts", content: "полная xyйня", verdict: d.FilterReject},
+		{name: "RussianLeetspeak", content: "6ля, ну и альбом", verdict: d.FilterReject},
+		{name: "ExtraWord", content: "SCAMMERS", verdict: d.FilterReject},
+		{name: "OneLink", content: "listen at https://example.com/album", verdict: d.FilterAllow},
+		{name: "ManyLinks", content: "buy at shop.xyz or www.example.com", verdict: d.FilterFlag},
+		{name: "RepeatedChars", content: "wow!!!!!!!!", verdict: d.FilterFlag},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := filter.CheckReview(tt.content)
+			assert.Equal(t, tt.verdict, result.Verdict(), result.Reasons)
+		})
+	}
+}
+
+func TestFilterCheckNickname(t *testing.T) {
+	t.Parallel()
+
+	filter, err := New(testConfig())
+	require.NoError(t, err)
+
+	assert.Equal(t, d.FilterAllow, filter.CheckNickname("bass_lover").Verdict())
+	assert.Equal(t, d.FilterReject, filter.CheckNickname("Real_Admin").Verdict())
+	assert.Equal(t, d.FilterReject, filter.CheckNickname("12345").Verdict())
+	assert.Equal(t, d.FilterReject, filter.CheckNickname("fuck_you").Verdict())
+}
+
+func TestFilterConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(nil)
+	assert.Error(t, err)
+
+	cfg := testConfig()
+	cfg.Links.Action = "ban"
+	_, err = New(cfg)
+	assert.Error(t, err)
+
+	cfg = testConfig()
+	cfg.Profanity.Languages = []string{"de"}
+	_, err = New(cfg)
+	assert.Error(t, err)
+
+	cfg = testConfig()
+	cfg.Nicknames.Patterns = []string{"("}
+	_, err = New(cfg)
+	assert.Error(t, err)
+
+	// выключенные этапы пропускают любой текст
+	filter, err := New(&Config{})
+	require.NoError(t, err)
+	assert.Equal(t, d.FilterAllow, filter.CheckReview("shit shit https://a.com https://b.com").Verdict())
+}
@@ -0,0 +1,214 @@
+package contentfilter
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"music-snap/pkg/app"
+	d "music-snap/services/musicsnap/internal/domain"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Stage: Этап фильтра, nil означает что текст пропущен
+type Stage interface {
+	Check(text string) *d.FilterReason
+}
+
+//go:embed wordlists/*.txt
+var wordlists embed.FS
+
+// leetspeak заменяет цифры и символы на буквы, которые ими обычно пишут
+var leetspeak = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '6': 'б', '7': 't',
+	'@': 'a', '$': 's', '!': 'i', '|': 'i',
+}
+
+// homoglyphs сводит кириллические буквы к похожим латинским, чтобы смесь алфавитов не обходила фильтр
+var homoglyphs = map[rune]rune{
+	'а': 'a', 'в': 'b', 'е': 'e', 'ё': 'e', 'к': 'k', 'м': 'm', 'н': 'h',
+	'о': 'o', 'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x',
+}
+
+func normalizeRune(r rune) rune {
+	r = unicode.ToLower(r)
+	if l, ok := leetspeak[r]; ok {
+		r = l
+	}
+	if l, ok := homoglyphs[r]; ok {
+		r = l
+	}
+	return r
+}
+
+// words splits text to words keeping leetspeak symbols inside them
+func words(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		_, leet := leetspeak[r]
+		return !unicode.IsLetter(r) && !leet
+	})
+}
+
+// profanityStage: Поиск слов из словаря ненормативной лексики
+type profanityStage struct {
+	verdict d.FilterVerdict
+	re      *regexp.Regexp
+}
+
+func newProfanityStage(verdict d.FilterVerdict, languages []string, extra []string) (profanityStage, error) {
+	entries := make([]string, 0, len(extra))
+	for _, lang := range languages {
+		file, err := wordlists.Open("wordlists/" + lang + ".txt")
+		if err != nil {
+			return profanityStage{}, app.NewError(http.StatusInternalServerError, "invalid config",
+				fmt.Sprintf("no wordlist for language %q", lang), err)
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line != "" && !strings.HasPrefix(line, "#") {
+				entries = append(entries, line)
+			}
+		}
+		_ = file.Close()
+	}
+	entries = append(entries, extra...)
+
+	patterns := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if pattern := entryPattern(entry); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	if len(patterns) == 0 {
+		return profanityStage{}, app.NewError(http.StatusInternalServerError, "invalid config",
+			"profanity filter is enabled without words", nil)
+	}
+
+	re, err := regexp.Compile(strings.Join(patterns, "|"))
+	if err != nil {
+		return profanityStage{}, app.NewError(http.StatusInternalServerError, "invalid config",
+			"can't compile profanity wordlist", err)
+	}
+	return profanityStage{verdict: verdict, re: re}, nil
+}
+
+// entryPattern matches normalized word with any letter repeated ("fuuuck"),
+// entry without '*' matches the whole word, '*' allows other letters before or after the root
+func entryPattern(entry string) string {
+	root := strings.Trim(entry, "*")
+	if root == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("(?:")
+	if !strings.HasPrefix(entry, "*") {
+		b.WriteString("^")
+	}
+	for _, r := range root {
+		b.WriteString(regexp.QuoteMeta(string(normalizeRune(r))) + "+")
+	}
+	if !strings.HasSuffix(entry, "*") {
+		b.WriteString("$")
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+func (s profanityStage) Check(text string) *d.FilterReason {
+	for _, word := range words(text) {
+		// "!" и "|" в конце слова чаще знаки препинания, чем leetspeak
+		candidates := []string{word, strings.Trim(word, "!|")}
+		for _, candidate := range candidates {
+			normalized := []rune(candidate)
+			for i, r := range normalized {
+				normalized[i] = normalizeRune(r)
+			}
+			if s.re.MatchString(string(normalized)) {
+				return &d.FilterReason{
+					Stage:   "profanity",
+					Verdict: s.verdict,
+					Code:    d.ReasonOffensive,
+					Message: "text contains profanity",
+				}
+			}
+		}
+	}
+	return nil
+}
+
+var linkRe = regexp.MustCompile(`(?i)(?:https?://|www\.)\S+|\b[a-z0-9-]+\.(?:com|net|org|ru|su|io|me|info|biz|xyz|top|link|click|shop|site|online)\b`)
+
+// linksStage: Ограничение количества ссылок в тексте
+type linksStage struct {
+	verdict  d.FilterVerdict
+	maxLinks int
+}
+
+func (s linksStage) Check(text string) *d.FilterReason {
+	links := len(linkRe.FindAllStringIndex(text, s.maxLinks+1))
+	if links <= s.maxLinks {
+		return nil
+	}
+	return &d.FilterReason{
+		Stage:   "links",
+		Verdict: s.verdict,
+		Code:    d.ReasonSpam,
+		Message: fmt.Sprintf("text contains more than %d links", s.maxLinks),
+	}
+}
+
+// repeatsStage: Спам одним повторяющимся символом ("!!!!!!!!!!!!", "ааааааааааа")
+type repeatsStage struct {
+	verdict d.FilterVerdict
+	maxRun  int
+}
+
+func (s repeatsStage) Check(text string) *d.FilterReason {
+	var prev rune
+	run := 0
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			prev, run = 0, 0
+			continue
+		}
+		r = unicode.ToLower(r)
+		if r == prev {
+			run++
+		} else {
+			prev, run = r, 1
+		}
+		if run > s.maxRun {
+			return &d.FilterReason{
+				Stage:   "repeats",
+				Verdict: s.verdict,
+				Code:    d.ReasonSpam,
+				Message: fmt.Sprintf("text repeats the same character more than %d times", s.maxRun),
+			}
+		}
+	}
+	return nil
+}
+
+// nicknameStage: Запрещённые шаблоны никнеймов, например выдающие себя за администрацию
+type nicknameStage struct {
+	verdict  d.FilterVerdict
+	patterns []*regexp.Regexp
+}
+
+func (s nicknameStage) Check(nickname string) *d.FilterReason {
+	for _, pattern := range s.patterns {
+		if pattern.MatchString(nickname) {
+			return &d.FilterReason{
+				Stage:   "nickname_patterns",
+				Verdict: s.verdict,
+				Code:    d.ReasonOther,
+				Message: "nickname is not allowed",
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	c "context"
+	"fmt"
+	"github.com/google/uuid"
+	global "go.opentelemetry.io/otel"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/config"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+func (s eventSvc) spanName(funcName string) string {
+	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
+}
+
+const (
+	defaultEventsLimit = 20
+	maxEventsLimit     = 100
+
+	defaultReminderMaxDelay  = 15 * time.Minute
+	defaultReminderBatchSize = 100
+)
+
+// defaultReminderOffsets are used when offsets of reminders are not configured
+var defaultReminderOffsets = []time.Duration{24 * time.Hour, 2 * time.Hour}
+
+func NewEventSvc(eventRepository ports.EventRepository, txs ports.TransactionFactory, outbox ports.OutboxRepository,
+	notifications ports.NotificationSvc, webhooks ports.WebhookPublisher, reminders ports.EventReminderRepository,
+	remindersCfg config.EventRemindersConfig) ports.EventService {
+	if len(remindersCfg.Offsets) == 0 {
+		remindersCfg.Offsets = defaultReminderOffsets
+	}
+	if remindersCfg.MaxDelay <= 0 {
+		remindersCfg.MaxDelay = defaultReminderMaxDelay
+	}
+	if remindersCfg.BatchSize <= 0 {
+		remindersCfg.BatchSize = defaultReminderBatchSize
+	}
+	return eventSvc{r: eventRepository, txs: txs, outbox: outbox, notifications: notifications, webhooks: webhooks,
+		reminders: reminders, remindersCfg: remindersCfg}
+}
+
+var _ ports.EventService = &eventSvc{}
+
+type eventSvc struct {
+	r             ports.EventRepository
+	txs           ports.TransactionFactory
+	outbox        ports.OutboxRepository
+	notifications ports.NotificationSvc
+	webhooks      ports.WebhookPublisher
+	reminders     ports.EventReminderRepository
+	remindersCfg  config.EventRemindersConfig
+}
+
+// CreateEvent makes actor the author of event together with co-authors from event
+func (s eventSvc) CreateEvent(ctx c.Context, actor domain.Actor, event domain.Event) (domain.Event, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("CreateEvent"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if actor.ID == uuid.Nil {
+		return domain.Event{}, app.NewError(http.StatusUnauthorized, "unauthorized", "guest can't create events", nil)
+	}
+	if err := event.Validate(); err != nil {
+		return domain.Event{}, app.NewError(http.StatusBadRequest, "invalid event", err.Error(), err)
+	}
+	event.Date = event.Date.UTC()
+	event.Authors = withAuthor(actor.ID, event.Authors)
+
+	var created domain.Event
+	err := inTransaction(ctx, s.txs, func(ctx c.Context) error {
+		var err error
+		created, err = s.r.Create(ctx, event)
+		if err != nil {
+			return err
+		}
+		return addEvent(ctx, s.outbox, domain.EventConcertCreated, domain.ConcertCreatedEvent{
+			EventID:  created.ID,
+			AuthorID: actor.ID,
+			Name:     created.Name,
+			Date:     created.Date,
+		})
+	})
+	if err != nil {
+		return domain.Event{}, err
+	}
+	return created, nil
+}
+
+// withAuthor puts author first and drops repeated co-authors
+func withAuthor(author uuid.UUID, coAuthors []uuid.UUID) []uuid.UUID {
+	authors := []uuid.UUID{author}
+	seen := map[uuid.UUID]bool{author: true}
+	for _, id := range coAuthors {
+		if !seen[id] && id != uuid.Nil {
+			seen[id] = true
+			authors = append(authors, id)
+		}
+	}
+	return authors
+}
+
+func (s eventSvc) GetEvent(ctx c.Context, eventID uuid.UUID) (domain.Event, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("GetEvent"))
+	defer span.End()
+
+	return s.r.GetByID(ctx, eventID)
+}
+
+// UpdateEvent changes event of actor, authors of event stay the same
+func (s eventSvc) UpdateEvent(ctx c.Context, actor domain.Actor, event domain.Event) (domain.Event, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("UpdateEvent"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if actor.ID == uuid.Nil {
+		return domain.Event{}, app.NewError(http.StatusUnauthorized, "unauthorized", "guest can't update events", nil)
+	}
+	prev, err := s.r.GetByID(ctx, event.ID)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	if !prev.HasAuthor(actor.ID) && !actor.HasRole(domain.AdminRole) {
+		return domain.Event{}, app.NewError(http.StatusForbidden, "can't update event of other user",
+			fmt.Sprintf("user %s is not author of event %s, admin rights needed", actor.ID, event.ID), nil)
+	}
+	if prev.IsCancelled() {
+		return domain.Event{}, app.NewError(http.StatusBadRequest, "event is cancelled",
+			fmt.Sprintf("event %s was cancelled at %s", event.ID, prev.CancelledAt), nil)
+	}
+	if err := event.Validate(); err != nil {
+		return domain.Event{}, app.NewError(http.StatusBadRequest, "invalid event", err.Error(), err)
+	}
+	event.Date = event.Date.UTC()
+	if event.Date.Equal(prev.Date) {
+		return s.r.Update(ctx, event)
+	}
+
+	// напоминания участникам переносятся вместе с событием
+	var updated domain.Event
+	err = inTransaction(ctx, s.txs, func(ctx c.Context) error {
+		updated, err = s.r.Update(ctx, event)
+		if err != nil {
+			return err
+		}
+		_, err = s.reminders.Schedule(ctx, event.ID, s.remindersCfg.Offsets, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		return domain.Event{}, err
+	}
+	return updated, nil
+}
+
+// CancelEvent is allowed to authors of event and admins
+func (s eventSvc) CancelEvent(ctx c.Context, actor domain.Actor, eventID uuid.UUID) (domain.Event, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("CancelEvent"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if actor.ID == uuid.Nil {
+		return domain.Event{}, app.NewError(http.StatusUnauthorized, "unauthorized", "guest can't cancel events", nil)
+	}
+	event, err := s.r.GetByID(ctx, eventID)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	if !event.HasAuthor(actor.ID) && !actor.HasRole(domain.AdminRole) {
+		return domain.Event{}, app.NewError(http.StatusForbidden, "can't cancel event of other user",
+			fmt.Sprintf("user %s is not author of event %s, admin rights needed", actor.ID, eventID), nil)
+	}
+
+	return s.r.Cancel(ctx, eventID)
+}
+
+func (s eventSvc) ListEvents(ctx c.Context, actor domain.Actor, filter domain.EventFilter) ([]domain.Event, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("ListEvents"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if err := filter.Validate(); err != nil {
+		return nil, app.NewError(http.StatusBadRequest, "invalid filter", err.Error(), err)
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultEventsLimit
+	}
+	if filter.Limit > maxEventsLimit {
+		filter.Limit = maxEventsLimit
+	}
+	if !filter.DateLeftBound.IsZero() {
+		filter.DateLeftBound = filter.DateLeftBound.UTC()
+	}
+	if !filter.DateRightBound.IsZero() {
+		filter.DateRightBound = filter.DateRightBound.UTC()
+	}
+
+	return s.r.List(ctx, filter)
+}
+
+// Participate adds actor to participants of upcoming event
+func (s eventSvc) Participate(ctx c.Context, actor domain.Actor, eventID uuid.UUID) (domain.Event, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("Participate"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if actor.ID == uuid.Nil {
+		return domain.Event{}, app.NewError(http.StatusUnauthorized, "unauthorized", "guest can't participate in events", nil)
+	}
+	event, err := s.r.GetByID(ctx, eventID)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	if event.IsCancelled() {
+		return domain.Event{}, app.NewError(http.StatusBadRequest, "event is cancelled",
+			fmt.Sprintf("event %s was cancelled at %s", eventID, event.CancelledAt), nil)
+	}
+	if event.IsOver(time.Now().UTC()) {
+		return domain.Event{}, app.NewError(http.StatusBadRequest, "event is over",
+			fmt.Sprintf("event %s started at %s", eventID, event.Date), nil)
+	}
+
+	err = inTransaction(ctx, s.txs, func(ctx c.Context) error {
+		err := s.r.AddParticipant(ctx, eventID, actor.ID)
+		if err != nil {
+			return err
+		}
+		_, err = s.reminders.Schedule(ctx, eventID, s.remindersCfg.Offsets, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		return addEvent(ctx, s.outbox, domain.EventConcertJoined, domain.ConcertJoinedEvent{
+			EventID:   eventID,
+			UserID:    actor.ID,
+			AuthorIDs: event.Authors,
+		})
+	})
+	if err != nil {
+		return domain.Event{}, err
+	}
+	return s.r.GetByID(ctx, eventID)
+}
+
+func (s eventSvc) CancelParticipation(ctx c.Context, actor domain.Actor, eventID uuid.UUID) (domain.Event, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("CancelParticipation"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if actor.ID == uuid.Nil {
+		return domain.Event{}, app.NewError(http.StatusUnauthorized, "unauthorized", "guest doesn't participate in events", nil)
+	}
+	err := s.r.RemoveParticipant(ctx, eventID, actor.ID)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	return s.r.GetByID(ctx, eventID)
+}
+
+// SendReminders sends due reminders, they are scheduled when user joins event or event is moved.
+// Reminders are marked sent in the transaction creating their notifications, so they are sent once by one of servers
+func (s eventSvc) SendReminders(ctx c.Context) (int, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("SendReminders"))
+	defer span.End()
+
+	now := time.Now().UTC()
+	sent := 0
+	err := inTransaction(ctx, s.txs, func(ctx c.Context) error {
+		due, err := s.reminders.ClaimDue(ctx, now, now.Add(-s.remindersCfg.MaxDelay), s.remindersCfg.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		notifications := make([]domain.Notification, 0, len(due))
+		for _, reminder := range due {
+			message, err := domain.NewNotificationMessage(reminder.Payload())
+			if err != nil {
+				return err
+			}
+			notifications = append(notifications, domain.Notification{
+				UserIDReceiver: reminder.UserID,
+				Type:           domain.NotificationEventReminder,
+				Message:        message,
+			})
+		}
+		sent = len(notifications)
+		return s.notifications.NotifyMany(ctx, notifications)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return sent, nil
+}
+
+// HandleEvent announces created event to followers of its author and sends event.joined
+// to webhooks of authors, the same id of webhook event keeps app webhooks from duplicates
+func (s eventSvc) HandleEvent(ctx c.Context, event domain.DomainEvent) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("HandleEvent"))
+	defer span.End()
+
+	switch event.Type {
+	case domain.EventConcertCreated:
+		var created domain.ConcertCreatedEvent
+		if err := event.Decode(&created); err != nil {
+			return err
+		}
+		message, err := domain.NewNotificationMessage(domain.EventPayload{
+			EventID: created.EventID, Name: created.Name, Date: created.Date})
+		if err != nil {
+			return err
+		}
+		return s.notifications.NotifySubscribers(ctx, domain.Notification{
+			UserIDSender: &created.AuthorID,
+			Type:         domain.NotificationEvent,
+			Message:      message,
+		})
+
+	case domain.EventConcertJoined:
+		var joined domain.ConcertJoinedEvent
+		if err := event.Decode(&joined); err != nil {
+			return err
+		}
+		for _, author := range joined.AuthorIDs {
+			err := s.webhooks.Publish(ctx, domain.WebhookEvent{
+				ID:        event.ID,
+				Type:      domain.WebhookEventJoined,
+				SubjectID: author,
+				Data: map[string]interface{}{
+					"event_id": joined.EventID,
+					"user_id":  joined.UserID,
+				},
+				CreatedAt: event.CreatedAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return nil
+}
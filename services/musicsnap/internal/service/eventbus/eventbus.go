@@ -0,0 +1,94 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/msbus"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"strconv"
+)
+
+const spanBaseName = "musicsnap/service/eventbus."
+
+var _ ports.DomainEventHandler = &Publisher{}
+
+// Publisher sends domain events of outbox to the bus, id of event is the key of message
+type Publisher struct {
+	bus msbus.Publisher
+}
+
+func NewPublisher(bus msbus.Publisher) *Publisher {
+	return &Publisher{bus: bus}
+}
+
+func (p *Publisher) HandleEvent(ctx context.Context, event domain.DomainEvent) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, spanBaseName+"Publisher.HandleEvent")
+	defer span.End()
+
+	return p.bus.Publish(ctx, Message(event))
+}
+
+// Message converts domain event to message of the bus, payload is the value and the rest goes to headers
+func Message(event domain.DomainEvent) msbus.Message {
+	return msbus.Message{
+		Key:   []byte(event.ID.String()),
+		Value: event.Payload,
+		Headers: map[string]string{
+			msbus.HeaderID:            event.ID.String(),
+			msbus.HeaderType:          event.Type,
+			msbus.HeaderSchemaVersion: strconv.Itoa(domain.DomainEventSchemaVersion),
+		},
+		Time: event.CreatedAt,
+	}
+}
+
+// Event converts message of the bus back to domain event
+func Event(message msbus.Message) (domain.DomainEvent, error) {
+	id, err := uuid.Parse(message.Headers[msbus.HeaderID])
+	if err != nil {
+		return domain.DomainEvent{}, fmt.Errorf("invalid id of bus message: %w", err)
+	}
+	return domain.DomainEvent{
+		ID:        id,
+		Type:      message.Headers[msbus.HeaderType],
+		Payload:   message.Value,
+		CreatedAt: message.Time,
+	}, nil
+}
+
+// NewDispatcher returns handler of bus messages which passes them to handlers by event type.
+// Messages of unknown type, newer schema or without id are skipped, retrying can't fix them
+func NewDispatcher(handlers map[string]ports.DomainEventHandler) msbus.Handler {
+	return func(ctx context.Context, message msbus.Message) error {
+		tr := global.Tracer(domain.ServiceName)
+		ctx, span := tr.Start(ctx, spanBaseName+"Dispatcher.Handle")
+		defer span.End()
+
+		logger := zapctx.Logger(ctx).With(zap.String("topic", message.Topic),
+			zap.String("type", message.Headers[msbus.HeaderType]))
+
+		version, err := strconv.Atoi(message.Headers[msbus.HeaderSchemaVersion])
+		if err != nil || version > domain.DomainEventSchemaVersion {
+			logger.Warn("unsupported schema version of bus message",
+				zap.String("version", message.Headers[msbus.HeaderSchemaVersion]))
+			return nil
+		}
+		event, err := Event(message)
+		if err != nil {
+			logger.Warn("invalid bus message", zap.Error(err))
+			return nil
+		}
+		handler, ok := handlers[event.Type]
+		if !ok {
+			logger.Warn("no handler of bus message")
+			return nil
+		}
+		return handler.HandleEvent(ctx, event)
+	}
+}
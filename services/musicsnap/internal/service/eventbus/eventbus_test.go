@@ -0,0 +1,54 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"music-snap/pkg/msbus"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"testing"
+	"time"
+)
+
+type handlerFunc func(ctx context.Context, event domain.DomainEvent) error
+
+func (f handlerFunc) HandleEvent(ctx context.Context, event domain.DomainEvent) error {
+	return f(ctx, event)
+}
+
+func TestPublishAndDispatch(t *testing.T) {
+	bus := msbus.NewMemoryBus(10)
+	publisher := NewPublisher(bus.Publisher(msbus.WriterConfig{Topic: "inbound"}))
+
+	event, err := domain.NewDomainEvent(domain.EventSubscribed,
+		domain.SubscribedEvent{SubscriberID: uuid.New(), FollowedID: uuid.New()})
+	require.NoError(t, err)
+	event.CreatedAt = time.Now().UTC().Truncate(time.Second)
+
+	ctx := context.Background()
+	require.NoError(t, publisher.HandleEvent(ctx, event))
+
+	newer := Message(event)
+	newer.Headers[msbus.HeaderSchemaVersion] = "2"
+	unknown := Message(event)
+	unknown.Headers[msbus.HeaderType] = "playlist_created"
+	require.NoError(t, bus.Publisher(msbus.WriterConfig{Topic: "inbound"}).Publish(ctx, newer, unknown))
+	require.NoError(t, bus.Close())
+
+	var received []domain.DomainEvent
+	dispatcher := NewDispatcher(map[string]ports.DomainEventHandler{
+		domain.EventSubscribed: handlerFunc(func(_ context.Context, e domain.DomainEvent) error {
+			received = append(received, e)
+			return nil
+		}),
+	})
+	require.NoError(t, bus.Consumer(msbus.ReaderConfig{Topic: "inbound"}).Consume(ctx, dispatcher))
+
+	require.Len(t, received, 1, "newer schema and unknown type are skipped")
+	assert.Equal(t, event.ID, received[0].ID)
+	assert.Equal(t, event.Type, received[0].Type)
+	assert.Equal(t, event.CreatedAt, received[0].CreatedAt)
+	assert.JSONEq(t, string(event.Payload), string(received[0].Payload))
+}
@@ -0,0 +1,188 @@
+package icalendar
+
+import (
+	"fmt"
+	d "music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType of encoded calendar
+const ContentType = "text/calendar; charset=utf-8"
+
+const (
+	prodID = "-//MusicSnap//Events//EN"
+	// utcLayout is the form of date-time in UTC, calendar apps convert it to zone of the user
+	utcLayout = "20060102T150405Z"
+	// maxLineOctets is the limit of content line length, longer lines are folded
+	maxLineOctets = 75
+)
+
+const (
+	defaultEventDuration   = 3 * time.Hour
+	defaultRefreshInterval = time.Hour
+	defaultUIDDomain       = "musicsnap"
+)
+
+type Config struct {
+	// EventDuration sets the end of events, events have only the start date
+	EventDuration time.Duration `mapstructure:"event_duration"`
+	// RefreshInterval is suggested to calendar apps polling the feed
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+	// UIDDomain makes UID of events globally unique, "<event id>@<domain>"
+	UIDDomain string `mapstructure:"uid_domain"`
+}
+
+var _ ports.CalendarEncoder = &Encoder{}
+
+// Encoder: Экспорт событий в iCalendar (RFC 5545)
+type Encoder struct {
+	eventDuration   time.Duration
+	refreshInterval time.Duration
+	uidDomain       string
+}
+
+func New(cfg *Config) *Encoder {
+	e := &Encoder{
+		eventDuration:   defaultEventDuration,
+		refreshInterval: defaultRefreshInterval,
+		uidDomain:       defaultUIDDomain,
+	}
+	if cfg == nil {
+		return e
+	}
+	if cfg.EventDuration > 0 {
+		e.eventDuration = cfg.EventDuration
+	}
+	if cfg.RefreshInterval > 0 {
+		e.refreshInterval = cfg.RefreshInterval
+	}
+	if cfg.UIDDomain != "" {
+		e.uidDomain = cfg.UIDDomain
+	}
+	return e
+}
+
+// Encode writes calendar with an event for every event of it. Changed events keep their UID and have greater
+// SEQUENCE, cancelled events stay with the CANCELLED status, so calendar apps update their copies
+func (e *Encoder) Encode(calendar d.Calendar) []byte {
+	w := &writer{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", prodID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	if calendar.Name != "" {
+		w.line("X-WR-CALNAME", escape(calendar.Name))
+	}
+	w.line("REFRESH-INTERVAL;VALUE=DURATION", duration(e.refreshInterval))
+	w.line("X-PUBLISHED-TTL", duration(e.refreshInterval))
+
+	for _, event := range calendar.Events {
+		e.writeEvent(w, event, calendar.GeneratedAt)
+	}
+
+	w.line("END", "VCALENDAR")
+	return []byte(w.String())
+}
+
+func (e *Encoder) writeEvent(w *writer, event d.Event, stamp time.Time) {
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", fmt.Sprintf("%s@%s", event.ID, e.uidDomain))
+	w.line("DTSTAMP", utc(stamp))
+	w.line("DTSTART", utc(event.Date))
+	w.line("DTEND", utc(event.Date.Add(e.eventDuration)))
+	w.line("SEQUENCE", fmt.Sprint(event.Sequence))
+	if !event.CreatedAt.IsZero() {
+		w.line("CREATED", utc(event.CreatedAt))
+	}
+	if !event.UpdatedAt.IsZero() {
+		w.line("LAST-MODIFIED", utc(event.UpdatedAt))
+	}
+	w.line("SUMMARY", escape(event.Name))
+	if description := eventDescription(event); description != "" {
+		w.line("DESCRIPTION", escape(description))
+	}
+	if event.Location != "" {
+		w.line("LOCATION", escape(event.Location))
+	}
+	if event.TicketLink != "" {
+		w.line("URL", event.TicketLink)
+	}
+	if event.IsCancelled() {
+		w.line("STATUS", "CANCELLED")
+	} else {
+		w.line("STATUS", "CONFIRMED")
+	}
+	w.line("END", "VEVENT")
+}
+
+// eventDescription adds links of event to its text, calendar apps have no fields for them
+func eventDescription(event d.Event) string {
+	parts := make([]string, 0, 3)
+	if event.Text != "" {
+		parts = append(parts, event.Text)
+	}
+	if event.TicketLink != "" {
+		parts = append(parts, "Tickets: "+event.TicketLink)
+	}
+	if event.LocationLink != "" {
+		parts = append(parts, "Map: "+event.LocationLink)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func utc(t time.Time) string {
+	return t.UTC().Format(utcLayout)
+}
+
+// duration formats positive duration in whole seconds as RFC 5545 duration, e.g. PT1H30M
+func duration(dur time.Duration) string {
+	seconds := int(dur / time.Second)
+	if seconds <= 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	b.WriteString("PT")
+	if h := seconds / 3600; h > 0 {
+		fmt.Fprintf(&b, "%dH", h)
+	}
+	if m := seconds % 3600 / 60; m > 0 {
+		fmt.Fprintf(&b, "%dM", m)
+	}
+	if s := seconds % 60; s > 0 {
+		fmt.Fprintf(&b, "%dS", s)
+	}
+	return b.String()
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escape escapes value of TEXT property
+func escape(text string) string {
+	return textEscaper.Replace(text)
+}
+
+type writer struct {
+	strings.Builder
+}
+
+// line writes content line ending with CRLF, lines longer than 75 octets are folded without breaking characters
+func (w *writer) line(name, value string) {
+	width := 0
+	for _, r := range name + ":" + value {
+		size := utf8.RuneLen(r)
+		if size < 0 {
+			size = len(string(utf8.RuneError))
+		}
+		if width+size > maxLineOctets {
+			w.WriteString("\r\n ")
+			width = 1
+		}
+		w.WriteRune(r)
+		width += size
+	}
+	w.WriteString("\r\n")
+}
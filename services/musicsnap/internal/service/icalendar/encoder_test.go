@@ -0,0 +1,114 @@
+package icalendar
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	d "music-snap/services/musicsnap/internal/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+// unfold joins folded lines back and splits calendar into content lines
+func unfold(t *testing.T, calendar []byte) []string {
+	text := string(calendar)
+	assert.True(t, strings.HasSuffix(text, "\r\n"))
+	assert.NotContains(t, strings.ReplaceAll(text, "\r\n", ""), "\n", "lines must end with CRLF")
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(text, "\r\n ", ""), "\r\n"), "\r\n")
+}
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	moscow := time.FixedZone("MSK", 3*60*60)
+	cancelledAt := time.Date(2026, 5, 2, 10, 0, 0, 0, time.UTC)
+	event := d.Event{
+		ID:           uuid.MustParse("6f1c2a54-8d8e-4a7f-9c39-5b7c7d3e2a10"),
+		Name:         "Spring concert; part 1, acoustic",
+		Date:         time.Date(2026, 5, 20, 19, 30, 0, 0, moscow),
+		Text:         "Bring friends\nand \\ good mood",
+		TicketLink:   "https://tickets.example/spring",
+		LocationLink: "https://maps.example/hall",
+		Location:     "Main hall",
+		Sequence:     2,
+		CreatedAt:    time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt:    time.Date(2026, 4, 3, 8, 15, 0, 0, time.UTC),
+	}
+	generatedAt := time.Date(2026, 4, 10, 0, 0, 0, 0, time.UTC)
+
+	encoder := New(&Config{EventDuration: 90 * time.Minute, UIDDomain: "musicsnap.test"})
+
+	t.Run("Event", func(t *testing.T) {
+		t.Parallel()
+
+		lines := unfold(t, encoder.Encode(d.Calendar{Name: "Concerts", Events: []d.Event{event}, GeneratedAt: generatedAt}))
+		assert.Equal(t, "BEGIN:VCALENDAR", lines[0])
+		assert.Equal(t, "END:VCALENDAR", lines[len(lines)-1])
+		assert.Subset(t, lines, []string{
+			"VERSION:2.0",
+			"X-WR-CALNAME:Concerts",
+			"REFRESH-INTERVAL;VALUE=DURATION:PT1H",
+			"BEGIN:VEVENT",
+			"UID:6f1c2a54-8d8e-4a7f-9c39-5b7c7d3e2a10@musicsnap.test",
+			"DTSTAMP:20260410T000000Z",
+			// время площадки переводится в UTC
+			"DTSTART:20260520T163000Z",
+			"DTEND:20260520T180000Z",
+			"SEQUENCE:2",
+			"LAST-MODIFIED:20260403T081500Z",
+			`SUMMARY:Spring concert\; part 1\, acoustic`,
+			`DESCRIPTION:Bring friends\nand \\ good mood\n\nTickets: https://tickets.example/spring\n\nMap: https://maps.example/hall`,
+			"LOCATION:Main hall",
+			"URL:https://tickets.example/spring",
+			"STATUS:CONFIRMED",
+			"END:VEVENT",
+		})
+	})
+
+	t.Run("Cancelled event", func(t *testing.T) {
+		t.Parallel()
+
+		cancelled := event
+		cancelled.CancelledAt = &cancelledAt
+		cancelled.Sequence = 3
+		lines := unfold(t, encoder.Encode(d.Calendar{Events: []d.Event{cancelled}, GeneratedAt: generatedAt}))
+		assert.Contains(t, lines, "STATUS:CANCELLED")
+		assert.Contains(t, lines, "SEQUENCE:3")
+		assert.NotContains(t, lines, "STATUS:CONFIRMED")
+	})
+
+	t.Run("Empty calendar", func(t *testing.T) {
+		t.Parallel()
+
+		calendar := string(New(nil).Encode(d.Calendar{GeneratedAt: generatedAt}))
+		assert.NotContains(t, calendar, "BEGIN:VEVENT")
+		assert.NotContains(t, calendar, "X-WR-CALNAME")
+	})
+}
+
+func TestLineFolding(t *testing.T) {
+	t.Parallel()
+
+	w := &writer{}
+	w.line("SUMMARY", strings.Repeat("концерт ", 20))
+	folded := strings.Split(strings.TrimSuffix(w.String(), "\r\n"), "\r\n")
+
+	assert.Greater(t, len(folded), 1)
+	for i, line := range folded {
+		assert.LessOrEqual(t, len(line), maxLineOctets)
+		assert.True(t, strings.ToValidUTF8(line, "") == line, "characters must not be split")
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(line, " "))
+		}
+	}
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("концерт ", 20), strings.ReplaceAll(strings.TrimSuffix(w.String(), "\r\n"), "\r\n ", ""))
+}
+
+func TestDuration(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "PT1H", duration(time.Hour))
+	assert.Equal(t, "PT1H30M", duration(90*time.Minute))
+	assert.Equal(t, "PT2M5S", duration(125*time.Second))
+	assert.Equal(t, "PT0S", duration(0))
+}
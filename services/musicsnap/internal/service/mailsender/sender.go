@@ -0,0 +1,75 @@
+package mailsender
+
+import (
+	c "context"
+	"fmt"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	d "music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+type Config struct {
+	// Host of SMTP server, mails are only logged when empty
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	// From is the sender address of every mail
+	From string `mapstructure:"from"`
+}
+
+// New returns SMTP sender or logging sender for configuration without host
+func New(cfg *Config) ports.MailSender {
+	if cfg == nil || cfg.Host == "" {
+		return logSender{}
+	}
+	return smtpSender{cfg: *cfg}
+}
+
+type smtpSender struct {
+	cfg Config
+}
+
+func (s smtpSender) Send(_ c.Context, mail d.Mail) error {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+
+	err := smtp.SendMail(addr, auth, s.cfg.From, []string{mail.To}, s.message(mail))
+	if err != nil {
+		return app.NewError(http.StatusBadGateway, "failed to send mail",
+			fmt.Sprintf("failed to send mail to %s", mail.To), err)
+	}
+	return nil
+}
+
+func (s smtpSender) message(mail d.Mail) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + s.cfg.From + "\r\n")
+	b.WriteString("To: " + mail.To + "\r\n")
+	b.WriteString("Subject: " + mail.Subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(mail.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// logSender: Отправитель для окружений без почтового сервера
+type logSender struct{}
+
+func (logSender) Send(ctx c.Context, mail d.Mail) error {
+	zapctx.Logger(ctx).Info("mail is not sent, smtp server is not configured",
+		zap.String("to", mail.To), zap.String("subject", mail.Subject))
+	return nil
+}
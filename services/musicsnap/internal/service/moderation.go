@@ -0,0 +1,176 @@
+package service
+
+import (
+	c "context"
+	"fmt"
+	global "go.opentelemetry.io/otel"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/config"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+const (
+	defaultClaimTTL = 15 * time.Minute
+	maxClaimBatch   = 20
+)
+
+func (s moderationSvc) spanName(funcName string) string {
+	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
+}
+
+func NewModerationSvc(moderationRepository ports.ModerationRepository, cfg config.ModerationConfig) ports.ModerationService {
+	claimTTL := cfg.ClaimTTL
+	if claimTTL <= 0 {
+		claimTTL = defaultClaimTTL
+	}
+	return moderationSvc{r: moderationRepository, claimTTL: claimTTL}
+}
+
+var _ ports.ModerationService = &moderationSvc{}
+
+type moderationSvc struct {
+	r        ports.ModerationRepository
+	claimTTL time.Duration
+}
+
+func canModerate(actor domain.Actor) bool {
+	return actor.HasRole(domain.AdminRole) || actor.HasRole(domain.ModeratorRole)
+}
+
+func (s moderationSvc) requireModerator(actor domain.Actor) error {
+	if !canModerate(actor) {
+		return app.NewError(http.StatusForbidden, "only moderators can moderate reviews",
+			"actor do not have admin or moderator role", nil)
+	}
+	return nil
+}
+
+// staleBefore returns the time before which claims are expired
+func (s moderationSvc) staleBefore() time.Time {
+	return time.Now().Add(-s.claimTTL)
+}
+
+func (s moderationSvc) ListQueue(ctx c.Context, actor domain.Actor, filter domain.ModerationQueueFilter, pag domain.IDPagination) ([]domain.ModerationQueueItem, domain.IDPagination, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("ListQueue"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	err := s.requireModerator(actor)
+	if err != nil {
+		return nil, domain.IDPagination{}, err
+	}
+
+	return s.r.ListQueue(ctx, filter, pag)
+}
+
+func (s moderationSvc) ClaimNext(ctx c.Context, actor domain.Actor, limit int) ([]domain.ModerationQueueItem, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("ClaimNext"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	err := s.requireModerator(actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit < 1 || limit > maxClaimBatch {
+		return nil, app.NewError(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxClaimBatch),
+			fmt.Sprintf("invalid claim limit %d", limit), nil)
+	}
+
+	return s.r.ClaimNext(ctx, actor.ID, s.staleBefore(), limit)
+}
+
+func (s moderationSvc) Claim(ctx c.Context, actor domain.Actor, reviewID int) (domain.ModerationQueueItem, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("Claim"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	err := s.requireModerator(actor)
+	if err != nil {
+		return domain.ModerationQueueItem{}, err
+	}
+
+	return s.r.Claim(ctx, reviewID, actor.ID, s.staleBefore())
+}
+
+// Release returns claimed review to the queue, admin can release claim of any moderator
+func (s moderationSvc) Release(ctx c.Context, actor domain.Actor, reviewID int) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("Release"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	err := s.requireModerator(actor)
+	if err != nil {
+		return err
+	}
+
+	return s.r.Release(ctx, reviewID, actor.ID, actor.HasRole(domain.AdminRole))
+}
+
+func (s moderationSvc) Approve(ctx c.Context, actor domain.Actor, reviewID int, comment string) (domain.Review, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("Approve"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	return s.decide(ctx, actor, domain.ModerationAction{
+		ReviewID:    reviewID,
+		ModeratorID: actor.ID,
+		Action:      domain.ModerationApprove,
+		Comment:     comment,
+	})
+}
+
+func (s moderationSvc) Reject(ctx c.Context, actor domain.Actor, reviewID int, reason domain.ModerationReasonCode, comment string) (domain.Review, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("Reject"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	return s.decide(ctx, actor, domain.ModerationAction{
+		ReviewID:    reviewID,
+		ModeratorID: actor.ID,
+		Action:      domain.ModerationReject,
+		ReasonCode:  &reason,
+		Comment:     comment,
+	})
+}
+
+// decide applies moderator decision, review claimed by other moderator can be decided only by admin
+func (s moderationSvc) decide(ctx c.Context, actor domain.Actor, decision domain.ModerationAction) (domain.Review, error) {
+	err := s.requireModerator(actor)
+	if err != nil {
+		return domain.Review{}, err
+	}
+
+	err = decision.ValidDecision()
+	if err != nil {
+		return domain.Review{}, app.NewError(http.StatusBadRequest, "invalid moderation decision",
+			"invalid moderation decision", err)
+	}
+
+	return s.r.Decide(ctx, decision, s.staleBefore(), actor.HasRole(domain.AdminRole))
+}
+
+func (s moderationSvc) ListActions(ctx c.Context, actor domain.Actor, filter domain.ModerationActionFilter, pag domain.IDPagination) ([]domain.ModerationAction, domain.IDPagination, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("ListActions"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	err := s.requireModerator(actor)
+	if err != nil {
+		return nil, domain.IDPagination{}, err
+	}
+
+	return s.r.ListActions(ctx, filter, pag)
+}
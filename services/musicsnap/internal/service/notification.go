@@ -4,47 +4,339 @@ import (
 	c "context"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/config"
 	"music-snap/services/musicsnap/internal/domain"
 	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
 	"reflect"
+	"time"
 )
 
 func (s notificationSvc) spanName(funcName string) string {
 	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
 }
 
-func NewNotificationService(notificationRepository ports.NotificationRepository) ports.NotificationSvc {
-	return notificationSvc{notificationRepository: notificationRepository}
+// NewNotificationService creates notification service, similar notifications are collapsed within aggregation window
+func NewNotificationService(notificationRepository ports.NotificationRepository, userRepository ports.UserRepository,
+	loader ports.BatchLoaderFactory, renderer ports.NotificationRenderer, mail ports.MailSender,
+	cfg config.NotificationsConfig) ports.NotificationSvc {
+	if cfg.AggregationWindow <= 0 {
+		cfg.AggregationWindow = time.Hour
+	}
+	if cfg.DigestLease <= 0 {
+		cfg.DigestLease = 10 * time.Minute
+	}
+	if cfg.DigestBaseBackoff <= 0 {
+		cfg.DigestBaseBackoff = 5 * time.Minute
+	}
+	if cfg.DigestMaxBackoff < cfg.DigestBaseBackoff {
+		cfg.DigestMaxBackoff = 12 * time.Hour
+	}
+	if cfg.DigestBatchSize <= 0 {
+		cfg.DigestBatchSize = 100
+	}
+	return notificationSvc{notificationRepository: notificationRepository, users: userRepository, loader: loader,
+		renderer: renderer, mail: mail, cfg: cfg}
 }
 
 var _ ports.NotificationSvc = &notificationSvc{}
 
 type notificationSvc struct {
 	notificationRepository ports.NotificationRepository
-	name                   string
+	users                  ports.UserRepository
+	loader                 ports.BatchLoaderFactory
+	renderer               ports.NotificationRenderer
+	mail                   ports.MailSender
+	cfg                    config.NotificationsConfig
 }
 
-func (s notificationSvc) GetNotifications(ctx c.Context, actor domain.Actor, pagination domain.UUIDPagination) ([]domain.Notification, domain.UUIDPagination, error) {
-	//TODO implement me
-	panic("implement me")
+func (s notificationSvc) GetNotifications(ctx c.Context, actor domain.Actor, unreadOnly bool, pagination domain.UUIDPagination) ([]domain.Notification, domain.UUIDPagination, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("GetNotifications"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	notifications, pagination, err := s.notificationRepository.ListByUser(ctx, actor.ID, unreadOnly, pagination)
+	if err != nil {
+		return nil, pagination, err
+	}
+	return s.RenderNotifications(ctx, actor.ID, notifications), pagination, nil
+}
+
+// RenderNotifications sets text of notifications in locale of receiver,
+// text of notification which failed to render stays empty
+func (s notificationSvc) RenderNotifications(ctx c.Context, receiverID uuid.UUID, notifications []domain.Notification) []domain.Notification {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("RenderNotifications"))
+	defer span.End()
+
+	if len(notifications) == 0 {
+		return notifications
+	}
+	logger := zapctx.Logger(ctx)
+
+	locale := domain.DefaultLocale
+	receiver, err := s.users.GetByID(ctx, receiverID)
+	if err != nil {
+		logger.Error("can't get locale of notifications receiver",
+			zap.String("userID", receiverID.String()), zap.Error(err))
+	} else if receiver.Locale != "" {
+		locale = receiver.Locale
+	}
+
+	loader := s.loader.NewBatchLoader(receiverID)
+	for _, notification := range notifications {
+		if notification.UserIDSender != nil {
+			loader.WantProfiles(*notification.UserIDSender)
+		}
+	}
+	err = loader.Load(ctx)
+	if err != nil {
+		logger.Error("can't load senders of notifications", zap.Error(err))
+	}
+
+	for i, notification := range notifications {
+		sender := ""
+		if notification.UserIDSender != nil {
+			if profile, ok := loader.Profile(*notification.UserIDSender); ok {
+				sender = profile.Nickname
+			}
+		}
+		notifications[i].Text, err = s.renderer.Render(locale, notification, sender)
+		if err != nil {
+			logger.Error("can't render notification",
+				zap.String("notificationID", notification.ID.String()), zap.Error(err))
+		}
+	}
+	return notifications
+}
+
+func (s notificationSvc) CountUnread(ctx c.Context, actor domain.Actor) (int, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("CountUnread"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	return s.notificationRepository.CountUnread(ctx, actor.ID)
 }
 
+// Notify skips notification of user about own action, notification with GroupKey is collapsed with similar ones
 func (s notificationSvc) Notify(ctx c.Context, notification domain.Notification) error {
-	//TODO implement me
-	panic("implement me")
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("Notify"))
+	defer span.End()
+
+	err := notification.Validate()
+	if err != nil {
+		return app.NewError(http.StatusBadRequest, "invalid notification", "notification validation error", err)
+	}
+	if notification.SelfSent() {
+		return nil
+	}
+
+	if notification.GroupKey != "" {
+		_, err = s.notificationRepository.Aggregate(ctx, notification, time.Now().Add(-s.cfg.AggregationWindow))
+		return err
+	}
+	_, err = s.notificationRepository.Create(ctx, notification)
+	return err
 }
 
-func (s notificationSvc) NotifyMany(ctx c.Context, notification []domain.Notification) error {
-	//TODO implement me
-	panic("implement me")
+func (s notificationSvc) NotifyMany(ctx c.Context, notifications []domain.Notification) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("NotifyMany"))
+	defer span.End()
+
+	toSend := make([]domain.Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		err := notification.Validate()
+		if err != nil {
+			return app.NewError(http.StatusBadRequest, "invalid notification", "notification validation error", err)
+		}
+		if !notification.SelfSent() {
+			toSend = append(toSend, notification)
+		}
+	}
+
+	_, err := s.notificationRepository.CreateMany(ctx, toSend)
+	return err
 }
 
+// NotifyUsers sends copy of notification to every user once
 func (s notificationSvc) NotifyUsers(ctx c.Context, notification domain.Notification, userIDs []uuid.UUID) error {
-	//TODO implement me
-	panic("implement me")
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("NotifyUsers"))
+	defer span.End()
+
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	notifications := make([]domain.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		n := notification
+		n.UserIDReceiver = userID
+		notifications = append(notifications, n)
+	}
+
+	return s.NotifyMany(ctx, notifications)
+}
+
+// NotifySubscribers sends notification of sender to followers who enabled alert of its type
+func (s notificationSvc) NotifySubscribers(ctx c.Context, notification domain.Notification) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("NotifySubscribers"))
+	defer span.End()
+
+	if notification.UserIDSender == nil {
+		return app.NewError(http.StatusBadRequest, "invalid notification",
+			"subscription alert without sender", nil)
+	}
+	if !domain.IsSubscriptionAlert(notification.Type) {
+		return app.NewError(http.StatusBadRequest, "invalid notification",
+			fmt.Sprintf("notification type %q is not subscription alert", notification.Type), nil)
+	}
+	_, err := s.notificationRepository.NotifySubscribers(ctx, notification)
+	return err
+}
+
+// ReviewPublished alerts followers of review author
+func (s notificationSvc) ReviewPublished(ctx c.Context, review domain.Review) error {
+	message, err := domain.NewNotificationMessage(domain.NewReviewPayload{ReviewID: review.ID, PieceID: review.PieceID})
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "failed to encode notification payload", err)
+	}
+	return s.NotifySubscribers(ctx, domain.Notification{
+		UserIDSender: &review.UserID,
+		Type:         domain.NotificationNewReview,
+		Message:      message,
+		ReviewID:     review.ID,
+	})
+}
+
+func (s notificationSvc) GetPreferences(ctx c.Context, actor domain.Actor) (domain.NotificationPreferences, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("GetPreferences"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	prefs, err := s.notificationRepository.GetPreferences(ctx, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	return prefs.Full(), nil
+}
+
+// UpdatePreferences changes only given cells of matrix
+func (s notificationSvc) UpdatePreferences(ctx c.Context, actor domain.Actor, prefs domain.NotificationPreferences) (domain.NotificationPreferences, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("UpdatePreferences"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	err := prefs.Validate()
+	if err != nil {
+		return nil, app.NewError(http.StatusBadRequest, "invalid notification preferences",
+			"notification preferences validation error", err)
+	}
+
+	err = s.notificationRepository.SetPreferences(ctx, actor.ID, prefs)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetPreferences(ctx, actor)
+}
+
+func (s notificationSvc) MarkAsRead(ctx c.Context, actor domain.Actor, notificationID uuid.UUID) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("MarkAsRead"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	return s.notificationRepository.MarkAsRead(ctx, actor.ID, notificationID)
+}
+
+func (s notificationSvc) MarkAllAsRead(ctx c.Context, actor domain.Actor) (int, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("MarkAllAsRead"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	return s.notificationRepository.MarkAllAsRead(ctx, actor.ID)
+}
+
+func (s notificationSvc) GetDigestSettings(ctx c.Context, actor domain.Actor) (domain.DigestSettings, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("GetDigestSettings"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	return s.notificationRepository.GetDigestSettings(ctx, actor.ID)
+}
+
+func (s notificationSvc) UpdateDigestSettings(ctx c.Context, actor domain.Actor, settings domain.DigestSettings) (domain.DigestSettings, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("UpdateDigestSettings"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	settings.UserID = actor.ID
+	err := settings.Validate()
+	if err != nil {
+		return domain.DigestSettings{}, app.NewError(http.StatusBadRequest, "invalid digest settings",
+			"digest settings validation error", err)
+	}
+	return s.notificationRepository.SetDigestSettings(ctx, settings)
+}
+
+// SendDigests mails due digests, digest without events is skipped but its period is closed.
+// Digest which failed to send is postponed with growing backoff so it does not block others
+func (s notificationSvc) SendDigests(ctx c.Context) (int, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("SendDigests"))
+	defer span.End()
+
+	digests, err := s.notificationRepository.ClaimDueDigests(ctx, time.Now().UTC(), s.cfg.DigestLease, s.cfg.DigestBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, digest := range digests {
+		if digest.Total() > 0 {
+			err = s.sendDigest(ctx, digest)
+			if err != nil {
+				zapctx.Logger(ctx).Error("can't send notification digest",
+					zap.String("userID", digest.UserID.String()), zap.Int("attempts", digest.Attempts), zap.Error(err))
+
+				retryAt := time.Now().UTC().Add(domain.Backoff(digest.Attempts, s.cfg.DigestBaseBackoff, s.cfg.DigestMaxBackoff))
+				err = s.notificationRepository.RescheduleDigest(ctx, digest.UserID, retryAt)
+				if err != nil {
+					return sent, err
+				}
+				continue
+			}
+			sent++
+		}
+
+		err = s.notificationRepository.MarkDigestSent(ctx, digest.UserID, digest.Until)
+		if err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
 }
 
-func (s notificationSvc) MarkAsRead(ctx c.Context, notificationID uuid.UUID) error {
-	//TODO implement me
-	panic("implement me")
+func (s notificationSvc) sendDigest(ctx c.Context, digest domain.Digest) error {
+	mail, err := s.renderer.RenderDigest(digest)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "failed to render digest", err)
+	}
+	return s.mail.Send(ctx, mail)
 }
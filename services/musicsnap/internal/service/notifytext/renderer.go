@@ -0,0 +1,166 @@
+package notifytext
+
+import (
+	"embed"
+	"fmt"
+	d "music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+// defaultTemplate is used for types without own template and for payloads which can't be decoded
+const defaultTemplate = "default"
+
+var _ ports.NotificationRenderer = &Renderer{}
+
+// Renderer: Текст уведомлений и дайджестов из шаблонов на языке получателя
+type Renderer struct {
+	templates map[string]*template.Template
+}
+
+// New parses templates of every locale, each locale must define template of every notification type
+func New() (*Renderer, error) {
+	r := &Renderer{templates: make(map[string]*template.Template, len(d.Locales))}
+
+	required := append([]string{defaultTemplate, "digest_subject", "digest_body"}, d.NotificationTypes...)
+	for _, locale := range d.Locales {
+		t, err := template.New(locale).Funcs(funcs(locale)).ParseFS(templatesFS, "templates/"+locale+".tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("can't parse templates of locale %s: %w", locale, err)
+		}
+		for _, name := range required {
+			if t.Lookup(name) == nil {
+				return nil, fmt.Errorf("template %q of locale %s is not defined", name, locale)
+			}
+		}
+		r.templates[locale] = t
+	}
+	return r, nil
+}
+
+func (r *Renderer) localized(locale string) *template.Template {
+	if t, ok := r.templates[locale]; ok {
+		return t
+	}
+	return r.templates[d.DefaultLocale]
+}
+
+type notificationData struct {
+	// Sender is nickname of the latest sender, empty for system notifications
+	Sender string
+	Count  int
+	// Others is the number of collapsed events besides the latest one
+	Others int
+	// P is typed payload of notification type
+	P interface{}
+}
+
+// Render renders text of notification, payload which can't be decoded is rendered by default template
+func (r *Renderer) Render(locale string, notification d.Notification, sender string) (string, error) {
+	t := r.localized(locale)
+
+	name := notification.Type
+	payload, err := notification.Payload()
+	if err != nil || t.Lookup(name) == nil {
+		name = defaultTemplate
+	}
+
+	count := max(notification.Count, 1)
+	var text strings.Builder
+	err = t.ExecuteTemplate(&text, name, notificationData{
+		Sender: sender,
+		Count:  count,
+		Others: count - 1,
+		P:      payload,
+	})
+	if err != nil {
+		return "", fmt.Errorf("can't render notification %q in %s: %w", notification.Type, locale, err)
+	}
+	return text.String(), nil
+}
+
+type digestLine struct {
+	Type  string
+	Count int
+}
+
+// RenderDigest renders mail of digest in its locale, lines are ordered by notification type
+func (r *Renderer) RenderDigest(digest d.Digest) (d.Mail, error) {
+	t := r.localized(digest.Locale)
+
+	types := make([]string, 0, len(digest.Counts))
+	for notificationType, count := range digest.Counts {
+		if count > 0 {
+			types = append(types, notificationType)
+		}
+	}
+	sort.Strings(types)
+
+	lines := make([]digestLine, len(types))
+	for i, notificationType := range types {
+		lines[i] = digestLine{Type: notificationType, Count: digest.Counts[notificationType]}
+	}
+
+	data := map[string]interface{}{
+		"Nickname":  digest.Nickname,
+		"Frequency": digest.Frequency,
+		"Since":     digest.Since,
+		"Until":     digest.Until,
+		"Lines":     lines,
+		"Total":     digest.Total(),
+	}
+
+	var subject, body strings.Builder
+	if err := t.ExecuteTemplate(&subject, "digest_subject", data); err != nil {
+		return d.Mail{}, fmt.Errorf("can't render digest subject: %w", err)
+	}
+	if err := t.ExecuteTemplate(&body, "digest_body", data); err != nil {
+		return d.Mail{}, fmt.Errorf("can't render digest body: %w", err)
+	}
+
+	return d.Mail{To: digest.Email, Subject: subject.String(), Body: body.String()}, nil
+}
+
+// funcs: Функции шаблонов, зависящие от языка
+func funcs(locale string) template.FuncMap {
+	dateLayout, datetimeLayout := "Jan 2, 2006", "Jan 2 15:04 MST"
+	plural := pluralEN
+	if locale == d.LocaleRU {
+		dateLayout, datetimeLayout = "02.01.2006", "02.01 15:04 MST"
+		plural = pluralRU
+	}
+	return template.FuncMap{
+		"plural":   plural,
+		"date":     func(t time.Time) string { return t.Format(dateLayout) },
+		"datetime": func(t time.Time) string { return t.Format(datetimeLayout) },
+	}
+}
+
+// pluralEN chooses form by english rules, few is never used
+func pluralEN(n int, one, _, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// pluralRU chooses form for 1, 2-4 and 5+ by russian rules: 21 отзыв, 22 отзыва, 25 отзывов
+func pluralRU(n int, one, few, many string) string {
+	n %= 100
+	if n >= 11 && n <= 14 {
+		return many
+	}
+	switch n % 10 {
+	case 1:
+		return one
+	case 2, 3, 4:
+		return few
+	}
+	return many
+}
@@ -0,0 +1,129 @@
+package notifytext
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	d "music-snap/services/musicsnap/internal/domain"
+	"testing"
+	"time"
+)
+
+func reaction(count int) d.Notification {
+	return d.Notification{
+		UserIDReceiver: uuid.New(),
+		Type:           d.NotificationReviewReaction,
+		Message:        map[string]interface{}{"review_id": 1, "reaction": "like"},
+		Count:          count,
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		locale       string
+		notification d.Notification
+		sender       string
+		text         string
+	}{
+		{name: "Single", locale: d.LocaleEN, notification: reaction(1), sender: "anna",
+			text: "anna reacted with like to your review"},
+		{name: "Collapsed", locale: d.LocaleEN, notification: reaction(15), sender: "anna",
+			text: "anna and 14 others reacted with like to your review"},
+		{name: "CollapsedTwo", locale: d.LocaleEN, notification: reaction(2), sender: "anna",
+			text: "anna and 1 other reacted with like to your review"},
+		{name: "Russian", locale: d.LocaleRU, notification: reaction(1), sender: "anna",
+			text: "anna оценивает вашу рецензию: like"},
+		{name: "RussianCollapsed", locale: d.LocaleRU, notification: reaction(23), sender: "anna",
+			text: "anna и ещё 22 пользователя оценили вашу рецензию: like"},
+		{name: "UnknownLocale", locale: "de", notification: reaction(1), sender: "anna",
+			text: "anna reacted with like to your review"},
+		{name: "NoSender", locale: d.LocaleEN, sender: "",
+			notification: d.Notification{Type: d.NotificationMention,
+				Message: map[string]interface{}{"source_type": "comment", "review_id": 1}},
+			text: "Someone mentioned you in a comment"},
+		{name: "System", locale: d.LocaleRU,
+			notification: d.Notification{Type: d.NotificationReportResolved,
+				Message: map[string]interface{}{"report_id": 1, "status": "upheld"}},
+			text: "Модератор принял вашу жалобу"},
+		{name: "UnknownType", locale: d.LocaleEN, notification: d.Notification{Type: "unknown"},
+			text: "You have a new notification"},
+		{name: "InvalidPayload", locale: d.LocaleEN,
+			notification: d.Notification{Type: d.NotificationNewReview,
+				Message: map[string]interface{}{"review_id": "not a number"}},
+			text: "You have a new notification"},
+		{name: "NewerVersion", locale: d.LocaleEN,
+			notification: d.Notification{Type: d.NotificationNewReview, Version: d.NotificationPayloadVersion + 1},
+			text:         "You have a new notification"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			text, err := r.Render(tt.locale, tt.notification, tt.sender)
+			require.NoError(t, err)
+			assert.Equal(t, tt.text, text)
+		})
+	}
+}
+
+func TestRenderEveryType(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	require.NoError(t, err)
+
+	for _, locale := range d.Locales {
+		for _, notificationType := range d.NotificationTypes {
+			text, err := r.Render(locale, d.Notification{Type: notificationType}, "anna")
+			require.NoError(t, err, "%s %s", locale, notificationType)
+			assert.NotEmpty(t, text, "%s %s", locale, notificationType)
+		}
+	}
+}
+
+func TestRenderDigest(t *testing.T) {
+	t.Parallel()
+
+	r, err := New()
+	require.NoError(t, err)
+
+	digest := d.Digest{
+		Email:     "anna@example.com",
+		Nickname:  "anna",
+		Locale:    d.LocaleRU,
+		Frequency: d.DigestDaily,
+		Since:     time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+		Until:     time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC),
+		Counts:    map[string]int{d.NotificationReviewReaction: 21, d.NotificationMention: 0},
+	}
+
+	mail, err := r.RenderDigest(digest)
+	require.NoError(t, err)
+	assert.Equal(t, "anna@example.com", mail.To)
+	assert.Equal(t, "Ежедневная сводка MusicSnap", mail.Subject)
+	assert.Contains(t, mail.Body, "21 реакция на ваши рецензии")
+	assert.NotContains(t, mail.Body, "упоминани")
+
+	digest.Locale = d.LocaleEN
+	mail, err = r.RenderDigest(digest)
+	require.NoError(t, err)
+	assert.Equal(t, "Your daily MusicSnap digest", mail.Subject)
+	assert.Contains(t, mail.Body, "21 reactions to your reviews")
+}
+
+func TestPluralRU(t *testing.T) {
+	t.Parallel()
+
+	forms := map[int]string{1: "one", 2: "few", 4: "few", 5: "many", 11: "many", 12: "many",
+		21: "one", 22: "few", 25: "many", 111: "many", 101: "one", 0: "many"}
+	for n, form := range forms {
+		assert.Equal(t, form, pluralRU(n, "one", "few", "many"), n)
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	c "context"
+	"fmt"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/config"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+func (s outboxSvc) spanName(funcName string) string {
+	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
+}
+
+// NewOutboxSvc creates relay of outbox events to handlers by event type and to subscribers of every event,
+// zero settings are replaced with defaults
+func NewOutboxSvc(outboxRepository ports.OutboxRepository, cfg config.OutboxConfig,
+	handlers map[string]ports.DomainEventHandler, subscribers ...ports.DomainEventHandler) ports.OutboxService {
+	if cfg.Lease <= 0 {
+		cfg.Lease = time.Minute
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = 10 * time.Second
+	}
+	if cfg.MaxBackoff < cfg.BaseBackoff {
+		cfg.MaxBackoff = time.Hour
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	return outboxSvc{r: outboxRepository, cfg: cfg, handlers: handlers, subscribers: subscribers}
+}
+
+var _ ports.OutboxService = &outboxSvc{}
+
+type outboxSvc struct {
+	r           ports.OutboxRepository
+	cfg         config.OutboxConfig
+	handlers    map[string]ports.DomainEventHandler
+	subscribers []ports.DomainEventHandler
+}
+
+// Relay passes due events to their handlers. Event is published after its handler succeeds,
+// failed one is retried with exponential backoff, so handlers get every event at least once
+func (s outboxSvc) Relay(ctx c.Context) (int, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("Relay"))
+	defer span.End()
+
+	events, err := s.r.ClaimDue(ctx, time.Now().UTC(), s.cfg.Lease, s.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, event := range events {
+		err = s.handle(ctx, event)
+		if err != nil {
+			zapctx.Logger(ctx).Error("can't handle outbox event", zap.String("type", event.Type),
+				zap.String("eventID", event.ID.String()), zap.Int("attempts", event.Attempts), zap.Error(err))
+
+			retryAt := time.Now().UTC().Add(domain.Backoff(event.Attempts, s.cfg.BaseBackoff, s.cfg.MaxBackoff))
+			err = s.r.Reschedule(ctx, event.Seq, retryAt, err.Error())
+			if err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		err = s.r.MarkPublished(ctx, event.Seq, time.Now().UTC())
+		if err != nil {
+			return published, err
+		}
+		published++
+	}
+	return published, nil
+}
+
+// handle skips events without handler, e.g. written by newer version of service.
+// Handler of event type runs until it succeeds once, then only failed subscribers retry the event,
+// so outage of the bus does not repeat side effects of the handler
+func (s outboxSvc) handle(ctx c.Context, event domain.DomainEvent) error {
+	handler, ok := s.handlers[event.Type]
+	if ok && event.HandledAt == nil {
+		if err := handler.HandleEvent(ctx, event); err != nil {
+			return err
+		}
+		if err := s.r.MarkHandled(ctx, event.Seq, time.Now().UTC()); err != nil {
+			return err
+		}
+	} else if !ok && len(s.subscribers) == 0 {
+		zapctx.Logger(ctx).Warn("no handler of outbox event", zap.String("type", event.Type),
+			zap.String("eventID", event.ID.String()))
+	}
+
+	for _, subscriber := range s.subscribers {
+		if err := subscriber.HandleEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inTransaction runs fn in a new unit of work: repositories called with ctx of fn
+// write changes and outbox events in one transaction, which is committed if fn succeeds
+func inTransaction(ctx c.Context, txs ports.TransactionFactory, fn func(ctx c.Context) error) error {
+	tx := txs.NewTransaction()
+	err := tx.Start(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.End(ctx)
+
+	err = fn(ports.WithTransaction(ctx, tx))
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// addEvent writes domain event to outbox, in transaction of ctx when there is one
+func addEvent(ctx c.Context, outbox ports.OutboxRepository, eventType string, payload interface{}) error {
+	event, err := domain.NewDomainEvent(eventType, payload)
+	if err != nil {
+		return app.NewError(http.StatusInternalServerError, "unknown error", "failed to encode domain event", err)
+	}
+	return outbox.Add(ctx, event)
+}
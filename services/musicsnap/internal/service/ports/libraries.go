@@ -1,6 +1,8 @@
 package ports
 
 import (
+	c "context"
+	"github.com/google/uuid"
 	d "music-snap/services/musicsnap/internal/domain"
 )
 
@@ -9,3 +11,40 @@ type JwtSvc interface {
 	Generate(user d.User) (string, error)
 	Parse(token string) (d.Actor, error)
 }
+
+// ContentFilter: Проверка пользовательского текста перед сохранением
+type ContentFilter interface {
+	CheckReview(content string) d.FilterResult
+	CheckNickname(nickname string) d.FilterResult
+}
+
+// StreamBus: Межсерверная шина событий потока (Postgres LISTEN/NOTIFY)
+type StreamBus interface {
+	// Subscribe returns channel woken on new events of user and function to unsubscribe
+	Subscribe(userID uuid.UUID) (<-chan struct{}, func())
+	// Draining is closed when server stops and streams must be finished
+	Draining() <-chan struct{}
+}
+
+// MailSender: Отправка писем пользователям
+type MailSender interface {
+	Send(ctx c.Context, mail d.Mail) error
+}
+
+// NotificationRenderer: Текст уведомлений и дайджестов на языке получателя
+type NotificationRenderer interface {
+	// Render renders notification, sender is nickname of its latest sender
+	Render(locale string, notification d.Notification, sender string) (string, error)
+	RenderDigest(digest d.Digest) (d.Mail, error)
+}
+
+// WebhookSender: Отправка подписанной доставки на адрес вебхука
+type WebhookSender interface {
+	// Send makes one attempt, its failure is described by the returned attempt
+	Send(ctx c.Context, delivery d.WebhookDelivery) d.WebhookAttempt
+}
+
+// CalendarEncoder: Экспорт событий в формате iCalendar
+type CalendarEncoder interface {
+	Encode(calendar d.Calendar) []byte
+}
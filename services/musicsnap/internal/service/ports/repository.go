@@ -40,19 +40,117 @@ type UserRepository interface {
 	UpdateSub(ctx c.Context, sub d.Subscription) (d.Subscription, error)
 	DeleteSub(ctx c.Context, sub d.Subscription) (d.Subscription, error)
 	ListSubscriptions(ctx c.Context, subscriberID uuid.UUID, followedID uuid.UUID, pag d.IDPagination) ([]d.Subscription, d.IDPagination, error)
+	// Block records block and removes subscriptions between users in both directions
+	Block(ctx c.Context, blockerID uuid.UUID, blockedID uuid.UUID) error
 }
 
 // ReviewRepository: Управление рецензиями
 type ReviewRepository interface {
 	Create(ctx c.Context, review d.Review) (d.Review, error)
-	Update(ctx c.Context, review d.Review) (d.Review, error)
+	Update(ctx c.Context, review d.Review, editorID uuid.UUID) (d.Review, error)
 	GetByID(ctx c.Context, id int) (d.Review, error)
 	GetList(ctx c.Context, filter d.ReviewFilter, pag d.IDPagination) ([]d.Review, d.IDPagination, error)
 	Delete(ctx c.Context, id int) (d.Review, error)
+
+	ListRevisions(ctx c.Context, reviewID int) ([]d.ReviewRevision, error)
+	GetRevision(ctx c.Context, reviewID, revision int) (d.ReviewRevision, error)
+
+	PublishScheduled(ctx c.Context, now time.Time, limit int) ([]d.Review, error)
+	// ClaimPublications hides due publications from other servers for lease and counts the attempt.
+	// With approvedOnly publications of not approved reviews wait for moderation
+	ClaimPublications(ctx c.Context, now time.Time, lease time.Duration, limit int, approvedOnly bool) ([]d.ReviewPublication, error)
+	MarkPublicationProcessed(ctx c.Context, reviewID int, processedAt time.Time) error
+	ReschedulePublication(ctx c.Context, reviewID int, retryAt time.Time, lastError string) error
+	// MarkPublicationHandled records that handler of publication ran, returns false if it already did.
+	// Called in unit of work of the handler, so the mark is kept only together with its changes
+	MarkPublicationHandled(ctx c.Context, reviewID int, handler string) (bool, error)
 	//CreateReaction(ctx c.Context, reaction d.Reaction) error
 	//GetComments(ctx c.Context, threadID uuid.UUID) ([]d.Comment, error)
 }
 
+// CatalogRepository: Управление музыкальным каталогом
+type CatalogRepository interface {
+	CreateArtist(ctx c.Context, artist d.Artist) (d.Artist, error)
+	GetArtist(ctx c.Context, id uuid.UUID) (d.Artist, error)
+
+	CreatePiece(ctx c.Context, piece d.Piece) (d.Piece, error)
+	UpdatePiece(ctx c.Context, piece d.Piece) (d.Piece, error)
+	GetPiece(ctx c.Context, id uuid.UUID) (d.Piece, error)
+	GetPieceByAlias(ctx c.Context, source, externalID string) (d.Piece, error)
+	ListPieces(ctx c.Context, filter d.PieceFilter, pag d.UUIDPagination) ([]d.Piece, d.UUIDPagination, error)
+
+	AddAlias(ctx c.Context, alias d.PieceAlias) (d.PieceAlias, error)
+}
+
+// RatingRepository: Управление быстрыми оценками
+type RatingRepository interface {
+	Upsert(ctx c.Context, rating d.Rating) (d.Rating, error)
+	Get(ctx c.Context, userID uuid.UUID, pieceID string) (d.Rating, error)
+	Delete(ctx c.Context, userID uuid.UUID, pieceID string) (d.Rating, error)
+	ListByUser(ctx c.Context, userID uuid.UUID, pag d.UUIDPagination) ([]d.Rating, d.UUIDPagination, error)
+}
+
+// ModerationRepository: Очередь модерации рецензий и журнал модераторов.
+// staleBefore - claims older than it are expired and can be taken by other moderator
+type ModerationRepository interface {
+	// Enqueue puts review into queue, reported reason replaces the previous one
+	Enqueue(ctx c.Context, reviewID int, reason d.QueueReason) error
+	ListQueue(ctx c.Context, filter d.ModerationQueueFilter, pag d.IDPagination) ([]d.ModerationQueueItem, d.IDPagination, error)
+	GetQueueItem(ctx c.Context, reviewID int) (d.ModerationQueueItem, error)
+
+	ClaimNext(ctx c.Context, moderatorID uuid.UUID, staleBefore time.Time, limit int) ([]d.ModerationQueueItem, error)
+	Claim(ctx c.Context, reviewID int, moderatorID uuid.UUID, staleBefore time.Time) (d.ModerationQueueItem, error)
+	// Release drops the claim, override allows dropping claim of other moderator
+	Release(ctx c.Context, reviewID int, moderatorID uuid.UUID, override bool) error
+	// Decide sets review status, removes it from queue and writes decision to the journal in one transaction
+	Decide(ctx c.Context, decision d.ModerationAction, staleBefore time.Time, override bool) (d.Review, error)
+
+	ListActions(ctx c.Context, filter d.ModerationActionFilter, pag d.IDPagination) ([]d.ModerationAction, d.IDPagination, error)
+}
+
+// ReportRepository: Жалобы пользователей и скрытие объектов по порогу жалоб
+type ReportRepository interface {
+	// Create files report or updates reporter's open report on the same target.
+	// Target is hidden when its open reports reach hideThreshold, reported review is queued for moderation
+	Create(ctx c.Context, report d.Report, hideThreshold int) (d.Report, d.ReportTarget, error)
+	List(ctx c.Context, filter d.ReportFilter, pag d.IDPagination) ([]d.Report, d.IDPagination, error)
+	GetTarget(ctx c.Context, targetType d.ReportTargetType, targetID string) (d.ReportTarget, error)
+	// HiddenTargets returns hidden ones among targetIDs
+	HiddenTargets(ctx c.Context, targetType d.ReportTargetType, targetIDs []string) ([]string, error)
+	// Resolve closes open reports of the target and notifies reporters, dismissal unhides the target.
+	// Returns amount of closed reports
+	Resolve(ctx c.Context, targetType d.ReportTargetType, targetID string, status d.ReportStatus, moderatorID uuid.UUID) (int, error)
+}
+
+// CommentRepository: Комментарии к рецензиям, тред рецензии создаётся с первым комментарием
+type CommentRepository interface {
+	// Create adds comment to the review thread at parent's depth + 1 and notifies the author
+	// of parent comment or of the review about it
+	Create(ctx c.Context, comment d.Comment) (d.Comment, error)
+	GetByID(ctx c.Context, id uuid.UUID) (d.Comment, error)
+	UpdateText(ctx c.Context, id uuid.UUID, text string) (d.Comment, error)
+	// Delete leaves tombstone without text, replies are kept
+	Delete(ctx c.Context, id uuid.UUID) (d.Comment, error)
+	// List returns comments of review in depth-first order, nil parentID lists the whole thread,
+	// otherwise replies of parent at any depth
+	List(ctx c.Context, reviewID int, parentID *uuid.UUID, pag d.UUIDPagination) ([]d.Comment, d.UUIDPagination, error)
+}
+
+// TagRepository: Индекс упоминаний и хэштегов в рецензиях и комментариях
+type TagRepository interface {
+	// Index replaces mentions and hashtags of the source with given ones. With notify mentioned users
+	// not notified yet get notification, unless there is a block between them and the author
+	Index(ctx c.Context, source d.TextSource, nicknames []string, tags []string, notify bool) error
+	// Trending counts uses of hashtags added since the time in visible reviews and their comments
+	Trending(ctx c.Context, since time.Time, approvedOnly bool, limit int) ([]d.TagTrend, error)
+}
+
+// StatsRepository: Агрегаты оценок и реакций
+type StatsRepository interface {
+	GetPieceStats(ctx c.Context, pieceID string) (d.TrackStats, error)
+	RebuildPieceStats(ctx c.Context) (int, error)
+}
+
 // ReactionRepository: Управление реакциями
 type ReactionRepository interface {
 	Create(ctx c.Context, reaction d.Reaction) (d.Reaction, error)
@@ -61,6 +159,34 @@ type ReactionRepository interface {
 	GetFromReview(ctx c.Context, reviewID int) ([]d.Reaction, error)
 	Update(ctx c.Context, reaction d.Reaction) (d.Reaction, error)
 	Delete(ctx c.Context, id int) error
+	// Toggle creates reaction, changes type of existing one or removes it when type is the same
+	Toggle(ctx c.Context, reaction d.Reaction) (d.Reaction, bool, error)
+	List(ctx c.Context, filter d.ReactionFilter, pag d.IDPagination) ([]d.Reaction, d.IDPagination, error)
+	// CountByReviews returns counts of every review from list, reviews without reactions are absent
+	CountByReviews(ctx c.Context, reviewIDs []int) (map[int]d.ReactionCounts, error)
+}
+
+// BatchLoader: Загрузка связанных данных списка по одному запросу на каждый вид данных.
+// Загрузчик живёт в пределах одного запроса и не потокобезопасен
+type BatchLoader interface {
+	// Want* collect ids, already loaded ids are skipped
+	WantProfiles(userIDs ...uuid.UUID)
+	WantRoles(userIDs ...uuid.UUID)
+	// WantReactions loads reaction counts of reviews and actor's own reactions to them
+	WantReactions(reviewIDs ...int)
+	Load(ctx c.Context) error
+
+	Profile(userID uuid.UUID) (d.Profile, bool)
+	Roles(userID uuid.UUID) d.Roles
+	// ReactionCounts is empty for review without reactions
+	ReactionCounts(reviewID int) d.ReactionCounts
+	// ActorReaction is nil when actor has no reaction to review
+	ActorReaction(reviewID int) *d.Reaction
+}
+
+// BatchLoaderFactory: Создание загрузчика на время запроса актора
+type BatchLoaderFactory interface {
+	NewBatchLoader(actorID uuid.UUID) BatchLoader
 }
 
 // PhotoRepository: Управление фотографиями событий
@@ -81,12 +207,38 @@ type ProfileCache interface {
 
 // EventRepository: Управление событиями
 type EventRepository interface {
-	Create(ctx c.Context, event d.Event) error
-	GetByID(ctx c.Context, id int) (d.Event, error)
-	Update(ctx c.Context, event d.Event) error
-	Delete(ctx c.Context, id int) error
-	ListByAuthor(ctx c.Context, authorID uuid.UUID) ([]d.Event, error)
-	AddPhoto(ctx c.Context, photo d.Photo) error
+	// Create saves event with its authors and photos
+	Create(ctx c.Context, event d.Event) (d.Event, error)
+	GetByID(ctx c.Context, id uuid.UUID) (d.Event, error)
+	// Update changes event and replaces its photos, authors stay, sequence of event grows
+	Update(ctx c.Context, event d.Event) (d.Event, error)
+	// Cancel marks event cancelled and increments its sequence, returns conflict for cancelled event
+	Cancel(ctx c.Context, id uuid.UUID) (d.Event, error)
+	List(ctx c.Context, filter d.EventFilter) ([]d.Event, error)
+	// ListFeed lists events since given time the user participates in, authors or follows authors of
+	ListFeed(ctx c.Context, userID uuid.UUID, since time.Time, limit int) ([]d.Event, error)
+	AddParticipant(ctx c.Context, eventID uuid.UUID, userID uuid.UUID) error
+	RemoveParticipant(ctx c.Context, eventID uuid.UUID, userID uuid.UUID) error
+}
+
+// EventReminderRepository: Очередь напоминаний участникам о начале событий
+type EventReminderRepository interface {
+	// Schedule creates reminders of participants of upcoming event at offsets before its start and
+	// recomputes them when event is moved, returns the number of created and recomputed reminders.
+	// In context of unit of work reminders are written in its transaction
+	Schedule(ctx c.Context, eventID uuid.UUID, offsets []time.Duration, now time.Time) (int, error)
+	// ClaimDue marks sent reminders due in (notBefore, now] of upcoming events, other servers skip them,
+	// in context of unit of work reminders stay unsent if it is rolled back
+	ClaimDue(ctx c.Context, now time.Time, notBefore time.Time, limit int) ([]d.EventReminder, error)
+}
+
+// CalendarFeedRepository: Управление токенами личных iCal-лент
+type CalendarFeedRepository interface {
+	Get(ctx c.Context, userID uuid.UUID) (d.CalendarFeed, error)
+	GetByToken(ctx c.Context, token string) (d.CalendarFeed, error)
+	// Save creates feed of user or replaces its token
+	Save(ctx c.Context, feed d.CalendarFeed) (d.CalendarFeed, error)
+	Delete(ctx c.Context, userID uuid.UUID) error
 }
 
 // PlaylistRepository: Управление плейлистами
@@ -99,9 +251,78 @@ type PlaylistRepository interface {
 
 // NotificationRepository: Управление уведомлениями
 type NotificationRepository interface {
-	Create(ctx c.Context, notification d.Notification) error
-	MarkAsRead(ctx c.Context, id uuid.UUID) error
-	ListByUser(ctx c.Context, userID uuid.UUID, unreadOnly bool) ([]d.Notification, error)
+	Create(ctx c.Context, notification d.Notification) (d.Notification, error)
+	// CreateMany inserts notifications of fan-out in one transaction, it joins unit of work from ctx
+	CreateMany(ctx c.Context, notifications []d.Notification) (int, error)
+	// MarkAsRead returns not found for notification of other user
+	MarkAsRead(ctx c.Context, userID uuid.UUID, id uuid.UUID) error
+	MarkAllAsRead(ctx c.Context, userID uuid.UUID) (int, error)
+	// ListByUser lists notifications from newest to oldest
+	ListByUser(ctx c.Context, userID uuid.UUID, unreadOnly bool, pag d.UUIDPagination) ([]d.Notification, d.UUIDPagination, error)
+	CountUnread(ctx c.Context, userID uuid.UUID) (int, error)
+	// NotifySubscribers sends notification to followers of sender who enabled alert of its type
+	NotifySubscribers(ctx c.Context, notification d.Notification) (int, error)
+
+	GetPreferences(ctx c.Context, userID uuid.UUID) (d.NotificationPreferences, error)
+	SetPreferences(ctx c.Context, userID uuid.UUID, prefs d.NotificationPreferences) error
+
+	// Aggregate collapses notification with GroupKey into unread one of the same group changed after since
+	Aggregate(ctx c.Context, notification d.Notification, since time.Time) (d.Notification, error)
+
+	GetDigestSettings(ctx c.Context, userID uuid.UUID) (d.DigestSettings, error)
+	SetDigestSettings(ctx c.Context, settings d.DigestSettings) (d.DigestSettings, error)
+	// ClaimDueDigests claims at most limit due digests for lease so other replicas skip them
+	ClaimDueDigests(ctx c.Context, now time.Time, lease time.Duration, limit int) ([]d.Digest, error)
+	MarkDigestSent(ctx c.Context, userID uuid.UUID, sentAt time.Time) error
+	RescheduleDigest(ctx c.Context, userID uuid.UUID, retryAt time.Time) error
+
+	// DeleteOutdated deletes at most limit outdated notifications, returns deleted read and unread ones
+	DeleteOutdated(ctx c.Context, readBefore, unreadBefore time.Time, limit int) (int, int, error)
+}
+
+// WebhookRepository: Управление вебхуками и их доставками
+type WebhookRepository interface {
+	Create(ctx c.Context, webhook d.Webhook) (d.Webhook, error)
+	GetByID(ctx c.Context, id int) (d.Webhook, error)
+	// List lists webhooks of user, or webhooks of the app when userID is nil
+	List(ctx c.Context, userID *uuid.UUID) ([]d.Webhook, error)
+	Update(ctx c.Context, webhook d.Webhook) (d.Webhook, error)
+	Delete(ctx c.Context, id int) error
+
+	// Enqueue creates deliveries of event for active webhooks of its subject and of the app subscribed to its type
+	Enqueue(ctx c.Context, event d.WebhookEvent) (int, error)
+	// ClaimDue takes pending deliveries due at now and postpones them by lease, so other servers skip them
+	ClaimDue(ctx c.Context, now time.Time, lease time.Duration, limit int) ([]d.WebhookDelivery, error)
+	// RecordAttempt logs attempt and finishes delivery, unless it failed and retryAt is set
+	RecordAttempt(ctx c.Context, attempt d.WebhookAttempt, retryAt *time.Time) (d.WebhookDelivery, error)
+	ListDeliveries(ctx c.Context, filter d.WebhookDeliveryFilter, pag d.IDPagination) ([]d.WebhookDelivery, d.IDPagination, error)
+	// GetDelivery returns delivery with log of its attempts
+	GetDelivery(ctx c.Context, id int64) (d.WebhookDelivery, error)
+	// Redeliver schedules delivery to be sent again now with a fresh number of attempts
+	Redeliver(ctx c.Context, id int64) (d.WebhookDelivery, error)
+}
+
+// OutboxRepository: Очередь доменных событий для публикации хотя бы один раз
+type OutboxRepository interface {
+	// Add writes event, in context of unit of work it is written in its transaction
+	Add(ctx c.Context, event d.DomainEvent) error
+	// ClaimDue takes unpublished events available at now in order of Seq and hides them from other relays for lease
+	ClaimDue(ctx c.Context, now time.Time, lease time.Duration, limit int) ([]d.DomainEvent, error)
+	MarkPublished(ctx c.Context, seq int64, publishedAt time.Time) error
+	// MarkHandled records success of handler of event type, it is not run again on retries of subscribers
+	MarkHandled(ctx c.Context, seq int64, handledAt time.Time) error
+	// Reschedule keeps failed event unpublished until retryAt
+	Reschedule(ctx c.Context, seq int64, retryAt time.Time, reason string) error
+}
+
+// StreamRepository: Управление событиями real-time потока
+type StreamRepository interface {
+	// AddFeedEvents adds event of published review to streams of author's followers
+	AddFeedEvents(ctx c.Context, review d.Review) (int, error)
+	// ListAfter lists events of user with position greater than afterID in order of commits
+	ListAfter(ctx c.Context, userID uuid.UUID, afterID int64, limit int) ([]d.StreamEvent, error)
+	// DeleteOutdated deletes at most limit events created before, returns the number of deleted ones
+	DeleteOutdated(ctx c.Context, before time.Time, limit int) (int, error)
 }
 
 // DescriptionRepository: Управление описаниями
@@ -131,15 +352,6 @@ type DescriptionRepository interface {
 //	Update(ctx c.Context, item d.PlaylistItem) error
 //	Delete(ctx c.Context, id uuid.UUID) error
 //}
-//// CommentRepository: Управление комментариями
-//type CommentRepository interface {
-//	Create(ctx c.Context, comment d.Comment) error
-//	GetByID(ctx c.Context, id uuid.UUID) (d.Comment, error)
-//	GetByThread(ctx c.Context, threadID uuid.UUID) ([]d.Comment, error)
-//	Update(ctx c.Context, comment d.Comment) error
-//	Delete(ctx c.Context, id uuid.UUID) error
-//}
-
 //// ThreadRepository: Управление тредами
 //type ThreadRepository interface {
 //	Create(ctx c.Context, thread d.Thread) error
@@ -147,13 +359,3 @@ type DescriptionRepository interface {
 //	Update(ctx c.Context, thread d.Thread) error
 //	Delete(ctx c.Context, id uuid.UUID) error
 //}
-
-//// RatingRepository: Управление оценками
-//type RatingRepository interface {
-//	Create(ctx c.Context, rating d.Rating) error
-//	GetByID(ctx c.Context, id uuid.UUID) (d.Rating, error)
-//	GetByUser(ctx c.Context, userID uuid.UUID) ([]d.Rating, error)
-//	GetByPiece(ctx c.Context, pieceID uuid.UUID) ([]d.Rating, error)
-//	Update(ctx c.Context, rating d.Rating) error
-//	Delete(ctx c.Context, id uuid.UUID) error
-//}
@@ -16,8 +16,81 @@ type NotificationSvc interface {
 	NotifyUsers(ctx c.Context, notification d.Notification, userIDs []uuid.UUID) error
 
 	// endpoint with pagination by UUID
-	GetNotifications(ctx c.Context, actor d.Actor, pagination d.UUIDPagination) ([]d.Notification, d.UUIDPagination, error)
-	MarkAsRead(ctx c.Context, notificationID uuid.UUID) error
+	GetNotifications(ctx c.Context, actor d.Actor, unreadOnly bool, pagination d.UUIDPagination) ([]d.Notification, d.UUIDPagination, error)
+	CountUnread(ctx c.Context, actor d.Actor) (int, error)
+	MarkAsRead(ctx c.Context, actor d.Actor, notificationID uuid.UUID) error
+	MarkAllAsRead(ctx c.Context, actor d.Actor) (int, error)
+
+	// No api endpoint, notification type is the alert of subscription
+	NotifySubscribers(ctx c.Context, notification d.Notification) error
+	// No api endpoint, new review alert of subscriptions
+	ReviewPublishedHandler
+
+	// GetPreferences returns full matrix of types and channels
+	GetPreferences(ctx c.Context, actor d.Actor) (d.NotificationPreferences, error)
+	UpdatePreferences(ctx c.Context, actor d.Actor, prefs d.NotificationPreferences) (d.NotificationPreferences, error)
+
+	GetDigestSettings(ctx c.Context, actor d.Actor) (d.DigestSettings, error)
+	UpdateDigestSettings(ctx c.Context, actor d.Actor, settings d.DigestSettings) (d.DigestSettings, error)
+	// No api endpoint, returns the number of sent digests
+	SendDigests(ctx c.Context) (int, error)
+	// No api endpoint, sets text of notifications in locale of receiver
+	RenderNotifications(ctx c.Context, receiverID uuid.UUID, notifications []d.Notification) []d.Notification
+}
+
+// StreamService: Real-time доставка уведомлений и ленты
+type StreamService interface {
+	// Stream sends events of actor after lastEventID to sink until ctx is done or stream is drained
+	Stream(ctx c.Context, actor d.Actor, lastEventID int64, sink StreamSink) error
+
+	// No api endpoint
+	ReviewPublishedHandler
+}
+
+// StreamSink: Транспорт потока событий одного клиента (SSE, WebSocket)
+type StreamSink interface {
+	Send(event d.StreamEvent) error
+	Heartbeat() error
+}
+
+// WebhookService: Исходящие вебхуки для внешних интеграций
+type WebhookService interface {
+	// CreateWebhook returns webhook with generated secret, webhook without owner is the app one and needs admin
+	CreateWebhook(ctx c.Context, actor d.Actor, webhook d.Webhook) (d.Webhook, error)
+	// ListWebhooks lists webhooks of actor, webhooks of the app are listed too for admin
+	ListWebhooks(ctx c.Context, actor d.Actor) ([]d.Webhook, error)
+	GetWebhook(ctx c.Context, actor d.Actor, webhookID int) (d.Webhook, error)
+	UpdateWebhook(ctx c.Context, actor d.Actor, webhook d.Webhook) (d.Webhook, error)
+	DeleteWebhook(ctx c.Context, actor d.Actor, webhookID int) error
+
+	// endpoint with pagination by ID, deliveries with status dead form the dead-letter list
+	ListDeliveries(ctx c.Context, actor d.Actor, filter d.WebhookDeliveryFilter, pagination d.IDPagination) ([]d.WebhookDelivery, d.IDPagination, error)
+	// GetDelivery returns delivery with log of attempts
+	GetDelivery(ctx c.Context, actor d.Actor, webhookID int, deliveryID int64) (d.WebhookDelivery, error)
+	Redeliver(ctx c.Context, actor d.Actor, webhookID int, deliveryID int64) (d.WebhookDelivery, error)
+
+	// No api endpoint
+	WebhookPublisher
+	// No api endpoint, review.published event
+	ReviewPublishedHandler
+	// No api endpoint, returns the number of delivered webhooks
+	DeliverDue(ctx c.Context) (int, error)
+}
+
+// WebhookPublisher: Постановка события в очередь доставки вебхуков
+type WebhookPublisher interface {
+	Publish(ctx c.Context, event d.WebhookEvent) error
+}
+
+// OutboxService: Публикация доменных событий outbox
+type OutboxService interface {
+	// No api endpoint, returns the number of published events
+	Relay(ctx c.Context) (int, error)
+}
+
+// DomainEventHandler: Обработка события outbox, одно событие может быть обработано повторно
+type DomainEventHandler interface {
+	HandleEvent(ctx c.Context, event d.DomainEvent) error
 }
 
 // AuthSvc: Бизнес-логика аутентификации
@@ -60,6 +133,9 @@ type SubscriptionSvc interface {
 
 	GetSubscriptions(ctx c.Context, actor d.Actor, subscriberID uuid.UUID, pag d.IDPagination) ([]d.Subscription, d.IDPagination, error)
 	GetSubscribers(ctx c.Context, actor d.Actor, followedID uuid.UUID, pagination d.IDPagination) ([]d.Subscription, d.IDPagination, error)
+
+	// No api endpoint, subscribed event
+	DomainEventHandler
 }
 
 // ReviewService: Бизнес-логика рецензий
@@ -70,17 +146,52 @@ type ReviewService interface {
 	DeleteReview(ctx c.Context, actor d.Actor, reviewID int) error
 	//ReviewsOfSubscriptions(ctx c.Context, actor d.Actor, filter d.ReviewFilter, pagination d.IDPagination) ([]d.Review, d.IDPagination, error)
 	ListReviews(ctx c.Context, actor d.Actor, filter d.ReviewFilter, pagination d.IDPagination) ([]d.Review, d.IDPagination, error)
+
+	ListRevisions(ctx c.Context, actor d.Actor, reviewID int) ([]d.ReviewRevision, error)
+	// DiffRevisions compares two revisions, by default the latest one with its predecessor
+	DiffRevisions(ctx c.Context, actor d.Actor, reviewID int, from, to *int) (d.ReviewRevisionDiff, error)
+
+	// PublishScheduled For publisher daemon, no api calls
+	PublishScheduled(ctx c.Context) (int, error)
+
+	// No api endpoint, review created event
+	DomainEventHandler
+}
+
+// ReviewPublishedHandler: Побочные эффекты первой публикации рецензии (лента, уведомления)
+type ReviewPublishedHandler interface {
+	ReviewPublished(ctx c.Context, review d.Review) error
+}
+
+// CatalogService: Бизнес-логика музыкального каталога
+type CatalogService interface {
+	CreateArtist(ctx c.Context, actor d.Actor, artist d.Artist) (d.Artist, error)
+	GetArtist(ctx c.Context, actor d.Actor, artistID uuid.UUID) (d.Artist, error)
+
+	CreatePiece(ctx c.Context, actor d.Actor, piece d.Piece) (d.Piece, error)
+	UpdatePiece(ctx c.Context, actor d.Actor, piece d.Piece) (d.Piece, error)
+	// pieceRef is canonical UUID or alias "source:external_id"
+	GetPiece(ctx c.Context, actor d.Actor, pieceRef string) (d.Piece, error)
+	// api endpoint with pagination by UUID for catalog search
+	ListPieces(ctx c.Context, actor d.Actor, filter d.PieceFilter, pagination d.UUIDPagination) ([]d.Piece, d.UUIDPagination, error)
+
+	AddAlias(ctx c.Context, actor d.Actor, alias d.PieceAlias) (d.PieceAlias, error)
 }
 
 type ReactionService interface {
-	CreateReaction(ctx c.Context, actor d.Actor, reaction d.Reaction) (d.Reaction, error)
+	// ToggleReaction adds reaction, changes its type or removes it when the same type is posted again,
+	// returned flag reports removal
+	ToggleReaction(ctx c.Context, actor d.Actor, reaction d.Reaction) (d.Reaction, bool, error)
 	UpdateReaction(ctx c.Context, actor d.Actor, reaction d.Reaction) (d.Reaction, error)
 
 	GetByReview(ctx c.Context, actor d.Actor, reviewID int) (d.Reaction, error)
 	RemoveReaction(ctx c.Context, actor d.Actor, reactionID int) error
-	ListReactions(ctx c.Context, reviewID int, pagination d.IDPagination) ([]d.Reaction, d.IDPagination, error)
+	ListReactions(ctx c.Context, actor d.Actor, filter d.ReactionFilter, pagination d.IDPagination) ([]d.Reaction, d.IDPagination, error)
 
-	CountReactions(ctx c.Context, reviewID uuid.UUID) (d.ReactionCount, error)
+	CountReactions(ctx c.Context, actor d.Actor, reviewID int) (d.ReactionCounts, error)
+
+	// No api endpoint, reaction added event
+	DomainEventHandler
 }
 
 // PhotoService: Бизнес-логика фотографий событий
@@ -90,6 +201,60 @@ type PhotoService interface {
 	GetPhoto(ctx c.Context, actor d.Actor, photo d.PhotoParams) (d.Photo, []byte, error)
 }
 
+// RatingService: Бизнес-логика быстрых оценок
+type RatingService interface {
+	SetRating(ctx c.Context, actor d.Actor, rating d.Rating) (d.Rating, error)
+	GetRating(ctx c.Context, actor d.Actor, userID uuid.UUID, pieceRef string) (d.Rating, error)
+	DeleteRating(ctx c.Context, actor d.Actor, userID uuid.UUID, pieceRef string) (d.Rating, error)
+	ListUserRatings(ctx c.Context, actor d.Actor, userID uuid.UUID, pag d.UUIDPagination) ([]d.Rating, d.UUIDPagination, error)
+}
+
+// ModerationService: Модерация рецензий, доступна модераторам и администраторам
+type ModerationService interface {
+	ListQueue(ctx c.Context, actor d.Actor, filter d.ModerationQueueFilter, pag d.IDPagination) ([]d.ModerationQueueItem, d.IDPagination, error)
+	// ClaimNext takes up to limit oldest unclaimed reviews
+	ClaimNext(ctx c.Context, actor d.Actor, limit int) ([]d.ModerationQueueItem, error)
+	Claim(ctx c.Context, actor d.Actor, reviewID int) (d.ModerationQueueItem, error)
+	Release(ctx c.Context, actor d.Actor, reviewID int) error
+
+	Approve(ctx c.Context, actor d.Actor, reviewID int, comment string) (d.Review, error)
+	Reject(ctx c.Context, actor d.Actor, reviewID int, reason d.ModerationReasonCode, comment string) (d.Review, error)
+
+	ListActions(ctx c.Context, actor d.Actor, filter d.ModerationActionFilter, pag d.IDPagination) ([]d.ModerationAction, d.IDPagination, error)
+}
+
+// ReportService: Жалобы пользователей на рецензии, профили, заметки и фото событий
+type ReportService interface {
+	CreateReport(ctx c.Context, actor d.Actor, report d.Report) (d.Report, error)
+	// ListReports returns reports of actor, moderators can see all reports
+	ListReports(ctx c.Context, actor d.Actor, filter d.ReportFilter, pag d.IDPagination) ([]d.Report, d.IDPagination, error)
+	GetReportTarget(ctx c.Context, actor d.Actor, targetType d.ReportTargetType, targetID string) (d.ReportTarget, error)
+	ResolveReports(ctx c.Context, actor d.Actor, targetType d.ReportTargetType, targetID string, status d.ReportStatus) (int, error)
+}
+
+// CommentService: Комментарии к рецензиям с ответами ограниченной глубины
+type CommentService interface {
+	CreateComment(ctx c.Context, actor d.Actor, comment d.Comment) (d.Comment, error)
+	GetComment(ctx c.Context, actor d.Actor, commentID uuid.UUID) (d.Comment, error)
+	// UpdateComment changes text of actor's comment
+	UpdateComment(ctx c.Context, actor d.Actor, commentID uuid.UUID, text string) (d.Comment, error)
+	// DeleteComment leaves tombstone, author and moderators can delete
+	DeleteComment(ctx c.Context, actor d.Actor, commentID uuid.UUID) error
+	ListComments(ctx c.Context, actor d.Actor, reviewID int, parentID *uuid.UUID, pag d.UUIDPagination) ([]d.Comment, d.UUIDPagination, error)
+}
+
+// TagService: Упоминания @nickname и хэштеги #tag в рецензиях и комментариях
+type TagService interface {
+	// IndexReview reparses review text, mentioned users are notified once review is public
+	IndexReview(ctx c.Context, review d.Review) error
+	// IndexComment reparses comment text, deleted comment leaves indexes
+	IndexComment(ctx c.Context, comment d.Comment) error
+	// TrendingTags returns most used hashtags of the trending window
+	TrendingTags(ctx c.Context, actor d.Actor, limit int) ([]d.TagTrend, error)
+
+	ReviewPublishedHandler
+}
+
 // StatsService: Бизнес-логика статистики
 type StatsService interface {
 	GetProfileStats(ctx c.Context, actor d.Actor, userID uuid.UUID) (d.ProfileStats, error)
@@ -106,6 +271,26 @@ type EventService interface {
 	UpdateEvent(ctx c.Context, actor d.Actor, event d.Event) (d.Event, error)
 	ListEvents(ctx c.Context, actor d.Actor, filters d.EventFilter) ([]d.Event, error)
 	Participate(ctx c.Context, actor d.Actor, eventID uuid.UUID) (d.Event, error)
+	CancelParticipation(ctx c.Context, actor d.Actor, eventID uuid.UUID) (d.Event, error)
+	// CancelEvent keeps event with the cancelled status, nobody can join or change it then
+	CancelEvent(ctx c.Context, actor d.Actor, eventID uuid.UUID) (d.Event, error)
+
+	// SendReminders For reminder daemon, no api calls. Returns the number of sent reminders
+	SendReminders(ctx c.Context) (int, error)
+
+	// No api endpoint, announcement to followers and event.joined webhooks
+	DomainEventHandler
+}
+
+// CalendarService: Экспорт событий в календари и личные iCal-ленты пользователей
+type CalendarService interface {
+	ExportEvent(ctx c.Context, eventID uuid.UUID) ([]byte, error)
+	GetFeed(ctx c.Context, actor d.Actor) (d.CalendarFeed, error)
+	// RotateFeed creates feed of actor or replaces its token, the old address stops working
+	RotateFeed(ctx c.Context, actor d.Actor) (d.CalendarFeed, error)
+	DeleteFeed(ctx c.Context, actor d.Actor) error
+	// RenderFeed encodes events of feed owner, the token is the only authorization of feed
+	RenderFeed(ctx c.Context, token string) ([]byte, error)
 }
 
 // NoteSvc: Бизнес-логика описаний
@@ -150,15 +335,6 @@ type PlaylistService interface {
 
 // TODO DEPRECATED
 
-//// CommentService: Бизнес-логика комментариев
-//type CommentService interface {
-//	CreateComment(ctx c.Context, actor d.Actor, comment d.Comment) error
-//	GetComment(ctx c.Context, commentID uuid.UUID) (d.Comment, error)
-//	UpdateComment(ctx c.Context, actor d.Actor, comment d.Comment) error
-//	DeleteComment(ctx c.Context, actor d.Actor, commentID uuid.UUID) error
-//	ListComments(ctx c.Context, threadID uuid.UUID) ([]d.Comment, error)
-//}
-//
 //// PlaylistItemService: Бизнес-логика элементов плейлиста
 //type PlaylistItemService interface {
 //	AddToPlaylist(ctx c.Context, actor d.Actor, item d.PlaylistItem) error
@@ -166,13 +342,3 @@ type PlaylistService interface {
 //	GetPlaylistItems(ctx c.Context, playlistID uuid.UUID) ([]d.PlaylistItem, error)
 //	UpdatePlaylistItem(ctx c.Context, actor d.Actor, item d.PlaylistItem) error
 //}
-
-//// RatingService: Бизнес-логика оценок
-//type RatingService interface {
-//	CreateRating(ctx c.Context, actor d.Actor, rating d.Rating) error
-//	GetRating(ctx c.Context, ratingID uuid.UUID) (d.Rating, error)
-//	UpdateRating(ctx c.Context, actor d.Actor, rating d.Rating) error
-//	DeleteRating(ctx c.Context, actor d.Actor, ratingID uuid.UUID) error
-//	ListRatingsByUser(ctx c.Context, userID uuid.UUID) ([]d.Rating, error)
-//	ListRatingsByPiece(ctx c.Context, pieceID uuid.UUID) ([]d.Rating, error)
-//}
@@ -4,9 +4,30 @@ import (
 	"context"
 )
 
+// Transaction: Единица работы над одной транзакцией БД.
+// Репозитории, вызванные с контекстом WithTransaction, работают в ней вместо своей транзакции
 type Transaction interface {
 	Start(ctx context.Context) error
 	Abort(ctx context.Context) error
 	Commit(ctx context.Context) error
+	// End aborts transaction which was neither committed nor aborted, it is deferred right after Start
 	End(ctx context.Context)
 }
+
+// TransactionFactory: Создание единицы работы на время операции сервиса
+type TransactionFactory interface {
+	NewTransaction() Transaction
+}
+
+type transactionKey struct{}
+
+// WithTransaction returns context in which repositories join started transaction
+func WithTransaction(ctx context.Context, tx Transaction) context.Context {
+	return context.WithValue(ctx, transactionKey{}, tx)
+}
+
+// TransactionFrom returns transaction of unit of work which ctx belongs to
+func TransactionFrom(ctx context.Context) (Transaction, bool) {
+	tx, ok := ctx.Value(transactionKey{}).(Transaction)
+	return tx, ok
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// randomHex returns size random bytes in hex
+func randomHex(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
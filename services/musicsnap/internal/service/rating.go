@@ -0,0 +1,98 @@
+package service
+
+import (
+	c "context"
+	"fmt"
+	"github.com/google/uuid"
+	global "go.opentelemetry.io/otel"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"reflect"
+)
+
+func (s ratingSvc) spanName(funcName string) string {
+	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
+}
+
+func NewRatingSvc(ratingRepository ports.RatingRepository, catalogRepository ports.CatalogRepository) ports.RatingService {
+	return ratingSvc{r: ratingRepository, catalog: catalogRepository}
+}
+
+var _ ports.RatingService = &ratingSvc{}
+
+type ratingSvc struct {
+	r       ports.RatingRepository
+	catalog ports.CatalogRepository
+}
+
+// SetRating creates actor rating of the piece or replaces the previous one
+func (s ratingSvc) SetRating(ctx c.Context, actor domain.Actor, rating domain.Rating) (domain.Rating, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("SetRating"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if !actor.HasRole(domain.AdminRole) && actor.ID != rating.UserID {
+		return domain.Rating{},
+			app.NewError(http.StatusForbidden, "user can't rate for other person",
+				"actor do not have admin role to set other persons rating", nil)
+	}
+
+	err := rating.Validate()
+	if err != nil {
+		return domain.Rating{},
+			app.NewError(http.StatusBadRequest, "invalid rating", "invalid fields for rating validation", err)
+	}
+
+	rating.PieceID, err = canonicalPieceID(ctx, s.catalog, rating.PieceID)
+	if err != nil {
+		return domain.Rating{}, err
+	}
+
+	return s.r.Upsert(ctx, rating)
+}
+
+func (s ratingSvc) GetRating(ctx c.Context, actor domain.Actor, userID uuid.UUID, pieceRef string) (domain.Rating, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("GetRating"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	piece, err := resolvePiece(ctx, s.catalog, pieceRef)
+	if err != nil {
+		return domain.Rating{}, err
+	}
+
+	return s.r.Get(ctx, userID, piece.ID.String())
+}
+
+func (s ratingSvc) DeleteRating(ctx c.Context, actor domain.Actor, userID uuid.UUID, pieceRef string) (domain.Rating, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("DeleteRating"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if !actor.HasRole(domain.AdminRole) && actor.ID != userID {
+		return domain.Rating{},
+			app.NewError(http.StatusForbidden, "user can't delete other persons rating",
+				"actor do not have admin role to delete other persons rating", nil)
+	}
+
+	piece, err := resolvePiece(ctx, s.catalog, pieceRef)
+	if err != nil {
+		return domain.Rating{}, err
+	}
+
+	return s.r.Delete(ctx, userID, piece.ID.String())
+}
+
+func (s ratingSvc) ListUserRatings(ctx c.Context, actor domain.Actor, userID uuid.UUID, pag domain.UUIDPagination) ([]domain.Rating, domain.UUIDPagination, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("ListUserRatings"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	return s.r.ListByUser(ctx, userID, pag)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	global "go.opentelemetry.io/otel"
 	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/config"
 	d "music-snap/services/musicsnap/internal/domain"
 	"music-snap/services/musicsnap/internal/service/ports"
 	"net/http"
@@ -16,24 +17,80 @@ func (s reactionSvc) spanName(funcName string) string {
 	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
 }
 
-func NewReactionSvc(reaction ports.ReactionRepository) ports.ReactionService {
-	return reactionSvc{r: reaction}
+// NewReactionSvc creates reaction service, without configured types only like and dislike are allowed
+func NewReactionSvc(reaction ports.ReactionRepository, reviewRepository ports.ReviewRepository,
+	notifications ports.NotificationSvc, txs ports.TransactionFactory, outbox ports.OutboxRepository,
+	cfg config.ReactionsConfig, preModeration bool) ports.ReactionService {
+	types := make(map[string]bool)
+	for _, t := range cfg.Types {
+		types[t] = true
+	}
+	if len(types) == 0 {
+		types[d.LikeReaction] = true
+		types[d.DislikeReaction] = true
+	}
+	return reactionSvc{r: reaction, reviews: reviewRepository, notifications: notifications,
+		txs: txs, outbox: outbox, types: types, preModeration: preModeration}
 }
 
 var _ ports.ReactionService = &reactionSvc{}
 
 type reactionSvc struct {
-	r ports.ReactionRepository
+	r             ports.ReactionRepository
+	reviews       ports.ReviewRepository
+	notifications ports.NotificationSvc
+	txs           ports.TransactionFactory
+	outbox        ports.OutboxRepository
+
+	types         map[string]bool
+	preModeration bool
 }
 
-func (r reactionSvc) ListReactions(ctx c.Context, reviewID int, pagination d.IDPagination) ([]d.Reaction, d.IDPagination, error) {
-	//TODO implement me
-	panic("implement me")
+func (s reactionSvc) checkType(reaction d.Reaction) error {
+	err := reaction.Validate()
+	if err != nil {
+		return app.NewError(http.StatusBadRequest, "invalid reaction", "reaction validation error", err)
+	}
+	if !s.types[reaction.Type] {
+		return app.NewError(http.StatusBadRequest, "reaction type is not supported",
+			fmt.Sprintf("reaction type %q is not configured", reaction.Type), nil)
+	}
+	return nil
 }
 
-func (r reactionSvc) CountReactions(ctx c.Context, reviewID uuid.UUID) (d.ReactionCount, error) {
-	//TODO implement me
-	panic("implement me")
+func (s reactionSvc) ListReactions(ctx c.Context, actor d.Actor, filter d.ReactionFilter, pagination d.IDPagination) ([]d.Reaction, d.IDPagination, error) {
+	tr := global.Tracer(d.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("ListReactions"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	_, err := visibleReview(ctx, s.reviews, actor, filter.ReviewID, s.preModeration)
+	if err != nil {
+		return nil, pagination, err
+	}
+
+	return s.r.List(ctx, filter, pagination)
+}
+
+func (s reactionSvc) CountReactions(ctx c.Context, actor d.Actor, reviewID int) (d.ReactionCounts, error) {
+	tr := global.Tracer(d.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("CountReactions"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	_, err := visibleReview(ctx, s.reviews, actor, reviewID, s.preModeration)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.r.CountByReviews(ctx, []int{reviewID})
+	if err != nil {
+		return nil, err
+	}
+	if counts[reviewID] == nil {
+		return d.ReactionCounts{}, nil
+	}
+	return counts[reviewID], nil
 }
 
 func (s reactionSvc) UpdateReaction(ctx c.Context, actor d.Actor, reaction d.Reaction) (d.Reaction, error) {
@@ -42,36 +99,96 @@ func (s reactionSvc) UpdateReaction(ctx c.Context, actor d.Actor, reaction d.Rea
 	defer span.End()
 	ToSpan(&span, actor)
 
-	if !actor.HasRole(d.AdminRole) && actor.ID != reaction.UserID {
+	existing, err := s.r.GetByID(ctx, reaction.ID)
+	if err != nil {
+		return d.Reaction{}, err
+	}
+	if !actor.HasRole(d.AdminRole) && actor.ID != existing.UserID {
 		return d.Reaction{},
 			app.NewError(http.StatusForbidden, "user can't update other persons reaction",
 				"actor do not have admin role to update other persons reaction", nil)
 	}
 
-	reviewUpdated, err := s.r.Update(ctx, reaction)
+	existing.Type = reaction.Type
+	err = s.checkType(existing)
+	if err != nil {
+		return d.Reaction{}, err
+	}
+
+	reviewUpdated, err := s.r.Update(ctx, existing)
 	if err != nil {
 		return d.Reaction{}, err
 	}
 	return reviewUpdated, nil
 }
 
-func (s reactionSvc) CreateReaction(ctx c.Context, actor d.Actor, reaction d.Reaction) (d.Reaction, error) {
+func (s reactionSvc) ToggleReaction(ctx c.Context, actor d.Actor, reaction d.Reaction) (d.Reaction, bool, error) {
 	tr := global.Tracer(d.ServiceName)
-	ctx, span := tr.Start(ctx, s.spanName("CreateReaction"))
+	ctx, span := tr.Start(ctx, s.spanName("ToggleReaction"))
 	defer span.End()
 	ToSpan(&span, actor)
 
+	if reaction.UserID == uuid.Nil {
+		reaction.UserID = actor.ID
+	}
 	if !actor.HasRole(d.AdminRole) && actor.ID != reaction.UserID {
-		return d.Reaction{},
+		return d.Reaction{}, false,
 			app.NewError(http.StatusForbidden, "user can't create other persons reaction",
 				"actor do not have admin role to create other persons reaction", nil)
 	}
 
-	reviewCreated, err := s.r.Create(ctx, reaction)
+	err := s.checkType(reaction)
 	if err != nil {
-		return d.Reaction{}, err
+		return d.Reaction{}, false, err
+	}
+	review, err := visibleReview(ctx, s.reviews, actor, reaction.ReviewID, s.preModeration)
+	if err != nil {
+		return d.Reaction{}, false, err
+	}
+
+	var toggled d.Reaction
+	var removed bool
+	err = inTransaction(ctx, s.txs, func(ctx c.Context) error {
+		toggled, removed, err = s.r.Toggle(ctx, reaction)
+		if err != nil || removed {
+			return err
+		}
+		return addEvent(ctx, s.outbox, d.EventReactionAdded, d.ReactionAddedEvent{
+			ReactionID: toggled.ID,
+			ReviewID:   review.ID,
+			AuthorID:   review.UserID,
+			UserID:     toggled.UserID,
+			Type:       toggled.Type,
+		})
+	})
+	if err != nil {
+		return d.Reaction{}, false, err
+	}
+	return toggled, removed, nil
+}
+
+// HandleEvent sends collapsed notification about reaction to the author of review
+func (s reactionSvc) HandleEvent(ctx c.Context, event d.DomainEvent) error {
+	tr := global.Tracer(d.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("HandleEvent"))
+	defer span.End()
+
+	var added d.ReactionAddedEvent
+	err := event.Decode(&added)
+	if err != nil {
+		return err
+	}
+	message, err := d.NewNotificationMessage(d.ReviewReactionPayload{ReviewID: added.ReviewID, Reaction: added.Type})
+	if err != nil {
+		return err
 	}
-	return reviewCreated, nil
+	return s.notifications.Notify(ctx, d.Notification{
+		UserIDReceiver: added.AuthorID,
+		UserIDSender:   &added.UserID,
+		Type:           d.NotificationReviewReaction,
+		GroupKey:       d.ReactionGroupKey(added.ReviewID, added.Type),
+		Message:        message,
+	})
 }
 
 func (s reactionSvc) GetByReview(ctx c.Context, actor d.Actor, reviewID int) (d.Reaction, error) {
@@ -0,0 +1,112 @@
+package service
+
+import (
+	c "context"
+	"fmt"
+	global "go.opentelemetry.io/otel"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/config"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"reflect"
+)
+
+const defaultReportHideThreshold = 3
+
+func (s reportSvc) spanName(funcName string) string {
+	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
+}
+
+func NewReportSvc(reportRepository ports.ReportRepository, cfg config.ModerationConfig) ports.ReportService {
+	hideThreshold := cfg.ReportHideThreshold
+	if hideThreshold <= 0 {
+		hideThreshold = defaultReportHideThreshold
+	}
+	return reportSvc{r: reportRepository, hideThreshold: hideThreshold}
+}
+
+var _ ports.ReportService = &reportSvc{}
+
+type reportSvc struct {
+	r             ports.ReportRepository
+	hideThreshold int
+}
+
+func (s reportSvc) CreateReport(ctx c.Context, actor domain.Actor, report domain.Report) (domain.Report, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("CreateReport"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	report.ReporterID = actor.ID
+
+	err := report.Validate()
+	if err != nil {
+		return domain.Report{}, app.NewError(http.StatusBadRequest, "invalid report", "report validation error", err)
+	}
+	if report.TargetType == domain.ReportTargetProfile && report.TargetID == actor.ID.String() {
+		return domain.Report{}, app.NewError(http.StatusBadRequest, "user can't report own profile",
+			"reporter and reported profile are the same", nil)
+	}
+
+	created, _, err := s.r.Create(ctx, report, s.hideThreshold)
+	if err != nil {
+		return domain.Report{}, err
+	}
+	return created, nil
+}
+
+func (s reportSvc) ListReports(ctx c.Context, actor domain.Actor, filter domain.ReportFilter, pag domain.IDPagination) ([]domain.Report, domain.IDPagination, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("ListReports"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	// пользователь видит только свои жалобы
+	if !canModerate(actor) {
+		filter.ReporterID = &actor.ID
+	}
+
+	return s.r.List(ctx, filter, pag)
+}
+
+func (s reportSvc) GetReportTarget(ctx c.Context, actor domain.Actor, targetType domain.ReportTargetType, targetID string) (domain.ReportTarget, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("GetReportTarget"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if !canModerate(actor) {
+		return domain.ReportTarget{}, app.NewError(http.StatusForbidden, "only moderators can see reported content",
+			"actor do not have admin or moderator role", nil)
+	}
+	err := targetType.ValidID(targetID)
+	if err != nil {
+		return domain.ReportTarget{}, app.NewError(http.StatusBadRequest, "invalid report target", "report target validation error", err)
+	}
+
+	return s.r.GetTarget(ctx, targetType, targetID)
+}
+
+func (s reportSvc) ResolveReports(ctx c.Context, actor domain.Actor, targetType domain.ReportTargetType, targetID string, status domain.ReportStatus) (int, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("ResolveReports"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if !canModerate(actor) {
+		return 0, app.NewError(http.StatusForbidden, "only moderators can resolve reports",
+			"actor do not have admin or moderator role", nil)
+	}
+	err := targetType.ValidID(targetID)
+	if err != nil {
+		return 0, app.NewError(http.StatusBadRequest, "invalid report target", "report target validation error", err)
+	}
+	if !status.Resolution() {
+		return 0, app.NewError(http.StatusBadRequest, "report can be only upheld or dismissed",
+			fmt.Sprintf("status %q is not a resolution", status), nil)
+	}
+
+	return s.r.Resolve(ctx, targetType, targetID, status, actor.ID)
+}
@@ -4,12 +4,19 @@ import (
 	c "context"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/juju/zaputil/zapctx"
 	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"maps"
 	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/config"
 	"music-snap/services/musicsnap/internal/domain"
 	"music-snap/services/musicsnap/internal/service/ports"
 	"net/http"
 	"reflect"
+	"slices"
+	"strconv"
+	"time"
 )
 
 func (s reviewSvc) spanName(funcName string) string {
@@ -17,16 +24,57 @@ func (s reviewSvc) spanName(funcName string) string {
 	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
 }
 
-func NewReviewSvc(reviewRepository ports.ReviewRepository, cache ports.ProfileCache) ports.ReviewService {
-	return reviewSvc{r: reviewRepository, c: cache}
+// Names of publication handlers are stored with their completion marks and must not change
+const (
+	tagsPublicationHandler         = "tags"
+	streamPublicationHandler       = "stream"
+	notificationPublicationHandler = "notifications"
+	webhookPublicationHandler      = "webhooks"
+)
+
+// NewReviewSvc creates review service, with preModeration reviews are public only after moderator approval.
+// Zero publication settings are replaced with defaults
+func NewReviewSvc(reviewRepository ports.ReviewRepository, catalogRepository ports.CatalogRepository,
+	reportRepository ports.ReportRepository, loader ports.BatchLoaderFactory, cache ports.ProfileCache,
+	filter ports.ContentFilter, tags ports.TagService, txs ports.TransactionFactory, outbox ports.OutboxRepository,
+	preModeration bool, publicationsCfg config.PublicationsConfig,
+	publishedHandlers map[string]ports.ReviewPublishedHandler) ports.ReviewService {
+	if publicationsCfg.Lease <= 0 {
+		publicationsCfg.Lease = 5 * time.Minute
+	}
+	if publicationsCfg.BaseBackoff <= 0 {
+		publicationsCfg.BaseBackoff = 30 * time.Second
+	}
+	if publicationsCfg.MaxBackoff < publicationsCfg.BaseBackoff {
+		publicationsCfg.MaxBackoff = time.Hour
+	}
+	if publicationsCfg.BatchSize <= 0 {
+		publicationsCfg.BatchSize = 100
+	}
+	return reviewSvc{r: reviewRepository, catalog: catalogRepository, reports: reportRepository,
+		loader: loader, c: cache, filter: filter, tags: tags, txs: txs, outbox: outbox, preModeration: preModeration,
+		publicationsCfg: publicationsCfg, published: publishedHandlers}
 }
 
 var _ ports.ReviewService = &reviewSvc{}
 
 type reviewSvc struct {
-	r   ports.ReviewRepository
-	c   ports.ProfileCache
-	jwt ports.JwtSvc
+	r       ports.ReviewRepository
+	catalog ports.CatalogRepository
+	reports ports.ReportRepository
+	// loader fills profiles and reactions of review lists
+	loader ports.BatchLoaderFactory
+	c      ports.ProfileCache
+	jwt    ports.JwtSvc
+	filter ports.ContentFilter
+	tags   ports.TagService
+	txs    ports.TransactionFactory
+	outbox ports.OutboxRepository
+
+	preModeration   bool
+	publicationsCfg config.PublicationsConfig
+	// published are handlers of the first publication by their names
+	published map[string]ports.ReviewPublishedHandler
 }
 
 func (s reviewSvc) validForCreation(r domain.Review) error {
@@ -38,6 +86,41 @@ func (s reviewSvc) validForCreation(r domain.Review) error {
 	}
 	return nil
 }
+
+// validSchedule checks that scheduled review is not published yet and its time is in future
+func (s reviewSvc) validSchedule(r domain.Review) error {
+	if r.PublishAt == nil {
+		return nil
+	}
+	if r.Published {
+		return app.NewError(http.StatusBadRequest, "published review can't be scheduled",
+			"review has both published flag and publish_at", nil)
+	}
+	if !r.PublishAt.After(time.Now()) {
+		return app.NewError(http.StatusBadRequest, "publish time must be in future",
+			fmt.Sprintf("publish_at %s is in the past", r.PublishAt.Format(time.RFC3339)), nil)
+	}
+	return nil
+}
+
+// canSeeHidden reports whether actor can see unpublished review, review hidden by moderation and its edit history
+func canSeeHidden(actor domain.Actor, review domain.Review) bool {
+	return actor.ID == review.UserID || actor.HasRole(domain.AdminRole) || actor.HasRole(domain.ModeratorRole)
+}
+
+// visibleReview returns review to actor who can see it, others get not found as for missing review
+func visibleReview(ctx c.Context, reviews ports.ReviewRepository, actor domain.Actor, reviewID int, preModeration bool) (domain.Review, error) {
+	review, err := reviews.GetByID(ctx, reviewID)
+	if err != nil {
+		return domain.Review{}, err
+	}
+	if !review.VisibleToPublic(preModeration) && !canSeeHidden(actor, review) {
+		return domain.Review{}, app.NewError(http.StatusNotFound, "review not found",
+			fmt.Sprintf("review %d is a draft or hidden by moderation", reviewID), nil)
+	}
+	return review, nil
+}
+
 func (s reviewSvc) CreateReview(ctx c.Context, actor domain.Actor, review domain.Review) (domain.Review, error) {
 	tr := global.Tracer(domain.ServiceName)
 	ctx, span := tr.Start(ctx, s.spanName("CreateReview"))
@@ -57,13 +140,71 @@ func (s reviewSvc) CreateReview(ctx c.Context, actor domain.Actor, review domain
 				"invalid fields for review validation", err)
 	}
 
-	reviewCreated, err := s.r.Create(ctx, review)
+	err = s.validSchedule(review)
+	if err != nil {
+		return domain.Review{}, err
+	}
+
+	filtered := s.filter.CheckReview(review.Content)
+	err = filterError(filtered, "review")
+	if err != nil {
+		return domain.Review{}, err
+	}
+
+	review.PieceID, err = canonicalPieceID(ctx, s.catalog, review.PieceID)
+	if err != nil {
+		return domain.Review{}, err
+	}
+
+	// упоминания и хэштеги индексируются обработчиком события, чтобы не потерять уведомления
+	var reviewCreated domain.Review
+	err = inTransaction(ctx, s.txs, func(ctx c.Context) error {
+		reviewCreated, err = s.r.Create(ctx, review)
+		if err != nil {
+			return err
+		}
+		return addEvent(ctx, s.outbox, domain.EventReviewCreated,
+			domain.ReviewCreatedEvent{ReviewID: reviewCreated.ID, AuthorID: reviewCreated.UserID})
+	})
 	if err != nil {
 		return domain.Review{}, err
 	}
+	holdFlagged(ctx, s.reports, filtered, domain.ReportTargetReview, strconv.Itoa(reviewCreated.ID))
 	return reviewCreated, nil
 }
 
+// HandleEvent indexes mentions and hashtags of created or updated review, indexing is idempotent.
+// Review deleted before the event is handled is skipped
+func (s reviewSvc) HandleEvent(ctx c.Context, event domain.DomainEvent) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("HandleEvent"))
+	defer span.End()
+
+	var reviewID int
+	switch event.Type {
+	case domain.EventReviewUpdated:
+		var updated domain.ReviewUpdatedEvent
+		if err := event.Decode(&updated); err != nil {
+			return err
+		}
+		reviewID = updated.ReviewID
+	default:
+		var created domain.ReviewCreatedEvent
+		if err := event.Decode(&created); err != nil {
+			return err
+		}
+		reviewID = created.ReviewID
+	}
+	review, err := s.r.GetByID(ctx, reviewID)
+	if err != nil {
+		if app.GetCode(err) == http.StatusNotFound {
+			return nil
+		}
+		return err
+	}
+	return s.tags.IndexReview(ctx, review)
+}
+
 func (s reviewSvc) UpdateReview(ctx c.Context, actor domain.Actor, review domain.Review) (domain.Review, error) {
 	tr := global.Tracer(domain.ServiceName)
 	ctx, span := tr.Start(ctx, s.spanName("UpdateReview"))
@@ -83,10 +224,36 @@ func (s reviewSvc) UpdateReview(ctx c.Context, actor domain.Actor, review domain
 				"invalid fields for review validation", err)
 	}
 
-	reviewUpdated, err := s.r.Update(ctx, review)
+	err = s.validSchedule(review)
+	if err != nil {
+		return domain.Review{}, err
+	}
+
+	filtered := s.filter.CheckReview(review.Content)
+	err = filterError(filtered, "review")
+	if err != nil {
+		return domain.Review{}, err
+	}
+
+	review.PieceID, err = canonicalPieceID(ctx, s.catalog, review.PieceID)
+	if err != nil {
+		return domain.Review{}, err
+	}
+
+	// упоминания и хэштеги переиндексируются обработчиком события, как и при создании
+	var reviewUpdated domain.Review
+	err = inTransaction(ctx, s.txs, func(ctx c.Context) error {
+		reviewUpdated, err = s.r.Update(ctx, review, actor.ID)
+		if err != nil {
+			return err
+		}
+		return addEvent(ctx, s.outbox, domain.EventReviewUpdated,
+			domain.ReviewUpdatedEvent{ReviewID: reviewUpdated.ID, AuthorID: reviewUpdated.UserID, EditorID: actor.ID})
+	})
 	if err != nil {
 		return domain.Review{}, err
 	}
+	holdFlagged(ctx, s.reports, filtered, domain.ReportTargetReview, strconv.Itoa(reviewUpdated.ID))
 	return reviewUpdated, nil
 }
 
@@ -99,6 +266,12 @@ func (s reviewSvc) GetReview(ctx c.Context, actor domain.Actor, revID int, piece
 	if err != nil {
 		return domain.Review{}, err
 	}
+
+	// черновик и скрытая модерацией рецензия для остальных не существуют
+	if !review.VisibleToPublic(s.preModeration) && !canSeeHidden(actor, review) {
+		return domain.Review{}, app.NewError(http.StatusNotFound, "review not found",
+			fmt.Sprintf("review %d is a draft or hidden by moderation", revID), nil)
+	}
 	return review, nil
 }
 
@@ -111,7 +284,7 @@ func (s reviewSvc) DeleteReview(ctx c.Context, actor domain.Actor, reviewID int)
 	review, err := s.r.GetByID(ctx, reviewID)
 	if err != nil {
 		return app.NewError(http.StatusNotFound, "review not found",
-			fmt.Sprintf("review with id %s not found", reviewID), err)
+			fmt.Sprintf("review with id %d not found", reviewID), err)
 	}
 
 	// Check if the actor is allowed to delete the review
@@ -127,6 +300,75 @@ func (s reviewSvc) DeleteReview(ctx c.Context, actor domain.Actor, reviewID int)
 	return nil
 }
 
+func (s reviewSvc) ListRevisions(ctx c.Context, actor domain.Actor, reviewID int) ([]domain.ReviewRevision, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("ListRevisions"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	review, err := s.r.GetByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSeeHidden(actor, review) {
+		return nil, app.NewError(http.StatusForbidden, "user can't see review revisions",
+			"actor is not review author and do not have admin or moderator role", nil)
+	}
+
+	return s.r.ListRevisions(ctx, reviewID)
+}
+
+func (s reviewSvc) DiffRevisions(ctx c.Context, actor domain.Actor, reviewID int, from, to *int) (domain.ReviewRevisionDiff, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("DiffRevisions"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if (from != nil && *from < 1) || (to != nil && *to < 1) {
+		return domain.ReviewRevisionDiff{}, app.NewError(http.StatusBadRequest, "invalid revision number",
+			"revision numbers start from 1", nil)
+	}
+
+	var toRevision domain.ReviewRevision
+	if to == nil {
+		revisions, err := s.ListRevisions(ctx, actor, reviewID)
+		if err != nil {
+			return domain.ReviewRevisionDiff{}, err
+		}
+		if len(revisions) == 0 {
+			return domain.ReviewRevisionDiff{}, app.NewError(http.StatusNotFound, "review revisions not found",
+				fmt.Sprintf("review %d has no revisions", reviewID), nil)
+		}
+		toRevision = revisions[len(revisions)-1]
+	} else {
+		review, err := s.r.GetByID(ctx, reviewID)
+		if err != nil {
+			return domain.ReviewRevisionDiff{}, err
+		}
+		if !canSeeHidden(actor, review) {
+			return domain.ReviewRevisionDiff{}, app.NewError(http.StatusForbidden, "user can't see review revisions",
+				"actor is not review author and do not have admin or moderator role", nil)
+		}
+		toRevision, err = s.r.GetRevision(ctx, reviewID, *to)
+		if err != nil {
+			return domain.ReviewRevisionDiff{}, err
+		}
+	}
+
+	// по умолчанию ревизия сравнивается с предыдущей, первая - сама с собой
+	fromRev := max(toRevision.Revision-1, 1)
+	if from != nil {
+		fromRev = *from
+	}
+	fromRevision, err := s.r.GetRevision(ctx, reviewID, fromRev)
+	if err != nil {
+		return domain.ReviewRevisionDiff{}, err
+	}
+
+	return domain.NewReviewRevisionDiff(fromRevision, toRevision), nil
+}
+
 //func (s reviewSvc) ReviewsOfSubscriptions(ctx c.Context, actor domain.Actor, filter domain.ReviewFilter, pagination domain.IDPagination) ([]domain.Review, domain.IDPagination, error) {
 //	//TODO implement me
 //	panic("implement me")
@@ -138,11 +380,117 @@ func (s reviewSvc) ListReviews(ctx c.Context, actor domain.Actor, filter domain.
 	defer span.End()
 	ToSpan(&span, actor)
 
-	reviews, pag, err := s.r.GetList(ctx, filter, pagination)
+	// черновики и скрытые модерацией рецензии видны только автору и модерации
+	ownReviews := filter.UserID != nil && *filter.UserID == actor.ID
+	if !ownReviews && !actor.HasRole(domain.AdminRole) && !actor.HasRole(domain.ModeratorRole) {
+		published, hidden := true, false
+		filter.Published = &published
+		filter.Hidden = &hidden
+		if s.preModeration {
+			approved := domain.ModerationApproved
+			filter.ModerationStatus = &approved
+		} else {
+			rejected := domain.ModerationRejected
+			filter.ExcludeModerationStatus = &rejected
+		}
+	}
+
+	if filter.PieceID != nil {
+		pieceID, err := canonicalPieceID(ctx, s.catalog, *filter.PieceID)
+		if err != nil {
+			return nil, domain.IDPagination{}, err
+		}
+		filter.PieceID = &pieceID
+	}
+
+	// профили загружаются вместе с реакциями страницы
+	listFilter := filter
+	listFilter.IncludeProfiles = false
+	reviews, pag, err := s.r.GetList(ctx, listFilter, pagination)
 	if err != nil {
 		return nil, domain.IDPagination{}, app.NewError(http.StatusInternalServerError, "error listing reviews",
 			"error while listing reviews", err)
 	}
 
+	loader := s.loader.NewBatchLoader(actor.ID)
+	for _, review := range reviews {
+		loader.WantReactions(review.ID)
+		if filter.IncludeProfiles {
+			loader.WantProfiles(review.UserID)
+		}
+	}
+	err = loader.Load(ctx)
+	if err != nil {
+		return nil, domain.IDPagination{}, err
+	}
+	for i := range reviews {
+		reviews[i].ReactionCounts = loader.ReactionCounts(reviews[i].ID)
+		reviews[i].ActorReaction = loader.ActorReaction(reviews[i].ID)
+		if profile, ok := loader.Profile(reviews[i].UserID); ok && filter.IncludeProfiles {
+			reviews[i].Profile = &profile
+		}
+	}
+
 	return reviews, pag, nil
 }
+
+// PublishScheduled publishes reviews which time has come and runs side effects of new publications
+func (s reviewSvc) PublishScheduled(ctx c.Context) (int, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("PublishScheduled"))
+	defer span.End()
+
+	published, err := s.r.PublishScheduled(ctx, time.Now(), s.publicationsCfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// обрабатываются и публикации, сделанные авторами сразу, не только отложенные.
+	// При премодерации публикация ждёт одобрения
+	publications, err := s.r.ClaimPublications(ctx, time.Now().UTC(), s.publicationsCfg.Lease,
+		s.publicationsCfg.BatchSize, s.preModeration)
+	if err != nil {
+		return len(published), err
+	}
+
+	// сбой одной публикации откладывает только её
+	for _, publication := range publications {
+		err = s.reviewPublished(ctx, publication.Review)
+		if err != nil {
+			zapctx.Logger(ctx).Error("can't handle review publication", zap.Int("reviewID", publication.Review.ID),
+				zap.Int("attempts", publication.Attempts), zap.Error(err))
+
+			retryAt := time.Now().UTC().Add(domain.Backoff(publication.Attempts,
+				s.publicationsCfg.BaseBackoff, s.publicationsCfg.MaxBackoff))
+			err = s.r.ReschedulePublication(ctx, publication.Review.ID, retryAt, err.Error())
+			if err != nil {
+				return len(published), err
+			}
+			continue
+		}
+
+		err = s.r.MarkPublicationProcessed(ctx, publication.Review.ID, time.Now().UTC())
+		if err != nil {
+			return len(published), err
+		}
+	}
+	return len(published), nil
+}
+
+// reviewPublished runs every handler once: handler changes are committed together with its mark,
+// so retry of the publication skips handlers which already succeeded
+func (s reviewSvc) reviewPublished(ctx c.Context, review domain.Review) error {
+	for _, name := range slices.Sorted(maps.Keys(s.published)) {
+		err := inTransaction(ctx, s.txs, func(ctx c.Context) error {
+			first, err := s.r.MarkPublicationHandled(ctx, review.ID, name)
+			if err != nil || !first {
+				return err
+			}
+			return s.published[name].ReviewPublished(ctx, review)
+		})
+		if err != nil {
+			return fmt.Errorf("%s handler of review %d publication: %w", name, review.ID, err)
+		}
+	}
+	return nil
+}
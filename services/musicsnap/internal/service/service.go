@@ -1,6 +1,8 @@
 package service
 
 import (
+	"music-snap/services/musicsnap/internal/config"
+	"music-snap/services/musicsnap/internal/domain"
 	"music-snap/services/musicsnap/internal/repository/postgre"
 	"music-snap/services/musicsnap/internal/service/ports"
 )
@@ -15,37 +17,101 @@ type MusicSnapService struct {
 	Reaction     ports.ReactionService
 	Photo        ports.PhotoService
 	Stats        ports.StatsService
+	Catalog      ports.CatalogService
+	Rating       ports.RatingService
+	Moderation   ports.ModerationService
+	Report       ports.ReportService
+	Comment      ports.CommentService
+	Tag          ports.TagService
+	Stream       ports.StreamService
+	Webhook      ports.WebhookService
+	Outbox       ports.OutboxService
+	// EventHandlers: Обработчики доменных событий по типу события
+	EventHandlers map[string]ports.DomainEventHandler
 
 	Event    ports.EventService
+	Calendar ports.CalendarService
 	Note     ports.NoteSvc
 	Playlist ports.PlaylistService
 }
 
-func New(r postgre.Repository, jwt ports.JwtSvc, cache ports.ProfileCache) MusicSnapService {
+func New(r postgre.Repository, jwt ports.JwtSvc, cache ports.ProfileCache, filter ports.ContentFilter,
+	moderationCfg config.ModerationConfig, publicationsCfg config.PublicationsConfig, commentsCfg config.CommentsConfig, tagsCfg config.TagsConfig,
+	reactionsCfg config.ReactionsConfig, bus ports.StreamBus, streamCfg config.StreamConfig,
+	mail ports.MailSender, renderer ports.NotificationRenderer, notificationsCfg config.NotificationsConfig,
+	webhookSender ports.WebhookSender, webhooksCfg config.WebhooksConfig, outboxCfg config.OutboxConfig,
+	eventBus ports.DomainEventHandler, calendarEncoder ports.CalendarEncoder, calendarCfg config.CalendarConfig,
+	remindersCfg config.EventRemindersConfig) MusicSnapService {
 
-	//notification := NewNotificationService(r.Notification)
+	notification := NewNotificationService(r.Notification, r.User, r.Loader, renderer, mail, notificationsCfg)
 
-	auth := NewAuthSvc(jwt, r.User)
-	user := NewUserSvc(r.User, jwt, cache)
-	subscription := NewSubscriptionSvc(r.User, cache)
-	review := NewReviewSvc(r.Review, cache)
-	reaction := NewReactionSvc(r.Reaction)
+	webhook := NewWebhookSvc(r.Webhook, webhookSender, webhooksCfg)
+
+	auth := NewAuthSvc(jwt, r.User, r.Report, filter)
+	user := NewUserSvc(r.User, jwt, cache, r.Report, filter)
+	subscription := NewSubscriptionSvc(r.User, cache, r.Transactions, r.Outbox, webhook)
+	catalog := NewCatalogSvc(r.Catalog)
+	tag := NewTagSvc(r.Tag, tagsCfg, moderationCfg.PreModeration)
+	stream := NewStreamSvc(r.Stream, bus, notification, streamCfg)
+	review := NewReviewSvc(r.Review, r.Catalog, r.Report, r.Loader, cache, filter, tag, r.Transactions, r.Outbox,
+		moderationCfg.PreModeration, publicationsCfg, map[string]ports.ReviewPublishedHandler{
+			tagsPublicationHandler:         tag,
+			streamPublicationHandler:       stream,
+			notificationPublicationHandler: notification,
+			webhookPublicationHandler:      webhook,
+		})
+	reaction := NewReactionSvc(r.Reaction, r.Review, notification, r.Transactions, r.Outbox, reactionsCfg, moderationCfg.PreModeration)
+	stats := NewStatsSvc(r.Stats, r.Catalog)
+	rating := NewRatingSvc(r.Rating, r.Catalog)
+	moderation := NewModerationSvc(r.Moderation, moderationCfg)
+	report := NewReportSvc(r.Report, moderationCfg)
+	comment := NewCommentSvc(r.Comment, r.Review, filter, tag, commentsCfg, moderationCfg.PreModeration)
+	// событие уходит во внешнюю шину, если она настроена
+	var subscribers []ports.DomainEventHandler
+	if eventBus != nil {
+		subscribers = append(subscribers, eventBus)
+	}
+	event := NewEventSvc(r.Event, r.Transactions, r.Outbox, notification, webhook, r.Reminder, remindersCfg)
+	calendar := NewCalendarSvc(r.Event, r.CalendarFeed, calendarEncoder, calendarCfg)
+	// одни и те же обработчики получают события outbox и события шины
+	eventHandlers := map[string]ports.DomainEventHandler{
+		domain.EventReviewCreated:  review,
+		domain.EventReviewUpdated:  review,
+		domain.EventSubscribed:     subscription,
+		domain.EventReactionAdded:  reaction,
+		domain.EventConcertCreated: event,
+		domain.EventConcertJoined:  event,
+	}
+	outbox := NewOutboxSvc(r.Outbox, outboxCfg, eventHandlers, subscribers...)
 	// TODO
 	//reaction := NewReactionSvc(r.Reaction)
 	//photo := NewPhotoSvc(r.Photo)
 
 	return MusicSnapService{
-		//Notification: notification,
+		Notification: notification,
 
 		Auth:         auth,
 		User:         user,
 		Subscription: subscription,
 
-		Review:   review,
-		Reaction: reaction,
+		Review:     review,
+		Reaction:   reaction,
+		Catalog:    catalog,
+		Stats:      stats,
+		Rating:     rating,
+		Moderation: moderation,
+		Report:     report,
+		Comment:    comment,
+		Tag:        tag,
+		Stream:     stream,
+		Webhook:    webhook,
+		Outbox:     outbox,
 		//Photo:    photo,
 
-		//Event:  event,
+		EventHandlers: eventHandlers,
+
+		Event:    event,
+		Calendar: calendar,
 		//Note:   note,
 		//Banner: banner,
 	}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	c "context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"reflect"
+)
+
+func (s statsSvc) spanName(funcName string) string {
+	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
+}
+
+func NewStatsSvc(statsRepository ports.StatsRepository, catalogRepository ports.CatalogRepository) ports.StatsService {
+	return statsSvc{r: statsRepository, catalog: catalogRepository}
+}
+
+var _ ports.StatsService = &statsSvc{}
+
+type statsSvc struct {
+	r       ports.StatsRepository
+	catalog ports.CatalogRepository
+}
+
+func (s statsSvc) GetProfileStats(ctx c.Context, actor domain.Actor, userID uuid.UUID) (domain.ProfileStats, error) {
+	tr := global.Tracer(domain.ServiceName)
+	_, span := tr.Start(ctx, s.spanName("GetProfileStats"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	return domain.ProfileStats{},
+		app.NewError(http.StatusNotImplemented, "profile stats are not implemented",
+			fmt.Sprintf("profile stats for user %s are not implemented", userID), nil)
+}
+
+// GetMusicTrackStats returns aggregates of piece by canonical id or alias, piece without reviews has zero stats
+func (s statsSvc) GetMusicTrackStats(ctx c.Context, actor domain.Actor, trackID string) (domain.TrackStats, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("GetMusicTrackStats"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	piece, err := resolvePiece(ctx, s.catalog, trackID)
+	if err != nil {
+		return domain.TrackStats{}, err
+	}
+
+	stats, err := s.r.GetPieceStats(ctx, piece.ID.String())
+	if err != nil {
+		if app.GetCode(err) == http.StatusNotFound {
+			return domain.TrackStats{PieceID: piece.ID.String()}, nil
+		}
+		return domain.TrackStats{}, err
+	}
+	return stats, nil
+}
+
+// UpdateDWH recomputes piece aggregates from scratch, triggers keep them up to date between rebuilds
+func (s statsSvc) UpdateDWH(ctx c.Context) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("UpdateDWH"))
+	defer span.End()
+
+	rebuilt, err := s.r.RebuildPieceStats(ctx)
+	if err != nil {
+		return err
+	}
+	zapctx.Logger(ctx).Info("piece stats rebuilt", zap.Int("pieces", rebuilt))
+	return nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	c "context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/services/musicsnap/internal/config"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"reflect"
+	"time"
+)
+
+const (
+	defaultHeartbeatInterval = 25 * time.Second
+	defaultStreamBatchSize   = 100
+)
+
+func (s streamSvc) spanName(funcName string) string {
+	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
+}
+
+func NewStreamSvc(streamRepository ports.StreamRepository, bus ports.StreamBus, notifications ports.NotificationSvc,
+	cfg config.StreamConfig) ports.StreamService {
+	heartbeatInterval := cfg.HeartbeatInterval
+	if heartbeatInterval <= 0 {
+		heartbeatInterval = defaultHeartbeatInterval
+	}
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultStreamBatchSize
+	}
+	return streamSvc{r: streamRepository, bus: bus, notifications: notifications,
+		heartbeatInterval: heartbeatInterval, batchSize: batchSize}
+}
+
+var _ ports.StreamService = &streamSvc{}
+
+type streamSvc struct {
+	r             ports.StreamRepository
+	bus           ports.StreamBus
+	notifications ports.NotificationSvc
+
+	heartbeatInterval time.Duration
+	batchSize         int
+}
+
+func (s streamSvc) Stream(ctx c.Context, actor domain.Actor, lastEventID int64, sink ports.StreamSink) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("Stream"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	// подписка оформляется до первого чтения, чтобы не потерять события между чтением и ожиданием
+	wake, unsubscribe := s.bus.Subscribe(actor.ID)
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(s.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		events, err := s.r.ListAfter(ctx, actor.ID, lastEventID, s.batchSize)
+		if err != nil {
+			return err
+		}
+		events = s.withTexts(ctx, actor.ID, events)
+		for _, event := range events {
+			err = sink.Send(event)
+			if err != nil {
+				return err
+			}
+			lastEventID = event.ID
+		}
+		if len(events) == s.batchSize {
+			continue
+		}
+
+	wait:
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-s.bus.Draining():
+				return nil
+			case <-wake:
+				break wait
+			case <-heartbeat.C:
+				err = sink.Heartbeat()
+				if err != nil {
+					return err
+				}
+				// событие могло ждать позицию за более старой транзакцией, которая не будит поток
+				break wait
+			}
+		}
+	}
+}
+
+// ReviewPublished adds published review to streams of author's followers
+func (s streamSvc) ReviewPublished(ctx c.Context, review domain.Review) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("ReviewPublished"))
+	defer span.End()
+
+	_, err := s.r.AddFeedEvents(ctx, review)
+	return err
+}
+
+// streamNotification: Уведомление в полезной нагрузке события потока, см. notifications_to_stream()
+type streamNotification struct {
+	ID       uuid.UUID              `json:"id"`
+	SenderID *uuid.UUID             `json:"sender_id"`
+	Type     string                 `json:"type"`
+	Message  map[string]interface{} `json:"message"`
+	Version  int                    `json:"version"`
+	Count    int                    `json:"count"`
+}
+
+// withTexts adds text rendered in locale of receiver to payloads of notification events,
+// event which payload can't be decoded is sent as is
+func (s streamSvc) withTexts(ctx c.Context, userID uuid.UUID, events []domain.StreamEvent) []domain.StreamEvent {
+	logger := zapctx.Logger(ctx)
+
+	indexes := make([]int, 0, len(events))
+	notifications := make([]domain.Notification, 0, len(events))
+	for i, event := range events {
+		if event.Kind != domain.NotificationStreamEvent {
+			continue
+		}
+		var n streamNotification
+		err := json.Unmarshal(event.Payload, &n)
+		if err != nil {
+			logger.Error("can't decode notification of stream event", zap.Int64("eventID", event.ID), zap.Error(err))
+			continue
+		}
+		indexes = append(indexes, i)
+		notifications = append(notifications, domain.Notification{
+			ID:             n.ID,
+			UserIDReceiver: userID,
+			UserIDSender:   n.SenderID,
+			Type:           n.Type,
+			Message:        n.Message,
+			Version:        n.Version,
+			Count:          n.Count,
+		})
+	}
+	if len(notifications) == 0 {
+		return events
+	}
+
+	notifications = s.notifications.RenderNotifications(ctx, userID, notifications)
+	for j, i := range indexes {
+		var payload map[string]interface{}
+		err := json.Unmarshal(events[i].Payload, &payload)
+		if err != nil {
+			continue
+		}
+		payload["text"] = notifications[j].Text
+		withText, err := json.Marshal(payload)
+		if err != nil {
+			continue
+		}
+		events[i].Payload = withText
+	}
+	return events
+}
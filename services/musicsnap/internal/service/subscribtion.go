@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	global "go.opentelemetry.io/otel"
@@ -18,16 +17,20 @@ func (s subscriptionSvc) spanName(funcName string) string {
 	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
 }
 
-func NewSubscriptionSvc(userRepository ports.UserRepository, cache ports.ProfileCache) ports.SubscriptionSvc {
-	return subscriptionSvc{r: userRepository, c: cache, name: "subscription"}
+func NewSubscriptionSvc(userRepository ports.UserRepository, cache ports.ProfileCache, txs ports.TransactionFactory,
+	outbox ports.OutboxRepository, webhooks ports.WebhookPublisher) ports.SubscriptionSvc {
+	return subscriptionSvc{r: userRepository, c: cache, txs: txs, outbox: outbox, webhooks: webhooks, name: "subscription"}
 }
 
 var _ ports.SubscriptionSvc = &subscriptionSvc{}
 
 type subscriptionSvc struct {
-	r    ports.UserRepository
-	c    ports.ProfileCache
-	name string
+	r        ports.UserRepository
+	c        ports.ProfileCache
+	txs      ports.TransactionFactory
+	outbox   ports.OutboxRepository
+	webhooks ports.WebhookPublisher
+	name     string
 }
 
 func (s subscriptionSvc) Create(ctx context.Context, followingActor d.Actor, sub d.Subscription) (d.Subscription, error) {
@@ -42,13 +45,44 @@ func (s subscriptionSvc) Create(ctx context.Context, followingActor d.Actor, sub
 			fmt.Sprintf("can't create sub between other user %s following %s, admin rights needed", followingActor.ID, sub.FollowedID), nil)
 	}
 
-	sub, err := s.r.CreateSub(ctx, sub)
+	var created d.Subscription
+	err := inTransaction(ctx, s.txs, func(ctx context.Context) error {
+		var err error
+		created, err = s.r.CreateSub(ctx, sub)
+		if err != nil {
+			return app.NewError(http.StatusBadRequest, "bad request, can't create subscription",
+				fmt.Sprintf("can't create sub between %s following %s", followingActor.ID, sub.FollowedID), err)
+		}
+		return addEvent(ctx, s.outbox, d.EventSubscribed,
+			d.SubscribedEvent{SubscriberID: created.SubscriberID, FollowedID: created.FollowedID})
+	})
 	if err != nil {
-		return d.Subscription{}, app.NewError(http.StatusBadRequest, "bad request, can't create subscription",
-			fmt.Sprintf("can't create sub between %s following %s", followingActor.ID, sub.FollowedID), err)
+		return d.Subscription{}, err
 	}
+	return created, nil
+}
 
-	return sub, nil
+// HandleEvent publishes user.followed webhook event, id of domain event keeps deliveries unique
+func (s subscriptionSvc) HandleEvent(ctx context.Context, event d.DomainEvent) error {
+	tr := global.Tracer(d.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("HandleEvent"))
+	defer span.End()
+
+	var subscribed d.SubscribedEvent
+	err := event.Decode(&subscribed)
+	if err != nil {
+		return err
+	}
+	return s.webhooks.Publish(ctx, d.WebhookEvent{
+		ID:        event.ID,
+		Type:      d.WebhookUserFollowed,
+		SubjectID: subscribed.FollowedID,
+		Data: map[string]interface{}{
+			"follower_id": subscribed.SubscriberID,
+			"followed_id": subscribed.FollowedID,
+		},
+		CreatedAt: event.CreatedAt,
+	})
 }
 
 func (s subscriptionSvc) Update(ctx context.Context, followingActor d.Actor, sub d.Subscription) (d.Subscription, error) {
@@ -69,7 +103,7 @@ func (s subscriptionSvc) Update(ctx context.Context, followingActor d.Actor, sub
 			fmt.Sprintf("can't find sub between %s following %s", sub.SubscriberID, sub.FollowedID), err)
 	}
 
-	prevSub.NotificationFlag = sub.NotificationFlag
+	prevSub.NotificationFlags = sub.NotificationFlags
 
 	sub, err = s.r.UpdateSub(ctx, prevSub)
 	if err != nil {
@@ -124,6 +158,7 @@ func (s subscriptionSvc) Delete(ctx context.Context, followingActor d.Actor, fol
 	return nil
 }
 
+// Block removes subscriptions between users, blocked user can't notify actor with mentions
 func (s subscriptionSvc) Block(ctx context.Context, actor d.Actor, other uuid.UUID) error {
 	tr := global.Tracer(d.ServiceName)
 	ctx, span := tr.Start(ctx, s.spanName("Block"))
@@ -131,31 +166,12 @@ func (s subscriptionSvc) Block(ctx context.Context, actor d.Actor, other uuid.UU
 
 	ToSpan(&span, actor)
 
-	subTo, errTo := s.r.GetSub(ctx, actor.ID, other)
-	subFrom, errFrom := s.r.GetSub(ctx, other, actor.ID)
-
-	if errTo != nil && errFrom != nil {
-		return app.NewError(http.StatusBadRequest, "subscription not found",
-			fmt.Sprintf("can't find any subs between %s and %s to block", actor.ID, other), errors.Join(errTo, errFrom))
-	}
-
-	if (actor.ID != subTo.SubscriberID || actor.ID != subFrom.FollowedID) && !actor.HasRole(d.AdminRole) {
-		return app.NewError(http.StatusUnauthorized, "can't update subscription from other user",
-			fmt.Sprintf("can't update sub between other user %s following %s, admin rights needed", actor, subFrom.FollowedID), nil)
+	if actor.ID == other {
+		return app.NewError(http.StatusBadRequest, "user can't block himself",
+			fmt.Sprintf("user %s tries to block himself", actor.ID), nil)
 	}
 
-	_, err := s.r.DeleteSub(ctx, subTo)
-	if err != nil {
-		return app.NewError(http.StatusBadRequest, "can't delete subscription",
-			fmt.Sprintf("can't delete subcription %v", subTo), err)
-	}
-	_, err = s.r.DeleteSub(ctx, subFrom)
-	if err != nil {
-		return app.NewError(http.StatusBadRequest, "can't delete subscription",
-			fmt.Sprintf("can't delete subcription %v", subFrom), err)
-	}
-
-	return nil
+	return s.r.Block(ctx, actor.ID, other)
 }
 
 func (s subscriptionSvc) GetSubscriptions(ctx context.Context, actor d.Actor, subscriberID uuid.UUID, pag d.IDPagination) ([]d.Subscription, d.IDPagination, error) {
@@ -0,0 +1,104 @@
+package service
+
+import (
+	c "context"
+	"fmt"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/services/musicsnap/internal/config"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultTrendingWindow = 24 * time.Hour
+	defaultTrendingLimit  = 10
+	maxTrendingLimit      = 100
+)
+
+func (s tagSvc) spanName(funcName string) string {
+	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
+}
+
+// NewTagSvc creates service of mentions and hashtags, with preModeration mentions in review
+// are notified only after moderator approval
+func NewTagSvc(tagRepository ports.TagRepository, cfg config.TagsConfig, preModeration bool) ports.TagService {
+	window := cfg.TrendingWindow
+	if window <= 0 {
+		window = defaultTrendingWindow
+	}
+	return tagSvc{r: tagRepository, trendingWindow: window, preModeration: preModeration}
+}
+
+var _ ports.TagService = &tagSvc{}
+
+type tagSvc struct {
+	r ports.TagRepository
+
+	trendingWindow time.Duration
+	preModeration  bool
+}
+
+func (s tagSvc) index(ctx c.Context, source domain.TextSource, notify bool) error {
+	return s.r.Index(ctx, source, domain.ParseMentions(source.Text), domain.ParseHashtags(source.Text), notify)
+}
+
+// IndexReview notifies mentioned users only when review became public, drafts are indexed silently
+func (s tagSvc) IndexReview(ctx c.Context, review domain.Review) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("IndexReview"))
+	defer span.End()
+
+	return s.index(ctx, domain.TextSource{
+		Type:     domain.TextSourceReview,
+		ID:       strconv.Itoa(review.ID),
+		ReviewID: review.ID,
+		AuthorID: review.UserID,
+		Text:     review.Content,
+	}, review.VisibleToPublic(s.preModeration))
+}
+
+// IndexComment indexes comment of visible review, tombstone clears its mentions and hashtags
+func (s tagSvc) IndexComment(ctx c.Context, comment domain.Comment) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("IndexComment"))
+	defer span.End()
+
+	return s.index(ctx, domain.TextSource{
+		Type:     domain.TextSourceComment,
+		ID:       comment.ID.String(),
+		ReviewID: comment.ReviewID,
+		AuthorID: comment.UserID,
+		Text:     comment.Text,
+	}, !comment.Deleted())
+}
+
+// ReviewPublished notifies users mentioned in draft or scheduled review at its publication
+func (s tagSvc) ReviewPublished(ctx c.Context, review domain.Review) error {
+	return s.IndexReview(ctx, review)
+}
+
+func (s tagSvc) TrendingTags(ctx c.Context, actor domain.Actor, limit int) ([]domain.TagTrend, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("TrendingTags"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if limit <= 0 || limit > maxTrendingLimit {
+		limit = defaultTrendingLimit
+	}
+	return s.r.Trending(ctx, time.Now().Add(-s.trendingWindow), s.preModeration, limit)
+}
+
+// indexComment updates mentions and hashtags of saved comment, failure is only logged
+func indexComment(ctx c.Context, tags ports.TagService, comment domain.Comment) {
+	err := tags.IndexComment(ctx, comment)
+	if err != nil {
+		zapctx.Logger(ctx).Error("can't index mentions and hashtags of comment",
+			zap.String("comment_id", comment.ID.String()), zap.Error(err))
+	}
+}
@@ -18,8 +18,9 @@ func (s userSvc) spanName(funcName string) string {
 	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
 }
 
-func NewUserSvc(userRepository ports.UserRepository, jwtSvc ports.JwtSvc, cache ports.ProfileCache) ports.UserSvc {
-	return userSvc{r: userRepository, c: cache, jwt: jwtSvc}
+func NewUserSvc(userRepository ports.UserRepository, jwtSvc ports.JwtSvc, cache ports.ProfileCache,
+	reportRepository ports.ReportRepository, filter ports.ContentFilter) ports.UserSvc {
+	return userSvc{r: userRepository, c: cache, jwt: jwtSvc, reports: reportRepository, filter: filter}
 }
 
 var _ ports.UserSvc = &userSvc{}
@@ -28,6 +29,33 @@ type userSvc struct {
 	r   ports.UserRepository
 	c   ports.ProfileCache
 	jwt ports.JwtSvc
+
+	reports ports.ReportRepository
+	filter  ports.ContentFilter
+}
+
+// hiddenProfiles returns profiles hidden by reports which actor can't see, moderators and owner see all
+func (s userSvc) hiddenProfiles(ctx c.Context, actor domain.Actor, userIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	hidden := make(map[uuid.UUID]bool)
+	if canModerate(actor) {
+		return hidden, nil
+	}
+
+	ids := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id != actor.ID {
+			ids = append(ids, id.String())
+		}
+	}
+
+	hiddenIDs, err := s.reports.HiddenTargets(ctx, domain.ReportTargetProfile, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range hiddenIDs {
+		hidden[uuid.MustParse(id)] = true
+	}
+	return hidden, nil
 }
 
 func (s userSvc) validForCreation(ctx c.Context, user domain.User) error {
@@ -43,6 +71,10 @@ func (s userSvc) validForCreation(ctx c.Context, user domain.User) error {
 		return app.NewError(http.StatusBadRequest, "invalid user fields for creation",
 			fmt.Sprintf("validation for creation error user password is empty"), nil)
 	}
+	if user.Locale != "" && !domain.ValidLocale(user.Locale) {
+		return app.NewError(http.StatusBadRequest, "unsupported locale",
+			fmt.Sprintf("validation for creation error locale %q is not supported", user.Locale), nil)
+	}
 	if _, err := s.r.GetByEmail(ctx, user.Email); err == nil {
 		return app.NewError(http.StatusBadRequest, "user with same mail already exists",
 			fmt.Sprintf("validation for creation error user with this email already exists"), nil)
@@ -84,10 +116,17 @@ func (s userSvc) Create(ctx c.Context, actor domain.Actor, user domain.User, pas
 				"invalid user fields for registration", err)
 	}
 
+	filtered := s.filter.CheckNickname(user.Nickname)
+	err = filterError(filtered, "nickname")
+	if err != nil {
+		return domain.User{}, err
+	}
+
 	userCreated, err := s.r.Create(ctx, user)
 	if err != nil {
 		return domain.User{}, err
 	}
+	holdFlagged(ctx, s.reports, filtered, domain.ReportTargetProfile, userCreated.ID.String())
 	return userCreated, nil
 }
 
@@ -126,6 +165,10 @@ func (s userSvc) validForUpdate(ctx c.Context, user domain.User) error {
 		return app.NewError(http.StatusBadRequest, "invalid user fields for update",
 			fmt.Sprintf("user password is empty"), nil)
 	}
+	if user.Locale != "" && !domain.ValidLocale(user.Locale) {
+		return app.NewError(http.StatusBadRequest, "unsupported locale",
+			fmt.Sprintf("locale %q is not supported", user.Locale), nil)
+	}
 	if grabbedUser, err := s.r.GetByEmail(ctx, user.Email); err == nil && grabbedUser.ID != user.ID {
 		return app.NewError(http.StatusBadRequest, "user with same mail already exists",
 			fmt.Sprintf("user with this email already exists"), nil)
@@ -164,10 +207,20 @@ func (s userSvc) Update(ctx c.Context, actor domain.Actor, user domain.User, pas
 				"invalid user fields for update", err)
 	}
 
+	// проверяется только новый никнейм, чтобы прочие правки не помечали профиль повторно
+	var filtered domain.FilterResult
+	if current, err := s.r.GetByID(ctx, user.ID); err != nil || current.Nickname != user.Nickname {
+		filtered = s.filter.CheckNickname(user.Nickname)
+		if err := filterError(filtered, "nickname"); err != nil {
+			return domain.User{}, err
+		}
+	}
+
 	userUpdated, err := s.r.UpdateUser(ctx, user)
 	if err != nil {
 		return domain.User{}, err
 	}
+	holdFlagged(ctx, s.reports, filtered, domain.ReportTargetProfile, userUpdated.ID.String())
 
 	return userUpdated, nil
 }
@@ -185,6 +238,15 @@ func (s userSvc) GetProfile(ctx c.Context, actor domain.Actor, userID uuid.UUID)
 			"can't get user by actor id", err)
 	}
 
+	hidden, err := s.hiddenProfiles(ctx, actor, []uuid.UUID{user.ID})
+	if err != nil {
+		return domain.Profile{}, err
+	}
+	if hidden[user.ID] {
+		return domain.Profile{}, app.NewError(http.StatusNotFound, "user not found",
+			fmt.Sprintf("profile %s is hidden by reports", user.ID), nil)
+	}
+
 	return user.Profile, nil
 }
 
@@ -202,9 +264,20 @@ func (s userSvc) GetProfilesList(ctx c.Context, actor domain.Actor, nickNameQuer
 			"can't get users by search query", err)
 	}
 
-	profiles := make([]domain.Profile, len(users))
+	userIDs := make([]uuid.UUID, len(users))
 	for i, user := range users {
-		profiles[i] = user.Profile
+		userIDs[i] = user.ID
+	}
+	hidden, err := s.hiddenProfiles(ctx, actor, userIDs)
+	if err != nil {
+		return []domain.Profile{}, pagination, err
+	}
+
+	profiles := make([]domain.Profile, 0, len(users))
+	for _, user := range users {
+		if !hidden[user.ID] {
+			profiles = append(profiles, user.Profile)
+		}
 	}
 	return profiles, pagination, nil
 }
@@ -275,12 +348,23 @@ func (s userSvc) UpdateProfile(ctx c.Context, actor domain.Actor, profile domain
 			"can't get user by actor id", err)
 	}
 
+	// проверяется только новый никнейм, чтобы прочие правки не помечали профиль повторно
+	var filtered domain.FilterResult
+	if profile.Nickname != user.Nickname {
+		filtered = s.filter.CheckNickname(profile.Nickname)
+		err = filterError(filtered, "nickname")
+		if err != nil {
+			return domain.Profile{}, err
+		}
+	}
+
 	user.Profile = profile
 
 	userCreated, err := s.r.UpdateUser(ctx, user)
 	if err != nil {
 		return domain.Profile{}, err
 	}
+	holdFlagged(ctx, s.reports, filtered, domain.ReportTargetProfile, userCreated.ID.String())
 
 	return userCreated.Profile, nil
 }
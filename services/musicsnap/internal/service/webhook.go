@@ -0,0 +1,304 @@
+package service
+
+import (
+	c "context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/juju/zaputil/zapctx"
+	global "go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"music-snap/pkg/app"
+	"music-snap/services/musicsnap/internal/config"
+	"music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+func (s webhookSvc) spanName(funcName string) string {
+	return fmt.Sprintf("%s/%s.%s.%s", "musicsnap", "service", reflect.TypeOf(s).Name(), funcName)
+}
+
+// webhookSecretSize is the number of random bytes in secret of webhook
+const webhookSecretSize = 32
+
+// NewWebhookSvc creates webhook service, zero settings of delivery are replaced with defaults
+func NewWebhookSvc(webhookRepository ports.WebhookRepository, sender ports.WebhookSender,
+	cfg config.WebhooksConfig) ports.WebhookService {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 8
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = 30 * time.Second
+	}
+	if cfg.MaxBackoff < cfg.BaseBackoff {
+		cfg.MaxBackoff = 6 * time.Hour
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = time.Minute
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 50
+	}
+	return webhookSvc{r: webhookRepository, sender: sender, cfg: cfg}
+}
+
+var _ ports.WebhookService = &webhookSvc{}
+
+type webhookSvc struct {
+	r      ports.WebhookRepository
+	sender ports.WebhookSender
+	cfg    config.WebhooksConfig
+}
+
+func (s webhookSvc) CreateWebhook(ctx c.Context, actor domain.Actor, webhook domain.Webhook) (domain.Webhook, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("CreateWebhook"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if actor.ID == uuid.Nil {
+		return domain.Webhook{}, app.NewError(http.StatusUnauthorized, "unauthorized", "guest can't create webhooks", nil)
+	}
+	if webhook.UserID == nil || *webhook.UserID != actor.ID {
+		if !actor.HasRole(domain.AdminRole) {
+			return domain.Webhook{}, app.NewError(http.StatusForbidden, "can't create webhook of other user or of the app",
+				fmt.Sprintf("user %s can't create webhook of %v, admin rights needed", actor.ID, webhook.UserID), nil)
+		}
+	}
+	if err := webhook.Validate(); err != nil {
+		return domain.Webhook{}, app.NewError(http.StatusBadRequest, "invalid webhook", err.Error(), err)
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return domain.Webhook{}, app.NewError(http.StatusInternalServerError, "unknown error", "failed to generate webhook secret", err)
+	}
+	webhook.Secret = secret
+	webhook.Active = true
+
+	return s.r.Create(ctx, webhook)
+}
+
+func newWebhookSecret() (string, error) {
+	return randomHex(webhookSecretSize)
+}
+
+func (s webhookSvc) ListWebhooks(ctx c.Context, actor domain.Actor) ([]domain.Webhook, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("ListWebhooks"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if actor.ID == uuid.Nil {
+		return nil, app.NewError(http.StatusUnauthorized, "unauthorized", "guest has no webhooks", nil)
+	}
+
+	webhooks, err := s.r.List(ctx, &actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.HasRole(domain.AdminRole) {
+		appWebhooks, err := s.r.List(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(appWebhooks, webhooks...)
+	}
+	return hideSecrets(webhooks), nil
+}
+
+func (s webhookSvc) GetWebhook(ctx c.Context, actor domain.Actor, webhookID int) (domain.Webhook, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("GetWebhook"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	webhook, err := s.ownWebhook(ctx, actor, webhookID)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+// UpdateWebhook changes url, event types and activity, the secret and the owner stay
+func (s webhookSvc) UpdateWebhook(ctx c.Context, actor domain.Actor, webhook domain.Webhook) (domain.Webhook, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("UpdateWebhook"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	prev, err := s.ownWebhook(ctx, actor, webhook.ID)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	webhook.UserID = prev.UserID
+	if err := webhook.Validate(); err != nil {
+		return domain.Webhook{}, app.NewError(http.StatusBadRequest, "invalid webhook", err.Error(), err)
+	}
+
+	updated, err := s.r.Update(ctx, webhook)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	updated.Secret = ""
+	return updated, nil
+}
+
+func (s webhookSvc) DeleteWebhook(ctx c.Context, actor domain.Actor, webhookID int) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("DeleteWebhook"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if _, err := s.ownWebhook(ctx, actor, webhookID); err != nil {
+		return err
+	}
+	return s.r.Delete(ctx, webhookID)
+}
+
+func (s webhookSvc) ListDeliveries(ctx c.Context, actor domain.Actor, filter domain.WebhookDeliveryFilter, pagination domain.IDPagination) ([]domain.WebhookDelivery, domain.IDPagination, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("ListDeliveries"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if _, err := s.ownWebhook(ctx, actor, filter.WebhookID); err != nil {
+		return nil, pagination, err
+	}
+	return s.r.ListDeliveries(ctx, filter, pagination)
+}
+
+func (s webhookSvc) GetDelivery(ctx c.Context, actor domain.Actor, webhookID int, deliveryID int64) (domain.WebhookDelivery, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("GetDelivery"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	return s.ownDelivery(ctx, actor, webhookID, deliveryID)
+}
+
+// Redeliver sends delivery again regardless of its status, e.g. one from the dead-letter list
+func (s webhookSvc) Redeliver(ctx c.Context, actor domain.Actor, webhookID int, deliveryID int64) (domain.WebhookDelivery, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("Redeliver"))
+	defer span.End()
+	ToSpan(&span, actor)
+
+	if _, err := s.ownDelivery(ctx, actor, webhookID, deliveryID); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	return s.r.Redeliver(ctx, deliveryID)
+}
+
+// ownWebhook returns webhook which actor may manage: own one, or any for admin
+func (s webhookSvc) ownWebhook(ctx c.Context, actor domain.Actor, webhookID int) (domain.Webhook, error) {
+	webhook, err := s.r.GetByID(ctx, webhookID)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	if actor.HasRole(domain.AdminRole) || (webhook.UserID != nil && *webhook.UserID == actor.ID) {
+		return webhook, nil
+	}
+	// чужой вебхук не отличается от несуществующего
+	return domain.Webhook{}, app.NewError(http.StatusNotFound, "webhook not found",
+		fmt.Sprintf("webhook %d is not available to user %s", webhookID, actor.ID), nil)
+}
+
+func (s webhookSvc) ownDelivery(ctx c.Context, actor domain.Actor, webhookID int, deliveryID int64) (domain.WebhookDelivery, error) {
+	if _, err := s.ownWebhook(ctx, actor, webhookID); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	delivery, err := s.r.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	if delivery.WebhookID != webhookID {
+		return domain.WebhookDelivery{}, app.NewError(http.StatusNotFound, "delivery not found",
+			fmt.Sprintf("delivery %d does not belong to webhook %d", deliveryID, webhookID), nil)
+	}
+	return delivery, nil
+}
+
+func hideSecrets(webhooks []domain.Webhook) []domain.Webhook {
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return webhooks
+}
+
+func (s webhookSvc) Publish(ctx c.Context, event domain.WebhookEvent) error {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("Publish"))
+	defer span.End()
+
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+
+	enqueued, err := s.r.Enqueue(ctx, event)
+	if err != nil {
+		return err
+	}
+	zapctx.Logger(ctx).Debug("webhook event enqueued", zap.String("type", event.Type),
+		zap.String("eventID", event.ID.String()), zap.Int("deliveries", enqueued))
+	return nil
+}
+
+// ReviewPublished enqueues event with id derived from review, so repeated publication is delivered once
+func (s webhookSvc) ReviewPublished(ctx c.Context, review domain.Review) error {
+	return s.Publish(ctx, domain.WebhookEvent{
+		ID:        uuid.NewSHA1(uuid.NameSpaceURL, []byte(fmt.Sprintf("musicsnap:review.published:%d", review.ID))),
+		Type:      domain.WebhookReviewPublished,
+		SubjectID: review.UserID,
+		Data: map[string]interface{}{
+			"review_id": review.ID,
+			"piece_id":  review.PieceID,
+			"user_id":   review.UserID,
+		},
+	})
+}
+
+// DeliverDue sends due deliveries one by one, failed ones are retried with exponential backoff
+// until MaxAttempts and then go to the dead-letter list
+func (s webhookSvc) DeliverDue(ctx c.Context) (int, error) {
+	tr := global.Tracer(domain.ServiceName)
+	ctx, span := tr.Start(ctx, s.spanName("DeliverDue"))
+	defer span.End()
+
+	deliveries, err := s.r.ClaimDue(ctx, time.Now().UTC(), s.cfg.Lease, s.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		attempt := s.sender.Send(ctx, delivery)
+
+		var retryAt *time.Time
+		attempts := delivery.Attempts + 1
+		if !attempt.Succeeded() && attempts < s.cfg.MaxAttempts {
+			at := attempt.AttemptedAt.Add(domain.Backoff(attempts, s.cfg.BaseBackoff, s.cfg.MaxBackoff))
+			retryAt = &at
+		}
+
+		recorded, err := s.r.RecordAttempt(ctx, attempt, retryAt)
+		if err != nil {
+			return delivered, err
+		}
+		switch recorded.Status {
+		case domain.DeliveryDelivered:
+			delivered++
+		case domain.DeliveryDead:
+			zapctx.Logger(ctx).Warn("webhook delivery moved to dead-letter list",
+				zap.Int64("deliveryID", delivery.ID), zap.Int("webhookID", delivery.WebhookID),
+				zap.Int("attempts", recorded.Attempts), zap.String("error", attempt.Error))
+		}
+	}
+	return delivered, nil
+}
@@ -0,0 +1,122 @@
+package webhooksender
+
+import (
+	"bytes"
+	c "context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	d "music-snap/services/musicsnap/internal/domain"
+	"music-snap/services/musicsnap/internal/service/ports"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Заголовки запроса доставки вебхука
+const (
+	EventHeader     = "X-MusicSnap-Event"
+	DeliveryHeader  = "X-MusicSnap-Delivery"
+	TimestampHeader = "X-MusicSnap-Timestamp"
+	// SignatureHeader is "sha256=" and hex HMAC-SHA256 of "<timestamp>.<body>" with secret of webhook
+	SignatureHeader = "X-MusicSnap-Signature"
+)
+
+const defaultTimeout = 10 * time.Second
+
+var errForbiddenAddress = errors.New("webhook receiver address is not public")
+
+type Config struct {
+	// Timeout of single delivery request
+	Timeout time.Duration `mapstructure:"timeout"`
+	// AllowPrivateNetworks allows delivery to loopback, private and link-local addresses, only for local testing
+	AllowPrivateNetworks bool `mapstructure:"allow_private_networks"`
+}
+
+func New(cfg *Config) ports.WebhookSender {
+	timeout := defaultTimeout
+	if cfg != nil && cfg.Timeout > 0 {
+		timeout = cfg.Timeout
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	if cfg == nil || !cfg.AllowPrivateNetworks {
+		// адрес проверяется после разрешения имени, поэтому DNS rebinding не обходит запрет
+		dialer.Control = refusePrivate
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return sender{client: &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// редирект на другой адрес не считается доставкой
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+type sender struct {
+	client *http.Client
+}
+
+func (s sender) Send(ctx c.Context, delivery d.WebhookDelivery) d.WebhookAttempt {
+	started := time.Now().UTC()
+	attempt := d.WebhookAttempt{DeliveryID: delivery.ID, AttemptedAt: started}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	timestamp := started.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "MusicSnap-Webhooks/1.0")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	attempt.Duration = time.Since(started)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer resp.Body.Close()
+
+	// тело ответа не сохраняется: журнал доставок виден владельцу вебхука
+	attempt.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		attempt.Error = fmt.Sprintf("receiver responded with status %d", resp.StatusCode)
+	}
+	return attempt
+}
+
+// refusePrivate is net.Dialer.Control which refuses connections to non-public addresses
+func refusePrivate(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !d.PublicAddress(ip) {
+		return errForbiddenAddress
+	}
+	return nil
+}
+
+// Sign returns value of SignatureHeader, receivers compute it the same way to verify payload
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,140 @@
+package webhooksender
+
+import (
+	c "context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	d "music-snap/services/musicsnap/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func delivery(t *testing.T, url string) d.WebhookDelivery {
+	event := d.WebhookEvent{
+		ID:        uuid.New(),
+		Type:      d.WebhookReviewPublished,
+		Data:      map[string]interface{}{"review_id": 7},
+		CreatedAt: time.Now().UTC(),
+	}
+	payload, err := event.Body()
+	require.NoError(t, err)
+	return d.WebhookDelivery{ID: 42, EventID: event.ID, EventType: event.Type, Payload: payload, URL: url, Secret: "s3cret"}
+}
+
+// local allows delivery to httptest servers on loopback
+var local = &Config{AllowPrivateNetworks: true}
+
+func TestSend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Signed delivery", func(t *testing.T) {
+		t.Parallel()
+
+		var got *http.Request
+		var body []byte
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		dl := delivery(t, receiver.URL)
+		attempt := New(local).Send(c.Background(), dl)
+
+		assert.True(t, attempt.Succeeded())
+		assert.Equal(t, http.StatusNoContent, attempt.StatusCode)
+		assert.Equal(t, dl.ID, attempt.DeliveryID)
+		require.NotNil(t, got)
+		assert.Equal(t, http.MethodPost, got.Method)
+		assert.Equal(t, d.WebhookReviewPublished, got.Header.Get(EventHeader))
+		assert.Equal(t, "42", got.Header.Get(DeliveryHeader))
+		assert.JSONEq(t, string(dl.Payload), string(body))
+
+		timestamp, err := strconv.ParseInt(got.Header.Get(TimestampHeader), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, Sign("s3cret", timestamp, body), got.Header.Get(SignatureHeader))
+		assert.NotEqual(t, Sign("other", timestamp, body), got.Header.Get(SignatureHeader))
+	})
+
+	t.Run("Receiver error", func(t *testing.T) {
+		t.Parallel()
+
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "try later", http.StatusServiceUnavailable)
+		}))
+		defer receiver.Close()
+
+		attempt := New(local).Send(c.Background(), delivery(t, receiver.URL))
+
+		assert.False(t, attempt.Succeeded())
+		assert.Equal(t, http.StatusServiceUnavailable, attempt.StatusCode)
+		assert.Contains(t, attempt.Error, "503")
+		assert.NotContains(t, attempt.Error, "try later")
+	})
+
+	t.Run("Redirect is not delivery", func(t *testing.T) {
+		t.Parallel()
+
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "https://elsewhere.example", http.StatusFound)
+		}))
+		defer receiver.Close()
+
+		attempt := New(local).Send(c.Background(), delivery(t, receiver.URL))
+
+		assert.False(t, attempt.Succeeded())
+		assert.Equal(t, http.StatusFound, attempt.StatusCode)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		t.Parallel()
+
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}))
+		defer receiver.Close()
+
+		attempt := New(&Config{Timeout: 50 * time.Millisecond, AllowPrivateNetworks: true}).Send(c.Background(), delivery(t, receiver.URL))
+
+		assert.False(t, attempt.Succeeded())
+		assert.Zero(t, attempt.StatusCode)
+		assert.NotEmpty(t, attempt.Error)
+	})
+
+	t.Run("Receiver down", func(t *testing.T) {
+		t.Parallel()
+
+		receiver := httptest.NewServer(http.NotFoundHandler())
+		url := receiver.URL
+		receiver.Close()
+
+		attempt := New(local).Send(c.Background(), delivery(t, url))
+
+		assert.False(t, attempt.Succeeded())
+		assert.Zero(t, attempt.StatusCode)
+		assert.NotEmpty(t, attempt.Error)
+	})
+
+	t.Run("Private address is refused", func(t *testing.T) {
+		t.Parallel()
+
+		called := false
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		defer receiver.Close()
+
+		attempt := New(nil).Send(c.Background(), delivery(t, receiver.URL))
+
+		assert.False(t, attempt.Succeeded())
+		assert.Zero(t, attempt.StatusCode)
+		assert.Contains(t, attempt.Error, errForbiddenAddress.Error())
+		assert.False(t, called)
+	})
+}
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_piece_aliases_piece_id;
+DROP INDEX IF EXISTS idx_tracks_album_id;
+DROP INDEX IF EXISTS idx_tracks_artist_id;
+DROP INDEX IF EXISTS idx_albums_artist_id;
+
+DROP TABLE IF EXISTS piece_aliases;
+DROP TABLE IF EXISTS tracks;
+DROP TABLE IF EXISTS albums;
+DROP TABLE IF EXISTS pieces;
+DROP TABLE IF EXISTS artists;
@@ -0,0 +1,82 @@
+-- Исполнители
+CREATE TABLE artists
+(
+    id         UUID PRIMARY KEY   DEFAULT gen_random_uuid(),
+    name       TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Произведения (канонические идентификаторы треков и альбомов)
+CREATE TABLE pieces
+(
+    id         UUID PRIMARY KEY   DEFAULT gen_random_uuid(),
+    type       TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT piece_type CHECK (type IN ('track', 'album'))
+);
+
+-- Альбомы
+CREATE TABLE albums
+(
+    id           UUID PRIMARY KEY REFERENCES pieces (id) ON DELETE CASCADE,
+    artist_id    UUID REFERENCES artists (id),
+    title        TEXT      NOT NULL,
+    cover_url    TEXT,
+    release_date TIMESTAMP,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Треки
+CREATE TABLE tracks
+(
+    id          UUID PRIMARY KEY REFERENCES pieces (id) ON DELETE CASCADE,
+    artist_id   UUID REFERENCES artists (id),
+    album_id    UUID REFERENCES albums (id) ON DELETE SET NULL,
+    title       TEXT      NOT NULL,
+    duration_ms INTEGER   NOT NULL DEFAULT 0 CHECK (duration_ms >= 0),
+    explicit    BOOLEAN   NOT NULL DEFAULT false,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Внешние идентификаторы произведений (Spotify, MusicBrainz, Яндекс Музыка)
+CREATE TABLE piece_aliases
+(
+    source      TEXT      NOT NULL,
+    external_id TEXT      NOT NULL,
+    piece_id    UUID      NOT NULL REFERENCES pieces (id) ON DELETE CASCADE,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (source, external_id),
+    CONSTRAINT alias_source CHECK (source IN ('spotify', 'musicbrainz', 'yandex_music'))
+);
+
+CREATE INDEX idx_albums_artist_id ON albums (artist_id);
+CREATE INDEX idx_tracks_artist_id ON tracks (artist_id);
+CREATE INDEX idx_tracks_album_id ON tracks (album_id);
+CREATE INDEX idx_piece_aliases_piece_id ON piece_aliases (piece_id);
+
+CREATE TRIGGER update_artists_updated_at
+    BEFORE UPDATE
+    ON artists
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at();
+CREATE TRIGGER update_pieces_updated_at
+    BEFORE UPDATE
+    ON pieces
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at();
+CREATE TRIGGER update_albums_updated_at
+    BEFORE UPDATE
+    ON albums
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at();
+CREATE TRIGGER update_tracks_updated_at
+    BEFORE UPDATE
+    ON tracks
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at();
@@ -0,0 +1,15 @@
+-- Контрольные точки импорта каталога
+CREATE TABLE catalog_import_checkpoints
+(
+    name        TEXT PRIMARY KEY,
+    byte_offset BIGINT    NOT NULL DEFAULT 0 CHECK (byte_offset >= 0),
+    records     BIGINT    NOT NULL DEFAULT 0 CHECK (records >= 0),
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_catalog_import_checkpoints_updated_at
+    BEFORE UPDATE
+    ON catalog_import_checkpoints
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at();
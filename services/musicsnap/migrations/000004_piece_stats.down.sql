@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS reactions_piece_stats ON reactions;
+DROP TRIGGER IF EXISTS reviews_piece_stats ON reviews;
+
+DROP FUNCTION IF EXISTS rebuild_piece_stats();
+DROP FUNCTION IF EXISTS reactions_piece_stats();
+DROP FUNCTION IF EXISTS reviews_piece_stats();
+DROP FUNCTION IF EXISTS piece_stats_add_reaction(VARCHAR, TEXT, INTEGER);
+DROP FUNCTION IF EXISTS piece_stats_add_rating(VARCHAR, INTEGER, BOOLEAN, INTEGER);
+
+DROP TABLE IF EXISTS piece_stats;
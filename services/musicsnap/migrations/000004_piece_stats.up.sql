@@ -0,0 +1,165 @@
+-- Агрегаты оценок и реакций по произведениям, поддерживаются триггерами
+CREATE TABLE piece_stats
+(
+    piece_id       VARCHAR(255) PRIMARY KEY,
+    ratings_count  INTEGER      NOT NULL DEFAULT 0 CHECK (ratings_count >= 0),
+    reviews_count  INTEGER      NOT NULL DEFAULT 0 CHECK (reviews_count >= 0),
+    rating_sum     BIGINT       NOT NULL DEFAULT 0 CHECK (rating_sum >= 0),
+    -- histogram[i] - количество оценок i, от 1 до 10
+    histogram      INTEGER[]    NOT NULL DEFAULT array_fill(0, ARRAY [10]),
+    likes_count    INTEGER      NOT NULL DEFAULT 0 CHECK (likes_count >= 0),
+    dislikes_count INTEGER      NOT NULL DEFAULT 0 CHECK (dislikes_count >= 0),
+    created_at     TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT histogram_size CHECK (array_length(histogram, 1) = 10)
+);
+
+CREATE TRIGGER update_piece_stats_updated_at
+    BEFORE UPDATE
+    ON piece_stats
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at();
+
+-- Добавляет (sign = 1) или вычитает (sign = -1) оценку произведения
+CREATE
+    OR REPLACE FUNCTION piece_stats_add_rating(p_piece_id VARCHAR, p_rating INTEGER, p_is_review BOOLEAN, sign INTEGER)
+    RETURNS VOID AS
+$$
+BEGIN
+    INSERT INTO piece_stats (piece_id) VALUES (p_piece_id) ON CONFLICT (piece_id) DO NOTHING;
+
+    UPDATE piece_stats
+    SET ratings_count       = ratings_count + sign,
+        reviews_count       = reviews_count + CASE WHEN p_is_review THEN sign ELSE 0 END,
+        rating_sum          = rating_sum + sign * p_rating,
+        histogram[p_rating] = histogram[p_rating] + sign
+    WHERE piece_id = p_piece_id;
+END;
+$$
+    language 'plpgsql';
+
+-- Добавляет или вычитает реакцию на рецензию произведения
+CREATE
+    OR REPLACE FUNCTION piece_stats_add_reaction(p_piece_id VARCHAR, p_type TEXT, sign INTEGER)
+    RETURNS VOID AS
+$$
+BEGIN
+    INSERT INTO piece_stats (piece_id) VALUES (p_piece_id) ON CONFLICT (piece_id) DO NOTHING;
+
+    UPDATE piece_stats
+    SET likes_count    = likes_count + CASE WHEN p_type = 'like' THEN sign ELSE 0 END,
+        dislikes_count = dislikes_count + CASE WHEN p_type = 'dislike' THEN sign ELSE 0 END
+    WHERE piece_id = p_piece_id;
+END;
+$$
+    language 'plpgsql';
+
+-- Учитываются только опубликованные рецензии
+CREATE
+    OR REPLACE FUNCTION reviews_piece_stats()
+    RETURNS TRIGGER AS
+$$
+DECLARE
+    reaction RECORD;
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.published THEN
+        PERFORM piece_stats_add_rating(OLD.piece_id, OLD.rating, true, -1);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.published THEN
+        PERFORM piece_stats_add_rating(NEW.piece_id, NEW.rating, true, 1);
+    END IF;
+
+    -- реакции переезжают вместе с рецензией на другое произведение
+    IF TG_OP = 'UPDATE' AND OLD.piece_id IS DISTINCT FROM NEW.piece_id THEN
+        FOR reaction IN SELECT type FROM reactions WHERE review_id = NEW.id
+            LOOP
+                PERFORM piece_stats_add_reaction(OLD.piece_id, reaction.type, -1);
+                PERFORM piece_stats_add_reaction(NEW.piece_id, reaction.type, 1);
+            END LOOP;
+    END IF;
+
+    RETURN NULL;
+END;
+$$
+    language 'plpgsql';
+
+CREATE TRIGGER reviews_piece_stats
+    AFTER INSERT OR DELETE OR UPDATE OF piece_id, rating, published
+    ON reviews
+    FOR EACH ROW
+EXECUTE FUNCTION reviews_piece_stats();
+
+CREATE
+    OR REPLACE FUNCTION reactions_piece_stats()
+    RETURNS TRIGGER AS
+$$
+DECLARE
+    review_piece_id VARCHAR;
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        SELECT piece_id INTO review_piece_id FROM reviews WHERE id = OLD.review_id;
+        IF FOUND THEN
+            PERFORM piece_stats_add_reaction(review_piece_id, OLD.type, -1);
+        END IF;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        SELECT piece_id INTO review_piece_id FROM reviews WHERE id = NEW.review_id;
+        IF FOUND THEN
+            PERFORM piece_stats_add_reaction(review_piece_id, NEW.type, 1);
+        END IF;
+    END IF;
+
+    RETURN NULL;
+END;
+$$
+    language 'plpgsql';
+
+CREATE TRIGGER reactions_piece_stats
+    AFTER INSERT OR DELETE OR UPDATE OF type, review_id
+    ON reactions
+    FOR EACH ROW
+EXECUTE FUNCTION reactions_piece_stats();
+
+-- Пересчет агрегатов с нуля по текущим данным
+CREATE
+    OR REPLACE FUNCTION rebuild_piece_stats()
+    RETURNS INTEGER AS
+$$
+DECLARE
+    rebuilt INTEGER;
+BEGIN
+    LOCK TABLE piece_stats IN EXCLUSIVE MODE;
+    DELETE FROM piece_stats;
+
+    INSERT INTO piece_stats (piece_id, ratings_count, reviews_count, rating_sum, histogram)
+    SELECT r.piece_id,
+           COUNT(*),
+           COUNT(*),
+           SUM(r.rating),
+           ARRAY(SELECT COUNT(r2.id)::INTEGER
+                 FROM generate_series(1, 10) g
+                          LEFT JOIN reviews r2 ON r2.piece_id = r.piece_id AND r2.published AND r2.rating = g
+                 GROUP BY g
+                 ORDER BY g)
+    FROM reviews r
+    WHERE r.published
+    GROUP BY r.piece_id;
+
+    INSERT INTO piece_stats (piece_id, likes_count, dislikes_count)
+    SELECT r.piece_id,
+           COUNT(*) FILTER (WHERE re.type = 'like'),
+           COUNT(*) FILTER (WHERE re.type = 'dislike')
+    FROM reactions re
+             JOIN reviews r ON r.id = re.review_id
+    GROUP BY r.piece_id
+    ON CONFLICT (piece_id) DO UPDATE SET likes_count    = EXCLUDED.likes_count,
+                                         dislikes_count = EXCLUDED.dislikes_count;
+
+    SELECT COUNT(*) INTO rebuilt FROM piece_stats;
+    RETURN rebuilt;
+END;
+$$
+    language 'plpgsql';
+
+SELECT rebuild_piece_stats();
@@ -0,0 +1,51 @@
+DROP TRIGGER IF EXISTS ratings_piece_stats ON ratings;
+DROP FUNCTION IF EXISTS ratings_piece_stats();
+
+DROP INDEX IF EXISTS idx_ratings_piece_id;
+ALTER TABLE ratings
+    DROP CONSTRAINT IF EXISTS ratings_user_piece_unique;
+ALTER TABLE ratings
+    ALTER COLUMN user_id DROP NOT NULL;
+
+-- Пересчет агрегатов с нуля по текущим данным
+CREATE
+    OR REPLACE FUNCTION rebuild_piece_stats()
+    RETURNS INTEGER AS
+$$
+DECLARE
+    rebuilt INTEGER;
+BEGIN
+    LOCK TABLE piece_stats IN EXCLUSIVE MODE;
+    DELETE FROM piece_stats;
+
+    INSERT INTO piece_stats (piece_id, ratings_count, reviews_count, rating_sum, histogram)
+    SELECT r.piece_id,
+           COUNT(*),
+           COUNT(*),
+           SUM(r.rating),
+           ARRAY(SELECT COUNT(r2.id)::INTEGER
+                 FROM generate_series(1, 10) g
+                          LEFT JOIN reviews r2 ON r2.piece_id = r.piece_id AND r2.published AND r2.rating = g
+                 GROUP BY g
+                 ORDER BY g)
+    FROM reviews r
+    WHERE r.published
+    GROUP BY r.piece_id;
+
+    INSERT INTO piece_stats (piece_id, likes_count, dislikes_count)
+    SELECT r.piece_id,
+           COUNT(*) FILTER (WHERE re.type = 'like'),
+           COUNT(*) FILTER (WHERE re.type = 'dislike')
+    FROM reactions re
+             JOIN reviews r ON r.id = re.review_id
+    GROUP BY r.piece_id
+    ON CONFLICT (piece_id) DO UPDATE SET likes_count    = EXCLUDED.likes_count,
+                                         dislikes_count = EXCLUDED.dislikes_count;
+
+    SELECT COUNT(*) INTO rebuilt FROM piece_stats;
+    RETURN rebuilt;
+END;
+$$
+    language 'plpgsql';
+
+SELECT rebuild_piece_stats();
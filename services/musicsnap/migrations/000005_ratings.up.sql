@@ -0,0 +1,90 @@
+-- Быстрые оценки без рецензии: одна оценка пользователя на произведение
+DELETE
+FROM ratings
+WHERE user_id IS NULL;
+
+DELETE
+FROM ratings r
+    USING ratings newer
+WHERE r.user_id = newer.user_id
+  AND r.piece_id = newer.piece_id
+  AND (r.updated_at, r.id) < (newer.updated_at, newer.id);
+
+ALTER TABLE ratings
+    ALTER COLUMN user_id SET NOT NULL;
+ALTER TABLE ratings
+    ADD CONSTRAINT ratings_user_piece_unique UNIQUE (user_id, piece_id);
+
+CREATE INDEX idx_ratings_piece_id ON ratings (piece_id);
+
+CREATE
+    OR REPLACE FUNCTION ratings_piece_stats()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM piece_stats_add_rating(OLD.piece_id, OLD.rating, false, -1);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM piece_stats_add_rating(NEW.piece_id, NEW.rating, false, 1);
+    END IF;
+
+    RETURN NULL;
+END;
+$$
+    language 'plpgsql';
+
+CREATE TRIGGER ratings_piece_stats
+    AFTER INSERT OR DELETE OR UPDATE OF piece_id, rating
+    ON ratings
+    FOR EACH ROW
+EXECUTE FUNCTION ratings_piece_stats();
+
+-- Пересчет агрегатов с нуля, оценки считаются по опубликованным рецензиям и быстрым оценкам
+CREATE
+    OR REPLACE FUNCTION rebuild_piece_stats()
+    RETURNS INTEGER AS
+$$
+DECLARE
+    rebuilt INTEGER;
+BEGIN
+    LOCK TABLE piece_stats IN EXCLUSIVE MODE;
+    DELETE FROM piece_stats;
+
+    WITH all_ratings AS (SELECT piece_id, rating, true AS is_review
+                         FROM reviews
+                         WHERE published
+                         UNION ALL
+                         SELECT piece_id, rating, false AS is_review
+                         FROM ratings)
+    INSERT
+    INTO piece_stats (piece_id, ratings_count, reviews_count, rating_sum, histogram)
+    SELECT a.piece_id,
+           COUNT(*),
+           COUNT(*) FILTER (WHERE a.is_review),
+           SUM(a.rating),
+           ARRAY(SELECT COUNT(a2.rating)::INTEGER
+                 FROM generate_series(1, 10) g
+                          LEFT JOIN all_ratings a2 ON a2.piece_id = a.piece_id AND a2.rating = g
+                 GROUP BY g
+                 ORDER BY g)
+    FROM all_ratings a
+    GROUP BY a.piece_id;
+
+    INSERT INTO piece_stats (piece_id, likes_count, dislikes_count)
+    SELECT r.piece_id,
+           COUNT(*) FILTER (WHERE re.type = 'like'),
+           COUNT(*) FILTER (WHERE re.type = 'dislike')
+    FROM reactions re
+             JOIN reviews r ON r.id = re.review_id
+    GROUP BY r.piece_id
+    ON CONFLICT (piece_id) DO UPDATE SET likes_count    = EXCLUDED.likes_count,
+                                         dislikes_count = EXCLUDED.dislikes_count;
+
+    SELECT COUNT(*) INTO rebuilt FROM piece_stats;
+    RETURN rebuilt;
+END;
+$$
+    language 'plpgsql';
+
+SELECT rebuild_piece_stats();
@@ -0,0 +1,7 @@
+ALTER TABLE reviews
+    DROP COLUMN IF EXISTS edited_at;
+
+DROP TRIGGER IF EXISTS review_revisions_append_only ON review_revisions;
+DROP FUNCTION IF EXISTS review_revisions_append_only();
+
+DROP TABLE IF EXISTS review_revisions;
@@ -0,0 +1,41 @@
+-- История правок рецензий, ревизии только добавляются
+CREATE TABLE review_revisions
+(
+    id         SERIAL PRIMARY KEY,
+    review_id  INTEGER      NOT NULL REFERENCES reviews (id) ON DELETE CASCADE,
+    revision   INTEGER      NOT NULL CHECK (revision > 0),
+    editor_id  UUID REFERENCES users (id) ON DELETE SET NULL,
+    piece_id   VARCHAR(255) NOT NULL,
+    rating     INTEGER      NOT NULL CHECK (rating BETWEEN 1 AND 10),
+    content    TEXT         NOT NULL,
+    photo_url  TEXT,
+    created_at TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT review_revisions_review_revision_unique UNIQUE (review_id, revision)
+);
+
+CREATE
+    OR REPLACE FUNCTION review_revisions_append_only()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'review revisions are append-only';
+END;
+$$
+    language 'plpgsql';
+
+-- удаление разрешено только каскадом вместе с рецензией
+CREATE TRIGGER review_revisions_append_only
+    BEFORE UPDATE
+    ON review_revisions
+    FOR EACH ROW
+EXECUTE FUNCTION review_revisions_append_only();
+
+-- Время последней правки содержимого, NULL у рецензий без правок
+ALTER TABLE reviews
+    ADD COLUMN edited_at TIMESTAMP;
+
+-- Первая ревизия существующих рецензий - их текущий текст
+INSERT INTO review_revisions (review_id, revision, editor_id, piece_id, rating, content, photo_url, created_at)
+SELECT id, 1, user_id, piece_id, rating, COALESCE(content, ''), COALESCE(photo_url, ''), created_at
+FROM reviews;
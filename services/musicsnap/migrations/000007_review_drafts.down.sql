@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS reviews_enqueue_publication ON reviews;
+DROP TRIGGER IF EXISTS reviews_set_published_at ON reviews;
+DROP FUNCTION IF EXISTS reviews_enqueue_publication();
+DROP FUNCTION IF EXISTS reviews_set_published_at();
+
+DROP TABLE IF EXISTS review_publications;
+
+DROP INDEX IF EXISTS idx_reviews_publish_at;
+ALTER TABLE reviews
+    DROP COLUMN IF EXISTS published_at,
+    DROP COLUMN IF EXISTS publish_at;
@@ -0,0 +1,64 @@
+-- Черновики и отложенная публикация рецензий
+ALTER TABLE reviews
+    ADD COLUMN publish_at   TIMESTAMP,
+    ADD COLUMN published_at TIMESTAMP;
+
+-- Уже опубликованные рецензии считаются опубликованными при создании
+UPDATE reviews
+SET published_at = created_at
+WHERE published;
+
+CREATE INDEX idx_reviews_publish_at ON reviews (publish_at) WHERE NOT published AND publish_at IS NOT NULL;
+
+-- Очередь побочных эффектов публикации, строка появляется один раз за жизнь рецензии
+CREATE TABLE review_publications
+(
+    review_id    INTEGER PRIMARY KEY REFERENCES reviews (id) ON DELETE CASCADE,
+    published_at TIMESTAMP NOT NULL,
+    processed_at TIMESTAMP
+);
+
+CREATE INDEX idx_review_publications_pending ON review_publications (published_at) WHERE processed_at IS NULL;
+
+-- Первая публикация фиксирует время, повторная публикация после снятия не меняет его
+CREATE
+    OR REPLACE FUNCTION reviews_set_published_at()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+    IF NEW.published AND NEW.published_at IS NULL THEN
+        NEW.published_at = NOW();
+        NEW.publish_at = NULL;
+    END IF;
+    RETURN NEW;
+END;
+$$
+    language 'plpgsql';
+
+CREATE TRIGGER reviews_set_published_at
+    BEFORE INSERT OR UPDATE OF published
+    ON reviews
+    FOR EACH ROW
+EXECUTE FUNCTION reviews_set_published_at();
+
+CREATE
+    OR REPLACE FUNCTION reviews_enqueue_publication()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+    IF NEW.published_at IS NOT NULL AND (TG_OP = 'INSERT' OR OLD.published_at IS NULL) THEN
+        INSERT INTO review_publications (review_id, published_at)
+        VALUES (NEW.id, NEW.published_at)
+        ON CONFLICT (review_id) DO NOTHING;
+    END IF;
+    RETURN NULL;
+END;
+$$
+    language 'plpgsql';
+
+-- без списка колонок: published_at меняется BEFORE триггером, а не самим запросом
+CREATE TRIGGER reviews_enqueue_publication
+    AFTER INSERT OR UPDATE
+    ON reviews
+    FOR EACH ROW
+EXECUTE FUNCTION reviews_enqueue_publication();
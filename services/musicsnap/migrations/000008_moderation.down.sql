@@ -0,0 +1,14 @@
+DROP TRIGGER IF EXISTS reviews_enqueue_moderation ON reviews;
+DROP FUNCTION IF EXISTS reviews_enqueue_moderation();
+
+DROP TRIGGER IF EXISTS reviews_sync_moderation ON reviews;
+DROP FUNCTION IF EXISTS reviews_sync_moderation();
+
+DROP TABLE IF EXISTS moderation_actions;
+DROP TABLE IF EXISTS moderation_queue;
+
+DROP INDEX IF EXISTS idx_reviews_moderation_status;
+
+ALTER TABLE reviews
+    DROP COLUMN IF EXISTS moderation_reason,
+    DROP COLUMN IF EXISTS moderation_status;
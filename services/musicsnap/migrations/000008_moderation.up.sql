@@ -0,0 +1,93 @@
+-- Модерация рецензий: статус, очередь и журнал действий модераторов
+ALTER TABLE reviews
+    ADD COLUMN moderation_status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (moderation_status IN ('pending', 'approved', 'rejected')),
+    ADD COLUMN moderation_reason TEXT;
+
+UPDATE reviews
+SET moderation_status = 'approved'
+WHERE moderated;
+
+CREATE INDEX idx_reviews_moderation_status ON reviews (moderation_status) WHERE moderation_status <> 'approved';
+
+-- Рецензия в очереди не более одного раза, причина показывает, почему она туда попала
+CREATE TABLE moderation_queue
+(
+    review_id   INTEGER PRIMARY KEY REFERENCES reviews (id) ON DELETE CASCADE,
+    reason      TEXT      NOT NULL CHECK (reason IN ('new', 'edited', 'reported')),
+    enqueued_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    claimed_by  UUID REFERENCES users (id) ON DELETE SET NULL,
+    claimed_at  TIMESTAMP
+);
+
+CREATE INDEX idx_moderation_queue_enqueued_at ON moderation_queue (enqueued_at);
+
+INSERT INTO moderation_queue (review_id, reason, enqueued_at)
+SELECT id, 'new', created_at
+FROM reviews
+WHERE moderation_status = 'pending';
+
+-- Журнал не ссылается на рецензию, чтобы переживать её удаление
+CREATE TABLE moderation_actions
+(
+    id           SERIAL PRIMARY KEY,
+    review_id    INTEGER   NOT NULL,
+    moderator_id UUID REFERENCES users (id) ON DELETE SET NULL,
+    action       TEXT      NOT NULL CHECK (action IN ('claim', 'release', 'approve', 'reject')),
+    reason_code  TEXT,
+    comment      TEXT      NOT NULL DEFAULT '',
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_moderation_actions_moderator_id ON moderation_actions (moderator_id, id);
+CREATE INDEX idx_moderation_actions_review_id ON moderation_actions (review_id, id);
+
+-- moderated оставлен для совместимости и выводится из статуса.
+-- Правка отклонённой рецензии возвращает её на модерацию
+CREATE
+    OR REPLACE FUNCTION reviews_sync_moderation()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.edited_at IS DISTINCT FROM OLD.edited_at
+        AND OLD.moderation_status = 'rejected' THEN
+        NEW.moderation_status = 'pending';
+        NEW.moderation_reason = NULL;
+    END IF;
+    NEW.moderated = NEW.moderation_status <> 'pending';
+    RETURN NEW;
+END;
+$$
+    language 'plpgsql';
+
+CREATE TRIGGER reviews_sync_moderation
+    BEFORE INSERT OR UPDATE
+    ON reviews
+    FOR EACH ROW
+EXECUTE FUNCTION reviews_sync_moderation();
+
+-- Новые рецензии попадают в очередь сразу, правки одобренных - на повторную проверку
+CREATE
+    OR REPLACE FUNCTION reviews_enqueue_moderation()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO moderation_queue (review_id, reason)
+        VALUES (NEW.id, 'new')
+        ON CONFLICT (review_id) DO NOTHING;
+    ELSIF NEW.edited_at IS DISTINCT FROM OLD.edited_at THEN
+        INSERT INTO moderation_queue (review_id, reason)
+        VALUES (NEW.id, 'edited')
+        ON CONFLICT (review_id) DO NOTHING;
+    END IF;
+    RETURN NULL;
+END;
+$$
+    language 'plpgsql';
+
+CREATE TRIGGER reviews_enqueue_moderation
+    AFTER INSERT OR UPDATE OF edited_at
+    ON reviews
+    FOR EACH ROW
+EXECUTE FUNCTION reviews_enqueue_moderation();
@@ -0,0 +1,5 @@
+ALTER TABLE reviews
+    DROP COLUMN IF EXISTS hidden;
+
+DROP TABLE IF EXISTS report_targets;
+DROP TABLE IF EXISTS reports;
@@ -0,0 +1,48 @@
+-- Жалобы пользователей на рецензии, профили, заметки и фото событий
+CREATE TABLE reports
+(
+    id          SERIAL PRIMARY KEY,
+    reporter_id UUID      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    target_type TEXT      NOT NULL CHECK (target_type IN ('review', 'profile', 'note', 'event_photo')),
+    target_id   TEXT      NOT NULL,
+    reason      TEXT      NOT NULL,
+    comment     TEXT      NOT NULL DEFAULT '',
+    status      TEXT      NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'upheld', 'dismissed')),
+    resolved_by UUID REFERENCES users (id) ON DELETE SET NULL,
+    resolved_at TIMESTAMP,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Одна открытая жалоба от пользователя на объект, после решения можно пожаловаться снова
+CREATE UNIQUE INDEX idx_reports_open_per_reporter ON reports (reporter_id, target_type, target_id) WHERE status = 'open';
+CREATE INDEX idx_reports_target ON reports (target_type, target_id) WHERE status = 'open';
+
+CREATE TRIGGER update_reports_updated_at
+    BEFORE UPDATE
+    ON reports
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at();
+
+-- Сводка по объекту жалоб: объект скрывается при достижении порога до решения модератора
+CREATE TABLE report_targets
+(
+    target_type  TEXT      NOT NULL,
+    target_id    TEXT      NOT NULL,
+    open_reports INTEGER   NOT NULL DEFAULT 0,
+    hidden_at    TIMESTAMP,
+    updated_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (target_type, target_id)
+);
+
+CREATE INDEX idx_report_targets_hidden ON report_targets (target_type) WHERE hidden_at IS NOT NULL;
+
+CREATE TRIGGER update_report_targets_updated_at
+    BEFORE UPDATE
+    ON report_targets
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at();
+
+-- Копия hidden_at из report_targets для фильтрации списков рецензий
+ALTER TABLE reviews
+    ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT false;
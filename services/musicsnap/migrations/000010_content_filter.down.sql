@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_reports_open_by_filter;
+
+DELETE
+FROM reports
+WHERE reporter_id IS NULL;
+
+ALTER TABLE reports
+    ALTER COLUMN reporter_id SET NOT NULL;
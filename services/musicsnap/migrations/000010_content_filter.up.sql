@@ -0,0 +1,6 @@
+-- Жалобы без автора подаёт фильтр контента: помеченный текст скрыт до решения модератора
+ALTER TABLE reports
+    ALTER COLUMN reporter_id DROP NOT NULL;
+
+-- Одна открытая жалоба фильтра на объект
+CREATE UNIQUE INDEX idx_reports_open_by_filter ON reports (target_type, target_id) WHERE status = 'open' AND reporter_id IS NULL;
@@ -0,0 +1,19 @@
+DROP TRIGGER IF EXISTS comments_count ON comments;
+DROP FUNCTION IF EXISTS comments_count();
+
+DROP INDEX IF EXISTS idx_comments_thread_path;
+DROP INDEX IF EXISTS idx_comments_seq;
+
+ALTER TABLE comments
+    DROP CONSTRAINT comments_thread_id_fkey,
+    ADD CONSTRAINT comments_thread_id_fkey FOREIGN KEY (thread_id) REFERENCES threads (id),
+    DROP COLUMN deleted_at,
+    DROP COLUMN edited_at,
+    DROP COLUMN path,
+    DROP COLUMN seq,
+    DROP COLUMN depth,
+    DROP COLUMN parent_id;
+
+ALTER TABLE threads
+    DROP COLUMN comments_count,
+    DROP COLUMN review_id;
@@ -0,0 +1,47 @@
+-- Тред комментариев рецензии, создаётся вместе с первым комментарием
+ALTER TABLE threads
+    ADD COLUMN review_id      INT UNIQUE REFERENCES reviews (id) ON DELETE CASCADE,
+    ADD COLUMN comments_count INT NOT NULL DEFAULT 0;
+
+-- Комментарии образуют дерево: path - seq предков и самого комментария, сортировка по нему даёт обход в глубину
+ALTER TABLE comments
+    ADD COLUMN parent_id  UUID REFERENCES comments (id) ON DELETE CASCADE,
+    ADD COLUMN depth      INT      NOT NULL DEFAULT 0,
+    ADD COLUMN seq        BIGSERIAL,
+    ADD COLUMN path       BIGINT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN edited_at  TIMESTAMP,
+    -- удалённый комментарий остаётся в дереве без текста, чтобы не терять ответы на него
+    ADD COLUMN deleted_at TIMESTAMP,
+    DROP CONSTRAINT comments_thread_id_fkey,
+    ADD CONSTRAINT comments_thread_id_fkey FOREIGN KEY (thread_id) REFERENCES threads (id) ON DELETE CASCADE;
+
+UPDATE comments
+SET path = ARRAY [seq];
+
+CREATE UNIQUE INDEX idx_comments_seq ON comments (seq);
+CREATE INDEX idx_comments_thread_path ON comments (thread_id, path);
+
+-- Количество комментариев без удалённых
+CREATE
+    OR REPLACE FUNCTION comments_count()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE threads SET comments_count = comments_count + 1 WHERE id = NEW.thread_id;
+    ELSIF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+        UPDATE threads SET comments_count = comments_count - 1 WHERE id = NEW.thread_id;
+    END IF;
+    RETURN NULL;
+END;
+$$
+    language 'plpgsql';
+
+CREATE TRIGGER comments_count
+    AFTER INSERT OR UPDATE OF deleted_at
+    ON comments
+    FOR EACH ROW
+EXECUTE FUNCTION comments_count();
+
+UPDATE threads t
+SET comments_count = (SELECT COUNT(*) FROM comments c WHERE c.thread_id = t.id AND c.deleted_at IS NULL);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS hashtags;
+DROP TABLE IF EXISTS mentions;
+DROP TABLE IF EXISTS user_blocks;
@@ -0,0 +1,42 @@
+-- Блокировки пользователей: заблокированный не может уведомлять заблокировавшего
+CREATE TABLE user_blocks
+(
+    blocker_id UUID      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    blocked_id UUID      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (blocker_id, blocked_id)
+);
+
+CREATE INDEX idx_user_blocks_blocked ON user_blocks (blocked_id);
+
+-- Упоминания @nickname в рецензиях и комментариях, source_id - id рецензии или комментария
+CREATE TABLE mentions
+(
+    source_type TEXT      NOT NULL CHECK (source_type IN ('review', 'comment')),
+    source_id   TEXT      NOT NULL,
+    review_id   INT       NOT NULL REFERENCES reviews (id) ON DELETE CASCADE,
+    user_id     UUID      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    author_id   UUID      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    -- уведомление отправляется один раз, когда текст становится публичным
+    notified_at TIMESTAMP,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (source_type, source_id, user_id)
+);
+
+CREATE INDEX idx_mentions_user ON mentions (user_id);
+CREATE INDEX idx_mentions_review ON mentions (review_id);
+
+-- Хэштеги #tag, created_at - время первого появления тега в тексте, по нему считаются популярные теги
+CREATE TABLE hashtags
+(
+    source_type TEXT      NOT NULL CHECK (source_type IN ('review', 'comment')),
+    source_id   TEXT      NOT NULL,
+    review_id   INT       NOT NULL REFERENCES reviews (id) ON DELETE CASCADE,
+    tag         TEXT      NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (source_type, source_id, tag)
+);
+
+CREATE INDEX idx_hashtags_tag_review ON hashtags (tag, review_id);
+CREATE INDEX idx_hashtags_created_at ON hashtags (created_at);
+CREATE INDEX idx_hashtags_review ON hashtags (review_id);
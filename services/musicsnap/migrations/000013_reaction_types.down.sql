@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS reactions_review_type_idx;
+
+DELETE
+FROM reactions
+WHERE type NOT IN ('like', 'dislike');
+
+ALTER TABLE reactions
+    DROP CONSTRAINT reaction_type;
+
+ALTER TABLE reactions
+    ADD CONSTRAINT reaction_type CHECK (type IN ('like', 'dislike'));
@@ -0,0 +1,10 @@
+-- Набор типов реакций задаётся конфигурацией сервиса, база проверяет только формат.
+-- В статистике произведений по-прежнему учитываются только like и dislike
+ALTER TABLE reactions
+    DROP CONSTRAINT reaction_type;
+
+ALTER TABLE reactions
+    ADD CONSTRAINT reaction_type CHECK (type ~ '^[a-z0-9_]{1,32}$');
+
+-- Подсчет реакций по типам и список отреагировавших с курсором по id
+CREATE INDEX reactions_review_type_idx ON reactions (review_id, type, id);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS notifications_user_unread_idx;
+DROP INDEX IF EXISTS notifications_user_created_idx;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS sender_id;
+
+ALTER TABLE notifications
+    ALTER COLUMN message TYPE TEXT USING message::text;
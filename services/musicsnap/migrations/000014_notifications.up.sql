@@ -0,0 +1,13 @@
+-- Полезная нагрузка уведомления хранится как JSONB
+ALTER TABLE notifications
+    ALTER COLUMN message TYPE JSONB USING message::jsonb;
+
+-- Отправитель уведомления, NULL для системных уведомлений
+ALTER TABLE notifications
+    ADD COLUMN sender_id UUID REFERENCES users (id) ON DELETE SET NULL;
+
+-- Лента уведомлений пользователя от новых к старым
+CREATE INDEX notifications_user_created_idx ON notifications (user_id, created_at DESC, id DESC);
+
+-- Счетчик непрочитанных
+CREATE INDEX notifications_user_unread_idx ON notifications (user_id) WHERE NOT read;
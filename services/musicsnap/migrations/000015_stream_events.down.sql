@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS notifications_to_stream ON notifications;
+DROP FUNCTION IF EXISTS notifications_to_stream();
+
+DROP TRIGGER IF EXISTS stream_events_notify ON stream_events;
+DROP FUNCTION IF EXISTS stream_events_notify();
+
+DROP TABLE IF EXISTS stream_events;
@@ -0,0 +1,45 @@
+-- События для real-time доставки клиентам, id - позиция для возобновления потока
+CREATE TABLE stream_events
+(
+    id         BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    kind       VARCHAR(32) NOT NULL,
+    payload    JSONB       NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX stream_events_user_idx ON stream_events (user_id, id);
+
+-- Новое событие будит подписчиков на всех репликах через LISTEN/NOTIFY
+CREATE FUNCTION stream_events_notify() RETURNS trigger AS
+$$
+BEGIN
+    PERFORM pg_notify('musicsnap_stream', json_build_object('id', NEW.id, 'user_id', NEW.user_id)::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER stream_events_notify
+    AFTER INSERT
+    ON stream_events
+    FOR EACH ROW
+EXECUTE FUNCTION stream_events_notify();
+
+-- Каждое уведомление попадает в поток получателя
+CREATE FUNCTION notifications_to_stream() RETURNS trigger AS
+$$
+BEGIN
+    INSERT INTO stream_events (user_id, kind, payload)
+    VALUES (NEW.user_id, 'notification',
+            jsonb_build_object('id', NEW.id, 'user_id', NEW.user_id, 'sender_id', NEW.sender_id,
+                               'type', NEW.type, 'message', NEW.message, 'read', NEW.read,
+                               'created_at', NEW.created_at));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notifications_to_stream
+    AFTER INSERT
+    ON notifications
+    FOR EACH ROW
+EXECUTE FUNCTION notifications_to_stream();
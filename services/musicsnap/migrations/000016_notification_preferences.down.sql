@@ -0,0 +1,35 @@
+DROP INDEX IF EXISTS notifications_user_unread_idx;
+CREATE INDEX notifications_user_unread_idx ON notifications (user_id) WHERE NOT read;
+
+CREATE OR REPLACE FUNCTION notifications_to_stream() RETURNS trigger AS
+$$
+BEGIN
+    INSERT INTO stream_events (user_id, kind, payload)
+    VALUES (NEW.user_id, 'notification',
+            jsonb_build_object('id', NEW.id, 'user_id', NEW.user_id, 'sender_id', NEW.sender_id,
+                               'type', NEW.type, 'message', NEW.message, 'read', NEW.read,
+                               'created_at', NEW.created_at));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS notifications_apply_preferences ON notifications;
+DROP FUNCTION IF EXISTS notifications_apply_preferences();
+DROP FUNCTION IF EXISTS notification_channels(UUID, TEXT);
+
+DELETE FROM notifications WHERE NOT 'in_app' = ANY (channels);
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS channels;
+
+DROP TABLE IF EXISTS notification_preferences;
+
+ALTER TABLE subscriptions
+    ADD COLUMN notification_flag BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE subscriptions
+SET notification_flag = (notification_flags ->> 'new_review')::boolean
+    OR (notification_flags ->> 'new_playlist')::boolean
+    OR (notification_flags ->> 'event')::boolean;
+
+ALTER TABLE subscriptions
+    DROP COLUMN notification_flags;
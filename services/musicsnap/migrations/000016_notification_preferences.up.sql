@@ -0,0 +1,76 @@
+-- Флаги подписки по типам событий вместо одного notification_flag
+ALTER TABLE subscriptions
+    ADD COLUMN notification_flags JSONB NOT NULL
+        DEFAULT '{"new_review": false, "new_playlist": false, "event": false}';
+
+UPDATE subscriptions
+SET notification_flags = jsonb_build_object('new_review', notification_flag,
+                                            'new_playlist', notification_flag,
+                                            'event', notification_flag);
+
+ALTER TABLE subscriptions
+    DROP COLUMN notification_flag;
+
+-- Настройки пользователя: тип уведомления x канал доставки, хранятся только явно заданные ячейки
+CREATE TABLE notification_preferences
+(
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    type       VARCHAR(64) NOT NULL,
+    channel    VARCHAR(16) NOT NULL,
+    enabled    BOOLEAN     NOT NULL,
+    updated_at TIMESTAMP   NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, type, channel),
+    CONSTRAINT notification_channel CHECK (channel IN ('in_app', 'email', 'push'))
+);
+
+-- Каналы, по которым уведомление будет доставлено
+ALTER TABLE notifications
+    ADD COLUMN channels TEXT[] NOT NULL DEFAULT '{in_app}';
+
+-- Значения по умолчанию совпадают с domain.defaultChannels
+CREATE FUNCTION notification_channels(receiver UUID, notification_type TEXT) RETURNS TEXT[] AS
+$$
+SELECT COALESCE(array_agg(c.channel ORDER BY c.channel), '{}')
+FROM (VALUES ('in_app', true), ('email', false), ('push', true)) AS c(channel, default_enabled)
+         LEFT JOIN notification_preferences p
+                   ON p.user_id = receiver AND p.type = notification_type AND p.channel = c.channel
+WHERE COALESCE(p.enabled, c.default_enabled);
+$$ LANGUAGE sql STABLE;
+
+-- Настройки применяются ко всем вставкам уведомлений, уведомление без каналов не сохраняется
+CREATE FUNCTION notifications_apply_preferences() RETURNS trigger AS
+$$
+BEGIN
+    NEW.channels := notification_channels(NEW.user_id, NEW.type);
+    IF cardinality(NEW.channels) = 0 THEN
+        RETURN NULL;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notifications_apply_preferences
+    BEFORE INSERT
+    ON notifications
+    FOR EACH ROW
+EXECUTE FUNCTION notifications_apply_preferences();
+
+-- В поток попадают только уведомления приложения
+CREATE OR REPLACE FUNCTION notifications_to_stream() RETURNS trigger AS
+$$
+BEGIN
+    IF NOT 'in_app' = ANY (NEW.channels) THEN
+        RETURN NEW;
+    END IF;
+    INSERT INTO stream_events (user_id, kind, payload)
+    VALUES (NEW.user_id, 'notification',
+            jsonb_build_object('id', NEW.id, 'user_id', NEW.user_id, 'sender_id', NEW.sender_id,
+                               'type', NEW.type, 'message', NEW.message, 'read', NEW.read,
+                               'created_at', NEW.created_at));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS notifications_user_unread_idx;
+CREATE INDEX notifications_user_unread_idx ON notifications (user_id) WHERE NOT read AND 'in_app' = ANY (channels);
@@ -0,0 +1,24 @@
+DROP TRIGGER IF EXISTS notifications_aggregated_to_stream ON notifications;
+
+CREATE OR REPLACE FUNCTION notifications_to_stream() RETURNS trigger AS
+$$
+BEGIN
+    IF NOT 'in_app' = ANY (NEW.channels) THEN
+        RETURN NEW;
+    END IF;
+    INSERT INTO stream_events (user_id, kind, payload)
+    VALUES (NEW.user_id, 'notification',
+            jsonb_build_object('id', NEW.id, 'user_id', NEW.user_id, 'sender_id', NEW.sender_id,
+                               'type', NEW.type, 'message', NEW.message, 'read', NEW.read,
+                               'created_at', NEW.created_at));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS notification_digests;
+
+DROP INDEX IF EXISTS notifications_group_idx;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS aggregated_count,
+    DROP COLUMN IF EXISTS group_key;
@@ -0,0 +1,44 @@
+-- Похожие уведомления в окне агрегации схлопываются в одно: "X и ещё 14 оценили рецензию"
+ALTER TABLE notifications
+    ADD COLUMN group_key        VARCHAR(255),
+    ADD COLUMN aggregated_count INT NOT NULL DEFAULT 1;
+
+CREATE INDEX notifications_group_idx ON notifications (user_id, type, group_key, created_at DESC)
+    WHERE group_key IS NOT NULL AND NOT read;
+
+-- Частота дайджеста пользователя, отсутствие строки - дайджест выключен
+CREATE TABLE notification_digests
+(
+    user_id      UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    frequency    VARCHAR(16) NOT NULL DEFAULT 'off',
+    last_sent_at TIMESTAMP,
+    updated_at   TIMESTAMP   NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT digest_frequency CHECK (frequency IN ('off', 'daily', 'weekly'))
+);
+
+CREATE INDEX notification_digests_due_idx ON notification_digests (frequency, last_sent_at)
+    WHERE frequency <> 'off';
+
+-- В поток попадает и новое событие, схлопнутое в существующее уведомление
+CREATE OR REPLACE FUNCTION notifications_to_stream() RETURNS trigger AS
+$$
+BEGIN
+    IF NOT 'in_app' = ANY (NEW.channels) THEN
+        RETURN NEW;
+    END IF;
+    INSERT INTO stream_events (user_id, kind, payload)
+    VALUES (NEW.user_id, 'notification',
+            jsonb_build_object('id', NEW.id, 'user_id', NEW.user_id, 'sender_id', NEW.sender_id,
+                               'type', NEW.type, 'message', NEW.message, 'read', NEW.read,
+                               'count', NEW.aggregated_count, 'created_at', NEW.created_at));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notifications_aggregated_to_stream
+    AFTER UPDATE OF aggregated_count
+    ON notifications
+    FOR EACH ROW
+    WHEN (NEW.aggregated_count > OLD.aggregated_count)
+EXECUTE FUNCTION notifications_to_stream();
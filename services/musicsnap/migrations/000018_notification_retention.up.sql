@@ -0,0 +1,2 @@
+-- Поиск устаревших уведомлений для удаления пачками, отдельно прочитанных и непрочитанных
+CREATE INDEX notifications_retention_idx ON notifications (read, created_at);
@@ -0,0 +1,21 @@
+CREATE OR REPLACE FUNCTION notifications_to_stream() RETURNS trigger AS
+$$
+BEGIN
+    IF NOT 'in_app' = ANY (NEW.channels) THEN
+        RETURN NEW;
+    END IF;
+    INSERT INTO stream_events (user_id, kind, payload)
+    VALUES (NEW.user_id, 'notification',
+            jsonb_build_object('id', NEW.id, 'user_id', NEW.user_id, 'sender_id', NEW.sender_id,
+                               'type', NEW.type, 'message', NEW.message, 'read', NEW.read,
+                               'count', NEW.aggregated_count, 'created_at', NEW.created_at));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS payload_version;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS user_locale,
+    DROP COLUMN IF EXISTS locale;
@@ -0,0 +1,23 @@
+-- Язык интерфейса пользователя, в нём отображаются уведомления и дайджест
+ALTER TABLE users
+    ADD COLUMN locale VARCHAR(8) NOT NULL DEFAULT 'en',
+    ADD CONSTRAINT user_locale CHECK (locale IN ('en', 'ru'));
+
+-- Версия схемы полезной нагрузки уведомления, старые версии приводятся к текущей при чтении
+ALTER TABLE notifications
+    ADD COLUMN payload_version SMALLINT NOT NULL DEFAULT 1;
+
+CREATE OR REPLACE FUNCTION notifications_to_stream() RETURNS trigger AS
+$$
+BEGIN
+    IF NOT 'in_app' = ANY (NEW.channels) THEN
+        RETURN NEW;
+    END IF;
+    INSERT INTO stream_events (user_id, kind, payload)
+    VALUES (NEW.user_id, 'notification',
+            jsonb_build_object('id', NEW.id, 'user_id', NEW.user_id, 'sender_id', NEW.sender_id,
+                               'type', NEW.type, 'message', NEW.message, 'version', NEW.payload_version,
+                               'read', NEW.read, 'count', NEW.aggregated_count, 'created_at', NEW.created_at));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
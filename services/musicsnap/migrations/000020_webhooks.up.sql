@@ -0,0 +1,49 @@
+-- Подписки внешних интеграций на события, без владельца - вебхук приложения на события всех пользователей
+CREATE TABLE webhooks
+(
+    id          SERIAL PRIMARY KEY,
+    user_id     UUID REFERENCES users (id) ON DELETE CASCADE,
+    url         TEXT      NOT NULL,
+    secret      TEXT      NOT NULL,
+    event_types TEXT[]    NOT NULL,
+    active      BOOLEAN   NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX webhooks_user_idx ON webhooks (user_id) WHERE active;
+
+-- Доставка события на вебхук, недоставленные после всех попыток остаются в статусе dead
+CREATE TABLE webhook_deliveries
+(
+    id              BIGSERIAL PRIMARY KEY,
+    webhook_id      INT          NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id        UUID         NOT NULL,
+    event_type      VARCHAR(64)  NOT NULL,
+    payload         JSONB        NOT NULL,
+    status          VARCHAR(16)  NOT NULL DEFAULT 'pending',
+    attempts        INT          NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP,
+    created_at      TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP    NOT NULL DEFAULT NOW(),
+    delivered_at    TIMESTAMP,
+
+    CONSTRAINT webhook_delivery_status CHECK (status IN ('pending', 'delivered', 'dead')),
+    CONSTRAINT webhook_delivery_event UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX webhook_deliveries_webhook_idx ON webhook_deliveries (webhook_id, status, id);
+
+-- Журнал попыток доставки
+CREATE TABLE webhook_delivery_attempts
+(
+    id           BIGSERIAL PRIMARY KEY,
+    delivery_id  BIGINT    NOT NULL REFERENCES webhook_deliveries (id) ON DELETE CASCADE,
+    status_code  INT       NOT NULL DEFAULT 0,
+    error        TEXT      NOT NULL DEFAULT '',
+    duration_ms  INT       NOT NULL DEFAULT 0,
+    attempted_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX webhook_delivery_attempts_delivery_idx ON webhook_delivery_attempts (delivery_id, id);
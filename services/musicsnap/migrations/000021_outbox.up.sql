@@ -0,0 +1,15 @@
+-- Доменные события, записанные в одной транзакции с изменением. Relay публикует их хотя бы один раз
+CREATE TABLE outbox_events
+(
+    seq          BIGSERIAL PRIMARY KEY,
+    id           UUID        NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    type         VARCHAR(64) NOT NULL,
+    payload      JSONB       NOT NULL,
+    attempts     INT         NOT NULL DEFAULT 0,
+    last_error   TEXT        NOT NULL DEFAULT '',
+    available_at TIMESTAMP   NOT NULL DEFAULT NOW(),
+    created_at   TIMESTAMP   NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMP
+);
+
+CREATE INDEX outbox_events_due_idx ON outbox_events (available_at, seq) WHERE published_at IS NULL;
@@ -0,0 +1,20 @@
+DROP TABLE IF EXISTS event_participants;
+
+DROP INDEX IF EXISTS event_photos_event_idx;
+ALTER TABLE event_photos
+    DROP COLUMN IF EXISTS position,
+    ALTER COLUMN id DROP DEFAULT,
+    ALTER COLUMN created_at DROP DEFAULT;
+
+DROP INDEX IF EXISTS event_authors_user_idx;
+ALTER TABLE event_authors
+    DROP CONSTRAINT IF EXISTS event_author_unique,
+    ALTER COLUMN id DROP DEFAULT,
+    ALTER COLUMN created_at DROP DEFAULT;
+
+DROP INDEX IF EXISTS events_date_idx;
+ALTER TABLE events
+    DROP COLUMN IF EXISTS location,
+    DROP COLUMN IF EXISTS text,
+    ALTER COLUMN id DROP DEFAULT,
+    ALTER COLUMN created_at DROP DEFAULT;
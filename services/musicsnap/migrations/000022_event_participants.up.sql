@@ -0,0 +1,34 @@
+-- События: идентификаторы и даты создания задаются базой, описание и место проведения
+ALTER TABLE events
+    ALTER COLUMN id SET DEFAULT gen_random_uuid(),
+    ALTER COLUMN created_at SET DEFAULT NOW(),
+    ADD COLUMN text     TEXT NOT NULL DEFAULT '',
+    ADD COLUMN location TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX events_date_idx ON events (date);
+
+ALTER TABLE event_authors
+    ALTER COLUMN id SET DEFAULT gen_random_uuid(),
+    ALTER COLUMN created_at SET DEFAULT NOW(),
+    ADD CONSTRAINT event_author_unique UNIQUE (event_id, user_id);
+
+CREATE INDEX event_authors_user_idx ON event_authors (user_id);
+
+-- position задаёт порядок фотографий события
+ALTER TABLE event_photos
+    ALTER COLUMN id SET DEFAULT gen_random_uuid(),
+    ALTER COLUMN created_at SET DEFAULT NOW(),
+    ADD COLUMN position INT NOT NULL DEFAULT 0;
+
+CREATE INDEX event_photos_event_idx ON event_photos (event_id, position);
+
+-- Участники событий, отмена участия удаляет строку
+CREATE TABLE event_participants
+(
+    event_id   UUID      NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    user_id    UUID      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (event_id, user_id)
+);
+
+CREATE INDEX event_participants_user_idx ON event_participants (user_id);
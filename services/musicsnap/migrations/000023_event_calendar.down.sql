@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS calendar_feeds;
+
+ALTER TABLE events
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS sequence;
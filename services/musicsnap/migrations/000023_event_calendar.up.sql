@@ -0,0 +1,13 @@
+-- sequence растёт при каждом изменении события, по нему календари заменяют старую копию,
+-- отменённое событие остаётся в лентах со статусом CANCELLED
+ALTER TABLE events
+    ADD COLUMN sequence     INT NOT NULL DEFAULT 0,
+    ADD COLUMN cancelled_at TIMESTAMP;
+
+-- Личные iCal-ленты пользователей, токен в ссылке заменяет авторизацию
+CREATE TABLE calendar_feeds
+(
+    user_id    UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    token      TEXT      NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,17 @@
+-- Напоминания участникам о начале событий, по одному на участника и смещение.
+-- remind_at пересчитывается при переносе события, sent_at не даёт отправить напоминание дважды
+CREATE TABLE event_reminders
+(
+    id             BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    event_id       UUID      NOT NULL,
+    user_id        UUID      NOT NULL,
+    -- за сколько секунд до начала события отправляется напоминание
+    offset_seconds INT       NOT NULL,
+    remind_at      TIMESTAMP NOT NULL,
+    sent_at        TIMESTAMP,
+    -- отмена участия удаляет напоминания
+    FOREIGN KEY (event_id, user_id) REFERENCES event_participants (event_id, user_id) ON DELETE CASCADE,
+    CONSTRAINT event_reminder_unique UNIQUE (event_id, user_id, offset_seconds)
+);
+
+CREATE INDEX event_reminders_due_idx ON event_reminders (remind_at) WHERE sent_at IS NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS notification_digests_due_idx;
+CREATE INDEX notification_digests_due_idx ON notification_digests (frequency, last_sent_at)
+    WHERE frequency <> 'off';
+
+ALTER TABLE notification_digests
+    DROP COLUMN IF EXISTS attempts,
+    DROP COLUMN IF EXISTS available_at;
@@ -0,0 +1,8 @@
+-- Дайджест захватывается одной репликой на время отправки, неудачная отправка откладывается с нарастающей задержкой
+ALTER TABLE notification_digests
+    ADD COLUMN available_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    ADD COLUMN attempts     INT       NOT NULL DEFAULT 0;
+
+DROP INDEX IF EXISTS notification_digests_due_idx;
+CREATE INDEX notification_digests_due_idx ON notification_digests (available_at, last_sent_at)
+    WHERE frequency <> 'off';
@@ -0,0 +1,4 @@
+-- Тела ответов получателей больше не хранятся в журнале доставок, он виден владельцу вебхука
+UPDATE webhook_delivery_attempts
+SET error = 'receiver responded with status ' || status_code
+WHERE status_code > 0 AND error <> '';
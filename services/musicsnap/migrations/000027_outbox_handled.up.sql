@@ -0,0 +1,8 @@
+-- Локальный обработчик отмечается отдельно от публикации в шину:
+-- при недоступности шины повторяется только публикация
+ALTER TABLE outbox_events
+    ADD COLUMN handled_at TIMESTAMP;
+
+UPDATE outbox_events
+SET handled_at = published_at
+WHERE published_at IS NOT NULL;
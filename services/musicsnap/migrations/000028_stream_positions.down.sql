@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS stream_events_retention_idx;
+DROP INDEX IF EXISTS stream_events_unpositioned_idx;
+DROP INDEX IF EXISTS stream_events_user_idx;
+CREATE INDEX stream_events_user_idx ON stream_events (user_id, id);
+
+DROP SEQUENCE IF EXISTS stream_events_position_seq;
+
+ALTER TABLE stream_events
+    DROP COLUMN IF EXISTS position,
+    DROP COLUMN IF EXISTS tx_id;
@@ -0,0 +1,20 @@
+-- id выдаётся при вставке, а транзакции фиксируются в другом порядке: клиент, продолживший поток после
+-- большего id, навсегда пропустил бы событие. Позиция в потоке назначается уже зафиксированным событиям
+-- в порядке транзакций, tx_id - транзакция вставки
+ALTER TABLE stream_events
+    ADD COLUMN tx_id    XID8 NOT NULL DEFAULT pg_current_xact_id(),
+    ADD COLUMN position BIGINT UNIQUE;
+
+-- существующие события остаются на своих местах, курсоры клиентов продолжают работать
+UPDATE stream_events
+SET position = id;
+
+CREATE SEQUENCE stream_events_position_seq;
+SELECT setval('stream_events_position_seq', GREATEST(COALESCE(MAX(id), 0), 1))
+FROM stream_events;
+
+DROP INDEX IF EXISTS stream_events_user_idx;
+CREATE INDEX stream_events_user_idx ON stream_events (user_id, position);
+CREATE INDEX stream_events_unpositioned_idx ON stream_events (tx_id, id) WHERE position IS NULL;
+-- удаление старых событий потока
+CREATE INDEX stream_events_retention_idx ON stream_events (created_at);
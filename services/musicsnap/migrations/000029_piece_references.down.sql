@@ -0,0 +1,113 @@
+-- Произведения-заглушки и неизвестный исполнитель остаются в каталоге, ссылки сохраняют канонические id
+
+ALTER TABLE albums
+    ALTER COLUMN artist_id DROP NOT NULL;
+ALTER TABLE tracks
+    ALTER COLUMN artist_id DROP NOT NULL;
+
+DROP TRIGGER IF EXISTS reviews_piece_stats ON reviews;
+DROP TRIGGER IF EXISTS ratings_piece_stats ON ratings;
+DROP TRIGGER IF EXISTS reactions_piece_stats ON reactions;
+
+ALTER TABLE reviews
+    DROP CONSTRAINT IF EXISTS reviews_piece_fk,
+    ALTER COLUMN piece_id TYPE VARCHAR(255);
+ALTER TABLE review_revisions
+    DROP CONSTRAINT IF EXISTS review_revisions_piece_fk,
+    ALTER COLUMN piece_id TYPE VARCHAR(255);
+ALTER TABLE ratings
+    DROP CONSTRAINT IF EXISTS ratings_piece_fk,
+    ALTER COLUMN piece_id TYPE VARCHAR(255);
+ALTER TABLE playlist_items
+    DROP CONSTRAINT IF EXISTS playlist_item_piece,
+    DROP CONSTRAINT IF EXISTS playlist_items_piece_fk,
+    ALTER COLUMN piece_id TYPE VARCHAR(255);
+UPDATE playlist_items
+SET piece_id = ''
+WHERE piece_id IS NULL;
+ALTER TABLE playlist_items
+    ALTER COLUMN piece_id SET NOT NULL;
+
+ALTER TABLE piece_stats
+    DROP CONSTRAINT IF EXISTS piece_stats_piece_fk,
+    ALTER COLUMN piece_id TYPE VARCHAR(255);
+
+DROP FUNCTION IF EXISTS piece_stats_add_rating(UUID, INTEGER, BOOLEAN, INTEGER);
+DROP FUNCTION IF EXISTS piece_stats_add_reaction(UUID, TEXT, INTEGER);
+
+-- Добавляет (sign = 1) или вычитает (sign = -1) оценку произведения
+CREATE FUNCTION piece_stats_add_rating(p_piece_id VARCHAR, p_rating INTEGER, p_is_review BOOLEAN, sign INTEGER)
+    RETURNS VOID AS
+$$
+BEGIN
+    INSERT INTO piece_stats (piece_id) VALUES (p_piece_id) ON CONFLICT (piece_id) DO NOTHING;
+
+    UPDATE piece_stats
+    SET ratings_count       = ratings_count + sign,
+        reviews_count       = reviews_count + CASE WHEN p_is_review THEN sign ELSE 0 END,
+        rating_sum          = rating_sum + sign * p_rating,
+        histogram[p_rating] = histogram[p_rating] + sign
+    WHERE piece_id = p_piece_id;
+END;
+$$
+    language 'plpgsql';
+
+-- Добавляет или вычитает реакцию на рецензию произведения
+CREATE FUNCTION piece_stats_add_reaction(p_piece_id VARCHAR, p_type TEXT, sign INTEGER)
+    RETURNS VOID AS
+$$
+BEGIN
+    INSERT INTO piece_stats (piece_id) VALUES (p_piece_id) ON CONFLICT (piece_id) DO NOTHING;
+
+    UPDATE piece_stats
+    SET likes_count    = likes_count + CASE WHEN p_type = 'like' THEN sign ELSE 0 END,
+        dislikes_count = dislikes_count + CASE WHEN p_type = 'dislike' THEN sign ELSE 0 END
+    WHERE piece_id = p_piece_id;
+END;
+$$
+    language 'plpgsql';
+
+CREATE
+    OR REPLACE FUNCTION reactions_piece_stats()
+    RETURNS TRIGGER AS
+$$
+DECLARE
+    review_piece_id VARCHAR;
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        SELECT piece_id INTO review_piece_id FROM reviews WHERE id = OLD.review_id;
+        IF FOUND THEN
+            PERFORM piece_stats_add_reaction(review_piece_id, OLD.type, -1);
+        END IF;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        SELECT piece_id INTO review_piece_id FROM reviews WHERE id = NEW.review_id;
+        IF FOUND THEN
+            PERFORM piece_stats_add_reaction(review_piece_id, NEW.type, 1);
+        END IF;
+    END IF;
+
+    RETURN NULL;
+END;
+$$
+    language 'plpgsql';
+
+CREATE TRIGGER reviews_piece_stats
+    AFTER INSERT OR DELETE OR UPDATE OF piece_id, rating, published
+    ON reviews
+    FOR EACH ROW
+EXECUTE FUNCTION reviews_piece_stats();
+
+CREATE TRIGGER ratings_piece_stats
+    AFTER INSERT OR DELETE OR UPDATE OF piece_id, rating
+    ON ratings
+    FOR EACH ROW
+EXECUTE FUNCTION ratings_piece_stats();
+
+CREATE TRIGGER reactions_piece_stats
+    AFTER INSERT OR DELETE OR UPDATE OF type, review_id
+    ON reactions
+    FOR EACH ROW
+EXECUTE FUNCTION reactions_piece_stats();
+
+SELECT rebuild_piece_stats();
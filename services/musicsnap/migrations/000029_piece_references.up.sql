@@ -0,0 +1,233 @@
+-- Рецензии, оценки и элементы плейлистов ссылаются на произведения каталога по каноническому id.
+-- Прежние id переводятся через внешние id каталога, для неизвестных создаются произведения-заглушки,
+-- пользовательский контент не удаляется
+
+-- триггеры со списком столбцов мешают смене типа piece_id, агрегаты пересчитываются в конце
+DROP TRIGGER IF EXISTS reviews_piece_stats ON reviews;
+DROP TRIGGER IF EXISTS ratings_piece_stats ON ratings;
+DROP TRIGGER IF EXISTS reactions_piece_stats ON reactions;
+
+-- Исполнитель произведений без указанного исполнителя, id совпадает с domain.UnknownArtistID
+INSERT INTO artists (id, name)
+VALUES ('00000000-0000-0000-0000-000000000001', 'Unknown artist')
+ON CONFLICT (id) DO NOTHING;
+
+CREATE TEMPORARY TABLE legacy_pieces
+(
+    legacy_id   VARCHAR(255) PRIMARY KEY,
+    piece_id    UUID,
+    placeholder BOOLEAN NOT NULL DEFAULT false
+);
+
+INSERT INTO legacy_pieces (legacy_id)
+SELECT piece_id FROM reviews
+UNION
+SELECT piece_id FROM review_revisions
+UNION
+SELECT piece_id FROM ratings
+UNION
+SELECT piece_id FROM playlist_items WHERE type = 'Piece';
+
+-- канонический id существующего произведения
+UPDATE legacy_pieces lp
+SET piece_id = p.id
+FROM pieces p
+WHERE lp.legacy_id ~* '^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$'
+  AND p.id = lp.legacy_id::UUID;
+
+-- внешний id вида "source:external_id"
+UPDATE legacy_pieces lp
+SET piece_id = pa.piece_id
+FROM piece_aliases pa
+WHERE lp.piece_id IS NULL
+  AND pa.source = split_part(lp.legacy_id, ':', 1)
+  AND pa.external_id = substr(lp.legacy_id, strpos(lp.legacy_id, ':') + 1);
+
+UPDATE legacy_pieces
+SET piece_id    = gen_random_uuid(),
+    placeholder = true
+WHERE piece_id IS NULL;
+
+-- Оценки одного пользователя под разными id одного произведения не сливаются:
+-- кроме последней, каждая получает собственную заглушку
+CREATE TEMPORARY TABLE rating_pieces AS
+SELECT r.id,
+       r.piece_id AS legacy_id,
+       CASE
+           WHEN row_number() OVER (PARTITION BY r.user_id, lp.piece_id ORDER BY r.updated_at DESC, r.id DESC) = 1
+               THEN lp.piece_id
+           ELSE gen_random_uuid()
+           END    AS piece_id
+FROM ratings r
+         JOIN legacy_pieces lp ON lp.legacy_id = r.piece_id;
+
+CREATE TEMPORARY TABLE placeholder_pieces AS
+SELECT piece_id, legacy_id
+FROM legacy_pieces
+WHERE placeholder
+UNION ALL
+SELECT rp.piece_id, rp.legacy_id
+FROM rating_pieces rp
+         JOIN legacy_pieces lp ON lp.legacy_id = rp.legacy_id
+WHERE rp.piece_id <> lp.piece_id;
+
+INSERT INTO pieces (id, type)
+SELECT piece_id, 'track'
+FROM placeholder_pieces;
+
+INSERT INTO tracks (id, artist_id, title)
+SELECT piece_id, '00000000-0000-0000-0000-000000000001', legacy_id
+FROM placeholder_pieces;
+
+-- заглушка находится по прежнему внешнему id, пока модератор не объединит ее с настоящим произведением
+INSERT INTO piece_aliases (source, external_id, piece_id)
+SELECT split_part(lp.legacy_id, ':', 1), substr(lp.legacy_id, strpos(lp.legacy_id, ':') + 1), lp.piece_id
+FROM legacy_pieces lp
+WHERE lp.placeholder
+  AND split_part(lp.legacy_id, ':', 1) IN ('spotify', 'musicbrainz', 'yandex_music')
+  AND trim(substr(lp.legacy_id, strpos(lp.legacy_id, ':') + 1)) <> ''
+ON CONFLICT (source, external_id) DO NOTHING;
+
+UPDATE reviews r
+SET piece_id = lp.piece_id::TEXT
+FROM legacy_pieces lp
+WHERE lp.legacy_id = r.piece_id;
+ALTER TABLE review_revisions DISABLE TRIGGER review_revisions_append_only;
+UPDATE review_revisions rv
+SET piece_id = lp.piece_id::TEXT
+FROM legacy_pieces lp
+WHERE lp.legacy_id = rv.piece_id;
+ALTER TABLE review_revisions ENABLE TRIGGER review_revisions_append_only;
+-- оценки меняются местами с заглушками, уникальность проверяется после обновления всех строк
+ALTER TABLE ratings
+    DROP CONSTRAINT ratings_user_piece_unique;
+UPDATE ratings r
+SET piece_id = rp.piece_id::TEXT
+FROM rating_pieces rp
+WHERE rp.id = r.id;
+UPDATE playlist_items pi
+SET piece_id = lp.piece_id::TEXT
+FROM legacy_pieces lp
+WHERE lp.legacy_id = pi.piece_id
+  AND pi.type = 'Piece';
+
+DROP TABLE placeholder_pieces, rating_pieces, legacy_pieces;
+
+ALTER TABLE reviews
+    ALTER COLUMN piece_id TYPE UUID USING piece_id::UUID,
+    ADD CONSTRAINT reviews_piece_fk FOREIGN KEY (piece_id) REFERENCES pieces (id);
+ALTER TABLE review_revisions
+    ALTER COLUMN piece_id TYPE UUID USING piece_id::UUID,
+    ADD CONSTRAINT review_revisions_piece_fk FOREIGN KEY (piece_id) REFERENCES pieces (id);
+ALTER TABLE ratings
+    ALTER COLUMN piece_id TYPE UUID USING piece_id::UUID,
+    ADD CONSTRAINT ratings_piece_fk FOREIGN KEY (piece_id) REFERENCES pieces (id),
+    ADD CONSTRAINT ratings_user_piece_unique UNIQUE (user_id, piece_id);
+-- элемент-описание не ссылается на произведение
+ALTER TABLE playlist_items
+    ALTER COLUMN piece_id DROP NOT NULL;
+UPDATE playlist_items
+SET piece_id = NULL
+WHERE type <> 'Piece';
+ALTER TABLE playlist_items
+    ALTER COLUMN piece_id TYPE UUID USING piece_id::UUID,
+    ADD CONSTRAINT playlist_items_piece_fk FOREIGN KEY (piece_id) REFERENCES pieces (id),
+    ADD CONSTRAINT playlist_item_piece CHECK (type <> 'Piece' OR piece_id IS NOT NULL);
+
+DELETE FROM piece_stats;
+ALTER TABLE piece_stats
+    ALTER COLUMN piece_id TYPE UUID USING piece_id::UUID,
+    ADD CONSTRAINT piece_stats_piece_fk FOREIGN KEY (piece_id) REFERENCES pieces (id) ON DELETE CASCADE;
+
+DROP FUNCTION IF EXISTS piece_stats_add_rating(VARCHAR, INTEGER, BOOLEAN, INTEGER);
+DROP FUNCTION IF EXISTS piece_stats_add_reaction(VARCHAR, TEXT, INTEGER);
+
+-- Добавляет (sign = 1) или вычитает (sign = -1) оценку произведения
+CREATE FUNCTION piece_stats_add_rating(p_piece_id UUID, p_rating INTEGER, p_is_review BOOLEAN, sign INTEGER)
+    RETURNS VOID AS
+$$
+BEGIN
+    INSERT INTO piece_stats (piece_id) VALUES (p_piece_id) ON CONFLICT (piece_id) DO NOTHING;
+
+    UPDATE piece_stats
+    SET ratings_count       = ratings_count + sign,
+        reviews_count       = reviews_count + CASE WHEN p_is_review THEN sign ELSE 0 END,
+        rating_sum          = rating_sum + sign * p_rating,
+        histogram[p_rating] = histogram[p_rating] + sign
+    WHERE piece_id = p_piece_id;
+END;
+$$
+    language 'plpgsql';
+
+-- Добавляет или вычитает реакцию на рецензию произведения
+CREATE FUNCTION piece_stats_add_reaction(p_piece_id UUID, p_type TEXT, sign INTEGER)
+    RETURNS VOID AS
+$$
+BEGIN
+    INSERT INTO piece_stats (piece_id) VALUES (p_piece_id) ON CONFLICT (piece_id) DO NOTHING;
+
+    UPDATE piece_stats
+    SET likes_count    = likes_count + CASE WHEN p_type = 'like' THEN sign ELSE 0 END,
+        dislikes_count = dislikes_count + CASE WHEN p_type = 'dislike' THEN sign ELSE 0 END
+    WHERE piece_id = p_piece_id;
+END;
+$$
+    language 'plpgsql';
+
+CREATE
+    OR REPLACE FUNCTION reactions_piece_stats()
+    RETURNS TRIGGER AS
+$$
+DECLARE
+    review_piece_id UUID;
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        SELECT piece_id INTO review_piece_id FROM reviews WHERE id = OLD.review_id;
+        IF FOUND THEN
+            PERFORM piece_stats_add_reaction(review_piece_id, OLD.type, -1);
+        END IF;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        SELECT piece_id INTO review_piece_id FROM reviews WHERE id = NEW.review_id;
+        IF FOUND THEN
+            PERFORM piece_stats_add_reaction(review_piece_id, NEW.type, 1);
+        END IF;
+    END IF;
+
+    RETURN NULL;
+END;
+$$
+    language 'plpgsql';
+
+CREATE TRIGGER reviews_piece_stats
+    AFTER INSERT OR DELETE OR UPDATE OF piece_id, rating, published
+    ON reviews
+    FOR EACH ROW
+EXECUTE FUNCTION reviews_piece_stats();
+
+CREATE TRIGGER ratings_piece_stats
+    AFTER INSERT OR DELETE OR UPDATE OF piece_id, rating
+    ON ratings
+    FOR EACH ROW
+EXECUTE FUNCTION ratings_piece_stats();
+
+CREATE TRIGGER reactions_piece_stats
+    AFTER INSERT OR DELETE OR UPDATE OF type, review_id
+    ON reactions
+    FOR EACH ROW
+EXECUTE FUNCTION reactions_piece_stats();
+
+SELECT rebuild_piece_stats();
+
+-- Альбом и трек всегда принадлежат исполнителю
+UPDATE albums
+SET artist_id = '00000000-0000-0000-0000-000000000001'
+WHERE artist_id IS NULL;
+UPDATE tracks
+SET artist_id = '00000000-0000-0000-0000-000000000001'
+WHERE artist_id IS NULL;
+
+ALTER TABLE albums
+    ALTER COLUMN artist_id SET NOT NULL;
+ALTER TABLE tracks
+    ALTER COLUMN artist_id SET NOT NULL;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS notifications_review_idx;
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS review_id;
+
+DROP INDEX IF EXISTS stream_events_review_idx;
+ALTER TABLE stream_events
+    DROP COLUMN IF EXISTS review_id;
+
+DROP TABLE IF EXISTS review_publication_handlers;
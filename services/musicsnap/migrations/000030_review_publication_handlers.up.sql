@@ -0,0 +1,21 @@
+-- Обработчик публикации отмечается в транзакции своих изменений,
+-- повтор публикации после сбоя пропускает уже выполненные обработчики
+CREATE TABLE review_publication_handlers
+(
+    review_id  INTEGER     NOT NULL REFERENCES reviews (id) ON DELETE CASCADE,
+    handler    VARCHAR(32) NOT NULL,
+    handled_at TIMESTAMP   NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (review_id, handler)
+);
+
+-- Событие ленты и оповещение подписчика о рецензии создаются не больше одного раза
+ALTER TABLE stream_events
+    ADD COLUMN review_id INTEGER;
+CREATE UNIQUE INDEX stream_events_review_idx ON stream_events (user_id, kind, review_id)
+    WHERE review_id IS NOT NULL;
+
+ALTER TABLE notifications
+    ADD COLUMN review_id INTEGER;
+CREATE UNIQUE INDEX notifications_review_idx ON notifications (user_id, type, review_id)
+    WHERE review_id IS NOT NULL;
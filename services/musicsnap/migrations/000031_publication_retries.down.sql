@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_review_publications_pending;
+CREATE INDEX idx_review_publications_pending ON review_publications (published_at) WHERE processed_at IS NULL;
+
+ALTER TABLE review_publications
+    DROP COLUMN IF EXISTS last_error,
+    DROP COLUMN IF EXISTS next_attempt_at,
+    DROP COLUMN IF EXISTS attempts;
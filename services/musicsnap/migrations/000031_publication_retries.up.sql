@@ -0,0 +1,9 @@
+-- Публикация берется в обработку с арендой, после сбоя повторяется с экспоненциальной задержкой
+-- и не задерживает остальные публикации
+ALTER TABLE review_publications
+    ADD COLUMN attempts        INT       NOT NULL DEFAULT 0,
+    ADD COLUMN next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    ADD COLUMN last_error      TEXT;
+
+DROP INDEX IF EXISTS idx_review_publications_pending;
+CREATE INDEX idx_review_publications_pending ON review_publications (next_attempt_at) WHERE processed_at IS NULL;